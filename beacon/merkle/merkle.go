@@ -20,7 +20,6 @@ package merkle
 import (
 	"crypto/sha256"
 	"errors"
-	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -32,11 +31,9 @@ type Value [32]byte
 // Values represent a series of merkle tree leaves/nodes.
 type Values []Value
 
-var valueT = reflect.TypeOf(Value{})
-
 // UnmarshalJSON parses a merkle value in hex syntax.
 func (m *Value) UnmarshalJSON(input []byte) error {
-	return hexutil.UnmarshalFixedJSON(valueT, input, m[:])
+	return hexutil.UnmarshalFixedJSONOf[Value](input, m[:])
 }
 
 // VerifyProof verifies a Merkle proof branch for a single value in a
@@ -739,23 +739,15 @@ func (s *Suite) TestNewPooledTxs(t *utesting.T) {
 }
 
 func makeSidecar(data ...byte) *types.BlobTxSidecar {
-	var (
-		blobs       = make([]kzg4844.Blob, len(data))
-		commitments []kzg4844.Commitment
-		proofs      []kzg4844.Proof
-	)
+	blobs := make([]kzg4844.Blob, len(data))
 	for i := range blobs {
 		blobs[i][0] = data[i]
-		c, _ := kzg4844.BlobToCommitment(blobs[i])
-		p, _ := kzg4844.ComputeBlobProof(blobs[i], c)
-		commitments = append(commitments, c)
-		proofs = append(proofs, p)
 	}
-	return &types.BlobTxSidecar{
-		Blobs:       blobs,
-		Commitments: commitments,
-		Proofs:      proofs,
+	sidecar, err := types.NewSidecarFromBlobs(types.BlobSidecarVersion0, blobs)
+	if err != nil {
+		panic(err)
 	}
+	return sidecar
 }
 
 func (s *Suite) makeBlobTxs(count, blobs int, discriminator byte) (txs types.Transactions) {
@@ -773,7 +765,7 @@ func (s *Suite) makeBlobTxs(count, blobs int, discriminator byte) (txs types.Tra
 			GasTipCap:  uint256.NewInt(1),
 			GasFeeCap:  uint256.MustFromBig(s.chain.Head().BaseFee()),
 			Gas:        100000,
-			BlobFeeCap: uint256.MustFromBig(eip4844.CalcBlobFee(*s.chain.Head().ExcessBlobGas())),
+			BlobFeeCap: uint256.MustFromBig(eip4844.CalcBlobFee(s.chain.config, s.chain.Head().Time(), *s.chain.Head().ExcessBlobGas())),
 			BlobHashes: makeSidecar(blobdata...).BlobHashes(),
 			Sidecar:    makeSidecar(blobdata...),
 		}
@@ -231,7 +231,11 @@ func ethFilter(args []string) (nodeFilter, error) {
 	case "mainnet":
 		filter = forkid.NewStaticFilter(params.MainnetChainConfig, core.DefaultGenesisBlock().ToBlock())
 	case "goerli":
-		filter = forkid.NewStaticFilter(params.GoerliChainConfig, core.DefaultGoerliGenesisBlock().ToBlock())
+		genesis, err := core.DefaultGenesisByChainID(5)
+		if err != nil {
+			return nil, err
+		}
+		filter = forkid.NewStaticFilter(genesis.Config, genesis.ToBlock())
 	case "sepolia":
 		filter = forkid.NewStaticFilter(params.SepoliaChainConfig, core.DefaultSepoliaGenesisBlock().ToBlock())
 	case "holesky":
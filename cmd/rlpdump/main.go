@@ -94,72 +94,7 @@ func main() {
 }
 
 func rlpToText(r io.Reader, out io.Writer) error {
-	s := rlp.NewStream(r, 0)
-	for {
-		if err := dump(s, 0, out); err != nil {
-			if err != io.EOF {
-				return err
-			}
-			break
-		}
-		fmt.Fprintln(out)
-		if *single {
-			break
-		}
-	}
-	return nil
-}
-
-func dump(s *rlp.Stream, depth int, out io.Writer) error {
-	kind, size, err := s.Kind()
-	if err != nil {
-		return err
-	}
-	switch kind {
-	case rlp.Byte, rlp.String:
-		str, err := s.Bytes()
-		if err != nil {
-			return err
-		}
-		if len(str) == 0 || !*noASCII && isASCII(str) {
-			fmt.Fprintf(out, "%s%q", ws(depth), str)
-		} else {
-			fmt.Fprintf(out, "%s%x", ws(depth), str)
-		}
-	case rlp.List:
-		s.List()
-		defer s.ListEnd()
-		if size == 0 {
-			fmt.Fprintf(out, ws(depth)+"[]")
-		} else {
-			fmt.Fprintln(out, ws(depth)+"[")
-			for i := 0; ; i++ {
-				if i > 0 {
-					fmt.Fprint(out, ",\n")
-				}
-				if err := dump(s, depth+1, out); err == rlp.EOL {
-					break
-				} else if err != nil {
-					return err
-				}
-			}
-			fmt.Fprint(out, ws(depth)+"]")
-		}
-	}
-	return nil
-}
-
-func isASCII(b []byte) bool {
-	for _, c := range b {
-		if c < 32 || c > 126 {
-			return false
-		}
-	}
-	return true
-}
-
-func ws(n int) string {
-	return strings.Repeat("  ", n)
+	return rlp.Dump(r, out, &rlp.DumpOptions{NoASCII: *noASCII, Single: *single})
 }
 
 func die(args ...interface{}) {
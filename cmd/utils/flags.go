@@ -1006,7 +1006,8 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 		case ctx.Bool(SepoliaFlag.Name):
 			urls = params.SepoliaBootnodes
 		case ctx.Bool(GoerliFlag.Name):
-			urls = params.GoerliBootnodes
+			network, _ := mustGoerli()
+			urls = network.Bootnodes
 		}
 	}
 	cfg.BootstrapNodes = mustParseBootnodes(urls)
@@ -1752,8 +1753,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		if !ctx.IsSet(NetworkIdFlag.Name) {
 			cfg.NetworkId = 5
 		}
-		cfg.Genesis = core.DefaultGoerliGenesisBlock()
-		SetDNSDiscoveryDefaults(cfg, params.GoerliGenesisHash)
+		network, genesis := mustGoerli()
+		cfg.Genesis = genesis
+		SetDNSDiscoveryDefaults(cfg, network.GenesisHash)
 	case ctx.Bool(DeveloperFlag.Name):
 		if !ctx.IsSet(NetworkIdFlag.Name) {
 			cfg.NetworkId = 1337
@@ -1850,13 +1852,26 @@ func SetDNSDiscoveryDefaults(cfg *ethconfig.Config, genesis common.Hash) {
 	if cfg.EthDiscoveryURLs != nil {
 		return // already set through flags/config
 	}
-	protocol := "all"
-	if url := params.KnownDNSNetwork(genesis, protocol); url != "" {
-		cfg.EthDiscoveryURLs = []string{url}
-		cfg.SnapDiscoveryURLs = cfg.EthDiscoveryURLs
+	if network, err := params.NetworkByGenesis(genesis); err == nil {
+		cfg.EthDiscoveryURLs = append([]string(nil), network.EthDNS...)
+		cfg.SnapDiscoveryURLs = append([]string(nil), network.SnapDNS...)
 	}
 }
 
+// mustGoerli returns the Görli network and its genesis block, terminating if
+// the network was excluded from the build with the nogoerli tag.
+func mustGoerli() (*params.Network, *core.Genesis) {
+	network, err := params.NetworkByChainID(5)
+	if err != nil {
+		Fatalf("Failed to load Görli network: %v", err)
+	}
+	genesis, err := core.DefaultGenesisByChainID(5)
+	if err != nil {
+		Fatalf("Failed to load Görli genesis: %v", err)
+	}
+	return network, genesis
+}
+
 // RegisterEthService adds an Ethereum client to the stack.
 // The second return value is the full node instance.
 func RegisterEthService(stack *node.Node, cfg *ethconfig.Config) (ethapi.Backend, *eth.Ethereum) {
@@ -2062,7 +2077,7 @@ func MakeGenesis(ctx *cli.Context) *core.Genesis {
 	case ctx.Bool(SepoliaFlag.Name):
 		genesis = core.DefaultSepoliaGenesisBlock()
 	case ctx.Bool(GoerliFlag.Name):
-		genesis = core.DefaultGoerliGenesisBlock()
+		_, genesis = mustGoerli()
 	case ctx.Bool(DeveloperFlag.Name):
 		Fatalf("Developer chains are ephemeral")
 	}
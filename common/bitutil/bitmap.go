@@ -0,0 +1,183 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bitutil
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Bitmap은 []uint64로 구성된 고정 크기의 비트 집합입니다. 비트 i는 words[i/64]의 i%64번째
+// 하위 비트에 저장됩니다. 블룸 비트 인덱스처럼 비트 벡터 사이의 논리 연산과 설정된 비트의 위치
+// 계산이 많이 필요한 곳을 위한 것입니다. 동시에 사용하는 것은 안전하지 않습니다.
+type Bitmap struct {
+	words []uint64
+	size  int
+}
+
+// NewBitmap은 모든 비트가 0인 size 비트 크기의 비트맵을 생성합니다.
+func NewBitmap(size int) *Bitmap {
+	if size < 0 {
+		panic(fmt.Sprintf("bitutil: negative bitmap size %d", size))
+	}
+	return &Bitmap{words: make([]uint64, (size+63)/64), size: size}
+}
+
+// Len은 비트맵의 비트 수를 반환합니다.
+func (b *Bitmap) Len() int {
+	return b.size
+}
+
+// Set은 비트 i를 1로 설정합니다.
+func (b *Bitmap) Set(i int) {
+	b.check(i)
+	b.words[i/64] |= 1 << (uint(i) % 64)
+}
+
+// Clear는 비트 i를 0으로 설정합니다.
+func (b *Bitmap) Clear(i int) {
+	b.check(i)
+	b.words[i/64] &^= 1 << (uint(i) % 64)
+}
+
+// Test는 비트 i가 설정되어 있는지 여부를 반환합니다.
+func (b *Bitmap) Test(i int) bool {
+	b.check(i)
+	return b.words[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// And는 b를 b와 x의 교집합으로 설정하고 b를 반환합니다. 두 비트맵의 크기는 같아야 합니다.
+func (b *Bitmap) And(x *Bitmap) *Bitmap {
+	b.checkSize(x)
+	for i, w := range x.words {
+		b.words[i] &= w
+	}
+	return b
+}
+
+// Or는 b를 b와 x의 합집합으로 설정하고 b를 반환합니다. 두 비트맵의 크기는 같아야 합니다.
+func (b *Bitmap) Or(x *Bitmap) *Bitmap {
+	b.checkSize(x)
+	for i, w := range x.words {
+		b.words[i] |= w
+	}
+	return b
+}
+
+// AndNot은 b에서 x에 설정된 비트를 지우고 b를 반환합니다. 두 비트맵의 크기는 같아야 합니다.
+func (b *Bitmap) AndNot(x *Bitmap) *Bitmap {
+	b.checkSize(x)
+	for i, w := range x.words {
+		b.words[i] &^= w
+	}
+	return b
+}
+
+// PopCount는 설정된 비트의 수를 반환합니다.
+func (b *Bitmap) PopCount() int {
+	var n int
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Rank는 i보다 앞에 있는 비트(0부터 i-1까지) 중 설정된 비트의 수를 반환합니다. i는 0부터
+// Len()까지의 값이어야 합니다.
+func (b *Bitmap) Rank(i int) int {
+	if i < 0 || i > b.size {
+		panic(fmt.Sprintf("bitutil: rank index %d out of range [0, %d]", i, b.size))
+	}
+	var n int
+	for _, w := range b.words[:i/64] {
+		n += bits.OnesCount64(w)
+	}
+	if rem := uint(i) % 64; rem != 0 {
+		n += bits.OnesCount64(b.words[i/64] & (1<<rem - 1))
+	}
+	return n
+}
+
+// Select는 k번째(0부터 시작) 설정된 비트의 위치를 반환합니다. 설정된 비트가 k개 이하이면
+// false를 반환합니다.
+func (b *Bitmap) Select(k int) (int, bool) {
+	if k < 0 {
+		return 0, false
+	}
+	for i, w := range b.words {
+		n := bits.OnesCount64(w)
+		if k >= n {
+			k -= n
+			continue
+		}
+		// 워드 안에서 하위 k개의 설정된 비트를 지운 뒤 가장 낮은 비트를 찾습니다.
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+		return i*64 + bits.TrailingZeros64(w), true
+	}
+	return 0, false
+}
+
+// NextSet은 i 이상인 첫 번째 설정된 비트의 위치를 반환합니다. 그런 비트가 없으면 false를
+// 반환합니다.
+func (b *Bitmap) NextSet(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= b.size {
+		return 0, false
+	}
+	idx := i / 64
+	w := b.words[idx] >> (uint(i) % 64)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for idx++; idx < len(b.words); idx++ {
+		if b.words[idx] != 0 {
+			return idx*64 + bits.TrailingZeros64(b.words[idx]), true
+		}
+	}
+	return 0, false
+}
+
+// ForEach는 설정된 각 비트의 위치를 오름차순으로 fn에 전달합니다. fn이 false를 반환하면 순회를
+// 중단합니다.
+func (b *Bitmap) ForEach(fn func(i int) bool) {
+	for idx, w := range b.words {
+		for w != 0 {
+			if !fn(idx*64 + bits.TrailingZeros64(w)) {
+				return
+			}
+			w &= w - 1
+		}
+	}
+}
+
+// check는 비트 위치 i가 범위 안에 있는지 확인합니다.
+func (b *Bitmap) check(i int) {
+	if i < 0 || i >= b.size {
+		panic(fmt.Sprintf("bitutil: bit index %d out of range [0, %d)", i, b.size))
+	}
+}
+
+// checkSize는 x가 b와 같은 크기인지 확인합니다.
+func (b *Bitmap) checkSize(x *Bitmap) {
+	if b.size != x.size {
+		panic(fmt.Sprintf("bitutil: bitmap size mismatch: %d != %d", b.size, x.size))
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bitutil
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBitmapBasic(t *testing.T) {
+	b := NewBitmap(130)
+	for _, i := range []int{0, 63, 64, 129} {
+		b.Set(i)
+	}
+	if !b.Test(63) || !b.Test(64) || b.Test(1) {
+		t.Fatal("wrong bit values")
+	}
+	if n := b.PopCount(); n != 4 {
+		t.Fatalf("wrong popcount %d", n)
+	}
+	b.Clear(63)
+	if b.Test(63) || b.PopCount() != 3 {
+		t.Fatal("bit not cleared")
+	}
+	var set []int
+	b.ForEach(func(i int) bool {
+		set = append(set, i)
+		return true
+	})
+	if !reflect.DeepEqual(set, []int{0, 64, 129}) {
+		t.Fatalf("wrong iteration %v", set)
+	}
+	if i, ok := b.NextSet(1); !ok || i != 64 {
+		t.Fatalf("wrong next set bit %d %v", i, ok)
+	}
+	if _, ok := b.NextSet(130); ok {
+		t.Fatal("next set bit found past the end")
+	}
+}
+
+func TestBitmapLogic(t *testing.T) {
+	x, y := NewBitmap(100), NewBitmap(100)
+	x.Set(1)
+	x.Set(70)
+	y.Set(70)
+	y.Set(99)
+
+	and := NewBitmap(100).Or(x).And(y)
+	if and.PopCount() != 1 || !and.Test(70) {
+		t.Error("wrong AND result")
+	}
+	or := NewBitmap(100).Or(x).Or(y)
+	if or.PopCount() != 3 {
+		t.Error("wrong OR result")
+	}
+	andNot := NewBitmap(100).Or(x).AndNot(y)
+	if andNot.PopCount() != 1 || !andNot.Test(1) {
+		t.Error("wrong AND NOT result")
+	}
+}
+
+// Tests rank and select against a naive implementation on random bitmaps.
+func TestBitmapRankSelect(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range []int{0, 1, 63, 64, 65, 500} {
+		b := NewBitmap(size)
+		var set []int
+		for i := 0; i < size; i++ {
+			if rng.Intn(3) == 0 {
+				b.Set(i)
+				set = append(set, i)
+			}
+		}
+		for i, rank := 0, 0; i <= size; i++ {
+			if have := b.Rank(i); have != rank {
+				t.Fatalf("size %d: wrong rank at %d: have %d, want %d", size, i, have, rank)
+			}
+			if i < size && b.Test(i) {
+				rank++
+			}
+		}
+		for k, want := range set {
+			if have, ok := b.Select(k); !ok || have != want {
+				t.Fatalf("size %d: wrong select(%d): have %d %v, want %d", size, k, have, ok, want)
+			}
+		}
+		if _, ok := b.Select(len(set)); ok {
+			t.Fatalf("size %d: select past the last set bit succeeded", size)
+		}
+	}
+}
@@ -57,6 +57,56 @@ func safeXORBytes(dst, a, b []byte) int {
 	return n
 }
 
+// XORBytesInPlace는 src의 바이트를 dst에 XOR하여 dst를 갱신합니다(dst ^= src).
+// 블룸 필터나 상태 복구처럼 큰 버퍼를 누적하는 경우 별도의 출력 버퍼 없이 사용할 수 있습니다.
+// XOR 연산을 수행한 바이트 수를 반환합니다.
+func XORBytesInPlace(dst, src []byte) int {
+	if supportsUnaligned { // 비정렬 메모리 접근을 지원하는 경우
+		return fastXORBytesInPlace(dst, src)
+	}
+	return safeXORBytesInPlace(dst, src)
+}
+
+// fastXORBytesInPlace는 한 번에 네 워드씩 제자리 XOR 연산을 수행합니다. 비정렬 메모리 접근을
+// 지원하는 아키텍처에서만 동작합니다.
+func fastXORBytesInPlace(dst, src []byte) int {
+	n := len(dst)
+	if len(src) < n {
+		n = len(src)
+	}
+	w := n / wordSize
+	if w > 0 {
+		dw := *(*[]uintptr)(unsafe.Pointer(&dst))
+		sw := *(*[]uintptr)(unsafe.Pointer(&src))
+		i := 0
+		for ; i+4 <= w; i += 4 {
+			dw[i] ^= sw[i]
+			dw[i+1] ^= sw[i+1]
+			dw[i+2] ^= sw[i+2]
+			dw[i+3] ^= sw[i+3]
+		}
+		for ; i < w; i++ {
+			dw[i] ^= sw[i]
+		}
+	}
+	for i := n - n%wordSize; i < n; i++ {
+		dst[i] ^= src[i]
+	}
+	return n
+}
+
+// safeXORBytesInPlace는 하나씩 제자리 XOR 연산을 수행합니다. 모든 아키텍처에서 동작합니다.
+func safeXORBytesInPlace(dst, src []byte) int {
+	n := len(dst)
+	if len(src) < n {
+		n = len(src)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] ^= src[i]
+	}
+	return n
+}
+
 // ANDBytes는 a와 b의 바이트를 AND 연산합니다. 결과를 저장할 dst의 공간이 충분하다고 가정합니다.
 // AND 연산을 수행한 바이트 수를 반환합니다.
 func ANDBytes(dst, a, b []byte) int {
@@ -178,3 +228,51 @@ func safeTestBytes(p []byte) bool {
 	}
 	return false
 }
+
+// TestBits는 a와 b에 공통으로 설정된 비트가 있는지(a&b != 0) 확인합니다. 중간 버퍼를 할당하지
+// 않으므로, 블룸 필터의 포함 여부 검사처럼 AND 결과가 필요 없는 경우에 사용합니다.
+// 길이가 다르면 짧은 쪽의 길이만큼만 검사합니다.
+func TestBits(a, b []byte) bool {
+	if supportsUnaligned { // 비정렬 메모리 접근을 지원하는 경우
+		return fastTestBits(a, b)
+	}
+	return safeTestBits(a, b)
+}
+
+// fastTestBits는 워드 단위로 공통 비트를 확인합니다. 비정렬 메모리 접근을 지원하는 아키텍처에서만 동작합니다.
+func fastTestBits(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	w := n / wordSize
+	if w > 0 {
+		aw := *(*[]uintptr)(unsafe.Pointer(&a))
+		bw := *(*[]uintptr)(unsafe.Pointer(&b))
+		for i := 0; i < w; i++ {
+			if aw[i]&bw[i] != 0 {
+				return true
+			}
+		}
+	}
+	for i := n - n%wordSize; i < n; i++ {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// safeTestBits는 하나씩 공통 비트를 확인합니다. 모든 아키텍처에서 동작합니다.
+func safeTestBits(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -38,6 +38,33 @@ func TestXOR(t *testing.T) {
 	}
 }
 
+// Tests that in-place bitwise XOR works for various alignments.
+func TestXORInPlace(t *testing.T) {
+	for alignS := 0; alignS < 2; alignS++ {
+		for alignD := 0; alignD < 2; alignD++ {
+			src := make([]byte, 1023)[alignS:]
+			for i := 0; i < len(src); i++ {
+				src[i] = byte(i)
+			}
+			d1 := make([]byte, 1023+alignD)[alignD:]
+			for i := 0; i < len(d1); i++ {
+				d1[i] = byte(len(d1) - i)
+			}
+			d2 := append([]byte{}, d1...)
+			want := make([]byte, len(d1))
+			safeXORBytes(want, d1, src)
+
+			if n := XORBytesInPlace(d1, src); n != len(src) {
+				t.Errorf("wrong byte count: have %d, want %d", n, len(src))
+			}
+			safeXORBytesInPlace(d2, src)
+			if !bytes.Equal(d1[:len(src)], want[:len(src)]) || !bytes.Equal(d1, d2) {
+				t.Error("not equal", d1, d2)
+			}
+		}
+	}
+}
+
 // Tests that bitwise AND works for various alignments.
 func TestAND(t *testing.T) {
 	for alignP := 0; alignP < 2; alignP++ {
@@ -112,6 +139,29 @@ func TestTest(t *testing.T) {
 	}
 }
 
+// Tests that common bit testing works for various alignments.
+func TestTestBits(t *testing.T) {
+	for align := 0; align < 2; align++ {
+		p := make([]byte, 1023)[align:]
+		q := make([]byte, 1023)[align:]
+		p[100], q[100] = 0x0f, 0xf0
+		if TestBits(p, q) || safeTestBits(p, q) {
+			t.Error("disjoint bits reported as common")
+		}
+		// Test for common bits in the bulk part
+		q[100] = 0x01
+		if !TestBits(p, q) || !safeTestBits(p, q) {
+			t.Error("common bit not found in bulk part")
+		}
+		// Test for common bits in the tail part
+		q[100] = 0
+		p[len(p)-1], q[len(q)-1] = 0x80, 0x80
+		if !TestBits(p, q) || !safeTestBits(p, q) {
+			t.Error("common bit not found in tail part")
+		}
+	}
+}
+
 // Benchmarks the potentially optimized XOR performance.
 func BenchmarkFastXOR1KB(b *testing.B) { benchmarkFastXOR(b, 1024) }
 func BenchmarkFastXOR2KB(b *testing.B) { benchmarkFastXOR(b, 2048) }
@@ -138,6 +188,32 @@ func benchmarkBaseXOR(b *testing.B, size int) {
 	}
 }
 
+// Benchmarks the potentially optimized in-place XOR performance.
+func BenchmarkFastXORInPlace1KB(b *testing.B) { benchmarkFastXORInPlace(b, 1024) }
+func BenchmarkFastXORInPlace2KB(b *testing.B) { benchmarkFastXORInPlace(b, 2048) }
+func BenchmarkFastXORInPlace4KB(b *testing.B) { benchmarkFastXORInPlace(b, 4096) }
+
+func benchmarkFastXORInPlace(b *testing.B, size int) {
+	p, q := make([]byte, size), make([]byte, size)
+
+	for i := 0; i < b.N; i++ {
+		XORBytesInPlace(p, q)
+	}
+}
+
+// Benchmarks the baseline in-place XOR performance.
+func BenchmarkBaseXORInPlace1KB(b *testing.B) { benchmarkBaseXORInPlace(b, 1024) }
+func BenchmarkBaseXORInPlace2KB(b *testing.B) { benchmarkBaseXORInPlace(b, 2048) }
+func BenchmarkBaseXORInPlace4KB(b *testing.B) { benchmarkBaseXORInPlace(b, 4096) }
+
+func benchmarkBaseXORInPlace(b *testing.B, size int) {
+	p, q := make([]byte, size), make([]byte, size)
+
+	for i := 0; i < b.N; i++ {
+		safeXORBytesInPlace(p, q)
+	}
+}
+
 // Benchmarks the potentially optimized AND performance.
 func BenchmarkFastAND1KB(b *testing.B) { benchmarkFastAND(b, 1024) }
 func BenchmarkFastAND2KB(b *testing.B) { benchmarkFastAND(b, 2048) }
@@ -219,3 +295,31 @@ func benchmarkBaseTest(b *testing.B, size int) {
 	}
 	GloBool = a // Use of benchmark "result" to prevent total dead code elimination.
 }
+
+// Benchmarks the potentially optimized common bit testing performance.
+func BenchmarkFastTestBits1KB(b *testing.B) { benchmarkFastTestBits(b, 1024) }
+func BenchmarkFastTestBits2KB(b *testing.B) { benchmarkFastTestBits(b, 2048) }
+func BenchmarkFastTestBits4KB(b *testing.B) { benchmarkFastTestBits(b, 4096) }
+
+func benchmarkFastTestBits(b *testing.B, size int) {
+	p, q := make([]byte, size), make([]byte, size)
+	a := false
+	for i := 0; i < b.N; i++ {
+		a = a != TestBits(p, q)
+	}
+	GloBool = a // Use of benchmark "result" to prevent total dead code elimination.
+}
+
+// Benchmarks the baseline common bit testing performance.
+func BenchmarkBaseTestBits1KB(b *testing.B) { benchmarkBaseTestBits(b, 1024) }
+func BenchmarkBaseTestBits2KB(b *testing.B) { benchmarkBaseTestBits(b, 2048) }
+func BenchmarkBaseTestBits4KB(b *testing.B) { benchmarkBaseTestBits(b, 4096) }
+
+func benchmarkBaseTestBits(b *testing.B, size int) {
+	p, q := make([]byte, size), make([]byte, size)
+	a := false
+	for i := 0; i < b.N; i++ {
+		a = a != safeTestBits(p, q)
+	}
+	GloBool = a // Use of benchmark "result" to prevent total dead code elimination.
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bitutil
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// StreamChunkSize는 스트리밍 압축에서 하나의 청크로 압축되는 비압축 데이터의 크기입니다.
+const StreamChunkSize = 4096
+
+// errCompressorClosed는 닫힌 Compressor에 쓰기를 시도할 때 반환됩니다.
+var errCompressorClosed = errors.New("write to closed compressor")
+
+// Compressor는 CompressBytes와 같은 희소 비트셋 알고리즘으로 데이터를 스트리밍 압축하는 io.WriteCloser입니다.
+//
+// 입력은 StreamChunkSize 크기의 청크로 나뉘어 각각 독립적으로 압축되며, 각 청크는 압축된 길이(uvarint)와
+// 압축된 바이트로 기록됩니다. 따라서 메모리 사용량은 입력 크기와 무관하게 청크 하나로 제한됩니다.
+// 스트림 형식은 CompressBytes의 출력과 호환되지 않으므로 NewDecompressor로만 해제할 수 있습니다.
+type Compressor struct {
+	w      io.Writer
+	buf    []byte // 아직 압축되지 않은 현재 청크
+	hdr    [binary.MaxVarintLen64]byte
+	err    error
+	closed bool
+}
+
+// NewCompressor는 압축된 데이터를 w에 기록하는 새 Compressor를 생성합니다.
+// 마지막 청크를 기록하려면 모든 데이터를 쓴 후 Close를 호출해야 합니다.
+func NewCompressor(w io.Writer) *Compressor {
+	return &Compressor{w: w, buf: make([]byte, 0, StreamChunkSize)}
+}
+
+// Write는 io.Writer를 구현합니다. 청크가 가득 찰 때마다 압축하여 하위 writer에 기록합니다.
+func (c *Compressor) Write(p []byte) (int, error) {
+	if c.closed {
+		return 0, errCompressorClosed
+	}
+	if c.err != nil {
+		return 0, c.err
+	}
+	written := 0
+	for len(p) > 0 {
+		n := copy(c.buf[len(c.buf):cap(c.buf)], p)
+		c.buf = c.buf[:len(c.buf)+n]
+		p = p[n:]
+		written += n
+
+		if len(c.buf) == cap(c.buf) {
+			if err := c.flushChunk(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close는 남아 있는 부분 청크를 압축하여 기록합니다. 하위 writer는 닫지 않습니다.
+func (c *Compressor) Close() error {
+	if c.closed {
+		return c.err
+	}
+	c.closed = true
+	if c.err == nil && len(c.buf) > 0 {
+		c.flushChunk()
+	}
+	return c.err
+}
+
+// flushChunk는 현재 청크를 압축하여 하위 writer에 기록하고 버퍼를 비웁니다.
+func (c *Compressor) flushChunk() error {
+	comp := CompressBytes(c.buf)
+	n := binary.PutUvarint(c.hdr[:], uint64(len(comp)))
+	if _, err := c.w.Write(c.hdr[:n]); err != nil {
+		c.err = err
+		return err
+	}
+	if _, err := c.w.Write(comp); err != nil {
+		c.err = err
+		return err
+	}
+	c.buf = c.buf[:0]
+	return nil
+}
+
+// Decompressor는 Compressor가 생성한 스트림을 해제하는 io.Reader입니다.
+type Decompressor struct {
+	r         *bufio.Reader
+	remaining int    // 아직 해제되지 않은 비압축 바이트 수
+	frame     []byte // 압축된 청크를 읽기 위한 버퍼
+	chunk     []byte // 현재 해제된 청크
+	err       error
+}
+
+// NewDecompressor는 r에서 압축된 스트림을 읽어 정확히 target 바이트를 해제하는 새 Decompressor를 생성합니다.
+// 읽기 효율을 위해 r에서 스트림의 끝을 넘어서까지 읽을 수 있습니다.
+func NewDecompressor(r io.Reader, target int) *Decompressor {
+	return &Decompressor{r: bufio.NewReader(r), remaining: target}
+}
+
+// Read는 io.Reader를 구현합니다. target 바이트를 모두 반환한 후에는 io.EOF를 반환합니다.
+func (d *Decompressor) Read(p []byte) (int, error) {
+	if len(d.chunk) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.remaining == 0 {
+			return 0, io.EOF
+		}
+		if err := d.nextChunk(); err != nil {
+			d.err = err
+			return 0, err
+		}
+	}
+	n := copy(p, d.chunk)
+	d.chunk = d.chunk[n:]
+	return n, nil
+}
+
+// nextChunk는 다음 압축된 청크를 읽어 해제합니다.
+func (d *Decompressor) nextChunk() error {
+	target := StreamChunkSize
+	if d.remaining < target {
+		target = d.remaining
+	}
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if size > uint64(target) {
+		return errExceededTarget
+	}
+	if cap(d.frame) < int(size) {
+		d.frame = make([]byte, size)
+	}
+	d.frame = d.frame[:size]
+	if _, err := io.ReadFull(d.r, d.frame); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	chunk, err := DecompressBytes(d.frame, target)
+	if err != nil {
+		return err
+	}
+	d.chunk = chunk
+	d.remaining -= target
+	return nil
+}
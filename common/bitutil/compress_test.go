@@ -18,7 +18,9 @@ package bitutil
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"testing"
 
@@ -149,6 +151,55 @@ func TestCompression(t *testing.T) {
 }
 
 // Crude benchmark for compressing random slices of bytes.
+// Tests that the streaming compressor and decompressor round trip data of
+// various sizes, independent of how the input is split across writes.
+func TestStreamingCycle(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	for _, size := range []int{0, 1, 100, StreamChunkSize - 1, StreamChunkSize, StreamChunkSize + 1, 3*StreamChunkSize + 17} {
+		data := make([]byte, size)
+		for i := 0; i < size/50; i++ {
+			data[random.Intn(size)] = byte(random.Intn(256))
+		}
+		var (
+			buf  bytes.Buffer
+			comp = NewCompressor(&buf)
+		)
+		for rest := data; len(rest) > 0; {
+			n := 1 + random.Intn(1000)
+			if n > len(rest) {
+				n = len(rest)
+			}
+			if _, err := comp.Write(rest[:n]); err != nil {
+				t.Fatalf("size %d: write failed: %v", size, err)
+			}
+			rest = rest[n:]
+		}
+		if err := comp.Close(); err != nil {
+			t.Fatalf("size %d: close failed: %v", size, err)
+		}
+		if size >= 100 && buf.Len() >= size {
+			t.Errorf("size %d: sparse data not compressed: %d bytes", size, buf.Len())
+		}
+		enc := buf.Bytes()
+
+		dec, err := io.ReadAll(NewDecompressor(bytes.NewReader(enc), size))
+		if err != nil {
+			t.Fatalf("size %d: decompression failed: %v", size, err)
+		}
+		if !bytes.Equal(dec, data) {
+			t.Fatalf("size %d: compress/decompress mismatch", size)
+		}
+		// Truncated streams must be rejected.
+		if len(enc) > 0 {
+			_, err = io.ReadAll(NewDecompressor(bytes.NewReader(enc[:len(enc)-1]), size))
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("size %d: truncated stream error mismatch: have %v, want %v", size, err, io.ErrUnexpectedEOF)
+			}
+		}
+	}
+}
+
 func BenchmarkEncoding1KBVerySparse(b *testing.B) { benchmarkEncoding(b, 1024, 0.0001) }
 func BenchmarkEncoding2KBVerySparse(b *testing.B) { benchmarkEncoding(b, 2048, 0.0001) }
 func BenchmarkEncoding4KBVerySparse(b *testing.B) { benchmarkEncoding(b, 4096, 0.0001) }
@@ -124,3 +124,22 @@ func TestTrimRightZeroes(t *testing.T) {
 		}
 	}
 }
+
+func TestTrimLeftZeroes(t *testing.T) {
+	tests := []struct {
+		arr []byte
+		exp []byte
+	}{
+		{FromHex("0x0000ffff00ff00"), FromHex("0xffff00ff00")},
+		{FromHex("0x00000000000000"), []byte{}},
+		{FromHex("0xff"), FromHex("0xff")},
+		{[]byte{}, []byte{}},
+		{FromHex("0xffffffffffff00"), FromHex("0xffffffffffff00")},
+	}
+	for i, test := range tests {
+		got := TrimLeftZeroes(test.arr)
+		if !bytes.Equal(got, test.exp) {
+			t.Errorf("test %d, got %x exp %x", i, got, test.exp)
+		}
+	}
+}
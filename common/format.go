@@ -37,6 +37,13 @@ func (d PrettyDuration) String() string {
 	return label
 }
 
+// ParsePrettyDuration은 PrettyDuration.String의 출력 형식(예: "1.5s", "2h45m0s")을 기간으로
+// 변환합니다.
+func ParsePrettyDuration(s string) (PrettyDuration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	return PrettyDuration(d), err
+}
+
 // PrettyAge는 time.Duration 값을 예쁘게 표시한 것으로, 값을 년/월/주를 포함한 하나의 최상위 단위(a single most significant unit)로 반올림합니다.
 type PrettyAge time.Time
 
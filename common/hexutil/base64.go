@@ -0,0 +1,72 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hexutil
+
+import (
+	"bytes"
+	"encoding/base64"
+	"reflect"
+)
+
+var base64BytesT = reflect.TypeOf(Base64Bytes(nil))
+
+// Base64Bytes는 Bytes와 같은 바이트 슬라이스이지만 JSON과 텍스트에서 16진수 대신 표준 base64
+// 문자열로 마샬링됩니다. gRPC 게이트웨이나 REST API처럼 바이트를 base64로 주고받는 프로토콜에
+// 사용합니다. 두 타입은 같은 기반 타입을 가지므로 Bytes(b)와 Base64Bytes(b)로 복사 없이
+// 변환할 수 있고, 같은 값을 프로토콜에 따라 다른 형식으로 내보낼 수 있습니다.
+//
+// 언마샬링할 때는 proto3 JSON 매핑과 같이 표준 및 URL 안전 알파벳을 모두 받아들이며, 패딩은
+// 생략할 수 있습니다. 빈 문자열은 빈 슬라이스로 디코딩됩니다.
+type Base64Bytes []byte
+
+// MarshalText는 encoding.TextMarshaler를 구현합니다.
+func (b Base64Bytes) MarshalText() ([]byte, error) {
+	result := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(result, b)
+	return result, nil
+}
+
+// UnmarshalJSON은 json.Unmarshaler를 구현합니다.
+func (b *Base64Bytes) UnmarshalJSON(input []byte) error {
+	if !isString(input) {
+		return errNonString(base64BytesT)
+	}
+	return wrapTypeError(b.UnmarshalText(input[1:len(input)-1]), base64BytesT)
+}
+
+// UnmarshalText는 encoding.TextUnmarshaler를 구현합니다.
+func (b *Base64Bytes) UnmarshalText(input []byte) error {
+	enc := base64.StdEncoding
+	if bytes.ContainsAny(input, "-_") {
+		enc = base64.URLEncoding
+	}
+	if len(input)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	dec := make([]byte, enc.DecodedLen(len(input)))
+	n, err := enc.Decode(dec, input)
+	if err != nil {
+		return ErrInvalidBase64
+	}
+	*b = dec[:n]
+	return nil
+}
+
+// String은 b의 base64 인코딩을 반환합니다.
+func (b Base64Bytes) String() string {
+	return base64.StdEncoding.EncodeToString(b)
+}
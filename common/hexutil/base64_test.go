@@ -0,0 +1,76 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hexutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var unmarshalBase64Tests = []unmarshalTest{
+	// invalid encoding
+	{input: "null", wantErr: errNonString(base64BytesT)},
+	{input: "10", wantErr: errNonString(base64BytesT)},
+	{input: `"A"`, wantErr: wrapTypeError(ErrInvalidBase64, base64BytesT)},
+	{input: `"AQ=A"`, wantErr: wrapTypeError(ErrInvalidBase64, base64BytesT)},
+	{input: `"+_8="`, wantErr: wrapTypeError(ErrInvalidBase64, base64BytesT)},
+
+	// valid encoding
+	{input: `""`, want: referenceBytes("")},
+	{input: `"Ag=="`, want: referenceBytes("02")},
+	{input: `"Ag"`, want: referenceBytes("02")},
+	{input: `"+/8="`, want: referenceBytes("fbff")},
+	{input: `"-_8"`, want: referenceBytes("fbff")},
+	{input: `"//////8="`, want: referenceBytes("ffffffffff")},
+}
+
+func TestUnmarshalBase64Bytes(t *testing.T) {
+	for _, test := range unmarshalBase64Tests {
+		var v Base64Bytes
+		err := json.Unmarshal([]byte(test.input), &v)
+		if !checkError(t, test.input, err, test.wantErr) {
+			continue
+		}
+		if !bytes.Equal(test.want.([]byte), v) {
+			t.Errorf("input %s: value mismatch: got %x, want %x", test.input, []byte(v), test.want)
+		}
+	}
+}
+
+func TestMarshalBase64Bytes(t *testing.T) {
+	// The same data can be exported as hex or base64 by swapping the field type.
+	type hexDTO struct{ Data Bytes }
+	type base64DTO struct{ Data Base64Bytes }
+
+	data := []byte{0xfb, 0xff, 0x00}
+	hexOut, _ := json.Marshal(hexDTO{Data: data})
+	b64Out, _ := json.Marshal(base64DTO{Data: data})
+	if string(hexOut) != `{"Data":"0xfbff00"}` {
+		t.Errorf("wrong hex output %s", hexOut)
+	}
+	if string(b64Out) != `{"Data":"+/8A"}` {
+		t.Errorf("wrong base64 output %s", b64Out)
+	}
+	if s := Base64Bytes(data).String(); s != "+/8A" {
+		t.Errorf("wrong String output %q", s)
+	}
+	var dec base64DTO
+	if err := json.Unmarshal(b64Out, &dec); err != nil || !bytes.Equal(dec.Data, data) {
+		t.Errorf("round trip failed: %x, %v", []byte(dec.Data), err)
+	}
+}
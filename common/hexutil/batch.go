@@ -0,0 +1,91 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hexutil
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// IndexError는 일괄 디코딩 함수가 반환하는 오류로, 디코딩에 실패한 입력의 인덱스를 포함합니다.
+type IndexError struct {
+	Index int   // 실패한 입력의 인덱스
+	Err   error // 해당 입력의 디코딩 오류
+}
+
+func (err *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %v", err.Index, err.Err)
+}
+
+func (err *IndexError) Unwrap() error { return err.Err }
+
+// DecodeAll은 0x 접두사가 있는 16진수 문자열들을 바이트열로 디코딩합니다. 입력 중 하나라도
+// 잘못되면 부분 결과 없이 실패한 인덱스를 담은 *IndexError를 반환합니다.
+func DecodeAll(inputs []string) ([][]byte, error) {
+	return decodeAll(inputs, Decode)
+}
+
+// EncodeAll은 각 바이트열을 0x 접두사가 있는 16진수 문자열로 인코딩합니다.
+func EncodeAll(bs [][]byte) []string {
+	enc := make([]string, len(bs))
+	for i, b := range bs {
+		enc[i] = Encode(b)
+	}
+	return enc
+}
+
+// DecodeUint64s는 0x 접두사가 있는 16진수 quantity 문자열들을 숫자로 디코딩합니다. 입력 중
+// 하나라도 잘못되면 부분 결과 없이 실패한 인덱스를 담은 *IndexError를 반환합니다.
+func DecodeUint64s(inputs []string) ([]uint64, error) {
+	return decodeAll(inputs, DecodeUint64)
+}
+
+// EncodeUint64s는 각 숫자를 0x 접두사가 있는 16진수 quantity 문자열로 인코딩합니다.
+func EncodeUint64s(is []uint64) []string {
+	enc := make([]string, len(is))
+	for i, v := range is {
+		enc[i] = EncodeUint64(v)
+	}
+	return enc
+}
+
+// DecodeBigs는 0x 접두사가 있는 16진수 quantity 문자열들을 big.Int로 디코딩합니다. 입력 중
+// 하나라도 잘못되면 부분 결과 없이 실패한 인덱스를 담은 *IndexError를 반환합니다.
+func DecodeBigs(inputs []string) ([]*big.Int, error) {
+	return decodeAll(inputs, DecodeBig)
+}
+
+// EncodeBigs는 각 big.Int를 0x 접두사가 있는 16진수 quantity 문자열로 인코딩합니다.
+func EncodeBigs(bigints []*big.Int) []string {
+	enc := make([]string, len(bigints))
+	for i, v := range bigints {
+		enc[i] = EncodeBig(v)
+	}
+	return enc
+}
+
+func decodeAll[T any](inputs []string, decode func(string) (T, error)) ([]T, error) {
+	out := make([]T, len(inputs))
+	for i, input := range inputs {
+		dec, err := decode(input)
+		if err != nil {
+			return nil, &IndexError{Index: i, Err: err}
+		}
+		out[i] = dec
+	}
+	return out, nil
+}
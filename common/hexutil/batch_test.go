@@ -0,0 +1,71 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hexutil
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeAll(t *testing.T) {
+	dec, err := DecodeAll([]string{"0x", "0x01", "0x0203"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]byte{{}, {0x01}, {0x02, 0x03}}
+	if !reflect.DeepEqual(dec, want) {
+		t.Fatalf("wrong result: %x", dec)
+	}
+	if enc := EncodeAll(want); !reflect.DeepEqual(enc, []string{"0x", "0x01", "0x0203"}) {
+		t.Fatalf("wrong encoding: %v", enc)
+	}
+
+	dec, err = DecodeAll([]string{"0x01", "0x1"})
+	var ierr *IndexError
+	if !errors.As(err, &ierr) || ierr.Index != 1 || !errors.Is(err, ErrOddLength) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if dec != nil {
+		t.Fatal("partial result returned on error")
+	}
+}
+
+func TestDecodeQuantities(t *testing.T) {
+	nums, err := DecodeUint64s([]string{"0x0", "0x1f"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(nums, []uint64{0, 31}) {
+		t.Fatalf("wrong result: %v", nums)
+	}
+	if _, err := DecodeUint64s([]string{"0x1", "0x01"}); !errors.Is(err, ErrLeadingZero) || err.Error() != "index 1: hex number with leading zero digits" {
+		t.Fatalf("wrong error: %v", err)
+	}
+
+	bigs, err := DecodeBigs(EncodeBigs([]*big.Int{big.NewInt(0), big.NewInt(255)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bigs[0].Sign() != 0 || bigs[1].Int64() != 255 {
+		t.Fatalf("wrong result: %v", bigs)
+	}
+	if enc := EncodeUint64s([]uint64{0, 31}); !reflect.DeepEqual(enc, []string{"0x0", "0x1f"}) {
+		t.Fatalf("wrong encoding: %v", enc)
+	}
+}
@@ -33,6 +33,8 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+
+	"github.com/holiman/uint256"
 )
 
 const uintBits = 32 << (uint64(^uint(0)) >> 63) // 64
@@ -48,6 +50,7 @@ var (
 	ErrUint64Range   = &decError{"hex number > 64 bits"}
 	ErrUintRange     = &decError{fmt.Sprintf("hex number > %d bits", uintBits)}
 	ErrBig256Range   = &decError{"hex number > 256 bits"}
+	ErrInvalidBase64 = &decError{"invalid base64 string"}
 )
 
 type decError struct{ msg string }
@@ -172,6 +175,56 @@ func MustDecodeBig(input string) *big.Int {
 	return dec
 }
 
+// DecodeU256는 0x 접두사가 있는 16진수 문자열을 uint256.Int로 디코딩합니다.
+// 입력 규칙과 오류는 DecodeBig과 동일하며, 256비트보다 큰 숫자는 허용되지 않습니다.
+func DecodeU256(input string) (*uint256.Int, error) {
+	raw, err := checkNumber(input)
+	if err != nil {
+		return nil, err
+	}
+	dec := new(uint256.Int)
+	if err := decodeU256(raw, dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// MustDecodeU256는 0x 접두사가 있는 16진수 문자열을 uint256.Int로 디코딩합니다.
+// 잘못된 입력에 대해서는 패닉이 발생합니다.
+func MustDecodeU256(input string) *uint256.Int {
+	dec, err := DecodeU256(input)
+	if err != nil {
+		panic(err)
+	}
+	return dec
+}
+
+// decodeU256는 접두사가 없는 16진수 숫자 raw를 out에 디코딩합니다. 입력의 구문이 올바른
+// 경우에만 out을 수정합니다.
+func decodeU256(raw string, out *uint256.Int) error {
+	if len(raw) > 64 {
+		return ErrBig256Range
+	}
+	var words [4]uint64
+	end := len(raw)
+	for i := 0; end > 0; i++ {
+		start := end - 16
+		if start < 0 {
+			start = 0
+		}
+		for ri := start; ri < end; ri++ {
+			nib := decodeNibble(raw[ri])
+			if nib == badNibble {
+				return ErrSyntax
+			}
+			words[i] = words[i]<<4 | nib
+		}
+		end = start
+	}
+	*out = words
+	return nil
+}
+
 // EncodeBig은 bigint를 0x 접두사가 있는 16진수 문자열로 인코딩합니다.
 func EncodeBig(bigint *big.Int) string {
 	if sign := bigint.Sign(); sign == 0 {
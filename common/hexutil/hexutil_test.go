@@ -180,6 +180,19 @@ func TestDecodeBig(t *testing.T) {
 	}
 }
 
+func TestDecodeU256(t *testing.T) {
+	for _, test := range decodeBigTests {
+		dec, err := DecodeU256(test.input)
+		if !checkError(t, test.input, err, test.wantErr) {
+			continue
+		}
+		if dec.ToBig().Cmp(test.want.(*big.Int)) != 0 {
+			t.Errorf("input %s: value mismatch: got %x, want %x", test.input, dec, test.want)
+			continue
+		}
+	}
+}
+
 func TestEncodeUint64(t *testing.T) {
 	for _, test := range encodeUint64Tests {
 		enc := EncodeUint64(test.input.(uint64))
@@ -103,37 +103,52 @@ func UnmarshalFixedJSON(typ reflect.Type, input, out []byte) error {
 	return wrapTypeError(UnmarshalFixedText(typ.String(), input[1:len(input)-1], out), typ)
 }
 
-// UnmarshalFixedText는 0x 접두사가 있는 문자열을 디코딩합니다. out의 길이는 필요한 입력 길이를 결정합니다.
-// 이 함수는 고정 크기 타입의 UnmarshalText 메서드를 구현하는 데 주로 사용됩니다.
-func UnmarshalFixedText(typname string, input, out []byte) error {
-	raw, err := checkText(input, true)
-	if err != nil {
-		return err
-	}
-	if len(raw)/2 != len(out) {
-		return fmt.Errorf("hex string has length %d, want %d for %s", len(raw), len(out)*2, typname)
+// UnmarshalFixedJSONOf는 UnmarshalFixedJSON과 같지만 대상 타입을 타입 매개변수 T로 받습니다.
+// reflect.Type과 타입 이름은 오류가 발생했을 때만 구하므로 올바른 입력을 디코딩하는 동안에는
+// 리플렉션을 사용하지 않습니다. 오류 값은 UnmarshalFixedJSON이 반환하는 것과 같습니다.
+func UnmarshalFixedJSONOf[T any](input, out []byte) error {
+	if !isString(input) {
+		return errNonString(typeOf[T]())
 	}
-	// out을 수정하기 전에 구문을 사전 확인합니다.
-	for _, b := range raw {
-		if decodeNibble(b) == badNibble {
-			return ErrSyntax
-		}
+	if err := decodeFixedText(input[1:len(input)-1], out, true); err != nil {
+		typ := typeOf[T]()
+		return wrapTypeError(fixedTextError(err, typ.String()), typ)
 	}
-	hex.Decode(out, raw)
 	return nil
 }
 
+// UnmarshalFixedText는 0x 접두사가 있는 문자열을 디코딩합니다. out의 길이는 필요한 입력 길이를 결정합니다.
+// 이 함수는 고정 크기 타입의 UnmarshalText 메서드를 구현하는 데 주로 사용됩니다.
+func UnmarshalFixedText(typname string, input, out []byte) error {
+	return fixedTextError(decodeFixedText(input, out, true), typname)
+}
+
 // UnmarshalFixedUnprefixedText는 0x 접두사가 있거나 없는 문자열을 디코딩합니다. out의 길이는 필요한 입력 길이를 결정합니다.
 // 이 함수는 고정 크기 타입의 UnmarshalText 메서드를 구현하는 데 주로 사용됩니다.
 func UnmarshalFixedUnprefixedText(typname string, input, out []byte) error {
-	raw, err := checkText(input, false)
+	return fixedTextError(decodeFixedText(input, out, false), typname)
+}
+
+// fixedLengthError는 16진수 문자열의 길이가 고정 크기 타입과 맞지 않을 때 decodeFixedText가
+// 반환합니다. 타입 이름은 fixedTextError가 오류 메시지를 만들 때 채웁니다.
+type fixedLengthError struct {
+	have, want int
+}
+
+func (err *fixedLengthError) Error() string {
+	return fmt.Sprintf("hex string has length %d, want %d", err.have, err.want)
+}
+
+// decodeFixedText는 input을 out에 디코딩합니다. 오류가 발생하면 out은 수정되지 않습니다.
+func decodeFixedText(input, out []byte, wantPrefix bool) error {
+	raw, err := checkText(input, wantPrefix)
 	if err != nil {
 		return err
 	}
 	if len(raw)/2 != len(out) {
-		return fmt.Errorf("hex string has length %d, want %d for %s", len(raw), len(out)*2, typname)
+		return &fixedLengthError{have: len(raw), want: len(out) * 2}
 	}
-	// Pre-verify syntax before modifying out.
+	// out을 수정하기 전에 구문을 사전 확인합니다.
 	for _, b := range raw {
 		if decodeNibble(b) == badNibble {
 			return ErrSyntax
@@ -143,6 +158,19 @@ func UnmarshalFixedUnprefixedText(typname string, input, out []byte) error {
 	return nil
 }
 
+// fixedTextError는 decodeFixedText의 길이 오류에 타입 이름을 붙입니다. 다른 오류는 그대로 반환합니다.
+func fixedTextError(err error, typname string) error {
+	if lerr, ok := err.(*fixedLengthError); ok {
+		return fmt.Errorf("%v for %s", lerr, typname)
+	}
+	return err
+}
+
+// typeOf는 T의 reflect.Type을 반환합니다. T가 인터페이스 타입이어도 nil이 아닌 값을 반환합니다.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
 // Big은 0x 접두사가 있는 JSON 문자열로 마샬링/언마샬링됩니다.
 // 0은 "0x0"으로 마샬링됩니다.
 //
@@ -226,19 +254,28 @@ func (b *Big) UnmarshalGraphQL(input interface{}) error {
 
 // U256은 0x 접두사가 있는 JSON 문자열로 마샬링/언마샬링됩니다.
 // 0은 "0x0"으로 마샬링됩니다.
+//
+// 마샬링 결과는 항상 앞에 0이 없는 최소 길이의 정규 16진수 표현이며, 언마샬링은 Big과 같은
+// 규칙으로 입력을 엄격하게 검증합니다. 256비트보다 큰 값은 거부됩니다.
 type U256 uint256.Int
 
-// U256은 0x 접두사가 있는 JSON 문자열로 마샬링/언마샬링됩니다.
+// MarshalText는 encoding.TextMarshaler를 구현합니다.
 func (b U256) MarshalText() ([]byte, error) {
 	u256 := (*uint256.Int)(&b)
 	return []byte(u256.Hex()), nil
 }
 
+// MarshalJSON은 json.Marshaler를 구현합니다.
+func (b U256) MarshalJSON() ([]byte, error) {
+	hex := (*uint256.Int)(&b).Hex()
+	out := make([]byte, 0, len(hex)+2)
+	out = append(out, '"')
+	out = append(out, hex...)
+	return append(out, '"'), nil
+}
+
 // UnmarshalJSON은 json.Unmarshaler를 구현합니다.
 func (b *U256) UnmarshalJSON(input []byte) error {
-	// The uint256.Int.UnmarshalJSON method accepts "dec", "0xhex"; we must be
-	// more strict, hence we check string and invoke SetFromHex directly.
-
 	// uint256.Int.UnmarshalJSON 메서드는 "dec", "0xhex"를 허용합니다.
 	// 더 엄격한 방식으로 입력을 확인해야 하므로, 입력이 문자열인지 확인합니다.
 	if !isString(input) {
@@ -250,18 +287,23 @@ func (b *U256) UnmarshalJSON(input []byte) error {
 		(*uint256.Int)(b).Clear()
 		return nil
 	}
-	err := (*uint256.Int)(b).SetFromHex(string(input[1 : len(input)-1]))
-	if err != nil {
-		return &json.UnmarshalTypeError{Value: err.Error(), Type: u256T}
-	}
-	return nil
+	return wrapTypeError(b.UnmarshalText(input[1:len(input)-1]), u256T)
 }
 
 // UnmarshalText는 encoding.TextUnmarshaler를 구현합니다.
 func (b *U256) UnmarshalText(input []byte) error {
 	// uint256.Int.UnmarshalText 메서드는 "dec", "0xhex"를 허용합니다.
-	// 더 엄격한 방식으로 입력을 확인하고, SetFromHex를 직접 호출합니다.
-	return (*uint256.Int)(b).SetFromHex(string(input))
+	// 더 엄격한 방식으로 입력을 확인하고, 16진수 숫자만 디코딩합니다.
+	raw, err := checkNumberText(input)
+	if err != nil {
+		return err
+	}
+	return decodeU256(string(raw), (*uint256.Int)(b))
+}
+
+// ToInt는 b를 uint256.Int로 변환합니다.
+func (b *U256) ToInt() *uint256.Int {
+	return (*uint256.Int)(b)
 }
 
 // String은 b의 16진수 인코딩을 반환합니다.
@@ -269,6 +311,26 @@ func (b *U256) String() string {
 	return (*uint256.Int)(b).Hex()
 }
 
+// ImplementsGraphQLType은 U256이 특정한 GraphQL 타입을 구현하는지 여부를 반환합니다.
+func (b U256) ImplementsGraphQLType(name string) bool { return name == "BigInt" }
+
+// UnmarshalGraphQL은 제공된 GraphQL 쿼리 데이터를 U256으로 변환합니다.
+func (b *U256) UnmarshalGraphQL(input interface{}) error {
+	var err error
+	switch input := input.(type) {
+	case string:
+		return b.UnmarshalText([]byte(input))
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("negative value %d for BigInt", input)
+		}
+		(*uint256.Int)(b).SetUint64(uint64(input))
+	default:
+		err = fmt.Errorf("unexpected type %T for BigInt", input)
+	}
+	return err
+}
+
 // Uint64는 0x 접두사가 있는 JSON 문자열로 마샬링/언마샬링됩니다.
 // 0은 "0x0"으로 마샬링됩니다.
 type Uint64 uint64
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/holiman/uint256"
@@ -265,6 +266,57 @@ func TestMarshalBig(t *testing.T) {
 	}
 }
 
+func TestMarshalU256(t *testing.T) {
+	for _, test := range encodeBigTests {
+		in := test.input.(*big.Int)
+		if in.Sign() < 0 {
+			continue
+		}
+		u, _ := uint256.FromBig(in)
+		out, err := json.Marshal((*U256)(u))
+		if err != nil {
+			t.Errorf("%d: %v", in, err)
+			continue
+		}
+		if want := `"` + test.want + `"`; string(out) != want {
+			t.Errorf("%d: MarshalJSON output mismatch: got %q, want %q", in, out, want)
+			continue
+		}
+		if out := (*U256)(u).String(); out != test.want {
+			t.Errorf("%x: String mismatch: got %q, want %q", in, out, test.want)
+			continue
+		}
+	}
+}
+
+func TestU256GraphQL(t *testing.T) {
+	var v U256
+	if !v.ImplementsGraphQLType("BigInt") {
+		t.Fatal("U256 should implement BigInt")
+	}
+	tests := []struct {
+		input   interface{}
+		want    uint64
+		wantErr bool
+	}{
+		{input: "0x2a", want: 42},
+		{input: int32(7), want: 7},
+		{input: "0x01", wantErr: true},
+		{input: int32(-1), wantErr: true},
+		{input: 1.5, wantErr: true},
+	}
+	for _, test := range tests {
+		err := v.UnmarshalGraphQL(test.input)
+		if (err != nil) != test.wantErr {
+			t.Errorf("input %v: error mismatch: have %v, want error %t", test.input, err, test.wantErr)
+			continue
+		}
+		if err == nil && v.ToInt().Uint64() != test.want {
+			t.Errorf("input %v: value mismatch: have %v, want %d", test.input, v.ToInt(), test.want)
+		}
+	}
+}
+
 var unmarshalUint64Tests = []unmarshalTest{
 	// invalid encoding
 	{input: "", wantErr: errJSONEOF},
@@ -432,3 +484,57 @@ func TestUnmarshalFixedUnprefixedText(t *testing.T) {
 		}
 	}
 }
+
+type fixedTestType [4]byte
+
+func TestUnmarshalFixedJSONOf(t *testing.T) {
+	typ := reflect.TypeOf(fixedTestType{})
+	tests := []struct {
+		input string
+		want  []byte
+	}{
+		{input: `0x44444444`},
+		{input: `""`},
+		{input: `"0x2"`},
+		{input: `"44444444"`},
+		{input: `"0x4444"`},
+		{input: `"0x444444gg"`, want: []byte{0, 0, 0, 0}},
+		{input: `"0x44444444"`, want: []byte{0x44, 0x44, 0x44, 0x44}},
+	}
+	for _, test := range tests {
+		var have, want fixedTestType
+		err := UnmarshalFixedJSONOf[fixedTestType]([]byte(test.input), have[:])
+		wantErr := UnmarshalFixedJSON(typ, []byte(test.input), want[:])
+		if !reflect.DeepEqual(err, wantErr) {
+			t.Errorf("%s: error mismatch: got %v, want %v", test.input, err, wantErr)
+		}
+		if have != want {
+			t.Errorf("%s: output mismatch: got %x, want %x", test.input, have, want)
+		}
+		if test.want != nil && !bytes.Equal(have[:], test.want) {
+			t.Errorf("%s: wrong output %x, want %x", test.input, have, test.want)
+		}
+	}
+}
+
+func BenchmarkUnmarshalFixedJSON(b *testing.B) {
+	input := []byte(`"0x123456789abcdef123456789abcdef123456789abcdef123456789abcdef1234"`)
+	var out [32]byte
+	b.Run("reflect", func(b *testing.B) {
+		typ := reflect.TypeOf(out)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := UnmarshalFixedJSON(typ, input, out[:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := UnmarshalFixedJSONOf[[32]byte](input, out[:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
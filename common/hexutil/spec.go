@@ -0,0 +1,93 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hexutil
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// InvalidParamsCode는 JSON-RPC 2.0 명세에서 잘못된 메서드 파라미터에 사용하는 오류 코드입니다.
+const InvalidParamsCode = -32602
+
+// SpecType은 이더리움 JSON-RPC 명세에서 16진수 값의 인코딩 형식입니다.
+type SpecType string
+
+const (
+	// Quantity는 정수 값의 인코딩입니다. 0x 접두사 뒤에 최소한 하나의 숫자가 있어야 하며
+	// 앞에 0이 붙을 수 없습니다. 숫자 0은 "0x0"입니다.
+	Quantity SpecType = "QUANTITY"
+	// Data는 바이트열의 인코딩입니다. 0x 접두사 뒤에 바이트당 두 개의 숫자가 있어야 하며,
+	// 빈 바이트열은 "0x"입니다.
+	Data SpecType = "DATA"
+)
+
+// SpecError는 DecodeQuantity와 DecodeData가 반환하는 오류입니다. 위반한 인코딩 형식과
+// 원인이 되는 hexutil 오류를 포함하며, ErrorCode를 구현하므로 RPC 서버는 이 오류를 그대로
+// 반환하여 잘못된 파라미터 오류 코드로 응답할 수 있습니다.
+type SpecError struct {
+	Type SpecType // 기대한 인코딩 형식
+	Err  error    // ErrSyntax, ErrLeadingZero 등 원인이 되는 오류
+}
+
+func (err *SpecError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", err.Type, err.Err)
+}
+
+func (err *SpecError) Unwrap() error { return err.Err }
+
+// ErrorCode는 JSON-RPC 오류 코드를 반환합니다.
+func (err *SpecError) ErrorCode() int { return InvalidParamsCode }
+
+// DecodeQuantity는 명세의 QUANTITY 형식인 16진수 문자열을 big.Int로 디코딩합니다. 접두사는
+// 소문자 "0x"여야 하고, 256비트보다 큰 숫자는 허용되지 않습니다. 잘못된 입력에 대해서는
+// *SpecError를 반환합니다.
+func DecodeQuantity(input string) (*big.Int, error) {
+	if err := checkSpecPrefix(input); err != nil {
+		return nil, &SpecError{Quantity, err}
+	}
+	dec, err := DecodeBig(input)
+	if err != nil {
+		return nil, &SpecError{Quantity, err}
+	}
+	return dec, nil
+}
+
+// DecodeData는 명세의 DATA 형식인 16진수 문자열을 바이트열로 디코딩합니다. 접두사는 소문자
+// "0x"여야 하고 숫자의 개수는 짝수여야 합니다. 잘못된 입력에 대해서는 *SpecError를 반환합니다.
+func DecodeData(input string) ([]byte, error) {
+	if err := checkSpecPrefix(input); err != nil {
+		return nil, &SpecError{Data, err}
+	}
+	dec, err := Decode(input)
+	if err != nil {
+		return nil, &SpecError{Data, err}
+	}
+	return dec, nil
+}
+
+// checkSpecPrefix는 input이 명세에서 요구하는 소문자 "0x" 접두사로 시작하는지 확인합니다.
+// has0xPrefix와 달리 "0X"는 허용하지 않습니다.
+func checkSpecPrefix(input string) error {
+	if len(input) == 0 {
+		return ErrEmptyString
+	}
+	if len(input) < 2 || input[:2] != "0x" {
+		return ErrMissingPrefix
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hexutil
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestDecodeQuantity(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *big.Int
+		err   error
+	}{
+		{input: "0x0", want: big.NewInt(0)},
+		{input: "0x400", want: big.NewInt(1024)},
+		{input: "0xfF", want: big.NewInt(255)},
+		{input: "", err: ErrEmptyString},
+		{input: "0x", err: ErrEmptyNumber},
+		{input: "0x0400", err: ErrLeadingZero},
+		{input: "0x00", err: ErrLeadingZero},
+		{input: "ff", err: ErrMissingPrefix},
+		{input: "0X1", err: ErrMissingPrefix},
+		{input: "0xg", err: ErrSyntax},
+		{input: "0x1" + string(bytes.Repeat([]byte{'0'}, 64)), err: ErrBig256Range},
+	}
+	for _, test := range tests {
+		dec, err := DecodeQuantity(test.input)
+		if test.err != nil {
+			checkSpecError(t, test.input, err, Quantity, test.err)
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.input, err)
+		} else if dec.Cmp(test.want) != 0 {
+			t.Errorf("%q: wrong result %v, want %v", test.input, dec, test.want)
+		}
+	}
+}
+
+func TestDecodeData(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []byte
+		err   error
+	}{
+		{input: "0x", want: []byte{}},
+		{input: "0x0041", want: []byte{0x00, 0x41}},
+		{input: "", err: ErrEmptyString},
+		{input: "0xf0f0f", err: ErrOddLength},
+		{input: "004200", err: ErrMissingPrefix},
+		{input: "0X00", err: ErrMissingPrefix},
+		{input: "0xzz", err: ErrSyntax},
+	}
+	for _, test := range tests {
+		dec, err := DecodeData(test.input)
+		if test.err != nil {
+			checkSpecError(t, test.input, err, Data, test.err)
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.input, err)
+		} else if !bytes.Equal(dec, test.want) {
+			t.Errorf("%q: wrong result %x, want %x", test.input, dec, test.want)
+		}
+	}
+}
+
+func checkSpecError(t *testing.T, input string, err error, typ SpecType, want error) {
+	t.Helper()
+	var serr *SpecError
+	if !errors.As(err, &serr) {
+		t.Errorf("%q: error %v is not a *SpecError", input, err)
+		return
+	}
+	if serr.Type != typ || !errors.Is(err, want) {
+		t.Errorf("%q: wrong error: have %v, want %s error %v", input, err, typ, want)
+	}
+	if code := serr.ErrorCode(); code != InvalidParamsCode {
+		t.Errorf("%q: wrong error code %d", input, code)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package lru
+
+import "sync"
+
+// Metrics receives cache events. Any of the callbacks may be nil. They are invoked
+// with the cache lock held and must not call back into the cache.
+type Metrics struct {
+	Hit   func()            // called when Get finds the key
+	Miss  func()            // called when Get does not find the key
+	Evict func(size uint64) // called for each item dropped to make room
+}
+
+// Config configures a BoundedCache. At least one of MaxItems and MaxSize must be set.
+type Config[K comparable, V any] struct {
+	MaxItems int                         // maximum number of items, zero means unbounded
+	MaxSize  uint64                      // maximum total size of items, zero means unbounded
+	SizeOf   func(key K, value V) uint64 // size of an item, required if MaxSize is set
+	FIFO     bool                        // evict in insertion order instead of least-recently-used order
+	OnEvict  func(key K, value V)        // called for each evicted item, with the cache lock held
+	Metrics  Metrics
+}
+
+// BoundedCache is a cache that is bounded by item count, total item size, or both.
+// Depending on the configuration, it evicts the least recently used or the oldest
+// inserted item when a bound is exceeded.
+//
+// Unlike SizeConstrainedCache, values do not have to be content-addressed: replacing
+// the value of an existing key accounts for the size difference.
+//
+// This type is safe for concurrent use.
+type BoundedCache[K comparable, V any] struct {
+	cfg   Config[K, V]
+	list  *list[K]
+	items map[K]boundedItem[K, V]
+	size  uint64
+	mu    sync.Mutex
+}
+
+type boundedItem[K any, V any] struct {
+	elem  *listElem[K]
+	value V
+	size  uint64
+}
+
+// NewBoundedCache creates a cache with the given configuration. It panics if the
+// configuration has no bound, or a size bound without a SizeOf function.
+func NewBoundedCache[K comparable, V any](cfg Config[K, V]) *BoundedCache[K, V] {
+	if cfg.MaxItems <= 0 && cfg.MaxSize == 0 {
+		panic("lru: cache configured without bound")
+	}
+	if cfg.MaxSize > 0 && cfg.SizeOf == nil {
+		panic("lru: size-bounded cache requires SizeOf")
+	}
+	return &BoundedCache[K, V]{
+		cfg:   cfg,
+		list:  newList[K](),
+		items: make(map[K]boundedItem[K, V]),
+	}
+}
+
+// NewFIFOCache creates a cache holding up to capacity items, which evicts items in
+// insertion order. Reading an item does not affect its eviction order.
+func NewFIFOCache[K comparable, V any](capacity int) *BoundedCache[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return NewBoundedCache(Config[K, V]{MaxItems: capacity, FIFO: true})
+}
+
+// NewSizedCache creates an LRU cache whose capacity is the total size of its items,
+// as reported by sizeOf.
+func NewSizedCache[K comparable, V any](maxSize uint64, sizeOf func(K, V) uint64) *BoundedCache[K, V] {
+	return NewBoundedCache(Config[K, V]{MaxSize: maxSize, SizeOf: sizeOf})
+}
+
+// Add adds a value to the cache. Returns true if an item was evicted to store the new item.
+// Items larger than the size bound are not stored; adding one removes any previous
+// value for the key.
+func (c *BoundedCache[K, V]) Add(key K, value V) (evicted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var size uint64
+	if c.cfg.SizeOf != nil {
+		size = c.cfg.SizeOf(key, value)
+	}
+	if c.cfg.MaxSize > 0 && size > c.cfg.MaxSize {
+		c.remove(key)
+		return false
+	}
+	if item, ok := c.items[key]; ok {
+		c.size = c.size - item.size + size
+		item.value, item.size = value, size
+		c.items[key] = item
+		if !c.cfg.FIFO {
+			c.list.moveToFront(item.elem)
+		}
+	} else {
+		elem := &listElem[K]{v: key}
+		c.list.pushElem(elem)
+		c.items[key] = boundedItem[K, V]{elem, value, size}
+		c.size += size
+	}
+	for c.overflow() {
+		c.evictOldest()
+		evicted = true
+	}
+	return evicted
+}
+
+// Contains reports whether the given key exists in the cache.
+func (c *BoundedCache[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Get retrieves a value from the cache. In LRU mode, this marks the key as recently used.
+func (c *BoundedCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		if c.cfg.Metrics.Miss != nil {
+			c.cfg.Metrics.Miss()
+		}
+		return value, false
+	}
+	if c.cfg.Metrics.Hit != nil {
+		c.cfg.Metrics.Hit()
+	}
+	if !c.cfg.FIFO {
+		c.list.moveToFront(item.elem)
+	}
+	return item.value, true
+}
+
+// Peek retrieves a value from the cache, but does not mark the key as recently used.
+func (c *BoundedCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	return item.value, ok
+}
+
+// Remove drops an item from the cache. Returns true if the key was present in cache.
+func (c *BoundedCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.remove(key)
+}
+
+// Len returns the current number of items in the cache.
+func (c *BoundedCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Size returns the current total size of the items in the cache.
+func (c *BoundedCache[K, V]) Size() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+// Purge empties the cache. Purged items are not reported as evicted.
+func (c *BoundedCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.list.init()
+	c.items = make(map[K]boundedItem[K, V])
+	c.size = 0
+}
+
+// Keys returns all keys in the cache, starting with the next one to be evicted.
+func (c *BoundedCache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, len(c.items))
+	return c.list.appendTo(keys)
+}
+
+// overflow reports whether the cache exceeds any of its bounds.
+func (c *BoundedCache[K, V]) overflow() bool {
+	return (c.cfg.MaxItems > 0 && len(c.items) > c.cfg.MaxItems) ||
+		(c.cfg.MaxSize > 0 && c.size > c.cfg.MaxSize)
+}
+
+// evictOldest drops the item at the back of the eviction order.
+func (c *BoundedCache[K, V]) evictOldest() {
+	elem := c.list.removeLast()
+	item := c.items[elem.v]
+	delete(c.items, elem.v)
+	c.size -= item.size
+
+	if c.cfg.Metrics.Evict != nil {
+		c.cfg.Metrics.Evict(item.size)
+	}
+	if c.cfg.OnEvict != nil {
+		c.cfg.OnEvict(elem.v, item.value)
+	}
+}
+
+func (c *BoundedCache[K, V]) remove(key K) bool {
+	item, ok := c.items[key]
+	if ok {
+		c.list.remove(item.elem)
+		delete(c.items, key)
+		c.size -= item.size
+	}
+	return ok
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFIFOCache(t *testing.T) {
+	cache := NewFIFOCache[int, int](3)
+	for i := 0; i < 3; i++ {
+		cache.Add(i, i)
+	}
+	// Reading the oldest item must not save it from eviction.
+	if v, ok := cache.Get(0); !ok || v != 0 {
+		t.Fatal("item 0 missing")
+	}
+	if !cache.Add(3, 3) {
+		t.Fatal("no eviction reported")
+	}
+	if cache.Contains(0) {
+		t.Fatal("oldest item not evicted")
+	}
+	// Updating an item keeps its insertion position.
+	cache.Add(1, 10)
+	cache.Add(4, 4)
+	if cache.Contains(1) {
+		t.Fatal("updated item not evicted in insertion order")
+	}
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []int{2, 3, 4}) {
+		t.Fatalf("wrong keys %v", keys)
+	}
+}
+
+func TestSizedCache(t *testing.T) {
+	sizeOf := func(_ string, v []byte) uint64 { return uint64(len(v)) }
+	cache := NewSizedCache[string, []byte](10, sizeOf)
+
+	cache.Add("a", make([]byte, 4))
+	cache.Add("b", make([]byte, 4))
+	cache.Get("a") // b is now least recently used
+	if !cache.Add("c", make([]byte, 4)) {
+		t.Fatal("no eviction reported")
+	}
+	if cache.Contains("b") || !cache.Contains("a") {
+		t.Fatal("wrong item evicted")
+	}
+	if size := cache.Size(); size != 8 {
+		t.Fatalf("wrong size %d", size)
+	}
+	// Replacing a value accounts for the size difference.
+	cache.Add("a", make([]byte, 1))
+	if size := cache.Size(); size != 5 {
+		t.Fatalf("wrong size after replace %d", size)
+	}
+	// Oversized values are not stored and drop the previous value.
+	if cache.Add("a", make([]byte, 11)) || cache.Contains("a") {
+		t.Fatal("oversized value stored")
+	}
+	if size := cache.Size(); size != 4 {
+		t.Fatalf("wrong size after oversized add %d", size)
+	}
+	cache.Purge()
+	if cache.Len() != 0 || cache.Size() != 0 {
+		t.Fatal("cache not empty after purge")
+	}
+}
+
+func TestBoundedCacheMetrics(t *testing.T) {
+	var hits, misses, evictions, evictedSize uint64
+	var evictedKeys []int
+	cache := NewBoundedCache(Config[int, string]{
+		MaxItems: 2,
+		MaxSize:  100,
+		SizeOf:   func(_ int, v string) uint64 { return uint64(len(v)) },
+		OnEvict:  func(k int, _ string) { evictedKeys = append(evictedKeys, k) },
+		Metrics: Metrics{
+			Hit:   func() { hits++ },
+			Miss:  func() { misses++ },
+			Evict: func(size uint64) { evictions++; evictedSize += size },
+		},
+	})
+	cache.Add(1, "one")
+	cache.Add(2, "two")
+	cache.Get(1)
+	cache.Get(3)
+	cache.Add(3, "three") // evicts 2 by count
+	cache.Peek(2)
+
+	if hits != 1 || misses != 1 || evictions != 1 || evictedSize != 3 {
+		t.Fatalf("wrong metrics: hits %d, misses %d, evictions %d, size %d", hits, misses, evictions, evictedSize)
+	}
+	if !reflect.DeepEqual(evictedKeys, []int{2}) {
+		t.Fatalf("wrong evicted keys %v", evictedKeys)
+	}
+}
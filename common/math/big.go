@@ -112,6 +112,7 @@ func (i *Decimal256) String() string {
 
 // ParseBig256는 10진수 또는 16진수 구문으로 s를 256비트 정수로 파싱합니다.
 // 앞에 0이 붙어있어도 상관없습니다. 빈 문자열은 0으로 파싱됩니다.
+// 가독성을 위해 숫자 사이에 밑줄을 사용할 수 있습니다(예: "58_750_000_000_000_000_000_000").
 func ParseBig256(s string) (*big.Int, bool) {
 	if s == "" {
 		return new(big.Int), true
@@ -119,9 +120,13 @@ func ParseBig256(s string) (*big.Int, bool) {
 	var bigint *big.Int
 	var ok bool
 	if len(s) >= 2 && (s[:2] == "0x" || s[:2] == "0X") {
-		bigint, ok = new(big.Int).SetString(s[2:], 16)
+		if digits, valid := stripUnderscores(s[2:]); valid {
+			bigint, ok = new(big.Int).SetString(digits, 16)
+		}
 	} else {
-		bigint, ok = new(big.Int).SetString(s, 10)
+		if digits, valid := stripUnderscores(s); valid {
+			bigint, ok = new(big.Int).SetString(digits, 10)
+		}
 	}
 	if ok && bigint.BitLen() > 256 {
 		bigint, ok = nil, false
@@ -129,6 +134,36 @@ func ParseBig256(s string) (*big.Int, bool) {
 	return bigint, ok
 }
 
+// FormatBig256는 x를 세 자리마다 밑줄로 구분한 10진수 문자열로 변환합니다
+// (예: 58750000000000000000000 -> "58_750_000_000_000_000_000_000").
+// 결과는 ParseBig256로 다시 파싱할 수 있습니다. nil은 "0"으로 변환됩니다.
+func FormatBig256(x *big.Int) string {
+	if x == nil {
+		return "0"
+	}
+	digits := x.String()
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= 3 {
+		return sign + digits
+	}
+	var (
+		out  = make([]byte, 0, len(digits)+len(digits)/3)
+		head = len(digits) % 3
+	)
+	if head == 0 {
+		head = 3
+	}
+	out = append(out, digits[:head]...)
+	for i := head; i < len(digits); i += 3 {
+		out = append(out, '_')
+		out = append(out, digits[i:i+3]...)
+	}
+	return sign + string(out)
+}
+
 // MustParseBig256는 s를 256비트 큰 정수로 파싱하고, 문자열이 유효하지 않으면 패닉을 발생시킵니다.
 func MustParseBig256(s string) *big.Int {
 	v, ok := ParseBig256(s)
@@ -243,6 +278,42 @@ func S256(x *big.Int) *big.Int {
 	return new(big.Int).Sub(x, tt256)
 }
 
+// SAdd는 256비트 2의 보수 숫자 x와 y의 합을 EVM 규칙에 따라 계산합니다.
+// 결과는 2^256으로 래핑된 256비트 2의 보수 숫자이며, x와 y는 변경되지 않습니다.
+func SAdd(x, y *big.Int) *big.Int {
+	return U256(new(big.Int).Add(x, y))
+}
+
+// SSub는 256비트 2의 보수 숫자 x와 y의 차를 EVM 규칙에 따라 계산합니다.
+// 결과는 2^256으로 래핑된 256비트 2의 보수 숫자이며, x와 y는 변경되지 않습니다.
+func SSub(x, y *big.Int) *big.Int {
+	return U256(new(big.Int).Sub(x, y))
+}
+
+// SMul는 256비트 2의 보수 숫자 x와 y의 곱을 EVM 규칙에 따라 계산합니다.
+// 2의 보수에서 곱의 하위 256비트는 부호와 무관하므로, 부호 없는 곱을 잘라서 계산합니다.
+func SMul(x, y *big.Int) *big.Int {
+	return U256(new(big.Int).Mul(x, y))
+}
+
+// SDiv는 256비트 2의 보수 숫자 x를 y로 나눈 몫을 EVM의 SDIV 규칙에 따라 계산합니다.
+// 몫은 0 방향으로 버림되며, y가 0이면 0을 반환합니다. -2^255 / -1은 래핑되어 -2^255가 됩니다.
+func SDiv(x, y *big.Int) *big.Int {
+	if y.Sign() == 0 {
+		return new(big.Int)
+	}
+	return U256(new(big.Int).Quo(S256(x), S256(y)))
+}
+
+// SMod는 256비트 2의 보수 숫자 x를 y로 나눈 나머지를 EVM의 SMOD 규칙에 따라 계산합니다.
+// 나머지의 부호는 x의 부호를 따르며, y가 0이면 0을 반환합니다.
+func SMod(x, y *big.Int) *big.Int {
+	if y.Sign() == 0 {
+		return new(big.Int)
+	}
+	return U256(new(big.Int).Rem(S256(x), S256(y)))
+}
+
 // Exp는 제곱을 통한 지수 연산을 구현합니다.
 // Exp는 새로운 큰 정수를 반환하며, base 또는 exponent를 변경하지 않습니다. 결과는 256비트로 잘립니다.
 //
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
 )
 
 func TestHexOrDecimal256(t *testing.T) {
@@ -42,6 +43,13 @@ func TestHexOrDecimal256(t *testing.T) {
 		{"00", big.NewInt(0), true},
 		{"0x00", big.NewInt(0), true},
 		{"0x012345678abc", big.NewInt(0x12345678abc), true},
+		// Underscore separators:
+		{"58_750_000_000_000_000_000_000", new(big.Int).Mul(big.NewInt(58750), BigPow(10, 18)), true},
+		{"0x1234_5678", big.NewInt(0x12345678), true},
+		{"_1", nil, false},
+		{"1_", nil, false},
+		{"1__0", nil, false},
+		{"0x_1", nil, false},
 		// Invalid syntax:
 		{"abcdef", nil, false},
 		{"0xgg", nil, false},
@@ -61,6 +69,32 @@ func TestHexOrDecimal256(t *testing.T) {
 	}
 }
 
+func TestFormatBig256(t *testing.T) {
+	tests := []struct {
+		input *big.Int
+		want  string
+	}{
+		{nil, "0"},
+		{big.NewInt(0), "0"},
+		{big.NewInt(999), "999"},
+		{big.NewInt(1000), "1_000"},
+		{big.NewInt(-1234567), "-1_234_567"},
+		{new(big.Int).Mul(big.NewInt(58750), BigPow(10, 18)), "58_750_000_000_000_000_000_000"},
+	}
+	for _, test := range tests {
+		have := FormatBig256(test.input)
+		if have != test.want {
+			t.Errorf("FormatBig256(%v) = %q, want %q", test.input, have, test.want)
+		}
+		if test.input == nil || test.input.Sign() < 0 {
+			continue
+		}
+		if dec, ok := ParseBig256(have); !ok || dec.Cmp(test.input) != 0 {
+			t.Errorf("ParseBig256(%q) = %v, want %v", have, dec, test.input)
+		}
+	}
+}
+
 func TestMustParseBig256(t *testing.T) {
 	defer func() {
 		if recover() == nil {
@@ -307,6 +341,55 @@ func TestS256(t *testing.T) {
 	}
 }
 
+func TestSignedArithmetic(t *testing.T) {
+	var (
+		minInt = BigPow(2, 255)                                  // -2^255
+		maxInt = new(big.Int).Sub(BigPow(2, 255), big.NewInt(1)) // 2^255-1
+		negOne = new(big.Int).Set(tt256m1)                       // -1
+	)
+	values := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(7),
+		minInt, maxInt, negOne, new(big.Int).Sub(tt256, big.NewInt(7)),
+	}
+	type op struct {
+		name string
+		fn   func(x, y *big.Int) *big.Int
+		ref  func(z, x, y *uint256.Int) *uint256.Int
+	}
+	ops := []op{
+		{"SAdd", SAdd, (*uint256.Int).Add},
+		{"SSub", SSub, (*uint256.Int).Sub},
+		{"SMul", SMul, (*uint256.Int).Mul},
+		{"SDiv", SDiv, (*uint256.Int).SDiv},
+		{"SMod", SMod, (*uint256.Int).SMod},
+	}
+	for _, o := range ops {
+		for _, x := range values {
+			for _, y := range values {
+				xcpy, ycpy := new(big.Int).Set(x), new(big.Int).Set(y)
+				have := o.fn(x, y)
+
+				ux, _ := uint256.FromBig(x)
+				uy, _ := uint256.FromBig(y)
+				want := o.ref(new(uint256.Int), ux, uy).ToBig()
+				if have.Cmp(want) != 0 {
+					t.Errorf("%s(%x, %x) = %x, want %x", o.name, x, y, have, want)
+				}
+				if x.Cmp(xcpy) != 0 || y.Cmp(ycpy) != 0 {
+					t.Errorf("%s modified its arguments", o.name)
+				}
+			}
+		}
+	}
+	// The INT_MIN / -1 case must wrap around to INT_MIN.
+	if have := SDiv(minInt, negOne); have.Cmp(minInt) != 0 {
+		t.Errorf("SDiv(INT_MIN, -1) = %x, want %x", have, minInt)
+	}
+	if have := SMod(minInt, negOne); have.Sign() != 0 {
+		t.Errorf("SMod(INT_MIN, -1) = %x, want 0", have)
+	}
+}
+
 func TestExp(t *testing.T) {
 	tests := []struct{ base, exponent, result *big.Int }{
 		{base: big.NewInt(0), exponent: big.NewInt(0), result: big.NewInt(1)},
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/bits"
 	"strconv"
+	"strings"
 )
 
 // 정수형의 임계값을 정의한다.
@@ -68,18 +69,39 @@ func (i HexOrDecimal64) MarshalText() ([]byte, error) {
 
 // ParseUint64는 10진수 또는 16진수 구문으로 s를 정수로 파싱합니다.
 // 앞에 0이 붙어있어도 괜찮습니다. 빈 문자열은 0으로 파싱됩니다.
+// 가독성을 위해 숫자 사이에 밑줄을 사용할 수 있습니다(예: "1_000_000").
 func ParseUint64(s string) (uint64, bool) {
 	if s == "" {
 		return 0, true
 	}
 	if len(s) >= 2 && (s[:2] == "0x" || s[:2] == "0X") {
-		v, err := strconv.ParseUint(s[2:], 16, 64)
+		digits, ok := stripUnderscores(s[2:])
+		if !ok {
+			return 0, false
+		}
+		v, err := strconv.ParseUint(digits, 16, 64)
 		return v, err == nil
 	}
-	v, err := strconv.ParseUint(s, 10, 64)
+	digits, ok := stripUnderscores(s)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(digits, 10, 64)
 	return v, err == nil
 }
 
+// stripUnderscores는 숫자 구분자로 사용된 밑줄을 제거합니다. 밑줄은 두 숫자 사이에만 올 수
+// 있으며, 맨 앞이나 맨 뒤에 오거나 연속된 밑줄이 있으면 false를 반환합니다.
+func stripUnderscores(s string) (string, bool) {
+	if !strings.Contains(s, "_") {
+		return s, true
+	}
+	if s[0] == '_' || s[len(s)-1] == '_' || strings.Contains(s, "__") {
+		return "", false
+	}
+	return strings.ReplaceAll(s, "_", ""), true
+}
+
 // MustParseUint64는 s를 정수로 파싱하고, 문자열이 유효하지 않으면 패닉합니다.
 func MustParseUint64(s string) uint64 {
 	v, ok := ParseUint64(s)
@@ -106,3 +128,50 @@ func SafeMul(x, y uint64) (uint64, bool) {
 	hi, lo := bits.Mul64(x, y)
 	return lo, hi != 0
 }
+
+// CeilDiv는 x를 y로 나눈 몫을 올림하여 반환합니다. (x+y-1)/y와 달리 오버플로우가 발생하지
+// 않습니다. y가 0이면 패닉합니다.
+func CeilDiv(x, y uint64) uint64 {
+	q := x / y
+	if x%y != 0 {
+		q++
+	}
+	return q
+}
+
+// NextPowerOfTwo는 x 이상인 가장 작은 2의 거듭제곱을 반환합니다. 0은 1로 올림됩니다.
+// 결과가 uint64를 넘어서면 오버플로우를 보고합니다.
+func NextPowerOfTwo(x uint64) (uint64, bool) {
+	if x <= 1 {
+		return 1, false
+	}
+	shift := bits.Len64(x - 1)
+	if shift == 64 {
+		return 0, true
+	}
+	return 1 << shift, false
+}
+
+// SaturatingAdd는 x+y를 반환하며, 오버플로우가 발생하면 MaxUint64를 반환합니다.
+func SaturatingAdd(x, y uint64) uint64 {
+	if sum, overflow := SafeAdd(x, y); !overflow {
+		return sum
+	}
+	return MaxUint64
+}
+
+// SaturatingSub는 x-y를 반환하며, y가 x보다 크면 0을 반환합니다.
+func SaturatingSub(x, y uint64) uint64 {
+	if x < y {
+		return 0
+	}
+	return x - y
+}
+
+// SaturatingMul는 x*y를 반환하며, 오버플로우가 발생하면 MaxUint64를 반환합니다.
+func SaturatingMul(x, y uint64) uint64 {
+	if prod, overflow := SafeMul(x, y); !overflow {
+		return prod
+	}
+	return MaxUint64
+}
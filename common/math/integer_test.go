@@ -81,6 +81,11 @@ func TestHexOrDecimal64(t *testing.T) {
 		{"0123456789", 123456789, true}, // note: not octal
 		{"0x00", 0, true},
 		{"0x012345678abc", 0x12345678abc, true},
+		// Underscore separators:
+		{"30_000_000", 30000000, true},
+		{"0xffff_ffff", 0xffffffff, true},
+		{"_30", 0, false},
+		{"30__000", 0, false},
 		// Invalid syntax:
 		{"abcdef", 0, false},
 		{"0xgg", 0, false},
@@ -114,3 +119,75 @@ func TestMustParseUint64Panic(t *testing.T) {
 	}()
 	MustParseUint64("ggg")
 }
+
+func TestCeilDiv(t *testing.T) {
+	tests := []struct{ x, y, want uint64 }{
+		{0, 1, 0},
+		{1, 1, 1},
+		{31, 32, 1},
+		{32, 32, 1},
+		{33, 32, 2},
+		{MaxUint64, 32, MaxUint64/32 + 1},
+		{MaxUint64, 1, MaxUint64},
+		{MaxUint64, MaxUint64, 1},
+	}
+	for _, test := range tests {
+		if have := CeilDiv(test.x, test.y); have != test.want {
+			t.Errorf("CeilDiv(%d, %d) = %d, want %d", test.x, test.y, have, test.want)
+		}
+	}
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	tests := []struct {
+		x, want  uint64
+		overflow bool
+	}{
+		{0, 1, false},
+		{1, 1, false},
+		{2, 2, false},
+		{3, 4, false},
+		{1000, 1024, false},
+		{1 << 63, 1 << 63, false},
+		{1<<63 + 1, 0, true},
+		{MaxUint64, 0, true},
+	}
+	for _, test := range tests {
+		have, overflow := NextPowerOfTwo(test.x)
+		if have != test.want || overflow != test.overflow {
+			t.Errorf("NextPowerOfTwo(%d) = %d, %t, want %d, %t", test.x, have, overflow, test.want, test.overflow)
+		}
+	}
+}
+
+func TestSaturating(t *testing.T) {
+	tests := []struct {
+		x, y uint64
+		op   operation
+		want uint64
+	}{
+		{1, 2, add, 3},
+		{MaxUint64 - 1, 1, add, MaxUint64},
+		{MaxUint64, 1, add, MaxUint64},
+		{3, 2, sub, 1},
+		{2, 3, sub, 0},
+		{0, MaxUint64, sub, 0},
+		{3, 2, mul, 6},
+		{MaxUint64 / 2, 2, mul, MaxUint64 - 1},
+		{MaxUint64 / 2, 3, mul, MaxUint64},
+	}
+	for i, test := range tests {
+		var have uint64
+		switch test.op {
+		case add:
+			have = SaturatingAdd(test.x, test.y)
+		case sub:
+			have = SaturatingSub(test.x, test.y)
+		case mul:
+			have = SaturatingMul(test.x, test.y)
+		}
+		if have != test.want {
+			t.Errorf("test %d: have %d, want %d", i, have, test.want)
+		}
+	}
+}
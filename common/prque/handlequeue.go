@@ -0,0 +1,142 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package prque
+
+import "golang.org/x/exp/constraints"
+
+// Handle refers to an item pushed into a HandleQueue. It can be used to remove the
+// item from the queue without tracking its heap index.
+type Handle[P constraints.Ordered, V any] struct {
+	value    V
+	priority P
+	removed  bool
+}
+
+// Value returns the item's value.
+func (h *Handle[P, V]) Value() V { return h.value }
+
+// Priority returns the item's priority.
+func (h *Handle[P, V]) Priority() P { return h.priority }
+
+// Removed reports whether the item was removed from the queue, either by Remove
+// or by being popped.
+func (h *Handle[P, V]) Removed() bool { return h.removed }
+
+// HandleQueue is a priority queue supporting removal by handle with lazy deletion.
+// Removed items are only marked as such and are discarded when they reach the top
+// of the queue, which makes Remove O(1). Once removed items outnumber live ones,
+// the queue is compacted to bound its memory usage.
+type HandleQueue[P constraints.Ordered, V any] struct {
+	queue *Prque[P, *Handle[P, V]]
+	live  int
+	dead  int
+}
+
+// NewHandleQueue creates a new, empty queue.
+func NewHandleQueue[P constraints.Ordered, V any]() *HandleQueue[P, V] {
+	return &HandleQueue[P, V]{queue: New[P, *Handle[P, V]](nil)}
+}
+
+// Push adds a value with the given priority and returns its handle.
+func (q *HandleQueue[P, V]) Push(data V, priority P) *Handle[P, V] {
+	h := &Handle[P, V]{value: data, priority: priority}
+	q.queue.Push(h, priority)
+	q.live++
+	return h
+}
+
+// Remove marks the item as removed. It returns false if the item was already
+// removed or popped.
+func (q *HandleQueue[P, V]) Remove(h *Handle[P, V]) bool {
+	if h.removed {
+		return false
+	}
+	h.removed = true
+	q.live--
+	q.dead++
+	if q.dead > q.live && q.dead > blockSize {
+		q.compact()
+	}
+	return true
+}
+
+// Peek returns the value with the greatest priority but does not pop it off.
+// It must not be called on an empty queue.
+func (q *HandleQueue[P, V]) Peek() (V, P) {
+	q.skipRemoved()
+	h, prio := q.queue.Peek()
+	return h.value, prio
+}
+
+// Pop removes the value with the greatest priority from the queue and returns it.
+// It must not be called on an empty queue.
+func (q *HandleQueue[P, V]) Pop() (V, P) {
+	q.skipRemoved()
+	h := q.queue.PopItem()
+	h.removed = true
+	q.live--
+	return h.value, h.priority
+}
+
+// PopItem pops only the value from the queue, dropping the associated priority.
+func (q *HandleQueue[P, V]) PopItem() V {
+	v, _ := q.Pop()
+	return v
+}
+
+// Empty reports whether the queue contains no live items.
+func (q *HandleQueue[P, V]) Empty() bool {
+	return q.live == 0
+}
+
+// Size returns the number of live items in the queue.
+func (q *HandleQueue[P, V]) Size() int {
+	return q.live
+}
+
+// Reset clears the contents of the queue. Handles of dropped items are marked
+// as removed.
+func (q *HandleQueue[P, V]) Reset() {
+	for !q.queue.Empty() {
+		q.queue.PopItem().removed = true
+	}
+	q.live, q.dead = 0, 0
+}
+
+// skipRemoved drops removed items from the top of the queue.
+func (q *HandleQueue[P, V]) skipRemoved() {
+	for !q.queue.Empty() {
+		h, _ := q.queue.Peek()
+		if !h.removed {
+			return
+		}
+		q.queue.PopItem()
+		q.dead--
+	}
+}
+
+// compact rebuilds the underlying queue without the removed items.
+func (q *HandleQueue[P, V]) compact() {
+	fresh := New[P, *Handle[P, V]](nil)
+	for !q.queue.Empty() {
+		h, prio := q.queue.Pop()
+		if !h.removed {
+			fresh.Push(h, prio)
+		}
+	}
+	q.queue, q.dead = fresh, 0
+}
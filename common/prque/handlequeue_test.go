@@ -0,0 +1,83 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package prque
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestHandleQueue(t *testing.T) {
+	const size = 4 * blockSize
+	var (
+		q       = NewHandleQueue[float64, int]()
+		handles = make([]*Handle[float64, int], size)
+		prios   = make([]float64, size)
+	)
+	for i := range handles {
+		prios[i] = rand.Float64()
+		handles[i] = q.Push(i, prios[i])
+	}
+	// Remove every item with an odd value, exercising compaction along the way.
+	var want []float64
+	for i, h := range handles {
+		if i%2 == 1 {
+			if !q.Remove(h) || q.Remove(h) {
+				t.Fatalf("wrong result removing item %d", i)
+			}
+		} else {
+			want = append(want, prios[i])
+		}
+	}
+	if q.Size() != len(want) {
+		t.Fatalf("wrong size: have %d, want %d", q.Size(), len(want))
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(want)))
+	for i, prio := range want {
+		v, p := q.Peek()
+		if p != prio {
+			t.Fatalf("pop %d: wrong peeked priority %v, want %v", i, p, prio)
+		}
+		if pv, pp := q.Pop(); pv != v || pp != p {
+			t.Fatalf("pop %d: popped item differs from peeked one", i)
+		}
+		if v%2 == 1 {
+			t.Fatalf("pop %d: removed item %d returned", i, v)
+		}
+		if !handles[v].Removed() || q.Remove(handles[v]) {
+			t.Fatalf("pop %d: popped handle not marked removed", i)
+		}
+	}
+	if !q.Empty() {
+		t.Fatal("queue not empty")
+	}
+}
+
+func TestHandleQueueReset(t *testing.T) {
+	q := NewHandleQueue[int64, string]()
+	a := q.Push("a", 1)
+	q.Push("b", 2)
+	q.Reset()
+	if !q.Empty() || !a.Removed() {
+		t.Fatal("queue not reset")
+	}
+	q.Push("c", 3)
+	if v := q.PopItem(); v != "c" {
+		t.Fatalf("wrong item after reset: %s", v)
+	}
+}
@@ -9,7 +9,7 @@
 // This is a duplicated and slightly modified version of "gopkg.in/karalabe/cookiejar.v2/collections/prque".
 
 // Package prque implements a priority queue data structure supporting arbitrary
-// value types and int64 priorities.
+// value types and any ordered priority type, such as int64 or float64.
 //
 // If you would like to use a min-priority queue, simply negate the priorities.
 //
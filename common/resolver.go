@@ -0,0 +1,75 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNoNameResolver는 사람이 읽을 수 있는 이름을 해석해야 하지만 등록된 이름 해석기가 없을 때 반환됩니다.
+var ErrNoNameResolver = errors.New("no name resolver registered")
+
+// NameResolver는 사람이 읽을 수 있는 이름(예: ENS 이름)을 주소로 해석합니다.
+type NameResolver interface {
+	ResolveName(name string) (Address, error)
+}
+
+// NameResolverFunc는 일반 함수를 NameResolver로 사용할 수 있게 하는 어댑터입니다.
+type NameResolverFunc func(name string) (Address, error)
+
+// ResolveName은 f(name)을 호출합니다.
+func (f NameResolverFunc) ResolveName(name string) (Address, error) {
+	return f(name)
+}
+
+var (
+	nameResolverLock sync.RWMutex
+	nameResolver     NameResolver
+)
+
+// RegisterNameResolver는 ResolveAddress가 16진수 주소가 아닌 입력에 대해 사용할 이름 해석기를
+// 설정합니다. nil을 전달하면 등록된 해석기가 제거됩니다.
+//
+// 이 훅은 사용자 입력을 받는 도구를 위한 것이며, 합의 코드에서는 사용되지 않습니다.
+func RegisterNameResolver(r NameResolver) {
+	nameResolverLock.Lock()
+	defer nameResolverLock.Unlock()
+
+	nameResolver = r
+}
+
+// ResolveAddress는 s를 주소로 변환합니다. s가 0x 접두사를 가지거나 16진수 주소 형식이면
+// ParseAddress로 엄격하게 검증하고, 그렇지 않으면 등록된 이름 해석기를 사용합니다.
+func ResolveAddress(s string) (Address, error) {
+	if has0xPrefix(s) || IsHexAddress(s) {
+		return ParseAddress(s)
+	}
+	nameResolverLock.RLock()
+	r := nameResolver
+	nameResolverLock.RUnlock()
+
+	if r == nil {
+		return Address{}, fmt.Errorf("%w: cannot resolve %q", ErrNoNameResolver, s)
+	}
+	addr, err := r.ResolveName(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("failed to resolve %q: %w", s, err)
+	}
+	return addr, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// SetElement은 Set의 요소가 만족해야 하는 제약입니다. 요소는 비교 가능해야 하며, 결정적인
+// 반복 순서를 위해 Cmp로 정렬할 수 있어야 합니다.
+type SetElement[T any] interface {
+	comparable
+	Cmp(other T) int
+}
+
+// Set은 요소의 집합입니다. 0 값(nil)은 읽기 전용 빈 집합으로 사용할 수 있으며,
+// 요소를 추가하려면 NewSet 또는 make로 생성해야 합니다.
+//
+// JSON으로 마샬링할 때는 정렬된 요소 배열로 인코딩되므로 결과가 결정적입니다.
+type Set[T SetElement[T]] map[T]struct{}
+
+// AddressSet은 주소의 집합입니다.
+type AddressSet = Set[Address]
+
+// HashSet은 해시의 집합입니다.
+type HashSet = Set[Hash]
+
+// NewSet은 주어진 요소들을 포함하는 새 집합을 생성합니다.
+func NewSet[T SetElement[T]](elems ...T) Set[T] {
+	s := make(Set[T], len(elems))
+	for _, elem := range elems {
+		s[elem] = struct{}{}
+	}
+	return s
+}
+
+// NewAddressSet은 주어진 주소들을 포함하는 새 집합을 생성합니다.
+func NewAddressSet(addrs ...Address) AddressSet {
+	return NewSet(addrs...)
+}
+
+// NewHashSet은 주어진 해시들을 포함하는 새 집합을 생성합니다.
+func NewHashSet(hashes ...Hash) HashSet {
+	return NewSet(hashes...)
+}
+
+// Add는 집합에 요소를 추가하고, 새로 추가되었는지 여부를 반환합니다.
+func (s Set[T]) Add(elem T) bool {
+	if _, ok := s[elem]; ok {
+		return false
+	}
+	s[elem] = struct{}{}
+	return true
+}
+
+// Remove는 집합에서 요소를 제거하고, 요소가 존재했는지 여부를 반환합니다.
+func (s Set[T]) Remove(elem T) bool {
+	if _, ok := s[elem]; !ok {
+		return false
+	}
+	delete(s, elem)
+	return true
+}
+
+// Contains는 집합에 요소가 포함되어 있는지 여부를 반환합니다.
+func (s Set[T]) Contains(elem T) bool {
+	_, ok := s[elem]
+	return ok
+}
+
+// Len은 집합의 요소 개수를 반환합니다.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Copy는 집합의 복사본을 반환합니다.
+func (s Set[T]) Copy() Set[T] {
+	cpy := make(Set[T], len(s))
+	for elem := range s {
+		cpy[elem] = struct{}{}
+	}
+	return cpy
+}
+
+// Union은 s와 other 중 어느 한쪽에라도 포함된 요소들의 새 집합을 반환합니다.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := make(Set[T], len(s)+len(other))
+	for elem := range s {
+		union[elem] = struct{}{}
+	}
+	for elem := range other {
+		union[elem] = struct{}{}
+	}
+	return union
+}
+
+// Intersect는 s와 other 모두에 포함된 요소들의 새 집합을 반환합니다.
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	inter := make(Set[T])
+	for elem := range small {
+		if _, ok := large[elem]; ok {
+			inter[elem] = struct{}{}
+		}
+	}
+	return inter
+}
+
+// List는 집합의 요소를 임의의 순서로 반환합니다.
+func (s Set[T]) List() []T {
+	list := make([]T, 0, len(s))
+	for elem := range s {
+		list = append(list, elem)
+	}
+	return list
+}
+
+// SortedList는 집합의 요소를 Cmp 순서로 정렬하여 반환합니다. 결정적인 반복 순서가
+// 필요한 경우(예: 해싱, 직렬화) 사용합니다.
+func (s Set[T]) SortedList() []T {
+	list := s.List()
+	sort.Slice(list, func(i, j int) bool { return list[i].Cmp(list[j]) < 0 })
+	return list
+}
+
+// MarshalJSON은 json.Marshaler를 구현합니다.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.SortedList())
+}
+
+// UnmarshalJSON은 json.Unmarshaler를 구현합니다.
+func (s *Set[T]) UnmarshalJSON(input []byte) error {
+	var list []T
+	if err := json.Unmarshal(input, &list); err != nil {
+		return err
+	}
+	*s = NewSet(list...)
+	return nil
+}
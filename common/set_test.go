@@ -0,0 +1,114 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAddressSet(t *testing.T) {
+	var (
+		a = Address{0x01}
+		b = Address{0x02}
+		c = Address{0x03}
+	)
+	s := NewAddressSet(c, a)
+	if !s.Add(b) || s.Add(b) {
+		t.Fatal("add reported wrong insertion status")
+	}
+	if !s.Contains(a) || !s.Contains(b) || !s.Contains(c) || s.Len() != 3 {
+		t.Fatal("set is missing elements")
+	}
+	if !s.Remove(c) || s.Remove(c) || s.Contains(c) {
+		t.Fatal("remove failed")
+	}
+	other := NewAddressSet(b, c)
+	if have, want := s.Union(other).SortedList(), []Address{a, b, c}; !reflect.DeepEqual(have, want) {
+		t.Errorf("union mismatch: have %v, want %v", have, want)
+	}
+	if have, want := s.Intersect(other).SortedList(), []Address{b}; !reflect.DeepEqual(have, want) {
+		t.Errorf("intersection mismatch: have %v, want %v", have, want)
+	}
+	// Operations on the nil set must not panic.
+	var empty AddressSet
+	if empty.Contains(a) || empty.Len() != 0 || empty.Union(s).Len() != 2 || empty.Intersect(s).Len() != 0 {
+		t.Error("nil set misbehaves")
+	}
+}
+
+func TestHashSet(t *testing.T) {
+	var (
+		a = Hash{0x01}
+		b = Hash{0x02}
+		c = Hash{0x03}
+	)
+	s := NewHashSet(c, a)
+	if !s.Add(b) || s.Add(b) {
+		t.Fatal("add reported wrong insertion status")
+	}
+	if !s.Remove(c) || s.Contains(c) || s.Len() != 2 {
+		t.Fatal("remove failed")
+	}
+	cpy := s.Copy()
+	cpy.Add(c)
+	if s.Contains(c) {
+		t.Fatal("copy shares storage with original")
+	}
+	if have, want := cpy.SortedList(), []Hash{a, b, c}; !reflect.DeepEqual(have, want) {
+		t.Errorf("sorted list mismatch: have %v, want %v", have, want)
+	}
+	if have, want := cpy.Intersect(NewHashSet(c, Hash{0x04})).SortedList(), []Hash{c}; !reflect.DeepEqual(have, want) {
+		t.Errorf("intersection mismatch: have %v, want %v", have, want)
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	addrs := NewAddressSet(Address{0x02}, Address{0x01})
+	enc, err := json.Marshal(addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `["0x0100000000000000000000000000000000000000","0x0200000000000000000000000000000000000000"]`
+	if string(enc) != want {
+		t.Fatalf("wrong encoding: have %s, want %s", enc, want)
+	}
+	var dec AddressSet
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, addrs) {
+		t.Fatalf("decoded set mismatch: have %v, want %v", dec, addrs)
+	}
+
+	hashes := NewHashSet(Hash{0x01})
+	enc, err = json.Marshal(hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hdec HashSet
+	if err := json.Unmarshal(enc, &hdec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hdec, hashes) {
+		t.Fatalf("decoded set mismatch: have %v, want %v", hdec, hashes)
+	}
+	if err := json.Unmarshal([]byte(`["0x01"]`), &hdec); err == nil {
+		t.Fatal("expected error for invalid hash")
+	}
+}
@@ -17,38 +17,83 @@
 package common
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // StorageSize는 사용자 친화적인 포맷을 지원하기 위해 float 값을 래핑한 별칭 타입이다.
 type StorageSize float64
 
+// storageUnits는 크기를 표시할 때 사용하는 이진 단위 목록이다. (큰 단위부터)
+var storageUnits = []struct {
+	size   StorageSize
+	symbol string
+}{
+	{1 << 40, "TiB"}, // 테비바이트
+	{1 << 30, "GiB"}, // 기비바이트
+	{1 << 20, "MiB"}, // 메비바이트
+	{1 << 10, "KiB"}, // 키비바이트
+}
+
+// format은 s를 가장 큰 이진 단위로 소수점 2자리까지 표시한다. sep은 숫자와 단위 사이의 구분자이다.
+// 출력은 로케일과 무관하다.
+func (s StorageSize) format(sep string) string {
+	for _, unit := range storageUnits {
+		if s > unit.size {
+			return fmt.Sprintf("%.2f%s%s", s/unit.size, sep, unit.symbol)
+		}
+	}
+	return fmt.Sprintf("%.2f%sB", s, sep) // 바이트
+}
+
 // String은 stringer 인터페이스를 구현하였다. (소수점 2자리까지 표시)
 func (s StorageSize) String() string {
-	if s > 1099511627776 {
-		return fmt.Sprintf("%.2f TiB", s/1099511627776) // 테비바이트
-	} else if s > 1073741824 {
-		return fmt.Sprintf("%.2f GiB", s/1073741824) // 기비바이트
-	} else if s > 1048576 {
-		return fmt.Sprintf("%.2f MiB", s/1048576) // 메비바이트
-	} else if s > 1024 {
-		return fmt.Sprintf("%.2f KiB", s/1024) // 킬로바이트
-	} else {
-		return fmt.Sprintf("%.2f B", s) // 바이트
-	}
+	return s.format(" ")
 }
 
 // TerminalString은 log.TerminalStringer를 구현하였으며, 로깅 중 콘솔 출력을 위한 문자열을 포맷합니다.
 func (s StorageSize) TerminalString() string {
-	if s > 1099511627776 {
-		return fmt.Sprintf("%.2fTiB", s/1099511627776)
-	} else if s > 1073741824 {
-		return fmt.Sprintf("%.2fGiB", s/1073741824)
-	} else if s > 1048576 {
-		return fmt.Sprintf("%.2fMiB", s/1048576)
-	} else if s > 1024 {
-		return fmt.Sprintf("%.2fKiB", s/1024)
-	} else {
-		return fmt.Sprintf("%.2fB", s)
+	return s.format("")
+}
+
+// parseUnits는 ParseStorageSize가 인식하는 단위와 그 크기이다. 대소문자를 구분하지 않는다.
+var parseUnits = map[string]StorageSize{
+	"":    1,
+	"b":   1,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+}
+
+var errInvalidStorageSize = errors.New("invalid storage size")
+
+// ParseStorageSize는 "1.5 GiB", "512MiB", "100" 같은 문자열을 크기로 변환한다. String과
+// TerminalString의 출력을 다시 읽을 수 있으며, 설정 파일의 크기 값을 읽는 데 사용한다.
+// 단위가 없으면 바이트로 취급한다. KiB, MiB 등은 1024의 거듭제곱이며, KB, MB 등은 1000의
+// 거듭제곱이다. 단위는 대소문자를 구분하지 않는다.
+func ParseStorageSize(input string) (StorageSize, error) {
+	str := strings.TrimSpace(input)
+	split := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if split < 0 {
+		split = len(str)
+	}
+	num, unit := str[:split], strings.ToLower(strings.TrimSpace(str[split:]))
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", errInvalidStorageSize, input)
+	}
+	scale, ok := parseUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w %q: unknown unit %q", errInvalidStorageSize, input, str[split:])
 	}
+	return StorageSize(value) * scale, nil
 }
@@ -18,6 +18,7 @@ package common
 
 import (
 	"testing"
+	"time"
 )
 
 func TestStorageSizeString(t *testing.T) {
@@ -57,3 +58,57 @@ func TestStorageSizeTerminalString(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStorageSize(t *testing.T) {
+	tests := []struct {
+		input string
+		size  StorageSize
+	}{
+		{"100", 100},
+		{"12.00 B", 12},
+		{"1.5 GiB", 1.5 * (1 << 30)},
+		{"512MiB", 512 << 20},
+		{"2 kib", 2048},
+		{" 3 TB ", 3e12},
+		{"1.25KB", 1250},
+	}
+	for _, test := range tests {
+		size, err := ParseStorageSize(test.input)
+		if err != nil {
+			t.Errorf("%q: %v", test.input, err)
+		} else if size != test.size {
+			t.Errorf("%q: got %v, want %v", test.input, float64(size), float64(test.size))
+		}
+	}
+	for _, input := range []string{"", "GiB", "-1 GiB", "1.5 XB", "1..5 MiB"} {
+		if _, err := ParseStorageSize(input); err == nil {
+			t.Errorf("%q: no error", input)
+		}
+	}
+	// The output of String and TerminalString must parse back.
+	for _, size := range []StorageSize{12, 2192, 2381273} {
+		for _, str := range []string{size.String(), size.TerminalString()} {
+			parsed, err := ParseStorageSize(str)
+			if err != nil {
+				t.Fatalf("%q: %v", str, err)
+			}
+			if parsed.String() != size.String() {
+				t.Errorf("%q: parsed to %v", str, parsed)
+			}
+		}
+	}
+}
+
+func TestParsePrettyDuration(t *testing.T) {
+	d := PrettyDuration(1500 * time.Millisecond)
+	parsed, err := ParsePrettyDuration(d.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != d {
+		t.Fatalf("got %v, want %v", parsed, d)
+	}
+	if _, err := ParsePrettyDuration("soon"); err == nil {
+		t.Fatal("no error for invalid duration")
+	}
+}
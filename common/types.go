@@ -18,6 +18,7 @@ package common
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"database/sql/driver"
 	"encoding/hex"
 	"encoding/json"
@@ -42,9 +43,6 @@ const (
 )
 
 var (
-	hashT    = reflect.TypeOf(Hash{})
-	addressT = reflect.TypeOf(Address{})
-
 	// MaxAddress는 가능한 주소 값의 최대값을 나타냅니다.
 	MaxAddress = HexToAddress("0xffffffffffffffffffffffffffffffffffffffff")
 
@@ -77,6 +75,12 @@ func (h Hash) Cmp(other Hash) int {
 	return bytes.Compare(h[:], other[:])
 }
 
+// ConstantTimeEqual은 두 해시가 같은지 여부를 상수 시간에 비교합니다.
+// 비교 시간이 내용에 따라 달라지면 안 되는 서명 관련 비교에 사용합니다.
+func (h Hash) ConstantTimeEqual(other Hash) bool {
+	return subtle.ConstantTimeCompare(h[:], other[:]) == 1
+}
+
 // Bytes는 해시의 바이트 표현을 반환합니다.
 func (h Hash) Bytes() []byte { return h[:] }
 
@@ -132,7 +136,7 @@ func (h *Hash) UnmarshalText(input []byte) error {
 
 // UnmarshalJSON은 16진수 형식의 json 입력을 해시로 변환합니다.
 func (h *Hash) UnmarshalJSON(input []byte) error {
-	return hexutil.UnmarshalFixedJSON(hashT, input, h[:])
+	return hexutil.UnmarshalFixedJSONOf[Hash](input, h[:])
 }
 
 // MarshalText는 h의 16진수 표현을 반환합니다.
@@ -237,11 +241,50 @@ func IsHexAddress(s string) bool {
 	return len(s) == 2*AddressLength && isHex(s) // 문자열의 길이가 40이고, 16진수 문자열인지 확인
 }
 
+var (
+	ErrAddressLength   = errors.New("invalid address length")
+	ErrAddressHex      = errors.New("invalid hex character in address")
+	ErrAddressChecksum = errors.New("invalid address checksum")
+)
+
+// ParseAddress는 16진수 문자열 s를 주소로 엄격하게 변환합니다. HexToAddress와 달리 입력을 자르거나
+// 채우지 않으며, 0x 접두사를 제외한 길이가 40자가 아니거나 16진수가 아닌 문자가 포함된 경우 오류를 반환합니다.
+//
+// 대소문자가 섞인 입력은 EIP-55 체크섬으로 취급되어 검증되며, 모두 소문자이거나 모두 대문자인
+// 입력은 체크섬 없이 허용됩니다.
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	hexstr := s
+	if has0xPrefix(hexstr) {
+		hexstr = hexstr[2:]
+	}
+	if len(hexstr) != 2*AddressLength {
+		return a, fmt.Errorf("%w: have %d hex characters, want %d", ErrAddressLength, len(hexstr), 2*AddressLength)
+	}
+	if !isHex(hexstr) {
+		return a, ErrAddressHex
+	}
+	hex.Decode(a[:], []byte(hexstr))
+
+	if hexstr != strings.ToLower(hexstr) && hexstr != strings.ToUpper(hexstr) {
+		if want := a.Hex(); want[2:] != hexstr {
+			return Address{}, fmt.Errorf("%w: have %s, want %s", ErrAddressChecksum, s, want)
+		}
+	}
+	return a, nil
+}
+
 // Cmp는 두 주소를 비교합니다. (0: 같음, -1: a < other, +1: a > other)
 func (a Address) Cmp(other Address) int {
 	return bytes.Compare(a[:], other[:])
 }
 
+// ConstantTimeEqual은 두 주소가 같은지 여부를 상수 시간에 비교합니다.
+// 비교 시간이 내용에 따라 달라지면 안 되는 서명 관련 비교에 사용합니다.
+func (a Address) ConstantTimeEqual(other Address) bool {
+	return subtle.ConstantTimeCompare(a[:], other[:]) == 1
+}
+
 // Bytes는 주소의 바이트 표현을 반환합니다.
 func (a Address) Bytes() []byte { return a[:] }
 
@@ -336,7 +379,7 @@ func (a *Address) UnmarshalText(input []byte) error {
 
 // UnmarshalJSON은 16진수 형식의 json 입력을 해시로 변환합니다.
 func (a *Address) UnmarshalJSON(input []byte) error {
-	return hexutil.UnmarshalFixedJSON(addressT, input, a[:])
+	return hexutil.UnmarshalFixedJSONOf[Address](input, a[:])
 }
 
 // Scan은 database/sql 패키지의 Scanner 인터페이스를 구현합니다.
@@ -409,7 +452,7 @@ func NewMixedcaseAddressFromString(hexaddr string) (*MixedcaseAddress, error) {
 
 // UnmarshalJSON은 입력을 MixedcaseAddress로 변환합니다.
 func (ma *MixedcaseAddress) UnmarshalJSON(input []byte) error {
-	if err := hexutil.UnmarshalFixedJSON(addressT, input, ma.addr[:]); err != nil {
+	if err := hexutil.UnmarshalFixedJSONOf[Address](input, ma.addr[:]); err != nil {
 		return err
 	}
 	return json.Unmarshal(input, &ma.original)
@@ -459,6 +502,34 @@ func (addr AddressEIP55) MarshalJSON() ([]byte, error) {
 	return json.Marshal(addr.String())
 }
 
+// MarshalText는 EIP55 형식의 주소를 반환합니다. 맵의 키로 사용될 때에도 체크섬이 유지됩니다.
+func (addr AddressEIP55) MarshalText() ([]byte, error) {
+	return []byte(addr.String()), nil
+}
+
+// UnmarshalText는 0x 접두사가 있는 16진수 주소를 ParseAddress와 같은 규칙으로 파싱합니다.
+// 대소문자가 섞여 있으면 EIP55 체크섬을 검증하며, 일치하지 않으면 ErrAddressChecksum을 반환합니다.
+func (addr *AddressEIP55) UnmarshalText(input []byte) error {
+	if !has0xPrefix(string(input)) {
+		return hexutil.ErrMissingPrefix
+	}
+	a, err := ParseAddress(string(input))
+	if err != nil {
+		return err
+	}
+	*addr = AddressEIP55(a)
+	return nil
+}
+
+// UnmarshalJSON은 JSON 문자열을 UnmarshalText와 같은 규칙으로 파싱합니다. 서버는 구조체 필드에
+// Address 대신 AddressEIP55를 사용하여 체크섬을 엄격하게 검증할 수 있습니다.
+func (addr *AddressEIP55) UnmarshalJSON(input []byte) error {
+	if !isString(input) {
+		return &json.UnmarshalTypeError{Value: "non-string", Type: reflect.TypeOf(AddressEIP55{})}
+	}
+	return addr.UnmarshalText(input[1 : len(input)-1])
+}
+
 // uint64 형식 정수의 별칭 타입입니다.
 type Decimal uint64
 
@@ -479,3 +550,23 @@ func (d *Decimal) UnmarshalJSON(input []byte) error {
 		return err
 	}
 }
+
+// FixedBytes는 고정 길이 바이트 배열 타입인 Hash와 Address를 묶는 타입 제약입니다.
+type FixedBytes interface {
+	Hash | Address
+}
+
+// Index는 list에서 item이 처음 나타나는 위치를 반환합니다. item이 없으면 -1을 반환합니다.
+func Index[T FixedBytes](list []T, item T) int {
+	for i := range list {
+		if list[i] == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains는 list에 item이 포함되어 있는지 여부를 반환합니다.
+func Contains[T FixedBytes](list []T, item T) bool {
+	return Index(list, item) >= 0
+}
@@ -20,12 +20,15 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func TestBytesConversion(t *testing.T) {
@@ -64,6 +67,57 @@ func TestIsHexAddress(t *testing.T) {
 	}
 }
 
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Address
+		err   error
+	}{
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), nil},
+		{"5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), nil},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), nil},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", Address{}, ErrAddressChecksum},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beae", Address{}, ErrAddressLength},
+		{"0x005aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Address{}, ErrAddressLength},
+		{"", Address{}, ErrAddressLength},
+		{"0xgaaeb6053f3e94c9b9a09f33669435e7ef1beaed", Address{}, ErrAddressHex},
+	}
+	for _, test := range tests {
+		addr, err := ParseAddress(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("input %q: error mismatch: have %v, want %v", test.input, err, test.err)
+		}
+		if addr != test.want {
+			t.Errorf("input %q: address mismatch: have %v, want %v", test.input, addr, test.want)
+		}
+	}
+}
+
+func TestResolveAddress(t *testing.T) {
+	defer RegisterNameResolver(nil)
+
+	want := HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	if _, err := ResolveAddress("vitalik.eth"); !errors.Is(err, ErrNoNameResolver) {
+		t.Fatalf("expected missing resolver error, got %v", err)
+	}
+	RegisterNameResolver(NameResolverFunc(func(name string) (Address, error) {
+		if name == "vitalik.eth" {
+			return want, nil
+		}
+		return Address{}, errors.New("unknown name")
+	}))
+	if addr, err := ResolveAddress("vitalik.eth"); err != nil || addr != want {
+		t.Fatalf("name resolution failed: %v %v", addr, err)
+	}
+	if _, err := ResolveAddress("unknown.eth"); err == nil {
+		t.Fatal("expected error for unknown name")
+	}
+	// Hex inputs must never reach the resolver, so malformed ones are rejected.
+	if _, err := ResolveAddress("0x1234"); !errors.Is(err, ErrAddressLength) {
+		t.Fatalf("expected length error, got %v", err)
+	}
+}
+
 func TestHashJsonValidation(t *testing.T) {
 	var tests = []struct {
 		Prefix string
@@ -585,6 +639,49 @@ func TestAddressEIP55(t *testing.T) {
 	}
 }
 
+func TestAddressEIP55Unmarshal(t *testing.T) {
+	want := AddressEIP55(HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{`"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"`, nil},
+		{`"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"`, nil},
+		{`"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"`, nil},
+		{`"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"`, ErrAddressChecksum},
+		{`"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"`, hexutil.ErrMissingPrefix},
+		{`"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA"`, ErrAddressLength},
+	}
+	for _, test := range tests {
+		var dec AddressEIP55
+		err := json.Unmarshal([]byte(test.input), &dec)
+		if !errors.Is(err, test.err) {
+			t.Errorf("input %s: wrong error %v, want %v", test.input, err, test.err)
+			continue
+		}
+		if err == nil && dec != want {
+			t.Errorf("input %s: wrong address %v", test.input, dec)
+		}
+	}
+	var dec AddressEIP55
+	if err := json.Unmarshal([]byte("123"), &dec); err == nil {
+		t.Error("no error for non-string input")
+	}
+
+	// Struct fields and map keys keep the checksum.
+	enc, err := json.Marshal(map[AddressEIP55]AddressEIP55{want: want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := `{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}`; string(enc) != exp {
+		t.Fatalf("wrong encoding %s", enc)
+	}
+	var m map[AddressEIP55]AddressEIP55
+	if err := json.Unmarshal(enc, &m); err != nil || m[want] != want {
+		t.Fatalf("wrong decoded map %v: %v", m, err)
+	}
+}
+
 func BenchmarkPrettyDuration(b *testing.B) {
 	var x = PrettyDuration(time.Duration(int64(1203123912312)))
 	b.Logf("Pre %s", time.Duration(x).String())
@@ -595,3 +692,38 @@ func BenchmarkPrettyDuration(b *testing.B) {
 	}
 	b.Logf("Post %s", a)
 }
+
+func TestConstantTimeEqual(t *testing.T) {
+	var (
+		h1 = HexToHash("0x01")
+		h2 = HexToHash("0x02")
+		a1 = HexToAddress("0x01")
+		a2 = HexToAddress("0x02")
+	)
+	if !h1.ConstantTimeEqual(h1) || h1.ConstantTimeEqual(h2) {
+		t.Error("hash constant time comparison mismatch")
+	}
+	if !a1.ConstantTimeEqual(a1) || a1.ConstantTimeEqual(a2) {
+		t.Error("address constant time comparison mismatch")
+	}
+}
+
+func TestIndexContains(t *testing.T) {
+	addrs := []Address{HexToAddress("0x01"), HexToAddress("0x02"), HexToAddress("0x02")}
+	if have := Index(addrs, HexToAddress("0x02")); have != 1 {
+		t.Errorf("address index mismatch: have %d, want %d", have, 1)
+	}
+	if Contains(addrs, HexToAddress("0x03")) {
+		t.Error("unexpected address found")
+	}
+	hashes := []Hash{HexToHash("0x01"), HexToHash("0x02")}
+	if have := Index(hashes, HexToHash("0x03")); have != -1 {
+		t.Errorf("hash index mismatch: have %d, want %d", have, -1)
+	}
+	if !Contains(hashes, HexToHash("0x01")) {
+		t.Error("expected hash to be found")
+	}
+	if Contains(nil, Hash{}) {
+		t.Error("unexpected hash found in nil list")
+	}
+}
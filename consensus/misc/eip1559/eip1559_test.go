@@ -129,3 +129,41 @@ func TestCalcBaseFee(t *testing.T) {
 		}
 	}
 }
+
+// TestCalcBaseFeeOverrides tests the base fee calculation with the EIP-1559
+// parameters overridden in the chain config.
+func TestCalcBaseFeeOverrides(t *testing.T) {
+	var (
+		denominator = uint64(50)
+		elasticity  = uint64(4)
+	)
+	tests := []struct {
+		parentBaseFee   int64
+		parentGasLimit  uint64
+		parentGasUsed   uint64
+		minBaseFee      *big.Int
+		expectedBaseFee int64
+	}{
+		{params.InitialBaseFee, 40000000, 10000000, nil, params.InitialBaseFee},                   // usage == target
+		{params.InitialBaseFee, 40000000, 5000000, nil, 990000000},                                // usage below target
+		{params.InitialBaseFee, 40000000, 15000000, nil, 1010000000},                              // usage above target
+		{params.InitialBaseFee, 40000000, 0, big.NewInt(990000000), 990000000},                    // clamped to minimum
+		{params.InitialBaseFee, 40000000, 5000000, big.NewInt(params.InitialBaseFee), 1000000000}, // minimum at parent fee
+	}
+	for i, test := range tests {
+		config := config()
+		config.EIP1559Denominator = &denominator
+		config.EIP1559Elasticity = &elasticity
+		config.EIP1559MinBaseFee = test.minBaseFee
+
+		parent := &types.Header{
+			Number:   common.Big32,
+			GasLimit: test.parentGasLimit,
+			GasUsed:  test.parentGasUsed,
+			BaseFee:  big.NewInt(test.parentBaseFee),
+		}
+		if have, want := CalcBaseFee(config, parent), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d  want %d, ", i, have, want)
+		}
+	}
+}
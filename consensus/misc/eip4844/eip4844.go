@@ -25,15 +25,10 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-var (
-	minBlobGasPrice            = big.NewInt(params.BlobTxMinBlobGasprice)
-	blobGaspriceUpdateFraction = big.NewInt(params.BlobTxBlobGaspriceUpdateFraction)
-)
-
 // VerifyEIP4844Header verifies the presence of the excessBlobGas field and that
 // if the current block contains no transactions, the excessBlobGas is updated
 // accordingly.
-func VerifyEIP4844Header(parent, header *types.Header) error {
+func VerifyEIP4844Header(config *params.ChainConfig, parent, header *types.Header) error {
 	// Verify the header is not malformed
 	if header.ExcessBlobGas == nil {
 		return errors.New("header is missing excessBlobGas")
@@ -42,8 +37,8 @@ func VerifyEIP4844Header(parent, header *types.Header) error {
 		return errors.New("header is missing blobGasUsed")
 	}
 	// Verify that the blob gas used remains within reasonable limits.
-	if *header.BlobGasUsed > params.MaxBlobGasPerBlock {
-		return fmt.Errorf("blob gas used %d exceeds maximum allowance %d", *header.BlobGasUsed, params.MaxBlobGasPerBlock)
+	if limit := MaxBlobGasPerBlock(config, header.Time); *header.BlobGasUsed > limit {
+		return fmt.Errorf("blob gas used %d exceeds maximum allowance %d", *header.BlobGasUsed, limit)
 	}
 	if *header.BlobGasUsed%params.BlobTxBlobGasPerBlob != 0 {
 		return fmt.Errorf("blob gas used %d not a multiple of blob gas per blob %d", header.BlobGasUsed, params.BlobTxBlobGasPerBlob)
@@ -57,7 +52,7 @@ func VerifyEIP4844Header(parent, header *types.Header) error {
 		parentExcessBlobGas = *parent.ExcessBlobGas
 		parentBlobGasUsed = *parent.BlobGasUsed
 	}
-	expectedExcessBlobGas := CalcExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed)
+	expectedExcessBlobGas := CalcExcessBlobGas(config, header.Time, parentExcessBlobGas, parentBlobGasUsed)
 	if *header.ExcessBlobGas != expectedExcessBlobGas {
 		return fmt.Errorf("invalid excessBlobGas: have %d, want %d, parent excessBlobGas %d, parent blobDataUsed %d",
 			*header.ExcessBlobGas, expectedExcessBlobGas, parentExcessBlobGas, parentBlobGasUsed)
@@ -66,33 +61,24 @@ func VerifyEIP4844Header(parent, header *types.Header) error {
 }
 
 // CalcExcessBlobGas calculates the excess blob gas after applying the set of
-// blobs on top of the excess blob gas.
-func CalcExcessBlobGas(parentExcessBlobGas uint64, parentBlobGasUsed uint64) uint64 {
-	excessBlobGas := parentExcessBlobGas + parentBlobGasUsed
-	if excessBlobGas < params.BlobTxTargetBlobGasPerBlock {
-		return 0
-	}
-	return excessBlobGas - params.BlobTxTargetBlobGasPerBlock
+// blobs on top of the excess blob gas, using the blob target active at headTime.
+func CalcExcessBlobGas(config *params.ChainConfig, headTime uint64, parentExcessBlobGas uint64, parentBlobGasUsed uint64) uint64 {
+	return types.CalcExcessBlobGas(config, headTime, parentExcessBlobGas, parentBlobGasUsed)
 }
 
-// CalcBlobFee calculates the blobfee from the header's excess blob gas field.
-func CalcBlobFee(excessBlobGas uint64) *big.Int {
-	return fakeExponential(minBlobGasPrice, new(big.Int).SetUint64(excessBlobGas), blobGaspriceUpdateFraction)
+// CalcBlobFee calculates the blobfee from the header's excess blob gas field,
+// using the update fraction active at the header's time.
+func CalcBlobFee(config *params.ChainConfig, time uint64, excessBlobGas uint64) *big.Int {
+	return types.CalcBlobFee(config, time, excessBlobGas)
 }
 
-// fakeExponential approximates factor * e ** (numerator / denominator) using
-// Taylor expansion.
-func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
-	var (
-		output = new(big.Int)
-		accum  = new(big.Int).Mul(factor, denominator)
-	)
-	for i := 1; accum.Sign() > 0; i++ {
-		output.Add(output, accum)
-
-		accum.Mul(accum, numerator)
-		accum.Div(accum, denominator)
-		accum.Div(accum, big.NewInt(int64(i)))
+// MaxBlobGasPerBlock returns the maximum blob gas that can be consumed by a
+// block created at the given time.
+func MaxBlobGasPerBlock(config *params.ChainConfig, time uint64) uint64 {
+	if config != nil {
+		if bc := config.BlobConfig(time); bc != nil {
+			return bc.MaxBlobGas()
+		}
 	}
-	return output.Div(output, denominator)
+	return params.MaxBlobGasPerBlock
 }
@@ -17,10 +17,9 @@
 package eip4844
 
 import (
-	"fmt"
-	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -50,7 +49,7 @@ func TestCalcExcessBlobGas(t *testing.T) {
 		{params.BlobTxBlobGasPerBlob - 1, (params.BlobTxTargetBlobGasPerBlock / params.BlobTxBlobGasPerBlob) - 1, 0},
 	}
 	for i, tt := range tests {
-		result := CalcExcessBlobGas(tt.excess, tt.blobs*params.BlobTxBlobGasPerBlob)
+		result := CalcExcessBlobGas(params.MainnetChainConfig, 0, tt.excess, tt.blobs*params.BlobTxBlobGasPerBlob)
 		if result != tt.want {
 			t.Errorf("test %d: excess blob gas mismatch: have %v, want %v", i, result, tt.want)
 		}
@@ -68,47 +67,41 @@ func TestCalcBlobFee(t *testing.T) {
 		{10 * 1024 * 1024, 23},
 	}
 	for i, tt := range tests {
-		have := CalcBlobFee(tt.excessBlobGas)
+		have := CalcBlobFee(params.MainnetChainConfig, 0, tt.excessBlobGas)
 		if have.Int64() != tt.blobfee {
 			t.Errorf("test %d: blobfee mismatch: have %v want %v", i, have, tt.blobfee)
 		}
 	}
 }
 
-func TestFakeExponential(t *testing.T) {
-	tests := []struct {
-		factor      int64
-		numerator   int64
-		denominator int64
-		want        int64
-	}{
-		// When numerator == 0 the return value should always equal the value of factor
-		{1, 0, 1, 1},
-		{38493, 0, 1000, 38493},
-		{0, 1234, 2345, 0}, // should be 0
-		{1, 2, 1, 6},       // approximate 7.389
-		{1, 4, 2, 6},
-		{1, 3, 1, 16}, // approximate 20.09
-		{1, 6, 2, 18},
-		{1, 4, 1, 49}, // approximate 54.60
-		{1, 8, 2, 50},
-		{10, 8, 2, 542}, // approximate 540.598
-		{11, 8, 2, 596}, // approximate 600.58
-		{1, 5, 1, 136},  // approximate 148.4
-		{1, 5, 2, 11},   // approximate 12.18
-		{2, 5, 2, 23},   // approximate 24.36
-		{1, 50000000, 2225652, 5709098764},
-	}
-	for i, tt := range tests {
-		f, n, d := big.NewInt(tt.factor), big.NewInt(tt.numerator), big.NewInt(tt.denominator)
-		original := fmt.Sprintf("%d %d %d", f, n, d)
-		have := fakeExponential(f, n, d)
-		if have.Int64() != tt.want {
-			t.Errorf("test %d: fake exponential mismatch: have %v want %v", i, have, tt.want)
-		}
-		later := fmt.Sprintf("%d %d %d", f, n, d)
-		if original != later {
-			t.Errorf("test %d: fake exponential modified arguments: have\n%v\nwant\n%v", i, later, original)
+func TestBlobScheduleParams(t *testing.T) {
+	var (
+		cancun = uint64(100)
+		prague = uint64(200)
+		config = &params.ChainConfig{
+			LondonBlock: common.Big0,
+			CancunTime:  &cancun,
+			PragueTime:  &prague,
+			BlobScheduleConfig: &params.BlobScheduleConfig{
+				Prague: &params.BlobConfig{Target: 4, Max: 8, UpdateFraction: 1000},
+			},
 		}
+		used = 5 * uint64(params.BlobTxBlobGasPerBlob)
+	)
+	// Cancun blocks use the default target, Prague blocks the configured one.
+	if have, want := CalcExcessBlobGas(config, cancun, 0, used), 2*uint64(params.BlobTxBlobGasPerBlob); have != want {
+		t.Errorf("cancun excess blob gas mismatch: have %d, want %d", have, want)
+	}
+	if have, want := CalcExcessBlobGas(config, prague, 0, used), uint64(params.BlobTxBlobGasPerBlob); have != want {
+		t.Errorf("prague excess blob gas mismatch: have %d, want %d", have, want)
+	}
+	if have, want := CalcBlobFee(config, prague, 2000), int64(7); have.Int64() != want {
+		t.Errorf("prague blob fee mismatch: have %v, want %v", have, want)
+	}
+	if have, want := MaxBlobGasPerBlock(config, cancun), uint64(params.MaxBlobGasPerBlock); have != want {
+		t.Errorf("cancun max blob gas mismatch: have %d, want %d", have, want)
+	}
+	if have, want := MaxBlobGasPerBlock(config, prague), 8*uint64(params.BlobTxBlobGasPerBlob); have != want {
+		t.Errorf("prague max blob gas mismatch: have %d, want %d", have, want)
 	}
 }
@@ -414,9 +414,10 @@ func (g *Genesis) configOrDefault(ghash common.Hash) *params.ChainConfig {
 		return params.MainnetChainConfig
 	case ghash == params.SepoliaGenesisHash:
 		return params.SepoliaChainConfig
-	case ghash == params.GoerliGenesisHash:
-		return params.GoerliChainConfig
 	default:
+		if network, err := params.NetworkByGenesis(ghash); err == nil {
+			return network.Config
+		}
 		return params.AllEthashProtocolChanges
 	}
 }
@@ -542,18 +543,6 @@ func DefaultGenesisBlock() *Genesis {
 	}
 }
 
-// DefaultGoerliGenesisBlock returns the Görli network genesis block.
-func DefaultGoerliGenesisBlock() *Genesis {
-	return &Genesis{
-		Config:     params.GoerliChainConfig,
-		Timestamp:  1548854791,
-		ExtraData:  hexutil.MustDecode("0x22466c6578692069732061207468696e6722202d204166726900000000000000e0a2bd4258d2768837baa26a28fe71dc079f84c70000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"),
-		GasLimit:   10485760,
-		Difficulty: big.NewInt(1),
-		Alloc:      decodePrealloc(goerliAllocData),
-	}
-}
-
 // DefaultSepoliaGenesisBlock returns the Sepolia network genesis block.
 func DefaultSepoliaGenesisBlock() *Genesis {
 	return &Genesis{
@@ -579,6 +568,29 @@ func DefaultHoleskyGenesisBlock() *Genesis {
 	}
 }
 
+// defaultGenesis maps the chain ID of every public network included in the
+// build to its genesis constructor. Optional networks register themselves from
+// files guarded by build tags.
+var defaultGenesis = map[uint64]func() *Genesis{
+	params.MainnetChainConfig.ChainID.Uint64(): DefaultGenesisBlock,
+	params.SepoliaChainConfig.ChainID.Uint64(): DefaultSepoliaGenesisBlock,
+	params.HoleskyChainConfig.ChainID.Uint64(): DefaultHoleskyGenesisBlock,
+}
+
+// DefaultGenesisByChainID returns the genesis block of the public network with
+// the given chain ID. Networks excluded from the build report
+// params.ErrNetworkRetired, unknown chain IDs params.ErrUnknownNetwork.
+func DefaultGenesisByChainID(chainID uint64) (*Genesis, error) {
+	if _, err := params.NetworkByChainID(chainID); err != nil {
+		return nil, err
+	}
+	genesis, ok := defaultGenesis[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: no genesis for chain ID %d", params.ErrUnknownNetwork, chainID)
+	}
+	return genesis(), nil
+}
+
 // DeveloperGenesisBlock returns the 'geth --dev' genesis block.
 func DeveloperGenesisBlock(gasLimit uint64, faucet *common.Address) *Genesis {
 	// Override the default period to the user requested one
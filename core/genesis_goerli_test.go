@@ -0,0 +1,41 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !nogoerli
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestGoerliGenesis(t *testing.T) {
+	genesis, err := DefaultGenesisByChainID(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis.Config != params.GoerliChainConfig {
+		t.Fatal("wrong goerli chain config")
+	}
+	db := rawdb.NewMemoryDatabase()
+	if have := genesis.MustCommit(db, trie.NewDatabase(db, trie.HashDefaults)).Hash(); have != params.GoerliGenesisHash {
+		t.Fatalf("wrong goerli genesis hash: have %x, want %x", have, params.GoerliGenesisHash)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build nogoerli
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGoerliGenesisExcluded(t *testing.T) {
+	if _, err := DefaultGenesisByChainID(5); !errors.Is(err, params.ErrNetworkRetired) {
+		t.Fatalf("wrong error for goerli genesis: %v", err)
+	}
+	if _, err := DefaultGenesisByChainID(1); err != nil {
+		t.Fatalf("failed to load mainnet genesis: %v", err)
+	}
+}
@@ -606,22 +606,28 @@ func ReadReceiptsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawVa
 // The receipt metadata fields are not guaranteed to be populated, so they
 // should not be used. Use ReadReceipts instead if the metadata is needed.
 func ReadRawReceipts(db ethdb.Reader, hash common.Hash, number uint64) types.Receipts {
+	stored := readStoredReceipts(db, hash, number)
+	if stored == nil {
+		return nil
+	}
+	return stored.Receipts
+}
+
+// readStoredReceipts retrieves and decodes the stored receipts of a block. The
+// result also tracks which receipts were stored with their contract address.
+func readStoredReceipts(db ethdb.Reader, hash common.Hash, number uint64) *types.StoredReceipts {
 	// Retrieve the flattened receipt slice
 	data := ReadReceiptsRLP(db, hash, number)
 	if len(data) == 0 {
 		return nil
 	}
 	// Convert the receipts from their storage form to their internal representation
-	storageReceipts := []*types.ReceiptForStorage{}
-	if err := rlp.DecodeBytes(data, &storageReceipts); err != nil {
+	stored := new(types.StoredReceipts)
+	if err := rlp.DecodeBytes(data, stored); err != nil {
 		log.Error("Invalid receipt array RLP", "hash", hash, "err", err)
 		return nil
 	}
-	receipts := make(types.Receipts, len(storageReceipts))
-	for i, storageReceipt := range storageReceipts {
-		receipts[i] = (*types.Receipt)(storageReceipt)
-	}
-	return receipts
+	return stored
 }
 
 // ReadReceipts retrieves all the transaction receipts belonging to a block, including
@@ -633,8 +639,8 @@ func ReadRawReceipts(db ethdb.Reader, hash common.Hash, number uint64) types.Rec
 // if the receipt itself is stored.
 func ReadReceipts(db ethdb.Reader, hash common.Hash, number uint64, time uint64, config *params.ChainConfig) types.Receipts {
 	// We're deriving many fields from the block body, retrieve beside the receipt
-	receipts := ReadRawReceipts(db, hash, number)
-	if receipts == nil {
+	stored := readStoredReceipts(db, hash, number)
+	if stored == nil {
 		return nil
 	}
 	body := ReadBody(db, hash, number)
@@ -653,13 +659,13 @@ func ReadReceipts(db ethdb.Reader, hash common.Hash, number uint64, time uint64,
 	// Compute effective blob gas price.
 	var blobGasPrice *big.Int
 	if header != nil && header.ExcessBlobGas != nil {
-		blobGasPrice = eip4844.CalcBlobFee(*header.ExcessBlobGas)
+		blobGasPrice = eip4844.CalcBlobFee(config, header.Time, *header.ExcessBlobGas)
 	}
-	if err := receipts.DeriveFields(config, hash, number, time, baseFee, blobGasPrice, body.Transactions); err != nil {
+	if err := stored.DeriveFields(config, hash, number, time, baseFee, blobGasPrice, body.Transactions); err != nil {
 		log.Error("Failed to derive block receipts fields", "hash", hash, "number", number, "err", err)
 		return nil
 	}
-	return receipts
+	return stored.Receipts
 }
 
 // WriteReceipts stores all the transaction receipts belonging to a block.
@@ -669,6 +675,27 @@ func WriteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64, rec
 	for i, receipt := range receipts {
 		storageReceipts[i] = (*types.ReceiptForStorage)(receipt)
 	}
+	writeReceiptsRLP(db, hash, number, storageReceipts)
+}
+
+// WriteReceiptsV2 stores all the transaction receipts belonging to a block using
+// the version 2 storage encoding. It additionally persists the contract address
+// and effective gas price of each receipt, so ReadReceipts does not need to
+// recover the sender of contract creations. The stored data remains readable by
+// all receipt accessors.
+//
+// Note that releases without support for the version 2 encoding fail to decode
+// these receipts, so a database written with it cannot be downgraded.
+func WriteReceiptsV2(db ethdb.KeyValueWriter, hash common.Hash, number uint64, receipts types.Receipts) {
+	storageReceipts := make([]*types.ReceiptForStorageV2, len(receipts))
+	for i, receipt := range receipts {
+		storageReceipts[i] = (*types.ReceiptForStorageV2)(receipt)
+	}
+	writeReceiptsRLP(db, hash, number, storageReceipts)
+}
+
+// writeReceiptsRLP encodes the given storage receipts and stores them.
+func writeReceiptsRLP(db ethdb.KeyValueWriter, hash common.Hash, number uint64, storageReceipts interface{}) {
 	bytes, err := rlp.EncodeToBytes(storageReceipts)
 	if err != nil {
 		log.Crit("Failed to encode block receipts", "err", err)
@@ -692,7 +719,8 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 type storedReceiptRLP struct {
 	PostStateOrStatus []byte
 	CumulativeGasUsed uint64
-	Logs              []*types.Log
+	Logs              []*types.LogForStorage
+	Rest              []rlp.RawValue `rlp:"tail"` // fields of the version 2 encoding
 }
 
 // ReceiptLogs is a barebone version of ReceiptForStorage which only keeps
@@ -708,7 +736,10 @@ func (r *receiptLogs) DecodeRLP(s *rlp.Stream) error {
 	if err := s.Decode(&stored); err != nil {
 		return err
 	}
-	r.Logs = stored.Logs
+	r.Logs = make([]*types.Log, len(stored.Logs))
+	for i, log := range stored.Logs {
+		r.Logs[i] = (*types.Log)(log)
+	}
 	return nil
 }
 
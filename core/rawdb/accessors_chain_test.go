@@ -412,6 +412,55 @@ func TestBlockReceiptStorage(t *testing.T) {
 	}
 }
 
+func TestBlockReceiptStorageV2(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSigner(params.TestChainConfig)
+	tx1 := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 0, Gas: 100000, GasPrice: big.NewInt(1)})
+	tx2 := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 1, To: &common.Address{0x02}, Gas: 21000, GasPrice: big.NewInt(2)})
+	body := &types.Body{Transactions: types.Transactions{tx1, tx2}}
+
+	receipts := types.Receipts{
+		{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 50000,
+			Logs:              []*types.Log{{Address: common.Address{0x11}}},
+			ContractAddress:   crypto.CreateAddress(crypto.PubkeyToAddress(key.PublicKey), 0),
+			EffectiveGasPrice: big.NewInt(1),
+		},
+		{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 71000,
+			EffectiveGasPrice: big.NewInt(2),
+		},
+	}
+	for _, r := range receipts {
+		r.Bloom = types.CreateBloom(types.Receipts{r})
+	}
+	hash := common.BytesToHash([]byte{0x03, 0x14})
+	WriteBody(db, hash, 0, body)
+	WriteReceiptsV2(db, hash, 0, receipts)
+
+	rs := ReadReceipts(db, hash, 0, 0, params.TestChainConfig)
+	if len(rs) != 2 {
+		t.Fatalf("wrong number of receipts: %d", len(rs))
+	}
+	if err := checkReceiptsRLP(rs, receipts); err != nil {
+		t.Fatal(err)
+	}
+	if rs[0].ContractAddress != receipts[0].ContractAddress || rs[1].ContractAddress != (common.Address{}) {
+		t.Fatal("wrong contract addresses")
+	}
+	if rs[0].GasUsed != 50000 || rs[1].GasUsed != 21000 {
+		t.Fatal("wrong derived gas used")
+	}
+	// The version 2 encoding is readable by the log accessor.
+	if logs := ReadLogs(db, hash, 0); len(logs) != 2 || len(logs[0]) != 1 || logs[0][0].Address != (common.Address{0x11}) {
+		t.Fatalf("wrong logs: %v", logs)
+	}
+}
+
 func checkReceiptsRLP(have, want types.Receipts) error {
 	if len(have) != len(want) {
 		return fmt.Errorf("receipts sizes mismatch: have %d, want %d", len(have), len(want))
@@ -19,6 +19,8 @@ package types
 
 import (
 	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -125,6 +128,32 @@ func (h *Header) Size() common.StorageSize {
 	return headerSize + common.StorageSize(len(h.Extra)+(h.Difficulty.BitLen()+h.Number.BitLen()+baseFeeBits)/8)
 }
 
+// MarshalJSONWithHash는 eth_getBlockByNumber 등의 RPC 응답과 같은 형태로 헤더를 JSON으로
+// 인코딩합니다. MarshalJSON의 출력에 더해 헤더의 RLP 인코딩 크기인 size와 totalDifficulty
+// 필드를 포함합니다. td가 nil이면 totalDifficulty는 null로 인코딩됩니다.
+func (h *Header) MarshalJSONWithHash(td *big.Int) ([]byte, error) {
+	enc, err := h.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	size, err := rlp.EncodeToBytes(h)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := json.Marshal(struct {
+		TotalDifficulty *hexutil.Big   `json:"totalDifficulty"`
+		Size            hexutil.Uint64 `json:"size"`
+	}{(*hexutil.Big)(td), hexutil.Uint64(len(size))})
+	if err != nil {
+		return nil, err
+	}
+	// 두 JSON 객체를 하나로 합칩니다: {...header...} + {...extra...} → {...header...,...extra...}
+	out := make([]byte, 0, len(enc)+len(extra))
+	out = append(out, enc[:len(enc)-1]...)
+	out = append(out, ',')
+	return append(out, extra[1:]...), nil
+}
+
 // SanityCheck는 몇 가지 기본적인 것들을 확인합니다.
 // 이러한 체크는 '정상적인' 프로덕션 값을 체크한다기 보다는, 주로 범위가 정해지지 않은 필드(big.Int 등)가
 // 처리 오버헤드를 추가하기 위해 정크 데이터로 채워지는 것을 방지하는 데 사용됩니다.
@@ -148,6 +177,54 @@ func (h *Header) SanityCheck() error {
 	return nil
 }
 
+var (
+	ErrPoSDifficulty = errors.New("non-zero difficulty in post-merge header")
+	ErrPoSNonce      = errors.New("non-zero nonce in post-merge header")
+	ErrPoSUncleHash  = errors.New("non-empty uncle hash in post-merge header")
+)
+
+// ValidatePoSFields는 머지 이후 블록 헤더의 합의 규칙을 확인합니다. 지분 증명 블록은
+// 난이도와 nonce가 0이어야 하며 엉클을 포함할 수 없습니다.
+func (h *Header) ValidatePoSFields() error {
+	if h.Difficulty != nil && h.Difficulty.Sign() != 0 {
+		return fmt.Errorf("%w: %v", ErrPoSDifficulty, h.Difficulty)
+	}
+	if h.Nonce != (BlockNonce{}) {
+		return fmt.Errorf("%w: %x", ErrPoSNonce, h.Nonce)
+	}
+	if h.UncleHash != EmptyUncleHash {
+		return fmt.Errorf("%w: %x", ErrPoSUncleHash, h.UncleHash)
+	}
+	return nil
+}
+
+// PrevRandao는 머지 이후 MixDigest 필드에 담긴 이전 비콘 블록의 RANDAO 값을 반환합니다.
+// 머지 이전 헤더에서는 작업 증명의 mix digest입니다.
+func (h *Header) PrevRandao() common.Hash {
+	return h.MixDigest
+}
+
+// IsPoS는 헤더가 주어진 체인 구성에서 지분 증명 블록인지 여부를 반환합니다. 터미널 총 난이도가
+// 설정된 체인에서 난이도가 0인 헤더와, 상하이 이후의 모든 헤더는 지분 증명 블록입니다.
+func (h *Header) IsPoS(config *params.ChainConfig) bool {
+	if config.TerminalTotalDifficulty == nil {
+		return false
+	}
+	if h.Difficulty != nil && h.Difficulty.Sign() == 0 {
+		return true
+	}
+	return h.Number != nil && config.IsShanghai(h.Number, h.Time)
+}
+
+// ValidateMerge는 헤더가 주어진 체인 구성에서 지분 증명 블록이면 ValidatePoSFields로 머지
+// 이후의 합의 규칙을 확인합니다. 작업 증명 블록에 대해서는 nil을 반환합니다.
+func (h *Header) ValidateMerge(config *params.ChainConfig) error {
+	if !h.IsPoS(config) {
+		return nil
+	}
+	return h.ValidatePoSFields()
+}
+
 // EmptyBody는 헤더를 완성하는 추가적인 'body'가 없는 경우 true를 반환합니다.
 // 즉, 트랜잭션이 없고, 엉클도 없고, 출금도 없습니다.
 func (h *Header) EmptyBody() bool {
@@ -259,6 +336,19 @@ func NewBlockWithWithdrawals(header *Header, txs []*Transaction, uncles []*Heade
 	return b.WithWithdrawals(withdrawals)
 }
 
+// NewPostMergeBlock은 엉클이 없는 지분 증명 블록을 생성합니다. 입력 데이터는 복사되므로,
+// 입력 데이터의 변경은 블록에 영향을 주지 않습니다.
+//
+// 헤더의 TxHash, UncleHash, ReceiptHash, Bloom, WithdrawalsHash 값은 입력으로부터 유도됩니다.
+// 헤더의 난이도나 nonce가 0이 아니면 오류를 반환합니다. nil 난이도는 0으로 설정됩니다.
+func NewPostMergeBlock(header *Header, txs []*Transaction, receipts []*Receipt, withdrawals []*Withdrawal, hasher TrieHasher) (*Block, error) {
+	b := NewBlockWithWithdrawals(header, txs, nil, receipts, withdrawals, hasher)
+	if err := b.header.ValidatePoSFields(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // CopyHeader는 블록 헤더의 깊은 복사본을 생성합니다.
 func CopyHeader(h *Header) *Header {
 	cpy := *h
@@ -0,0 +1,172 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Equal은 두 헤더의 모든 필드가 같은지 여부를 반환합니다. big.Int 필드는 값으로 비교하므로
+// 내부 표현이 달라도 같은 값이면 같다고 판단하지만, nil과 0은 서로 다릅니다. 선택적 포인터
+// 필드도 마찬가지로 nil과 0 값을 구분합니다. 주로 테스트에서 reflect.DeepEqual 대신 사용합니다.
+func (h *Header) Equal(other *Header) bool {
+	return len(h.Diff(other)) == 0
+}
+
+// Diff는 두 헤더에서 값이 다른 필드를 "필드: 값 != 값" 형식으로 나열합니다. 두 헤더가 같으면
+// 빈 슬라이스를 반환합니다. 비교 규칙은 Equal과 같습니다.
+func (h *Header) Diff(other *Header) []string {
+	var d differ
+	d.header("", h, other)
+	return d.diffs
+}
+
+// Equal은 두 블록의 헤더, 트랜잭션, 엉클, 출금 목록이 모두 같은지 여부를 반환합니다.
+// 트랜잭션은 해시로 비교합니다. 출금 목록은 nil과 빈 목록을 구분하는데, 둘의 인코딩이 다르기
+// 때문입니다. 캐시된 값과 ReceivedAt, ReceivedFrom은 비교하지 않습니다.
+func (b *Block) Equal(other *Block) bool {
+	return len(b.Diff(other)) == 0
+}
+
+// Diff는 두 블록에서 값이 다른 부분을 나열합니다. 두 블록이 같으면 빈 슬라이스를 반환합니다.
+// 비교 규칙은 Equal과 같습니다.
+func (b *Block) Diff(other *Block) []string {
+	var d differ
+	if b == nil || other == nil {
+		d.nilness("block", b == nil, other == nil)
+		return d.diffs
+	}
+	d.header("header.", b.header, other.header)
+
+	if len(b.transactions) != len(other.transactions) {
+		d.add("len(transactions)", len(b.transactions), len(other.transactions))
+	} else {
+		for i, tx := range b.transactions {
+			if have, want := tx.Hash(), other.transactions[i].Hash(); have != want {
+				d.add(fmt.Sprintf("transactions[%d]", i), have, want)
+			}
+		}
+	}
+	if len(b.uncles) != len(other.uncles) {
+		d.add("len(uncles)", len(b.uncles), len(other.uncles))
+	} else {
+		for i, uncle := range b.uncles {
+			d.header(fmt.Sprintf("uncles[%d].", i), uncle, other.uncles[i])
+		}
+	}
+	switch {
+	case (b.withdrawals == nil) != (other.withdrawals == nil):
+		d.nilness("withdrawals", b.withdrawals == nil, other.withdrawals == nil)
+	case len(b.withdrawals) != len(other.withdrawals):
+		d.add("len(withdrawals)", len(b.withdrawals), len(other.withdrawals))
+	default:
+		for i, w := range b.withdrawals {
+			if o := other.withdrawals[i]; (w == nil) != (o == nil) || (w != nil && *w != *o) {
+				d.add(fmt.Sprintf("withdrawals[%d]", i), w, o)
+			}
+		}
+	}
+	return d.diffs
+}
+
+// differ는 Diff 메서드가 찾은 차이를 모읍니다.
+type differ struct {
+	diffs []string
+}
+
+func (d *differ) add(field string, have, want interface{}) {
+	d.diffs = append(d.diffs, fmt.Sprintf("%s: %v != %v", field, have, want))
+}
+
+func (d *differ) nilness(field string, haveNil, wantNil bool) {
+	if haveNil != wantNil {
+		d.add(field, nilString(haveNil), nilString(wantNil))
+	}
+}
+
+// header는 두 헤더의 필드를 비교하고, 필드 이름 앞에 prefix를 붙여 차이를 기록합니다.
+func (d *differ) header(prefix string, h, o *Header) {
+	if h == nil || o == nil {
+		d.nilness(prefix+"header", h == nil, o == nil)
+		return
+	}
+	d.value(prefix+"ParentHash", h.ParentHash, o.ParentHash)
+	d.value(prefix+"UncleHash", h.UncleHash, o.UncleHash)
+	d.value(prefix+"Coinbase", h.Coinbase, o.Coinbase)
+	d.value(prefix+"Root", h.Root, o.Root)
+	d.value(prefix+"TxHash", h.TxHash, o.TxHash)
+	d.value(prefix+"ReceiptHash", h.ReceiptHash, o.ReceiptHash)
+	if h.Bloom != o.Bloom {
+		d.add(prefix+"Bloom", fmt.Sprintf("%x", h.Bloom[:]), fmt.Sprintf("%x", o.Bloom[:]))
+	}
+	d.bigInt(prefix+"Difficulty", h.Difficulty, o.Difficulty)
+	d.bigInt(prefix+"Number", h.Number, o.Number)
+	d.value(prefix+"GasLimit", h.GasLimit, o.GasLimit)
+	d.value(prefix+"GasUsed", h.GasUsed, o.GasUsed)
+	d.value(prefix+"Time", h.Time, o.Time)
+	if !bytes.Equal(h.Extra, o.Extra) {
+		d.add(prefix+"Extra", fmt.Sprintf("%x", h.Extra), fmt.Sprintf("%x", o.Extra))
+	}
+	d.value(prefix+"MixDigest", h.MixDigest, o.MixDigest)
+	d.value(prefix+"Nonce", h.Nonce.Uint64(), o.Nonce.Uint64())
+	d.bigInt(prefix+"BaseFee", h.BaseFee, o.BaseFee)
+	d.hashPtr(prefix+"WithdrawalsHash", h.WithdrawalsHash, o.WithdrawalsHash)
+	d.uint64Ptr(prefix+"BlobGasUsed", h.BlobGasUsed, o.BlobGasUsed)
+	d.uint64Ptr(prefix+"ExcessBlobGas", h.ExcessBlobGas, o.ExcessBlobGas)
+	d.hashPtr(prefix+"ParentBeaconRoot", h.ParentBeaconRoot, o.ParentBeaconRoot)
+}
+
+func (d *differ) value(field string, have, want interface{}) {
+	if have != want {
+		d.add(field, have, want)
+	}
+}
+
+func (d *differ) bigInt(field string, have, want *big.Int) {
+	if have == nil || want == nil {
+		d.nilness(field, have == nil, want == nil)
+	} else if have.Cmp(want) != 0 {
+		d.add(field, have, want)
+	}
+}
+
+func (d *differ) hashPtr(field string, have, want *common.Hash) {
+	if have == nil || want == nil {
+		d.nilness(field, have == nil, want == nil)
+	} else if *have != *want {
+		d.add(field, *have, *want)
+	}
+}
+
+func (d *differ) uint64Ptr(field string, have, want *uint64) {
+	if have == nil || want == nil {
+		d.nilness(field, have == nil, want == nil)
+	} else if *have != *want {
+		d.add(field, *have, *want)
+	}
+}
+
+func nilString(isNil bool) string {
+	if isNil {
+		return "nil"
+	}
+	return "non-nil"
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/internal/blocktest"
+)
+
+func TestHeaderEqual(t *testing.T) {
+	blobGas := uint64(0)
+	h := &Header{
+		Difficulty:  new(big.Int).SetBytes([]byte{0}),
+		Number:      big.NewInt(10),
+		Extra:       []byte{1, 2},
+		BaseFee:     big.NewInt(7),
+		BlobGasUsed: &blobGas,
+	}
+	// Same values with different big.Int representation and fresh pointers.
+	other := CopyHeader(h)
+	other.Difficulty = new(big.Int)
+	if !h.Equal(other) {
+		t.Fatalf("equal headers reported different: %v", h.Diff(other))
+	}
+
+	other.BlobGasUsed = nil
+	other.BaseFee = big.NewInt(8)
+	other.ParentBeaconRoot = &common.Hash{}
+	want := []string{
+		"BaseFee: 7 != 8",
+		"BlobGasUsed: non-nil != nil",
+		"ParentBeaconRoot: nil != non-nil",
+	}
+	if diff := h.Diff(other); !reflect.DeepEqual(diff, want) {
+		t.Fatalf("wrong diff\nhave %q\nwant %q", diff, want)
+	}
+	if h.Equal(other) {
+		t.Fatal("different headers reported equal")
+	}
+	if diff := h.Diff(nil); !reflect.DeepEqual(diff, []string{"header: non-nil != nil"}) {
+		t.Fatalf("wrong diff against nil header: %q", diff)
+	}
+}
+
+func TestBlockEqual(t *testing.T) {
+	header := &Header{Number: big.NewInt(1), Difficulty: big.NewInt(0)}
+	txs := []*Transaction{NewTx(&LegacyTx{Nonce: 1, GasPrice: big.NewInt(1)})}
+	b1 := NewBlockWithWithdrawals(header, txs, nil, nil, []*Withdrawal{{Index: 1}}, blocktest.NewHasher())
+	b2 := NewBlockWithWithdrawals(header, txs, nil, nil, []*Withdrawal{{Index: 1}}, blocktest.NewHasher())
+	if !b1.Equal(b2) {
+		t.Fatalf("equal blocks reported different: %v", b1.Diff(b2))
+	}
+
+	b3 := NewBlockWithWithdrawals(header, txs, nil, nil, []*Withdrawal{}, blocktest.NewHasher())
+	want := []string{
+		"header.WithdrawalsHash: " + b1.Header().WithdrawalsHash.String() + " != " + b3.Header().WithdrawalsHash.String(),
+		"len(withdrawals): 1 != 0",
+	}
+	if diff := b1.Diff(b3); !reflect.DeepEqual(diff, want) {
+		t.Fatalf("wrong diff\nhave %q\nwant %q", diff, want)
+	}
+	// Blocks without withdrawals differ from blocks with an empty list.
+	b4 := b3.WithWithdrawals(nil)
+	if diff := b3.Diff(b4); !reflect.DeepEqual(diff, []string{"withdrawals: non-nil != nil"}) {
+		t.Fatalf("wrong diff for nil withdrawals: %q", diff)
+	}
+	if diff := b1.Diff(nil); !reflect.DeepEqual(diff, []string{"block: non-nil != nil"}) {
+		t.Fatalf("wrong diff against nil block: %q", diff)
+	}
+}
@@ -18,11 +18,14 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/blocktest"
@@ -317,3 +320,171 @@ func TestRlpDecodeParentHash(t *testing.T) {
 		}
 	}
 }
+
+func TestWithdrawalsTotalAmount(t *testing.T) {
+	ws := Withdrawals{
+		{Index: 1, Amount: 1},
+		{Index: 2, Amount: 2_000_000_000},
+	}
+	total, err := ws.TotalAmount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2_000_000_001 {
+		t.Fatalf("wrong total: have %d, want %d", total, 2_000_000_001)
+	}
+	wei, err := ws.TotalAmountWei()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := new(big.Int).SetString("2000000001000000000", 10); wei.Cmp(want) != 0 {
+		t.Fatalf("wrong total in wei: have %v, want %v", wei, want)
+	}
+	ws = append(ws, &Withdrawal{Index: 3, Amount: math.MaxUint64 - 2_000_000_000})
+	if _, err := ws.TotalAmount(); !errors.Is(err, ErrWithdrawalAmountOverflow) {
+		t.Fatalf("wrong error for overflowing total: %v", err)
+	}
+}
+
+func TestWithdrawalsValidate(t *testing.T) {
+	ws := Withdrawals{
+		{Index: 7, Validator: 1, Address: common.Address{0x01}, Amount: 10},
+		{Index: 8, Validator: 2, Address: common.Address{0x02}, Amount: 20},
+	}
+	root := DeriveSha(ws, blocktest.NewHasher())
+	if err := ws.Validate(root, blocktest.NewHasher()); err != nil {
+		t.Fatalf("valid withdrawals rejected: %v", err)
+	}
+	if err := ws.Validate(common.Hash{}, blocktest.NewHasher()); !errors.Is(err, ErrWithdrawalRootMismatch) {
+		t.Fatalf("wrong error for root mismatch: %v", err)
+	}
+	swapped := Withdrawals{ws[1], ws[0]}
+	if err := swapped.Validate(DeriveSha(swapped, blocktest.NewHasher()), blocktest.NewHasher()); !errors.Is(err, ErrWithdrawalIndexOrder) {
+		t.Fatalf("wrong error for unordered indices: %v", err)
+	}
+	if err := (Withdrawals{ws[0], nil}).Validate(root, blocktest.NewHasher()); err == nil {
+		t.Fatal("nil withdrawal accepted")
+	}
+	empty := Withdrawals{}
+	if err := empty.Validate(DeriveSha(empty, blocktest.NewHasher()), blocktest.NewHasher()); err != nil {
+		t.Fatalf("empty withdrawals rejected: %v", err)
+	}
+}
+
+func TestNewPostMergeBlock(t *testing.T) {
+	header := &Header{Number: big.NewInt(1), GasLimit: 30_000_000}
+	block, err := NewPostMergeBlock(header, nil, nil, []*Withdrawal{}, blocktest.NewHasher())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.UncleHash() != EmptyUncleHash || block.Difficulty().Sign() != 0 {
+		t.Fatal("post-merge block has pre-merge fields")
+	}
+	if h := block.Header().WithdrawalsHash; h == nil || *h != EmptyWithdrawalsHash {
+		t.Fatal("wrong withdrawals hash")
+	}
+
+	tests := []struct {
+		header *Header
+		err    error
+	}{
+		{&Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)}, ErrPoSDifficulty},
+		{&Header{Number: big.NewInt(1), Nonce: EncodeNonce(1)}, ErrPoSNonce},
+	}
+	for i, test := range tests {
+		if _, err := NewPostMergeBlock(test.header, nil, nil, nil, blocktest.NewHasher()); !errors.Is(err, test.err) {
+			t.Errorf("test %d: wrong error: have %v, want %v", i, err, test.err)
+		}
+	}
+	uncled := &Header{UncleHash: common.Hash{1}}
+	if err := uncled.ValidatePoSFields(); !errors.Is(err, ErrPoSUncleHash) {
+		t.Fatalf("wrong error for uncle hash: %v", err)
+	}
+}
+
+func TestHeaderPoSAccessors(t *testing.T) {
+	config := *params.TestChainConfig
+	config.TerminalTotalDifficulty = big.NewInt(0)
+	shanghai := uint64(100)
+	config.ShanghaiTime = &shanghai
+
+	tests := []struct {
+		header *Header
+		pos    bool
+		err    error
+	}{
+		{&Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)}, false, nil},
+		{&Header{Number: big.NewInt(1), Difficulty: big.NewInt(0)}, true, nil},
+		{&Header{Number: big.NewInt(1), Difficulty: big.NewInt(0), Nonce: EncodeNonce(1), UncleHash: EmptyUncleHash}, true, ErrPoSNonce},
+		{&Header{Number: big.NewInt(1), Difficulty: big.NewInt(2), Time: shanghai}, true, ErrPoSDifficulty},
+	}
+	for i, test := range tests {
+		if test.header.UncleHash == (common.Hash{}) {
+			test.header.UncleHash = EmptyUncleHash
+		}
+		if pos := test.header.IsPoS(&config); pos != test.pos {
+			t.Errorf("test %d: IsPoS = %t, want %t", i, pos, test.pos)
+		}
+		if err := test.header.ValidateMerge(&config); !errors.Is(err, test.err) {
+			t.Errorf("test %d: wrong error: have %v, want %v", i, err, test.err)
+		}
+	}
+	// Without a terminal total difficulty, no header is proof-of-stake.
+	if (&Header{Difficulty: big.NewInt(0)}).IsPoS(params.AllEthashProtocolChanges) {
+		t.Error("header reported as proof-of-stake on chain without merge")
+	}
+	h := &Header{MixDigest: common.Hash{1}}
+	if h.PrevRandao() != h.MixDigest {
+		t.Error("PrevRandao does not return the mix digest")
+	}
+}
+
+func TestHeaderMarshalJSONWithHash(t *testing.T) {
+	header := &Header{
+		Difficulty: big.NewInt(2),
+		Number:     big.NewInt(100),
+		GasLimit:   30_000_000,
+		Extra:      []byte("test"),
+		BaseFee:    big.NewInt(7),
+	}
+	enc, err := header.MarshalJSONWithHash(big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %v", enc, err)
+	}
+	headerRLP, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rlpSize := len(headerRLP)
+	if fields["hash"] != header.Hash().Hex() {
+		t.Errorf("wrong hash %v, want %v", fields["hash"], header.Hash().Hex())
+	}
+	if fields["size"] != hexutil.EncodeUint64(uint64(rlpSize)) {
+		t.Errorf("wrong size %v, want %d", fields["size"], rlpSize)
+	}
+	if fields["totalDifficulty"] != "0x3e8" {
+		t.Errorf("wrong total difficulty %v", fields["totalDifficulty"])
+	}
+	if fields["number"] != "0x64" || fields["baseFeePerGas"] != "0x7" {
+		t.Errorf("header fields missing: %s", enc)
+	}
+	// Without total difficulty the field must still be present as null.
+	enc, _ = header.MarshalJSONWithHash(nil)
+	fields = nil
+	json.Unmarshal(enc, &fields)
+	if td, ok := fields["totalDifficulty"]; !ok || td != nil {
+		t.Errorf("wrong nil total difficulty encoding: %s", enc)
+	}
+	// The output must still decode as a header.
+	var dec Header
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Hash() != header.Hash() {
+		t.Fatal("decoded header hash mismatch")
+	}
+}
@@ -19,6 +19,7 @@ package types
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -35,6 +36,9 @@ const (
 
 	// BloomBitLength는 헤더 로그 블룸에 사용되는 비트 수를 나타냅니다.
 	BloomBitLength = 8 * BloomByteLength
+
+	// bloomHashCount는 필터에 항목 하나를 추가할 때 설정되는 비트 수입니다.
+	bloomHashCount = 3
 )
 
 // Bloom은 2048 비트 블룸 필터를 나타냅니다.
@@ -70,6 +74,19 @@ func (b *Bloom) add(d []byte, buf []byte) {
 	b[i3] |= v3
 }
 
+// Or는 other에 설정된 모든 비트를 b에 설정합니다. 결과 필터는 두 필터에 추가된 모든 항목을
+// 포함합니다. 여러 블록의 블룸을 하나로 합칠 때 사용합니다.
+func (b *Bloom) Or(other Bloom) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// Equal은 두 필터가 같은지 여부를 반환합니다.
+func (b Bloom) Equal(other Bloom) bool {
+	return b == other
+}
+
 // Big는 b를 big.Int로 변환합니다.
 // 참고: 블룸 필터를 big.Int로 변환한 다음 GetBytes를 호출하더라도
 // 블룸 필터의 바이트와 동일한 바이트를 반환하지 않습니다. 왜냐하면 big.Int는 앞의 비어있는 바이트를 잘라내기 때문입니다.
@@ -105,27 +122,43 @@ func CreateBloom(receipts Receipts) Bloom {
 	buf := make([]byte, 6)
 	var bin Bloom
 	for _, receipt := range receipts {
-		for _, log := range receipt.Logs {
-			bin.add(log.Address.Bytes(), buf) // 로그를 발생시킨 컨트랙트 주소를 해싱하여 블룸 필터에 추가합니다.
-			for _, b := range log.Topics {
-				bin.add(b[:], buf) // 로그의 토픽을 해싱하여 블룸 필터에 추가합니다.
-			}
-		}
+		bin.addLogs(receipt.Logs, buf)
 	}
 	return bin
 }
 
+// CreateBloomFromLogs는 주어진 로그에 대한 블룸 필터를 생성합니다.
+func CreateBloomFromLogs(logs []*Log) Bloom {
+	var bin Bloom
+	bin.addLogs(logs, make([]byte, 6))
+	return bin
+}
+
 // LogsBloom는 주어진 로그에 대한 블룸 바이트를 반환합니다.
 func LogsBloom(logs []*Log) []byte {
-	buf := make([]byte, 6)
-	var bin Bloom
+	bin := CreateBloomFromLogs(logs)
+	return bin[:]
+}
+
+// addLogs는 로그의 주소와 토픽을 필터에 추가합니다.
+func (b *Bloom) addLogs(logs []*Log, buf []byte) {
 	for _, log := range logs {
-		bin.add(log.Address.Bytes(), buf) // 로그를 발생시킨 컨트랙트 주소를 해싱하여 블룸 필터에 추가합니다.
-		for _, b := range log.Topics {
-			bin.add(b[:], buf) // 로그의 토픽을 해싱하여 블룸 필터에 추가합니다.
+		b.add(log.Address.Bytes(), buf) // 로그를 발생시킨 컨트랙트 주소를 해싱하여 블룸 필터에 추가합니다.
+		for _, topic := range log.Topics {
+			b.add(topic[:], buf) // 로그의 토픽을 해싱하여 블룸 필터에 추가합니다.
 		}
 	}
-	return bin[:]
+}
+
+// FalsePositiveEstimate는 numEntries개의 항목(주소 또는 토픽)이 추가된 블룸 필터에서
+// 추가되지 않은 항목에 대해 Test가 true를 반환할 확률의 추정치를 반환합니다.
+func FalsePositiveEstimate(numEntries int) float64 {
+	if numEntries <= 0 {
+		return 0
+	}
+	// 각 비트가 설정되지 않은 채로 남아 있을 확률은 (1 - 1/m)^(k*n)입니다.
+	unset := math.Pow(1-1.0/BloomBitLength, float64(bloomHashCount*numEntries))
+	return math.Pow(1-unset, bloomHashCount)
 }
 
 // Bloom9은 주어진 데이터에 대한 블룸 필터를 바이트열로 반환합니다.
@@ -17,7 +17,9 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -55,6 +57,43 @@ func TestBloom(t *testing.T) {
 }
 
 // TestBloomExtensively does some more thorough tests
+func TestBloomOr(t *testing.T) {
+	logsA := []*Log{{Address: common.Address{1}, Topics: []common.Hash{{2}}}}
+	logsB := []*Log{{Address: common.Address{3}}}
+
+	combined := CreateBloomFromLogs(logsA)
+	combined.Or(CreateBloomFromLogs(logsB))
+	if !combined.Equal(CreateBloomFromLogs(append(logsA, logsB...))) {
+		t.Fatal("combined bloom differs from bloom of all logs")
+	}
+	for _, item := range [][]byte{common.Address{1}.Bytes(), common.Hash{2}.Bytes(), common.Address{3}.Bytes()} {
+		if !combined.Test(item) {
+			t.Errorf("combined bloom does not contain %x", item)
+		}
+	}
+	if !bytes.Equal(LogsBloom(logsA), CreateBloomFromLogs(logsA).Bytes()) {
+		t.Fatal("LogsBloom mismatch")
+	}
+}
+
+func TestFalsePositiveEstimate(t *testing.T) {
+	if p := FalsePositiveEstimate(0); p != 0 {
+		t.Fatalf("non-zero estimate for empty filter: %v", p)
+	}
+	// With a single entry, three of 2048 bits are set.
+	if p, want := FalsePositiveEstimate(1), math.Pow(3.0/2048, 3); math.Abs(p-want)/want > 0.01 {
+		t.Fatalf("wrong estimate for one entry: %v, want ~%v", p, want)
+	}
+	prev := 0.0
+	for _, n := range []int{10, 100, 1000, 10000} {
+		p := FalsePositiveEstimate(n)
+		if p <= prev || p > 1 {
+			t.Fatalf("estimate for %d entries out of order: %v", n, p)
+		}
+		prev = p
+	}
+}
+
 func TestBloomExtensively(t *testing.T) {
 	var exp = common.HexToHash("c8d3ca65cdb4874300a9e39475508f23ed6da09fdbc487f89a2dcf50b09eb263")
 	var b Bloom
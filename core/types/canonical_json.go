@@ -0,0 +1,122 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CanonicalJSON은 접근 목록의 정규 JSON 인코딩을 반환합니다. 정규 인코딩은 객체의 키가
+// 사전순으로 정렬되고, 공백이 없으며, 모든 16진수 문자열이 소문자로 표기됩니다. 오프체인
+// 증명이나 서명처럼 언어에 관계없이 같은 바이트 표현이 필요한 경우에 사용합니다.
+// 비어 있는 목록은 nil 여부와 관계없이 null이 아닌 []로 인코딩됩니다.
+func (al AccessList) CanonicalJSON() ([]byte, error) {
+	list := make(AccessList, len(al))
+	for i, tuple := range al {
+		list[i] = tuple
+		if list[i].StorageKeys == nil {
+			list[i].StorageKeys = []common.Hash{}
+		}
+	}
+	return canonicalJSON(list)
+}
+
+// CanonicalJSON은 로그의 정규 JSON 인코딩을 반환합니다. 필드는 MarshalJSON과 같으며, 토픽이
+// 없으면 []로 인코딩됩니다.
+func (l *Log) CanonicalJSON() ([]byte, error) {
+	if l.Topics == nil {
+		cpy := *l
+		cpy.Topics = []common.Hash{}
+		l = &cpy
+	}
+	return canonicalJSON(l)
+}
+
+// CanonicalJSON은 영수증의 정규 JSON 인코딩을 반환합니다. 필드는 MarshalJSON과 같으며, 로그가
+// 없으면 []로 인코딩됩니다. 로그의 토픽은 Log.CanonicalJSON과 같이 인코딩됩니다.
+func (r *Receipt) CanonicalJSON() ([]byte, error) {
+	cpy := *r
+	cpy.Logs = make([]*Log, len(r.Logs))
+	for i, log := range r.Logs {
+		cpy.Logs[i] = log
+		if log.Topics == nil {
+			l := *log
+			l.Topics = []common.Hash{}
+			cpy.Logs[i] = &l
+		}
+	}
+	return canonicalJSON(&cpy)
+}
+
+// canonicalJSON은 v를 JSON으로 인코딩한 후, 키를 정렬하고 공백을 제거하며 16진수 문자열을
+// 소문자로 바꾸어 다시 인코딩합니다. 숫자는 원래의 표기를 그대로 유지합니다.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(enc))
+	dec.UseNumber()
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	out := json.NewEncoder(&buf)
+	out.SetEscapeHTML(false)
+	// encoding/json은 맵의 키를 정렬하여 인코딩합니다.
+	if err := out.Encode(canonicalizeJSON(tree)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// canonicalizeJSON은 디코딩된 JSON 값의 모든 16진수 문자열을 소문자로 바꿉니다.
+func canonicalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = canonicalizeJSON(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = canonicalizeJSON(elem)
+		}
+	case string:
+		if isHexString(v) {
+			return strings.ToLower(v)
+		}
+	}
+	return v
+}
+
+// isHexString은 s가 0x 접두사가 붙은 16진수 문자열인지 여부를 반환합니다.
+func isHexString(s string) bool {
+	if len(s) < 2 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return false
+	}
+	for _, c := range s[2:] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
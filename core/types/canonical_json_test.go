@@ -0,0 +1,138 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCanonicalJSONVectors(t *testing.T) {
+	var (
+		zeroAddr  = "0x" + strings.Repeat("0", 40)
+		zeroBloom = "0x" + strings.Repeat("0", 512)
+		hash      = func(b string) string { return "0x" + b + strings.Repeat("0", 64-len(b)) }
+		addr      = func(b string) string { return "0x" + b + strings.Repeat("0", 40-len(b)) }
+	)
+	testLog := &Log{
+		Address:     common.Address{0xaa},
+		Topics:      []common.Hash{{0x01}},
+		Data:        []byte{0xde, 0xad},
+		BlockNumber: 10,
+		TxHash:      common.Hash{0x02},
+		TxIndex:     1,
+		BlockHash:   common.Hash{0x03},
+		Index:       2,
+	}
+	tests := []struct {
+		name string
+		fn   func() ([]byte, error)
+		want string
+	}{
+		{
+			name: "nil access list",
+			fn:   AccessList(nil).CanonicalJSON,
+			want: `[]`,
+		},
+		{
+			name: "access list",
+			fn: AccessList{
+				{Address: common.HexToAddress("0x00000000000000000000000000000000000000AB"), StorageKeys: []common.Hash{{0x01}, {0xff}}},
+				{Address: common.Address{0x02}},
+			}.CanonicalJSON,
+			want: `[{"address":"0x00000000000000000000000000000000000000ab","storageKeys":["` + hash("01") + `","` + hash("ff") + `"]},` +
+				`{"address":"` + addr("02") + `","storageKeys":[]}]`,
+		},
+		{
+			name: "log",
+			fn:   testLog.CanonicalJSON,
+			want: `{"address":"` + addr("aa") + `","blockHash":"` + hash("03") + `","blockNumber":"0xa","data":"0xdead","logIndex":"0x2",` +
+				`"removed":false,"topics":["` + hash("01") + `"],"transactionHash":"` + hash("02") + `","transactionIndex":"0x1"}`,
+		},
+		{
+			name: "log without topics",
+			fn:   (&Log{Removed: true}).CanonicalJSON,
+			want: `{"address":"` + zeroAddr + `","blockHash":"` + hash("") + `","blockNumber":"0x0","data":"0x","logIndex":"0x0",` +
+				`"removed":true,"topics":[],"transactionHash":"` + hash("") + `","transactionIndex":"0x0"}`,
+		},
+		{
+			name: "receipt",
+			fn: (&Receipt{
+				Type:              DynamicFeeTxType,
+				Status:            ReceiptStatusSuccessful,
+				CumulativeGasUsed: 21000,
+				Logs:              []*Log{testLog},
+				TxHash:            common.Hash{0x02},
+				GasUsed:           21000,
+				EffectiveGasPrice: big.NewInt(1000000000),
+				BlockHash:         common.Hash{0x03},
+				BlockNumber:       big.NewInt(10),
+				TransactionIndex:  1,
+			}).CanonicalJSON,
+			want: `{"blockHash":"` + hash("03") + `","blockNumber":"0xa","contractAddress":"` + zeroAddr + `","cumulativeGasUsed":"0x5208",` +
+				`"effectiveGasPrice":"0x3b9aca00","gasUsed":"0x5208","logs":[{"address":"` + addr("aa") + `","blockHash":"` + hash("03") + `",` +
+				`"blockNumber":"0xa","data":"0xdead","logIndex":"0x2","removed":false,"topics":["` + hash("01") + `"],` +
+				`"transactionHash":"` + hash("02") + `","transactionIndex":"0x1"}],"logsBloom":"` + zeroBloom + `","root":"0x","status":"0x1",` +
+				`"transactionHash":"` + hash("02") + `","transactionIndex":"0x1","type":"0x2"}`,
+		},
+		{
+			name: "receipt without logs",
+			fn:   (&Receipt{Status: ReceiptStatusFailed, EffectiveGasPrice: big.NewInt(7)}).CanonicalJSON,
+			want: `{"blockHash":"` + hash("") + `","contractAddress":"` + zeroAddr + `","cumulativeGasUsed":"0x0","effectiveGasPrice":"0x7",` +
+				`"gasUsed":"0x0","logs":[],"logsBloom":"` + zeroBloom + `","root":"0x","status":"0x0","transactionHash":"` + hash("") + `",` +
+				`"transactionIndex":"0x0"}`,
+		},
+	}
+	for _, test := range tests {
+		have, err := test.fn()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if string(have) != test.want {
+			t.Errorf("%s: encoding mismatch\nhave %s\nwant %s", test.name, have, test.want)
+		}
+	}
+}
+
+func TestCanonicalJSONNormalization(t *testing.T) {
+	input := map[string]interface{}{
+		"z":     "0xABCDEF",
+		"a":     []interface{}{"0XFF", "0xNOTHEX", "<&>"},
+		"m":     map[string]interface{}{"y": 1, "b": true},
+		"plain": "Hello",
+	}
+	have, err := canonicalJSON(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":["0xff","0xNOTHEX","<&>"],"m":{"b":true,"y":1},"plain":"Hello","z":"0xabcdef"}`
+	if string(have) != want {
+		t.Fatalf("encoding mismatch\nhave %s\nwant %s", have, want)
+	}
+	// Encoding must not modify the original value.
+	log := &Log{}
+	if _, err := log.CanonicalJSON(); err != nil {
+		t.Fatal(err)
+	}
+	if log.Topics != nil {
+		t.Fatal("CanonicalJSON modified the log")
+	}
+}
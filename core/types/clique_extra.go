@@ -0,0 +1,75 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	CliqueExtraVanity = 32                     // extra-data 앞부분에 서명자 vanity를 위해 예약된 바이트 수
+	CliqueExtraSeal   = crypto.SignatureLength // extra-data 끝부분에 서명자 봉인을 위해 예약된 바이트 수
+)
+
+var (
+	ErrCliqueExtraTooShort = errors.New("clique extra-data too short")
+	ErrCliqueExtraSigners  = errors.New("clique extra-data signer list has invalid length")
+)
+
+// CliqueExtra는 Clique 헤더의 extra-data 구조입니다. extra-data는 32바이트의 vanity, 체크포인트
+// 블록에서만 존재하는 서명자 주소 목록, 65바이트의 서명(봉인)을 차례로 이어 붙인 것입니다.
+type CliqueExtra struct {
+	Vanity  [CliqueExtraVanity]byte
+	Signers []common.Address
+	Seal    [CliqueExtraSeal]byte
+}
+
+// Parse는 extra-data를 구성 요소로 분리하여 e에 저장합니다. extra-data가 vanity와 봉인보다
+// 짧거나 그 사이의 서명자 목록이 주소 길이의 배수가 아니면 오류를 반환합니다.
+func (e *CliqueExtra) Parse(extra []byte) error {
+	if len(extra) < CliqueExtraVanity+CliqueExtraSeal {
+		return fmt.Errorf("%w: %d bytes, want at least %d", ErrCliqueExtraTooShort, len(extra), CliqueExtraVanity+CliqueExtraSeal)
+	}
+	signers := extra[CliqueExtraVanity : len(extra)-CliqueExtraSeal]
+	if len(signers)%common.AddressLength != 0 {
+		return fmt.Errorf("%w: %d bytes", ErrCliqueExtraSigners, len(signers))
+	}
+	copy(e.Vanity[:], extra)
+	copy(e.Seal[:], extra[len(extra)-CliqueExtraSeal:])
+	e.Signers = nil
+	if len(signers) > 0 {
+		e.Signers = make([]common.Address, len(signers)/common.AddressLength)
+		for i := range e.Signers {
+			copy(e.Signers[i][:], signers[i*common.AddressLength:])
+		}
+	}
+	return nil
+}
+
+// Assemble은 e를 extra-data로 인코딩하여 반환합니다.
+func (e *CliqueExtra) Assemble() []byte {
+	extra := make([]byte, 0, CliqueExtraVanity+len(e.Signers)*common.AddressLength+CliqueExtraSeal)
+	extra = append(extra, e.Vanity[:]...)
+	for _, signer := range e.Signers {
+		extra = append(extra, signer[:]...)
+	}
+	return append(extra, e.Seal[:]...)
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCliqueExtra(t *testing.T) {
+	extra := CliqueExtra{
+		Vanity:  [CliqueExtraVanity]byte{1},
+		Signers: []common.Address{{0x10}, {0x20}},
+		Seal:    [CliqueExtraSeal]byte{2, 3},
+	}
+	enc := extra.Assemble()
+	if len(enc) != CliqueExtraVanity+2*common.AddressLength+CliqueExtraSeal {
+		t.Fatalf("wrong extra-data length %d", len(enc))
+	}
+	if enc[0] != 1 || enc[CliqueExtraVanity] != 0x10 || enc[len(enc)-CliqueExtraSeal+1] != 3 {
+		t.Fatalf("wrong extra-data layout: %x", enc)
+	}
+	var dec CliqueExtra
+	if err := dec.Parse(enc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, extra) {
+		t.Fatalf("round trip mismatch: %+v", dec)
+	}
+
+	// Non-checkpoint extra-data has no signers.
+	enc = (&CliqueExtra{Vanity: extra.Vanity, Seal: extra.Seal}).Assemble()
+	if err := dec.Parse(enc); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Signers != nil || !bytes.Equal(dec.Assemble(), enc) {
+		t.Fatal("wrong parse result without signers")
+	}
+
+	if err := dec.Parse(make([]byte, CliqueExtraVanity+CliqueExtraSeal-1)); !errors.Is(err, ErrCliqueExtraTooShort) {
+		t.Fatalf("wrong error for short extra-data: %v", err)
+	}
+	if err := dec.Parse(make([]byte, CliqueExtraVanity+CliqueExtraSeal+5)); !errors.Is(err, ErrCliqueExtraSigners) {
+		t.Fatalf("wrong error for bad signer list: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// 이 파일의 오류 타입은 실패 원인의 세부 정보를 담습니다. 각 타입은 대응하는 센티넬 오류로
+// Unwrap되므로 errors.Is로 원인을 구분할 수 있고, errors.As로 세부 정보를 얻을 수 있습니다.
+//
+//	var chainErr *types.ChainIDError
+//	if errors.As(err, &chainErr) {
+//		log.Warn("Wrong chain", "have", chainErr.Have, "want", chainErr.Want)
+//	}
+
+// ChainIDError는 트랜잭션의 체인 ID가 서명자의 체인 ID와 일치하지 않을 때 반환됩니다.
+// ErrInvalidChainId로 Unwrap됩니다.
+type ChainIDError struct {
+	Have *big.Int // 트랜잭션의 체인 ID
+	Want *big.Int // 서명자의 체인 ID
+}
+
+func (e *ChainIDError) Error() string {
+	return fmt.Sprintf("%v: have %d want %d", ErrInvalidChainId, e.Have, e.Want)
+}
+
+func (e *ChainIDError) Unwrap() error { return ErrInvalidChainId }
+
+// TxTypeError는 지원되지 않는 트랜잭션 타입을 만났을 때 반환됩니다. ErrTxTypeNotSupported로
+// Unwrap되며, 오류 메시지는 ErrTxTypeNotSupported와 같습니다.
+type TxTypeError struct {
+	Type byte // 지원되지 않는 트랜잭션 타입
+}
+
+func (e *TxTypeError) Error() string { return ErrTxTypeNotSupported.Error() }
+
+func (e *TxTypeError) Unwrap() error { return ErrTxTypeNotSupported }
+
+// SignatureError는 서명 값 v, r, s가 유효하지 않을 때 반환됩니다. ErrInvalidSig로 Unwrap되며,
+// 오류 메시지는 ErrInvalidSig와 같습니다.
+type SignatureError struct {
+	V, R, S *big.Int
+}
+
+func (e *SignatureError) Error() string { return ErrInvalidSig.Error() }
+
+func (e *SignatureError) Unwrap() error { return ErrInvalidSig }
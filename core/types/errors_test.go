@@ -0,0 +1,85 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestChainIDError(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx, err := SignNewTx(key, NewLondonSigner(big.NewInt(1)), &DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Sender(NewLondonSigner(big.NewInt(5)), tx)
+	if !errors.Is(err, ErrInvalidChainId) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	var chainErr *ChainIDError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("error is not a *ChainIDError: %T", err)
+	}
+	if chainErr.Have.Int64() != 1 || chainErr.Want.Int64() != 5 {
+		t.Fatalf("wrong chain IDs in error: have %v, want %v", chainErr.Have, chainErr.Want)
+	}
+	if msg := err.Error(); msg != "invalid chain id for signer: have 1 want 5" {
+		t.Fatalf("wrong error message: %q", msg)
+	}
+}
+
+func TestTxTypeError(t *testing.T) {
+	var tx Transaction
+	err := tx.UnmarshalBinary(common.FromHex("70c0"))
+	var typeErr *TxTypeError
+	if !errors.As(err, &typeErr) || typeErr.Type != 0x70 {
+		t.Fatalf("wrong error for unknown type: %v", err)
+	}
+	if !errors.Is(err, ErrTxTypeNotSupported) || err.Error() != ErrTxTypeNotSupported.Error() {
+		t.Fatalf("error does not match ErrTxTypeNotSupported: %v", err)
+	}
+	// Signers report the type of the rejected transaction.
+	_, err = Sender(HomesteadSigner{}, NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), V: big.NewInt(0), R: big.NewInt(1), S: big.NewInt(1)}))
+	if !errors.As(err, &typeErr) || typeErr.Type != DynamicFeeTxType {
+		t.Fatalf("wrong error from signer: %v", err)
+	}
+}
+
+func TestSignatureError(t *testing.T) {
+	tx := NewTx(&LegacyTx{V: big.NewInt(27), R: big.NewInt(0), S: big.NewInt(1)})
+	_, err := Sender(HomesteadSigner{}, tx)
+	if !errors.Is(err, ErrInvalidSig) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	var sigErr *SignatureError
+	if !errors.As(err, &sigErr) {
+		t.Fatalf("error is not a *SignatureError: %T", err)
+	}
+	if sigErr.V.Int64() != 27 || sigErr.R.Sign() != 0 || sigErr.S.Int64() != 1 {
+		t.Fatalf("wrong signature values in error: v %v r %v s %v", sigErr.V, sigErr.R, sigErr.S)
+	}
+}
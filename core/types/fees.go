@@ -0,0 +1,124 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var minBlobGasPrice = big.NewInt(params.BlobTxMinBlobGasprice)
+
+// CalcBaseFee는 parent 다음 블록의 EIP-1559 기본 수수료를 계산합니다. parent가 London 포크 이전
+// 블록이면 초기 기본 수수료를 반환합니다.
+func CalcBaseFee(parent *Header, config *params.ChainConfig) *big.Int {
+	// 현재 블록이 첫 번째 EIP-1559 블록이면 InitialBaseFee를 반환합니다. 하한은 계산된
+	// 기본 수수료에만 적용됩니다.
+	if !config.IsLondon(parent.Number) {
+		return new(big.Int).SetUint64(params.InitialBaseFee)
+	}
+
+	parentGasTarget := parent.GasLimit / config.ElasticityMultiplier()
+	// 부모의 gasUsed가 목표와 같으면 기본 수수료는 변하지 않습니다.
+	if parent.GasUsed == parentGasTarget {
+		return new(big.Int).Set(parent.BaseFee)
+	}
+
+	var (
+		num   = new(big.Int)
+		denom = new(big.Int)
+	)
+
+	if parent.GasUsed > parentGasTarget {
+		// 부모 블록이 목표보다 많은 가스를 사용했다면 기본 수수료는 증가합니다.
+		// max(1, parentBaseFee * gasUsedDelta / parentGasTarget / baseFeeChangeDenominator)
+		num.SetUint64(parent.GasUsed - parentGasTarget)
+		num.Mul(num, parent.BaseFee)
+		num.Div(num, denom.SetUint64(parentGasTarget))
+		num.Div(num, denom.SetUint64(config.BaseFeeChangeDenominator()))
+		baseFeeDelta := math.BigMax(num, common.Big1)
+
+		return num.Add(parent.BaseFee, baseFeeDelta)
+	}
+	// 그렇지 않고 부모 블록이 목표보다 적은 가스를 사용했다면 기본 수수료는 감소합니다.
+	// max(minBaseFee, parentBaseFee - parentBaseFee * gasUsedDelta / parentGasTarget / baseFeeChangeDenominator)
+	num.SetUint64(parentGasTarget - parent.GasUsed)
+	num.Mul(num, parent.BaseFee)
+	num.Div(num, denom.SetUint64(parentGasTarget))
+	num.Div(num, denom.SetUint64(config.BaseFeeChangeDenominator()))
+	baseFee := num.Sub(parent.BaseFee, num)
+
+	return math.BigMax(baseFee, config.MinBaseFee())
+}
+
+// CalcExcessBlobGas는 부모 블록의 초과 blob 가스에 부모 블록이 사용한 blob 가스를 적용한 후의
+// 초과 blob 가스를 계산합니다. 목표 blob 가스는 headTime에 활성화된 포크의 blob 매개변수를 따릅니다.
+func CalcExcessBlobGas(config *params.ChainConfig, headTime uint64, parentExcessBlobGas uint64, parentBlobGasUsed uint64) uint64 {
+	target := blobConfig(config, headTime).TargetBlobGas()
+
+	excessBlobGas := parentExcessBlobGas + parentBlobGasUsed
+	if excessBlobGas < target {
+		return 0
+	}
+	return excessBlobGas - target
+}
+
+// NextExcessBlobGas는 parent 다음에 headTime으로 생성되는 블록의 초과 blob 가스를 반환합니다.
+// parent가 Cancun 포크 이전 블록이면(ExcessBlobGas 필드가 없으면) 0에서 시작합니다.
+func NextExcessBlobGas(parent *Header, config *params.ChainConfig, headTime uint64) uint64 {
+	if parent.ExcessBlobGas == nil || parent.BlobGasUsed == nil {
+		return 0
+	}
+	return CalcExcessBlobGas(config, headTime, *parent.ExcessBlobGas, *parent.BlobGasUsed)
+}
+
+// CalcBlobFee는 time에 생성된 헤더의 초과 blob 가스로부터 blob 가스 가격을 계산합니다.
+func CalcBlobFee(config *params.ChainConfig, time uint64, excessBlobGas uint64) *big.Int {
+	fraction := new(big.Int).SetUint64(blobConfig(config, time).UpdateFraction)
+	return fakeExponential(minBlobGasPrice, new(big.Int).SetUint64(excessBlobGas), fraction)
+}
+
+// blobConfig는 time에 적용되는 blob 매개변수의 복사본을 반환합니다. 구성이 없거나 Cancun
+// 이전이면 Cancun 기본값을 사용합니다.
+func blobConfig(config *params.ChainConfig, time uint64) *params.BlobConfig {
+	if config != nil {
+		if bc := config.BlobConfig(time); bc != nil {
+			return bc
+		}
+	}
+	bc := *params.DefaultCancunBlobConfig
+	return &bc
+}
+
+// fakeExponential은 테일러 전개를 사용하여 factor * e ** (numerator / denominator)를 근사합니다.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
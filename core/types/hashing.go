@@ -91,6 +91,45 @@ type DerivableList interface {
 	EncodeIndex(int, *bytes.Buffer)
 }
 
+// DerivableSlice는 RLP 인코더의 슬라이스를 DerivableList로 변환합니다. 각 요소는 EncodeRLP로
+// 인코딩됩니다. EncodeIndex를 직접 구현하지 않고도 사용자 정의 목록의 트라이 루트를 계산할 수
+// 있습니다.
+//
+//	root := DeriveSha(DerivableSlice[*Withdrawal](withdrawals), hasher)
+type DerivableSlice[T rlp.Encoder] []T
+
+// Len은 DerivableList를 구현합니다.
+func (s DerivableSlice[T]) Len() int { return len(s) }
+
+// EncodeIndex는 i번째 요소를 w에 인코딩합니다. 인코딩 오류는 DeriveSha와 마찬가지로 무시됩니다.
+func (s DerivableSlice[T]) EncodeIndex(i int, w *bytes.Buffer) {
+	s[i].EncodeRLP(w)
+}
+
+// derivableFunc는 DerivableFunc가 반환하는 DerivableList입니다.
+type derivableFunc[T any] struct {
+	items  []T
+	encode func(T, *bytes.Buffer)
+}
+
+func (d derivableFunc[T]) Len() int { return len(d.items) }
+
+func (d derivableFunc[T]) EncodeIndex(i int, w *bytes.Buffer) {
+	d.encode(d.items[i], w)
+}
+
+// DerivableFunc는 items의 각 요소를 encode로 인코딩하는 DerivableList를 반환합니다. 트라이에
+// 저장되는 값이 요소의 RLP 인코딩이 아닌 경우(예: 타입이 지정된 트랜잭션의 바이너리 인코딩)
+// 사용합니다.
+func DerivableFunc[T any](items []T, encode func(T, *bytes.Buffer)) DerivableList {
+	return derivableFunc[T]{items, encode}
+}
+
+// DeriveSliceSha는 RLP 인코더 슬라이스의 머클 루트를 계산합니다.
+func DeriveSliceSha[T rlp.Encoder](items []T, hasher TrieHasher) common.Hash {
+	return DeriveSha(DerivableSlice[T](items), hasher)
+}
+
 func encodeForDerive(list DerivableList, i int, buf *bytes.Buffer) []byte {
 	buf.Reset()
 	list.EncodeIndex(i, buf)
@@ -100,7 +139,11 @@ func encodeForDerive(list DerivableList, i int, buf *bytes.Buffer) []byte {
 }
 
 // DeriveSha는 블록 헤더의 트랜잭션, 영수증 및 출금의 머클루트를 계산합니다.
+// hasher가 nil이면 내장 ListHasher를 사용합니다.
 func DeriveSha(list DerivableList, hasher TrieHasher) common.Hash {
+	if hasher == nil {
+		hasher = NewListHasher()
+	}
 	hasher.Reset()
 
 	valueBuf := encodeBufferPool.Get().(*bytes.Buffer)
@@ -228,3 +228,27 @@ func (d *hashToHumanReadable) Update(i []byte, i2 []byte) error {
 func (d *hashToHumanReadable) Hash() common.Hash {
 	return common.Hash{}
 }
+
+func TestDerivableSlice(t *testing.T) {
+	withdrawals := make([]*types.Withdrawal, 200)
+	for i := range withdrawals {
+		withdrawals[i] = &types.Withdrawal{Index: uint64(i), Validator: uint64(i), Amount: 1}
+	}
+	exp := types.DeriveSha(types.Withdrawals(withdrawals), trie.NewStackTrie(nil))
+	if got := types.DeriveSliceSha(withdrawals, trie.NewStackTrie(nil)); got != exp {
+		t.Fatalf("withdrawals root mismatch: got %x exp %x", got, exp)
+	}
+
+	txs, err := genTxs(200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := types.DerivableFunc([]*types.Transaction(txs), func(tx *types.Transaction, w *bytes.Buffer) {
+		enc, _ := tx.MarshalBinary()
+		w.Write(enc)
+	})
+	exp = types.DeriveSha(txs, trie.NewStackTrie(nil))
+	if got := types.DeriveSha(list, trie.NewStackTrie(nil)); got != exp {
+		t.Fatalf("transactions root mismatch: got %x exp %x", got, exp)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ListHasher는 trie 패키지 없이 머클 패트리샤 트라이의 루트 해시를 계산하는 최소한의
+// TrieHasher 구현입니다. 트랜잭션, 영수증, 출금 목록처럼 루트만 필요하고 트라이 데이터베이스는
+// 필요하지 않은 경우에 사용합니다. 삽입된 값은 Hash가 호출될 때까지 보관되며, 키는
+// 어떤 순서로 삽입되어도 됩니다. 같은 키를 여러 번 삽입하면 마지막 값이 사용됩니다.
+//
+// 모든 노드를 메모리에서 한 번에 계산하므로 큰 상태 트라이에는 trie.StackTrie를 사용하십시오.
+type ListHasher struct {
+	pairs []listHasherPair
+}
+
+// NewListHasher는 비어 있는 ListHasher를 생성합니다.
+func NewListHasher() *ListHasher {
+	return new(ListHasher)
+}
+
+// Reset은 삽입된 모든 키와 값을 지웁니다.
+func (h *ListHasher) Reset() {
+	h.pairs = h.pairs[:0]
+}
+
+// Update는 키와 값을 트라이에 삽입합니다. 빈 값은 키를 삭제하는 것으로 취급됩니다.
+// 값은 Hash가 호출될 때까지 참조되므로 호출자는 그동안 수정해서는 안 됩니다.
+func (h *ListHasher) Update(key, value []byte) error {
+	h.pairs = append(h.pairs, listHasherPair{keybytesToNibbles(key), value})
+	return nil
+}
+
+// Hash는 삽입된 키와 값으로 구성된 트라이의 루트 해시를 반환합니다.
+func (h *ListHasher) Hash() common.Hash {
+	pairs := append([]listHasherPair(nil), h.pairs...)
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+	})
+	// 중복된 키는 마지막 값만 남기고, 빈 값은 제거합니다.
+	var unique []listHasherPair
+	for i, p := range pairs {
+		if i+1 < len(pairs) && bytes.Equal(p.key, pairs[i+1].key) {
+			continue
+		}
+		if len(p.value) > 0 {
+			unique = append(unique, p)
+		}
+	}
+	if len(unique) == 0 {
+		return EmptyRootHash
+	}
+	return crypto.Keccak256Hash(encodeListTrieNode(unique, 0))
+}
+
+// DeriveRoot는 내장 ListHasher로 목록의 머클 루트를 계산합니다. trie 패키지를 가져올 수
+// 없는 라이브러리에서 DeriveSha 대신 사용할 수 있습니다.
+//
+//	root := DeriveRoot(Withdrawals(withdrawals))
+func DeriveRoot(list DerivableList) common.Hash {
+	return DeriveSha(list, NewListHasher())
+}
+
+// listHasherPair는 니블 단위로 변환된 키와 그 값입니다.
+type listHasherPair struct {
+	key   []byte
+	value []byte
+}
+
+// encodeListTrieNode는 키가 정렬되고 중복이 없는 pairs로 구성된, depth 니블 아래의 서브트라이
+// 노드의 RLP 인코딩을 반환합니다.
+func encodeListTrieNode(pairs []listHasherPair, depth int) []byte {
+	w := rlp.NewEncoderBuffer(nil)
+	defer w.Flush()
+
+	if len(pairs) == 1 {
+		// 리프 노드: [HP(남은 키, 리프), 값]
+		list := w.List()
+		w.WriteBytes(hexPrefix(pairs[0].key[depth:], true))
+		w.WriteBytes(pairs[0].value)
+		w.ListEnd(list)
+		return w.ToBytes()
+	}
+	// 키는 정렬되어 있으므로 첫 번째와 마지막 키의 공통 접두사가 모든 키의 공통 접두사입니다.
+	first, last := pairs[0].key[depth:], pairs[len(pairs)-1].key[depth:]
+	prefix := 0
+	for prefix < len(first) && prefix < len(last) && first[prefix] == last[prefix] {
+		prefix++
+	}
+	if prefix > 0 {
+		// 확장 노드: [HP(공통 접두사, 확장), 자식 브랜치]
+		list := w.List()
+		w.WriteBytes(hexPrefix(first[:prefix], false))
+		writeListTrieRef(&w, encodeListTrieNode(pairs, depth+prefix))
+		w.ListEnd(list)
+		return w.ToBytes()
+	}
+	// 브랜치 노드: 16개의 자식과 이 위치에서 끝나는 키의 값
+	var value []byte
+	if len(pairs[0].key) == depth {
+		value, pairs = pairs[0].value, pairs[1:]
+	}
+	list := w.List()
+	for nibble := byte(0); nibble < 16; nibble++ {
+		end := 0
+		for end < len(pairs) && pairs[end].key[depth] == nibble {
+			end++
+		}
+		if end == 0 {
+			w.Write(rlp.EmptyString)
+			continue
+		}
+		writeListTrieRef(&w, encodeListTrieNode(pairs[:end], depth+1))
+		pairs = pairs[end:]
+	}
+	w.WriteBytes(value)
+	w.ListEnd(list)
+	return w.ToBytes()
+}
+
+// writeListTrieRef는 자식 노드에 대한 참조를 씁니다. 32바이트보다 짧은 노드는 그대로 포함되고,
+// 그렇지 않으면 노드의 해시가 기록됩니다.
+func writeListTrieRef(w *rlp.EncoderBuffer, node []byte) {
+	if len(node) < 32 {
+		w.Write(node)
+		return
+	}
+	w.WriteBytes(crypto.Keccak256(node))
+}
+
+// keybytesToNibbles는 키의 각 바이트를 두 개의 니블로 나눕니다.
+func keybytesToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b / 16
+		nibbles[i*2+1] = b % 16
+	}
+	return nibbles
+}
+
+// hexPrefix는 니블 경로를 리프 여부와 함께 hex-prefix 인코딩합니다.
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	var flag byte
+	if leaf {
+		flag = 2
+	}
+	out := make([]byte, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		out[0] = (flag+1)<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	} else {
+		out[0] = flag << 4
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		out[i/2+1] = nibbles[i]<<4 | nibbles[i+1]
+	}
+	return out
+}
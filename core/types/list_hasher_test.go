@@ -0,0 +1,70 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestListHasherDeriveSha(t *testing.T) {
+	if root := types.DeriveRoot(types.Transactions{}); root != types.EmptyRootHash {
+		t.Fatalf("wrong empty root: %x", root)
+	}
+	for _, n := range []int{1, 2, 3, 16, 17, 127, 128, 129, 300, 1000} {
+		list := newDummy(n)
+		list.len = n
+		exp := types.DeriveSha(list, trie.NewStackTrie(nil))
+		if got := types.DeriveRoot(list); got != exp {
+			t.Fatalf("%d items: root mismatch: have %x, want %x", n, got, exp)
+		}
+		if got := types.DeriveSha(list, nil); got != exp {
+			t.Fatalf("%d items: nil hasher root mismatch: have %x, want %x", n, got, exp)
+		}
+	}
+	var withdrawals types.Withdrawals
+	for i := 0; i < 20; i++ {
+		withdrawals = append(withdrawals, &types.Withdrawal{Index: uint64(i), Validator: uint64(i), Address: common.Address{byte(i)}, Amount: 32})
+	}
+	if got, exp := types.DeriveRoot(withdrawals), types.DeriveSha(withdrawals, trie.NewStackTrie(nil)); got != exp {
+		t.Fatalf("withdrawals root mismatch: have %x, want %x", got, exp)
+	}
+}
+
+func TestListHasherUnordered(t *testing.T) {
+	var (
+		h   = types.NewListHasher()
+		ref = trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	)
+	keys := []string{"doe", "dog", "do", "horse", "dogglesworth", "dog"}
+	values := []string{"reindeer", "puppy", "verb", "stallion", "cat", "coin"}
+	for i := range keys {
+		h.Update([]byte(keys[i]), []byte(values[i]))
+		ref.MustUpdate([]byte(keys[i]), []byte(values[i]))
+	}
+	if got, exp := h.Hash(), ref.Hash(); got != exp {
+		t.Fatalf("root mismatch: have %x, want %x", got, exp)
+	}
+	h.Reset()
+	if root := h.Hash(); root != types.EmptyRootHash {
+		t.Fatalf("wrong root after reset: %x", root)
+	}
+}
@@ -17,8 +17,12 @@
 package types
 
 import (
+	"bytes"
+	"io"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 //go:generate go run ../../rlp/rlpgen -type Log -out gen_log_rlp.go
@@ -60,3 +64,90 @@ type logMarshaling struct {
 	TxIndex     hexutil.Uint
 	Index       hexutil.Uint
 }
+
+// LogStorageVersion은 데이터베이스에 저장된 로그의 인코딩 형식입니다.
+type LogStorageVersion uint8
+
+const (
+	// LogStorageLegacy는 v1.9.0 이전 버전이 사용하던 형식으로, 컨센서스 필드 뒤에
+	// BlockNumber, TxHash, TxIndex, BlockHash, Index 파생 필드가 이어집니다.
+	LogStorageLegacy LogStorageVersion = iota + 1
+
+	// LogStorageCurrent는 현재 형식으로, 컨센서스 인코딩과 같습니다.
+	LogStorageCurrent
+)
+
+// LogForStorage는 데이터베이스 스토리지 형식으로 직렬화되는 로그 래퍼입니다.
+// 인코딩은 항상 현재 형식을 사용하며, 디코딩은 레거시 형식과 현재 형식을 모두 허용합니다.
+type LogForStorage Log
+
+// EncodeRLP는 rlp.Encoder를 구현하며 로그를 현재 스토리지 형식으로 인코딩합니다.
+func (l *LogForStorage) EncodeRLP(w io.Writer) error {
+	return (*Log)(l).EncodeRLP(w)
+}
+
+// DecodeRLP는 rlp.Decoder를 구현하며 레거시 또는 현재 스토리지 형식의 로그를 디코딩합니다.
+func (l *LogForStorage) DecodeRLP(s *rlp.Stream) error {
+	_, err := l.decode(s)
+	return err
+}
+
+// decode는 스트림에서 로그를 디코딩하고 감지된 스토리지 형식을 반환합니다. 컨센서스 필드 뒤에
+// 리스트 요소가 더 있으면 레거시 형식으로 간주합니다.
+func (l *LogForStorage) decode(s *rlp.Stream) (LogStorageVersion, error) {
+	if _, err := s.List(); err != nil {
+		return 0, err
+	}
+	*l = LogForStorage{}
+	if err := s.Decode(&l.Address); err != nil {
+		return 0, err
+	}
+	if err := s.Decode(&l.Topics); err != nil {
+		return 0, err
+	}
+	if err := s.Decode(&l.Data); err != nil {
+		return 0, err
+	}
+	version := LogStorageCurrent
+	if _, _, err := s.Kind(); err == nil {
+		version = LogStorageLegacy
+		for _, field := range []interface{}{&l.BlockNumber, &l.TxHash, &l.TxIndex, &l.BlockHash, &l.Index} {
+			if err := s.Decode(field); err != nil {
+				return 0, err
+			}
+		}
+	} else if err != rlp.EOL {
+		return 0, err
+	}
+	return version, s.ListEnd()
+}
+
+// DecodeLogForStorage는 임의의 geth 버전이 저장한 로그를 디코딩하고, 감지된 스토리지 형식을
+// 함께 반환합니다. 레거시 형식에 포함된 파생 필드도 채워집니다.
+func DecodeLogForStorage(b []byte) (*Log, LogStorageVersion, error) {
+	r := bytes.NewReader(b)
+	s := rlp.NewStream(r, uint64(len(b)))
+	var log LogForStorage
+	version, err := log.decode(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	if r.Len() > 0 {
+		return nil, 0, rlp.ErrMoreThanOneValue
+	}
+	return (*Log)(&log), version, nil
+}
+
+// MigrateLogForStorage는 저장된 로그를 현재 스토리지 형식으로 다시 인코딩합니다. 이미 현재
+// 형식이면 입력을 그대로 반환합니다. 레거시 형식의 파생 필드는 버려지므로, 필요하다면
+// 먼저 DecodeLogForStorage로 읽어야 합니다.
+func MigrateLogForStorage(b []byte) ([]byte, error) {
+	log, version, err := DecodeLogForStorage(b)
+	if err != nil {
+		return nil, err
+	}
+	if version == LogStorageCurrent {
+		return b, nil
+	}
+	return rlp.EncodeToBytes((*LogForStorage)(log))
+}
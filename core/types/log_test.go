@@ -17,6 +17,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"reflect"
@@ -25,6 +26,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var unmarshalLogTests = map[string]struct {
@@ -130,3 +132,76 @@ func checkError(t *testing.T, testname string, got, want error) bool {
 	}
 	return false
 }
+
+func TestLogForStorage(t *testing.T) {
+	log := &Log{
+		Address:     common.HexToAddress("0xecf8f87f810ecf450940c9f60066b4a7a501d6a7"),
+		Topics:      []common.Hash{common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+		Data:        []byte{0x01, 0x02},
+		BlockNumber: 2019236,
+		TxHash:      common.HexToHash("0x3b198bfd5d2907285af009e9ae84a0ecd63677110d89d7e030251acb87f6487e"),
+		TxIndex:     3,
+		BlockHash:   common.HexToHash("0x656c34545f90a730a19008c0e7a7cd4fb3895064b48d6d69761bd5abad681056"),
+		Index:       2,
+	}
+	current, err := rlp.EncodeToBytes((*LogForStorage)(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	consensus, _ := rlp.EncodeToBytes(log)
+	if !bytes.Equal(current, consensus) {
+		t.Fatalf("storage encoding differs from consensus encoding: %x != %x", current, consensus)
+	}
+	legacy, err := rlp.EncodeToBytes([]interface{}{log.Address, log.Topics, log.Data, log.BlockNumber, log.TxHash, log.TxIndex, log.BlockHash, log.Index})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Current format decodes only the consensus fields.
+	dec, version, err := DecodeLogForStorage(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Log{Address: log.Address, Topics: log.Topics, Data: log.Data}
+	if version != LogStorageCurrent || !reflect.DeepEqual(dec, want) {
+		t.Fatalf("current format mismatch: version %d, log %v", version, spew.Sdump(dec))
+	}
+	// Legacy format also restores the stored derived fields.
+	dec, version, err = DecodeLogForStorage(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != LogStorageLegacy || !reflect.DeepEqual(dec, log) {
+		t.Fatalf("legacy format mismatch: version %d, log %v", version, spew.Sdump(dec))
+	}
+	// The rlp.Decoder implementation accepts both formats.
+	var decoded []*LogForStorage
+	list, _ := rlp.EncodeToBytes([]rlp.RawValue{current, legacy})
+	if err := rlp.DecodeBytes(list, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[1].BlockNumber != log.BlockNumber {
+		t.Fatalf("wrong decoded list: %v", spew.Sdump(decoded))
+	}
+
+	// Migration rewrites legacy logs and leaves current ones untouched.
+	migrated, err := MigrateLogForStorage(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(migrated, current) {
+		t.Fatalf("migrated log mismatch: %x != %x", migrated, current)
+	}
+	if migrated, _ := MigrateLogForStorage(current); !bytes.Equal(migrated, current) {
+		t.Fatal("current log changed by migration")
+	}
+
+	// Truncated legacy logs and trailing data are rejected.
+	truncated, _ := rlp.EncodeToBytes([]interface{}{log.Address, log.Topics, log.Data, log.BlockNumber})
+	if _, _, err := DecodeLogForStorage(truncated); err == nil {
+		t.Fatal("no error for truncated legacy log")
+	}
+	if _, _, err := DecodeLogForStorage(append(current, 0x80)); !errors.Is(err, rlp.ErrMoreThanOneValue) {
+		t.Fatalf("wrong error for trailing data: %v", err)
+	}
+}
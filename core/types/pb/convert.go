@@ -0,0 +1,466 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pb
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+var (
+	// ErrHashMismatch는 변환된 트랜잭션이나 헤더의 해시가 메시지의 Hash 필드와 다를 때 반환됩니다.
+	ErrHashMismatch = errors.New("pb: hash mismatch")
+
+	errInvalidLength = errors.New("pb: invalid field length")
+)
+
+// FromTransaction은 tx를 protobuf 메시지로 변환합니다.
+func FromTransaction(tx *types.Transaction) *Transaction {
+	v, r, s := tx.RawSignatureValues()
+	hash := tx.Hash()
+	m := &Transaction{
+		Type:      uint32(tx.Type()),
+		ChainID:   bigBytes(tx.ChainId()),
+		Nonce:     tx.Nonce(),
+		GasPrice:  bigBytes(tx.GasPrice()),
+		GasTipCap: bigBytes(tx.GasTipCap()),
+		GasFeeCap: bigBytes(tx.GasFeeCap()),
+		Gas:       tx.Gas(),
+		Value:     bigBytes(tx.Value()),
+		Data:      common.CopyBytes(tx.Data()),
+		V:         bigBytes(v),
+		R:         bigBytes(r),
+		S:         bigBytes(s),
+		Hash:      hash[:],
+	}
+	if to := tx.To(); to != nil {
+		m.To = to.Bytes()
+	}
+	for _, tuple := range tx.AccessList() {
+		t := &AccessTuple{Address: tuple.Address.Bytes()}
+		for _, key := range tuple.StorageKeys {
+			t.StorageKeys = append(t.StorageKeys, key.Bytes())
+		}
+		m.AccessList = append(m.AccessList, t)
+	}
+	if tx.Type() == types.BlobTxType {
+		m.BlobFeeCap = bigBytes(tx.BlobGasFeeCap())
+		for _, h := range tx.BlobHashes() {
+			m.BlobHashes = append(m.BlobHashes, h.Bytes())
+		}
+	}
+	return m
+}
+
+// ToTransaction은 메시지를 트랜잭션으로 변환합니다. Hash 필드가 설정되어 있으면 변환된
+// 트랜잭션의 해시와 비교하여, 다르면 ErrHashMismatch를 반환합니다.
+func (m *Transaction) ToTransaction() (*types.Transaction, error) {
+	var d decoder
+	var (
+		to         = d.optionalAddress("to", m.To)
+		accessList = d.accessList(m.AccessList)
+		inner      types.TxData
+	)
+	switch m.Type {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    m.Nonce,
+			GasPrice: d.big("gas_price", m.GasPrice),
+			Gas:      m.Gas,
+			To:       to,
+			Value:    d.big("value", m.Value),
+			Data:     common.CopyBytes(m.Data),
+			V:        d.big("v", m.V),
+			R:        d.big("r", m.R),
+			S:        d.big("s", m.S),
+		}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    d.big("chain_id", m.ChainID),
+			Nonce:      m.Nonce,
+			GasPrice:   d.big("gas_price", m.GasPrice),
+			Gas:        m.Gas,
+			To:         to,
+			Value:      d.big("value", m.Value),
+			Data:       common.CopyBytes(m.Data),
+			AccessList: accessList,
+			V:          d.big("v", m.V),
+			R:          d.big("r", m.R),
+			S:          d.big("s", m.S),
+		}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    d.big("chain_id", m.ChainID),
+			Nonce:      m.Nonce,
+			GasTipCap:  d.big("gas_tip_cap", m.GasTipCap),
+			GasFeeCap:  d.big("gas_fee_cap", m.GasFeeCap),
+			Gas:        m.Gas,
+			To:         to,
+			Value:      d.big("value", m.Value),
+			Data:       common.CopyBytes(m.Data),
+			AccessList: accessList,
+			V:          d.big("v", m.V),
+			R:          d.big("r", m.R),
+			S:          d.big("s", m.S),
+		}
+	case types.BlobTxType:
+		if to == nil {
+			return nil, errors.New("pb: blob transaction without recipient")
+		}
+		blobHashes := make([]common.Hash, len(m.BlobHashes))
+		for i, h := range m.BlobHashes {
+			blobHashes[i] = d.hash("blob_hashes", h)
+		}
+		inner = &types.BlobTx{
+			ChainID:    d.u256("chain_id", m.ChainID),
+			Nonce:      m.Nonce,
+			GasTipCap:  d.u256("gas_tip_cap", m.GasTipCap),
+			GasFeeCap:  d.u256("gas_fee_cap", m.GasFeeCap),
+			Gas:        m.Gas,
+			To:         *to,
+			Value:      d.u256("value", m.Value),
+			Data:       common.CopyBytes(m.Data),
+			AccessList: accessList,
+			BlobFeeCap: d.u256("blob_fee_cap", m.BlobFeeCap),
+			BlobHashes: blobHashes,
+			V:          d.u256("v", m.V),
+			R:          d.u256("r", m.R),
+			S:          d.u256("s", m.S),
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", types.ErrTxTypeNotSupported, m.Type)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	tx := types.NewTx(inner)
+	if err := checkHash(m.Hash, tx.Hash()); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// FromLog는 로그를 protobuf 메시지로 변환합니다.
+func FromLog(l *types.Log) *Log {
+	m := &Log{
+		Address:     l.Address.Bytes(),
+		Data:        common.CopyBytes(l.Data),
+		BlockNumber: l.BlockNumber,
+		TxHash:      l.TxHash.Bytes(),
+		TxIndex:     uint64(l.TxIndex),
+		BlockHash:   l.BlockHash.Bytes(),
+		Index:       uint64(l.Index),
+		Removed:     l.Removed,
+	}
+	for _, topic := range l.Topics {
+		m.Topics = append(m.Topics, topic.Bytes())
+	}
+	return m
+}
+
+// ToLog는 메시지를 로그로 변환합니다.
+func (m *Log) ToLog() (*types.Log, error) {
+	var d decoder
+	l := &types.Log{
+		Address:     d.address("address", m.Address),
+		Topics:      make([]common.Hash, len(m.Topics)),
+		Data:        common.CopyBytes(m.Data),
+		BlockNumber: m.BlockNumber,
+		TxHash:      d.hash("tx_hash", m.TxHash),
+		TxIndex:     uint(m.TxIndex),
+		BlockHash:   d.hash("block_hash", m.BlockHash),
+		Index:       uint(m.Index),
+		Removed:     m.Removed,
+	}
+	for i, topic := range m.Topics {
+		l.Topics[i] = d.hash("topics", topic)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if l.Data == nil {
+		l.Data = []byte{}
+	}
+	return l, nil
+}
+
+// FromReceipt는 영수증을 protobuf 메시지로 변환합니다.
+func FromReceipt(r *types.Receipt) *Receipt {
+	m := &Receipt{
+		Type:              uint32(r.Type),
+		PostState:         common.CopyBytes(r.PostState),
+		Status:            r.Status,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		LogsBloom:         common.CopyBytes(r.Bloom[:]),
+		TxHash:            r.TxHash.Bytes(),
+		ContractAddress:   r.ContractAddress.Bytes(),
+		GasUsed:           r.GasUsed,
+		EffectiveGasPrice: optionalBigBytes(r.EffectiveGasPrice),
+		BlobGasUsed:       r.BlobGasUsed,
+		BlobGasPrice:      optionalBigBytes(r.BlobGasPrice),
+		BlockHash:         r.BlockHash.Bytes(),
+		BlockNumber:       optionalBigBytes(r.BlockNumber),
+		TransactionIndex:  uint64(r.TransactionIndex),
+	}
+	for _, l := range r.Logs {
+		m.Logs = append(m.Logs, FromLog(l))
+	}
+	return m
+}
+
+// ToReceipt는 메시지를 영수증으로 변환합니다.
+func (m *Receipt) ToReceipt() (*types.Receipt, error) {
+	if m.Type > 0x7f {
+		return nil, fmt.Errorf("%w: %d", types.ErrTxTypeNotSupported, m.Type)
+	}
+	var d decoder
+	r := &types.Receipt{
+		Type:              uint8(m.Type),
+		PostState:         common.CopyBytes(m.PostState),
+		Status:            m.Status,
+		CumulativeGasUsed: m.CumulativeGasUsed,
+		Logs:              make([]*types.Log, len(m.Logs)),
+		TxHash:            d.hash("tx_hash", m.TxHash),
+		ContractAddress:   d.address("contract_address", m.ContractAddress),
+		GasUsed:           m.GasUsed,
+		EffectiveGasPrice: d.optionalBig("effective_gas_price", m.EffectiveGasPrice),
+		BlobGasUsed:       m.BlobGasUsed,
+		BlobGasPrice:      d.optionalBig("blob_gas_price", m.BlobGasPrice),
+		BlockHash:         d.hash("block_hash", m.BlockHash),
+		BlockNumber:       d.optionalBig("block_number", m.BlockNumber),
+		TransactionIndex:  uint(m.TransactionIndex),
+	}
+	if len(m.LogsBloom) > 0 {
+		if len(m.LogsBloom) != types.BloomByteLength {
+			return nil, fmt.Errorf("%w: logs_bloom has %d bytes", errInvalidLength, len(m.LogsBloom))
+		}
+		r.Bloom.SetBytes(m.LogsBloom)
+	}
+	for i, ml := range m.Logs {
+		l, err := ml.ToLog()
+		if err != nil {
+			return nil, fmt.Errorf("log %d: %w", i, err)
+		}
+		r.Logs[i] = l
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return r, nil
+}
+
+// FromHeader는 헤더를 protobuf 메시지로 변환합니다.
+func FromHeader(h *types.Header) *Header {
+	hash := h.Hash()
+	m := &Header{
+		ParentHash:  h.ParentHash.Bytes(),
+		UncleHash:   h.UncleHash.Bytes(),
+		Coinbase:    h.Coinbase.Bytes(),
+		Root:        h.Root.Bytes(),
+		TxHash:      h.TxHash.Bytes(),
+		ReceiptHash: h.ReceiptHash.Bytes(),
+		Bloom:       common.CopyBytes(h.Bloom[:]),
+		Difficulty:  bigBytes(h.Difficulty),
+		Number:      bigBytes(h.Number),
+		GasLimit:    h.GasLimit,
+		GasUsed:     h.GasUsed,
+		Time:        h.Time,
+		Extra:       common.CopyBytes(h.Extra),
+		MixDigest:   h.MixDigest.Bytes(),
+		Nonce:       common.CopyBytes(h.Nonce[:]),
+		BaseFee:     optionalBigBytes(h.BaseFee),
+		Hash:        hash[:],
+	}
+	if h.WithdrawalsHash != nil {
+		m.WithdrawalsHash = h.WithdrawalsHash.Bytes()
+	}
+	if h.BlobGasUsed != nil {
+		v := *h.BlobGasUsed
+		m.BlobGasUsed = &v
+	}
+	if h.ExcessBlobGas != nil {
+		v := *h.ExcessBlobGas
+		m.ExcessBlobGas = &v
+	}
+	if h.ParentBeaconRoot != nil {
+		m.ParentBeaconRoot = h.ParentBeaconRoot.Bytes()
+	}
+	return m
+}
+
+// ToHeader는 메시지를 헤더로 변환합니다. Hash 필드가 설정되어 있으면 변환된 헤더의 해시와
+// 비교하여, 다르면 ErrHashMismatch를 반환합니다.
+func (m *Header) ToHeader() (*types.Header, error) {
+	var d decoder
+	h := &types.Header{
+		ParentHash:  d.hash("parent_hash", m.ParentHash),
+		UncleHash:   d.hash("uncle_hash", m.UncleHash),
+		Coinbase:    d.address("coinbase", m.Coinbase),
+		Root:        d.hash("root", m.Root),
+		TxHash:      d.hash("tx_hash", m.TxHash),
+		ReceiptHash: d.hash("receipt_hash", m.ReceiptHash),
+		Difficulty:  d.big("difficulty", m.Difficulty),
+		Number:      d.big("number", m.Number),
+		GasLimit:    m.GasLimit,
+		GasUsed:     m.GasUsed,
+		Time:        m.Time,
+		Extra:       common.CopyBytes(m.Extra),
+		MixDigest:   d.hash("mix_digest", m.MixDigest),
+		BaseFee:     d.optionalBig("base_fee", m.BaseFee),
+	}
+	if h.Extra == nil {
+		h.Extra = []byte{}
+	}
+	if len(m.Bloom) > 0 {
+		if len(m.Bloom) != types.BloomByteLength {
+			return nil, fmt.Errorf("%w: bloom has %d bytes", errInvalidLength, len(m.Bloom))
+		}
+		h.Bloom.SetBytes(m.Bloom)
+	}
+	if len(m.Nonce) > 0 {
+		if len(m.Nonce) != len(h.Nonce) {
+			return nil, fmt.Errorf("%w: nonce has %d bytes", errInvalidLength, len(m.Nonce))
+		}
+		copy(h.Nonce[:], m.Nonce)
+	}
+	if m.WithdrawalsHash != nil {
+		hash := d.hash("withdrawals_hash", m.WithdrawalsHash)
+		h.WithdrawalsHash = &hash
+	}
+	if m.BlobGasUsed != nil {
+		v := *m.BlobGasUsed
+		h.BlobGasUsed = &v
+	}
+	if m.ExcessBlobGas != nil {
+		v := *m.ExcessBlobGas
+		h.ExcessBlobGas = &v
+	}
+	if m.ParentBeaconRoot != nil {
+		root := d.hash("parent_beacon_root", m.ParentBeaconRoot)
+		h.ParentBeaconRoot = &root
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if err := checkHash(m.Hash, h.Hash()); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// bigBytes는 정수를 앞의 0을 제외한 빅 엔디언 바이트로 변환합니다. nil은 nil이 됩니다.
+func bigBytes(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+	return v.Bytes()
+}
+
+// optionalBigBytes는 bigBytes와 같지만, 0을 빈 슬라이스로 변환하여 nil과 구분합니다.
+func optionalBigBytes(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+	return append([]byte{}, v.Bytes()...)
+}
+
+func checkHash(want []byte, have common.Hash) error {
+	if len(want) > 0 && common.BytesToHash(want) != have {
+		return fmt.Errorf("%w: have %x, want %x", ErrHashMismatch, have, want)
+	}
+	return nil
+}
+
+// decoder는 메시지 필드를 변환하면서 처음 발생한 오류를 기록합니다.
+type decoder struct {
+	err error
+}
+
+func (d *decoder) fail(name string, b []byte) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s has %d bytes", errInvalidLength, name, len(b))
+	}
+}
+
+// hash는 32바이트 해시를 변환합니다. 빈 값은 0 해시입니다.
+func (d *decoder) hash(name string, b []byte) common.Hash {
+	if len(b) != 0 && len(b) != common.HashLength {
+		d.fail(name, b)
+	}
+	return common.BytesToHash(b)
+}
+
+// address는 20바이트 주소를 변환합니다. 빈 값은 0 주소입니다.
+func (d *decoder) address(name string, b []byte) common.Address {
+	if len(b) != 0 && len(b) != common.AddressLength {
+		d.fail(name, b)
+	}
+	return common.BytesToAddress(b)
+}
+
+func (d *decoder) optionalAddress(name string, b []byte) *common.Address {
+	if b == nil {
+		return nil
+	}
+	if len(b) != common.AddressLength {
+		d.fail(name, b)
+	}
+	addr := common.BytesToAddress(b)
+	return &addr
+}
+
+func (d *decoder) big(name string, b []byte) *big.Int {
+	if len(b) > 32 {
+		d.fail(name, b)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func (d *decoder) optionalBig(name string, b []byte) *big.Int {
+	if b == nil {
+		return nil
+	}
+	return d.big(name, b)
+}
+
+func (d *decoder) u256(name string, b []byte) *uint256.Int {
+	if len(b) > 32 {
+		d.fail(name, b)
+		return new(uint256.Int)
+	}
+	return new(uint256.Int).SetBytes(b)
+}
+
+func (d *decoder) accessList(tuples []*AccessTuple) types.AccessList {
+	if len(tuples) == 0 {
+		return nil
+	}
+	list := make(types.AccessList, len(tuples))
+	for i, t := range tuples {
+		list[i].Address = d.address("access_list.address", t.Address)
+		list[i].StorageKeys = make([]common.Hash, len(t.StorageKeys))
+		for j, key := range t.StorageKeys {
+			list[i].StorageKeys[j] = d.hash("access_list.storage_keys", key)
+		}
+	}
+	return list
+}
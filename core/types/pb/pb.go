@@ -0,0 +1,548 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// pb 패키지는 트랜잭션, 영수증, 로그, 헤더를 protobuf로 직렬화합니다.
+//
+// 메시지 정의는 types.proto에 있으며, 코덱은 코드 생성기 없이 스키마에 맞추어 직접 작성되어
+// 있습니다. 코덱과 스키마의 일치 여부는 테스트에서 표준 protobuf 구현과 비교하여 확인합니다. 인덱서나 데이터 파이프라인은 types.proto로 다른 언어의 코드를 생성하여 이 패키지가
+// 만든 메시지를 읽을 수 있습니다. core/types 값과의 변환은 From*와 To* 함수가 담당합니다.
+package pb
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// AccessTuple은 접근 목록의 항목 하나입니다.
+type AccessTuple struct {
+	Address     []byte   // 1
+	StorageKeys [][]byte // 2
+}
+
+// Transaction은 서명된 트랜잭션입니다. 값이 256비트 이하인 정수 필드는 앞의 0을 제외한 빅 엔디언
+// 바이트입니다. To가 nil이면 컨트랙트 생성 트랜잭션입니다.
+type Transaction struct {
+	Type       uint32         // 1
+	ChainID    []byte         // 2
+	Nonce      uint64         // 3
+	GasPrice   []byte         // 4
+	GasTipCap  []byte         // 5
+	GasFeeCap  []byte         // 6
+	Gas        uint64         // 7
+	To         []byte         // 8, optional
+	Value      []byte         // 9
+	Data       []byte         // 10
+	AccessList []*AccessTuple // 11
+	BlobFeeCap []byte         // 12
+	BlobHashes [][]byte       // 13
+	V          []byte         // 14
+	R          []byte         // 15
+	S          []byte         // 16
+	Hash       []byte         // 17
+}
+
+// Log는 컨트랙트 로그 이벤트입니다.
+type Log struct {
+	Address     []byte   // 1
+	Topics      [][]byte // 2
+	Data        []byte   // 3
+	BlockNumber uint64   // 4
+	TxHash      []byte   // 5
+	TxIndex     uint64   // 6
+	BlockHash   []byte   // 7
+	Index       uint64   // 8
+	Removed     bool     // 9
+}
+
+// Receipt는 트랜잭션 영수증입니다. optional 필드는 nil이면 생략됩니다.
+type Receipt struct {
+	Type              uint32 // 1
+	PostState         []byte // 2
+	Status            uint64 // 3
+	CumulativeGasUsed uint64 // 4
+	LogsBloom         []byte // 5
+	Logs              []*Log // 6
+	TxHash            []byte // 7
+	ContractAddress   []byte // 8
+	GasUsed           uint64 // 9
+	EffectiveGasPrice []byte // 10, optional
+	BlobGasUsed       uint64 // 11
+	BlobGasPrice      []byte // 12, optional
+	BlockHash         []byte // 13
+	BlockNumber       []byte // 14, optional
+	TransactionIndex  uint64 // 15
+}
+
+// Header는 블록 헤더입니다. optional 필드는 nil이면 생략되므로, 포크 이전의 헤더와 값이 0인
+// 필드를 구분할 수 있습니다.
+type Header struct {
+	ParentHash       []byte  // 1
+	UncleHash        []byte  // 2
+	Coinbase         []byte  // 3
+	Root             []byte  // 4
+	TxHash           []byte  // 5
+	ReceiptHash      []byte  // 6
+	Bloom            []byte  // 7
+	Difficulty       []byte  // 8
+	Number           []byte  // 9
+	GasLimit         uint64  // 10
+	GasUsed          uint64  // 11
+	Time             uint64  // 12
+	Extra            []byte  // 13
+	MixDigest        []byte  // 14
+	Nonce            []byte  // 15
+	BaseFee          []byte  // 16, optional
+	WithdrawalsHash  []byte  // 17, optional
+	BlobGasUsed      *uint64 // 18, optional
+	ExcessBlobGas    *uint64 // 19, optional
+	ParentBeaconRoot []byte  // 20, optional
+	Hash             []byte  // 21
+}
+
+// Marshal은 메시지의 protobuf 인코딩을 반환합니다.
+func (m *AccessTuple) Marshal() ([]byte, error) { return m.appendTo(nil), nil }
+
+// Marshal은 메시지의 protobuf 인코딩을 반환합니다.
+func (m *Transaction) Marshal() ([]byte, error) { return m.appendTo(nil), nil }
+
+// Marshal은 메시지의 protobuf 인코딩을 반환합니다.
+func (m *Log) Marshal() ([]byte, error) { return m.appendTo(nil), nil }
+
+// Marshal은 메시지의 protobuf 인코딩을 반환합니다.
+func (m *Receipt) Marshal() ([]byte, error) { return m.appendTo(nil), nil }
+
+// Marshal은 메시지의 protobuf 인코딩을 반환합니다.
+func (m *Header) Marshal() ([]byte, error) { return m.appendTo(nil), nil }
+
+func (m *AccessTuple) appendTo(b []byte) []byte {
+	e := encoder(b)
+	e.bytes(1, m.Address)
+	e.repeatedBytes(2, m.StorageKeys)
+	return e
+}
+
+func (m *Transaction) appendTo(b []byte) []byte {
+	e := encoder(b)
+	e.varint(1, uint64(m.Type))
+	e.bytes(2, m.ChainID)
+	e.varint(3, m.Nonce)
+	e.bytes(4, m.GasPrice)
+	e.bytes(5, m.GasTipCap)
+	e.bytes(6, m.GasFeeCap)
+	e.varint(7, m.Gas)
+	e.optionalBytes(8, m.To)
+	e.bytes(9, m.Value)
+	e.bytes(10, m.Data)
+	for _, t := range m.AccessList {
+		e.message(11, t)
+	}
+	e.bytes(12, m.BlobFeeCap)
+	e.repeatedBytes(13, m.BlobHashes)
+	e.bytes(14, m.V)
+	e.bytes(15, m.R)
+	e.bytes(16, m.S)
+	e.bytes(17, m.Hash)
+	return e
+}
+
+func (m *Log) appendTo(b []byte) []byte {
+	e := encoder(b)
+	e.bytes(1, m.Address)
+	e.repeatedBytes(2, m.Topics)
+	e.bytes(3, m.Data)
+	e.varint(4, m.BlockNumber)
+	e.bytes(5, m.TxHash)
+	e.varint(6, m.TxIndex)
+	e.bytes(7, m.BlockHash)
+	e.varint(8, m.Index)
+	e.bool(9, m.Removed)
+	return e
+}
+
+func (m *Receipt) appendTo(b []byte) []byte {
+	e := encoder(b)
+	e.varint(1, uint64(m.Type))
+	e.bytes(2, m.PostState)
+	e.varint(3, m.Status)
+	e.varint(4, m.CumulativeGasUsed)
+	e.bytes(5, m.LogsBloom)
+	for _, l := range m.Logs {
+		e.message(6, l)
+	}
+	e.bytes(7, m.TxHash)
+	e.bytes(8, m.ContractAddress)
+	e.varint(9, m.GasUsed)
+	e.optionalBytes(10, m.EffectiveGasPrice)
+	e.varint(11, m.BlobGasUsed)
+	e.optionalBytes(12, m.BlobGasPrice)
+	e.bytes(13, m.BlockHash)
+	e.optionalBytes(14, m.BlockNumber)
+	e.varint(15, m.TransactionIndex)
+	return e
+}
+
+func (m *Header) appendTo(b []byte) []byte {
+	e := encoder(b)
+	e.bytes(1, m.ParentHash)
+	e.bytes(2, m.UncleHash)
+	e.bytes(3, m.Coinbase)
+	e.bytes(4, m.Root)
+	e.bytes(5, m.TxHash)
+	e.bytes(6, m.ReceiptHash)
+	e.bytes(7, m.Bloom)
+	e.bytes(8, m.Difficulty)
+	e.bytes(9, m.Number)
+	e.varint(10, m.GasLimit)
+	e.varint(11, m.GasUsed)
+	e.varint(12, m.Time)
+	e.bytes(13, m.Extra)
+	e.bytes(14, m.MixDigest)
+	e.bytes(15, m.Nonce)
+	e.optionalBytes(16, m.BaseFee)
+	e.optionalBytes(17, m.WithdrawalsHash)
+	e.optionalVarint(18, m.BlobGasUsed)
+	e.optionalVarint(19, m.ExcessBlobGas)
+	e.optionalBytes(20, m.ParentBeaconRoot)
+	e.bytes(21, m.Hash)
+	return e
+}
+
+// Unmarshal은 protobuf 인코딩에서 메시지를 디코딩합니다. 알 수 없는 필드는 무시합니다.
+func (m *AccessTuple) Unmarshal(b []byte) error {
+	*m = AccessTuple{}
+	return decodeFields(b, func(num protowire.Number, f field) (err error) {
+		switch num {
+		case 1:
+			m.Address, err = f.bytes()
+		case 2:
+			err = f.appendBytes(&m.StorageKeys)
+		}
+		return err
+	})
+}
+
+// Unmarshal은 protobuf 인코딩에서 메시지를 디코딩합니다. 알 수 없는 필드는 무시합니다.
+func (m *Transaction) Unmarshal(b []byte) error {
+	*m = Transaction{}
+	return decodeFields(b, func(num protowire.Number, f field) (err error) {
+		switch num {
+		case 1:
+			m.Type, err = f.uint32()
+		case 2:
+			m.ChainID, err = f.bytes()
+		case 3:
+			m.Nonce, err = f.uint64()
+		case 4:
+			m.GasPrice, err = f.bytes()
+		case 5:
+			m.GasTipCap, err = f.bytes()
+		case 6:
+			m.GasFeeCap, err = f.bytes()
+		case 7:
+			m.Gas, err = f.uint64()
+		case 8:
+			m.To, err = f.bytes()
+		case 9:
+			m.Value, err = f.bytes()
+		case 10:
+			m.Data, err = f.bytes()
+		case 11:
+			t := new(AccessTuple)
+			if err = f.message(t); err == nil {
+				m.AccessList = append(m.AccessList, t)
+			}
+		case 12:
+			m.BlobFeeCap, err = f.bytes()
+		case 13:
+			err = f.appendBytes(&m.BlobHashes)
+		case 14:
+			m.V, err = f.bytes()
+		case 15:
+			m.R, err = f.bytes()
+		case 16:
+			m.S, err = f.bytes()
+		case 17:
+			m.Hash, err = f.bytes()
+		}
+		return err
+	})
+}
+
+// Unmarshal은 protobuf 인코딩에서 메시지를 디코딩합니다. 알 수 없는 필드는 무시합니다.
+func (m *Log) Unmarshal(b []byte) error {
+	*m = Log{}
+	return decodeFields(b, func(num protowire.Number, f field) (err error) {
+		switch num {
+		case 1:
+			m.Address, err = f.bytes()
+		case 2:
+			err = f.appendBytes(&m.Topics)
+		case 3:
+			m.Data, err = f.bytes()
+		case 4:
+			m.BlockNumber, err = f.uint64()
+		case 5:
+			m.TxHash, err = f.bytes()
+		case 6:
+			m.TxIndex, err = f.uint64()
+		case 7:
+			m.BlockHash, err = f.bytes()
+		case 8:
+			m.Index, err = f.uint64()
+		case 9:
+			m.Removed, err = f.bool()
+		}
+		return err
+	})
+}
+
+// Unmarshal은 protobuf 인코딩에서 메시지를 디코딩합니다. 알 수 없는 필드는 무시합니다.
+func (m *Receipt) Unmarshal(b []byte) error {
+	*m = Receipt{}
+	return decodeFields(b, func(num protowire.Number, f field) (err error) {
+		switch num {
+		case 1:
+			m.Type, err = f.uint32()
+		case 2:
+			m.PostState, err = f.bytes()
+		case 3:
+			m.Status, err = f.uint64()
+		case 4:
+			m.CumulativeGasUsed, err = f.uint64()
+		case 5:
+			m.LogsBloom, err = f.bytes()
+		case 6:
+			l := new(Log)
+			if err = f.message(l); err == nil {
+				m.Logs = append(m.Logs, l)
+			}
+		case 7:
+			m.TxHash, err = f.bytes()
+		case 8:
+			m.ContractAddress, err = f.bytes()
+		case 9:
+			m.GasUsed, err = f.uint64()
+		case 10:
+			m.EffectiveGasPrice, err = f.bytes()
+		case 11:
+			m.BlobGasUsed, err = f.uint64()
+		case 12:
+			m.BlobGasPrice, err = f.bytes()
+		case 13:
+			m.BlockHash, err = f.bytes()
+		case 14:
+			m.BlockNumber, err = f.bytes()
+		case 15:
+			m.TransactionIndex, err = f.uint64()
+		}
+		return err
+	})
+}
+
+// Unmarshal은 protobuf 인코딩에서 메시지를 디코딩합니다. 알 수 없는 필드는 무시합니다.
+func (m *Header) Unmarshal(b []byte) error {
+	*m = Header{}
+	return decodeFields(b, func(num protowire.Number, f field) (err error) {
+		switch num {
+		case 1:
+			m.ParentHash, err = f.bytes()
+		case 2:
+			m.UncleHash, err = f.bytes()
+		case 3:
+			m.Coinbase, err = f.bytes()
+		case 4:
+			m.Root, err = f.bytes()
+		case 5:
+			m.TxHash, err = f.bytes()
+		case 6:
+			m.ReceiptHash, err = f.bytes()
+		case 7:
+			m.Bloom, err = f.bytes()
+		case 8:
+			m.Difficulty, err = f.bytes()
+		case 9:
+			m.Number, err = f.bytes()
+		case 10:
+			m.GasLimit, err = f.uint64()
+		case 11:
+			m.GasUsed, err = f.uint64()
+		case 12:
+			m.Time, err = f.uint64()
+		case 13:
+			m.Extra, err = f.bytes()
+		case 14:
+			m.MixDigest, err = f.bytes()
+		case 15:
+			m.Nonce, err = f.bytes()
+		case 16:
+			m.BaseFee, err = f.bytes()
+		case 17:
+			m.WithdrawalsHash, err = f.bytes()
+		case 18:
+			m.BlobGasUsed, err = f.optionalUint64()
+		case 19:
+			m.ExcessBlobGas, err = f.optionalUint64()
+		case 20:
+			m.ParentBeaconRoot, err = f.bytes()
+		case 21:
+			m.Hash, err = f.bytes()
+		}
+		return err
+	})
+}
+
+// encoder는 protobuf 필드를 바이트 슬라이스에 덧붙입니다. proto3 규칙에 따라 기본값인 필드는
+// 생략하고, optional 필드는 nil이 아니면 값이 비어 있어도 기록합니다.
+type encoder []byte
+
+func (e *encoder) varint(num protowire.Number, v uint64) {
+	if v != 0 {
+		*e = protowire.AppendTag(*e, num, protowire.VarintType)
+		*e = protowire.AppendVarint(*e, v)
+	}
+}
+
+func (e *encoder) optionalVarint(num protowire.Number, v *uint64) {
+	if v != nil {
+		*e = protowire.AppendTag(*e, num, protowire.VarintType)
+		*e = protowire.AppendVarint(*e, *v)
+	}
+}
+
+func (e *encoder) bool(num protowire.Number, v bool) {
+	if v {
+		e.varint(num, 1)
+	}
+}
+
+func (e *encoder) bytes(num protowire.Number, v []byte) {
+	if len(v) > 0 {
+		e.optionalBytes(num, v)
+	}
+}
+
+func (e *encoder) optionalBytes(num protowire.Number, v []byte) {
+	if v != nil {
+		*e = protowire.AppendTag(*e, num, protowire.BytesType)
+		*e = protowire.AppendBytes(*e, v)
+	}
+}
+
+// repeatedBytes는 빈 요소도 개수가 유지되도록 모든 요소를 기록합니다.
+func (e *encoder) repeatedBytes(num protowire.Number, vs [][]byte) {
+	for _, v := range vs {
+		*e = protowire.AppendTag(*e, num, protowire.BytesType)
+		*e = protowire.AppendBytes(*e, v)
+	}
+}
+
+func (e *encoder) message(num protowire.Number, m interface{ appendTo([]byte) []byte }) {
+	*e = protowire.AppendTag(*e, num, protowire.BytesType)
+	*e = protowire.AppendBytes(*e, m.appendTo(nil))
+}
+
+var errWireType = errors.New("pb: wrong wire type")
+
+// field는 디코딩된 필드 값 하나입니다.
+type field struct {
+	typ protowire.Type
+	v   uint64 // VarintType의 값
+	b   []byte // BytesType의 값
+}
+
+// decodeFields는 b의 각 필드를 set에 전달합니다. varint와 길이 접두 필드가 아닌 필드는
+// 건너뜁니다.
+func decodeFields(b []byte, set func(protowire.Number, field) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f := field{typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.v, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.b, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+		if err := set(num, f); err != nil {
+			return fmt.Errorf("field %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+func (f field) uint64() (uint64, error) {
+	if f.typ != protowire.VarintType {
+		return 0, errWireType
+	}
+	return f.v, nil
+}
+
+func (f field) optionalUint64() (*uint64, error) {
+	v, err := f.uint64()
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (f field) uint32() (uint32, error) {
+	v, err := f.uint64()
+	return uint32(v), err
+}
+
+func (f field) bool() (bool, error) {
+	v, err := f.uint64()
+	return v != 0, err
+}
+
+// bytes는 입력을 참조하지 않도록 값의 복사본을 반환합니다. 빈 값도 nil이 아닌 슬라이스로
+// 반환하여 optional 필드의 존재 여부를 유지합니다.
+func (f field) bytes() ([]byte, error) {
+	if f.typ != protowire.BytesType {
+		return nil, errWireType
+	}
+	return append([]byte{}, f.b...), nil
+}
+
+func (f field) appendBytes(list *[][]byte) error {
+	b, err := f.bytes()
+	if err == nil {
+		*list = append(*list, b)
+	}
+	return err
+}
+
+func (f field) message(m interface{ Unmarshal([]byte) error }) error {
+	if f.typ != protowire.BytesType {
+		return errWireType
+	}
+	return m.Unmarshal(f.b)
+}
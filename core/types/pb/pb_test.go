@@ -0,0 +1,198 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pb
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func signedTxs(t *testing.T) []*types.Transaction {
+	key, _ := crypto.GenerateKey()
+	to := common.Address{0x01}
+	inners := []types.TxData{
+		&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(7), Gas: 21000, To: &to, Value: big.NewInt(1)},
+		&types.LegacyTx{Nonce: 2, GasPrice: big.NewInt(7), Gas: 60000, Data: []byte{0x60, 0x00}},
+		&types.AccessListTx{ChainID: big.NewInt(1), GasPrice: big.NewInt(7), Gas: 30000, To: &to,
+			AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{0x02}, {}}}}},
+		&types.DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(0), GasFeeCap: big.NewInt(9), Gas: 21000, To: &to, Value: big.NewInt(0)},
+		&types.BlobTx{ChainID: uint256.NewInt(1), GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(9), Gas: 21000, To: to,
+			Value: new(uint256.Int), BlobFeeCap: uint256.NewInt(3), BlobHashes: []common.Hash{{0x01, 0x02}}},
+	}
+	signer := types.NewCancunSigner(big.NewInt(1))
+	txs := make([]*types.Transaction, len(inners))
+	for i, inner := range inners {
+		tx, err := types.SignNewTx(key, signer, inner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs[i] = tx
+	}
+	return txs
+}
+
+func TestTransactionRoundTrip(t *testing.T) {
+	for i, tx := range signedTxs(t) {
+		enc, err := FromTransaction(tx).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m Transaction
+		if err := m.Unmarshal(enc); err != nil {
+			t.Fatalf("tx %d: unmarshal error: %v", i, err)
+		}
+		have, err := m.ToTransaction()
+		if err != nil {
+			t.Fatalf("tx %d: conversion error: %v", i, err)
+		}
+		if have.Hash() != tx.Hash() {
+			t.Fatalf("tx %d: hash mismatch", i)
+		}
+		if (have.To() == nil) != (tx.To() == nil) {
+			t.Fatalf("tx %d: recipient presence mismatch", i)
+		}
+	}
+}
+
+func TestTransactionHashCheck(t *testing.T) {
+	m := FromTransaction(signedTxs(t)[0])
+	m.Nonce++
+	if _, err := m.ToTransaction(); !errors.Is(err, ErrHashMismatch) {
+		t.Fatalf("wrong error for modified transaction: %v", err)
+	}
+	m = FromTransaction(signedTxs(t)[0])
+	m.To = []byte{1, 2, 3}
+	if _, err := m.ToTransaction(); !errors.Is(err, errInvalidLength) {
+		t.Fatalf("wrong error for short address: %v", err)
+	}
+	m.Type = 0x42
+	if _, err := m.ToTransaction(); !errors.Is(err, types.ErrTxTypeNotSupported) {
+		t.Fatalf("wrong error for unknown type: %v", err)
+	}
+}
+
+func TestReceiptRoundTrip(t *testing.T) {
+	r := &types.Receipt{
+		Type:              types.DynamicFeeTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 42000,
+		Logs: []*types.Log{{
+			Address:     common.Address{0x11},
+			Topics:      []common.Hash{{0x22}},
+			Data:        []byte{1, 2, 3},
+			BlockNumber: 5,
+			Index:       3,
+		}},
+		TxHash:            common.Hash{0x33},
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(0),
+		BlockNumber:       big.NewInt(5),
+		TransactionIndex:  1,
+	}
+	r.Bloom = types.CreateBloom(types.Receipts{r})
+
+	enc, err := FromReceipt(r).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m Receipt
+	if err := m.Unmarshal(enc); err != nil {
+		t.Fatal(err)
+	}
+	have, err := m.ToReceipt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have.EffectiveGasPrice == nil || have.EffectiveGasPrice.Sign() != 0 || have.BlobGasPrice != nil {
+		t.Fatalf("optional fields not preserved: %v %v", have.EffectiveGasPrice, have.BlobGasPrice)
+	}
+	haveEnc, _ := have.MarshalBinary()
+	wantEnc, _ := r.MarshalBinary()
+	if string(haveEnc) != string(wantEnc) || have.TxHash != r.TxHash || have.Logs[0].Index != 3 || have.TransactionIndex != 1 {
+		t.Fatal("receipt mismatch after round trip")
+	}
+}
+
+func TestHeaderRoundTrip(t *testing.T) {
+	zero := uint64(0)
+	h := &types.Header{
+		Difficulty:       big.NewInt(0),
+		Number:           big.NewInt(100),
+		GasLimit:         30_000_000,
+		Time:             1700000000,
+		Extra:            []byte("extra"),
+		Nonce:            types.EncodeNonce(9),
+		BaseFee:          big.NewInt(0),
+		WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+		BlobGasUsed:      &zero,
+		ExcessBlobGas:    &zero,
+		ParentBeaconRoot: &common.Hash{},
+	}
+	enc, err := FromHeader(h).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m Header
+	if err := m.Unmarshal(enc); err != nil {
+		t.Fatal(err)
+	}
+	have, err := m.ToHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !have.Equal(h) {
+		t.Fatalf("header mismatch: %v", have.Diff(h))
+	}
+
+	// Headers before London must not gain a base fee.
+	legacy := &types.Header{Difficulty: big.NewInt(1), Number: big.NewInt(1)}
+	if have, err := FromHeader(legacy).ToHeader(); err != nil || have.BaseFee != nil || have.Hash() != legacy.Hash() {
+		t.Fatalf("legacy header mismatch: %v", err)
+	}
+}
+
+func TestUnmarshalUnknownFields(t *testing.T) {
+	enc, _ := (&Log{Index: 7}).Marshal()
+	enc = protowire.AppendTag(enc, 100, protowire.Fixed32Type)
+	enc = protowire.AppendFixed32(enc, 1)
+	enc = protowire.AppendTag(enc, 101, protowire.BytesType)
+	enc = protowire.AppendBytes(enc, []byte("future"))
+
+	var m Log
+	if err := m.Unmarshal(enc); err != nil {
+		t.Fatal(err)
+	}
+	if m.Index != 7 {
+		t.Fatalf("wrong index %d", m.Index)
+	}
+	// Known fields with the wrong wire type are rejected.
+	bad := protowire.AppendTag(nil, 1, protowire.VarintType)
+	bad = protowire.AppendVarint(bad, 1)
+	if err := m.Unmarshal(bad); !errors.Is(err, errWireType) {
+		t.Fatalf("wrong error for wire type mismatch: %v", err)
+	}
+	if err := m.Unmarshal([]byte{0x0a, 0x05, 0x01}); err == nil {
+		t.Fatal("no error for truncated input")
+	}
+}
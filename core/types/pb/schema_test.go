@@ -0,0 +1,234 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pb
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The codec in pb.go is written by hand, so these tests check it against the
+// reference protobuf implementation. types.proto is loaded into a dynamic
+// descriptor, and every message must survive a round trip through dynamicpb
+// without unknown fields and with identical encodings.
+
+var (
+	protoMessageRE = regexp.MustCompile(`^message (\w+) \{$`)
+	protoFieldRE   = regexp.MustCompile(`^(?:(repeated|optional) )?(\w+) (\w+) = (\d+);$`)
+)
+
+var protoScalarTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"bool":   descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"bytes":  descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32": descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"uint64": descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+}
+
+// loadSchema parses the subset of the protobuf language used by types.proto
+// into a file descriptor.
+func loadSchema(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	src, err := os.ReadFile("types.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:   proto.String("types.proto"),
+		Syntax: proto.String("proto3"),
+	}
+	var msg *descriptorpb.DescriptorProto
+	for i, line := range strings.Split(string(src), "\n") {
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = line[:j]
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "syntax ") || strings.HasPrefix(line, "option "):
+		case strings.HasPrefix(line, "package "):
+			fd.Package = proto.String(strings.TrimSuffix(strings.TrimPrefix(line, "package "), ";"))
+		case msg == nil && protoMessageRE.MatchString(line):
+			msg = &descriptorpb.DescriptorProto{Name: proto.String(protoMessageRE.FindStringSubmatch(line)[1])}
+			fd.MessageType = append(fd.MessageType, msg)
+		case msg != nil && line == "}":
+			msg = nil
+		case msg != nil && protoFieldRE.MatchString(line):
+			m := protoFieldRE.FindStringSubmatch(line)
+			num, _ := strconv.Atoi(m[4])
+			field := &descriptorpb.FieldDescriptorProto{
+				Name:   proto.String(m[3]),
+				Number: proto.Int32(int32(num)),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}
+			if typ, ok := protoScalarTypes[m[2]]; ok {
+				field.Type = typ.Enum()
+			} else {
+				field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+				field.TypeName = proto.String("." + fd.GetPackage() + "." + m[2])
+			}
+			switch m[1] {
+			case "repeated":
+				field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			case "optional":
+				// proto3 optional fields live in a synthetic oneof.
+				field.Proto3Optional = proto.Bool(true)
+				field.OneofIndex = proto.Int32(int32(len(msg.OneofDecl)))
+				msg.OneofDecl = append(msg.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + m[3])})
+			}
+			msg.Field = append(msg.Field, field)
+		default:
+			t.Fatalf("types.proto:%d: unsupported syntax %q", i+1, line)
+		}
+	}
+	file, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	return file
+}
+
+type message interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// checkSchema verifies that the encoding of m is understood by the reference
+// implementation and that both implementations produce the same bytes.
+func checkSchema(t *testing.T, file protoreflect.FileDescriptor, name string, m message) {
+	t.Helper()
+
+	desc := file.Messages().ByName(protoreflect.Name(name))
+	if desc == nil {
+		t.Fatalf("message %s not in schema", name)
+	}
+	enc, err := m.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dyn := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(enc, dyn); err != nil {
+		t.Fatalf("%s: reference decoding failed: %v", name, err)
+	}
+	if err := checkKnownFields(dyn); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	ref, err := proto.MarshalOptions{Deterministic: true}.Marshal(dyn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc, ref) {
+		t.Fatalf("%s: encoding mismatch\nhave %x\nwant %x", name, enc, ref)
+	}
+	if err := m.Unmarshal(ref); err != nil {
+		t.Fatalf("%s: decoding reference encoding failed: %v", name, err)
+	}
+	if again, _ := m.Marshal(); !bytes.Equal(again, ref) {
+		t.Fatalf("%s: reference encoding not preserved\nhave %x\nwant %x", name, again, ref)
+	}
+}
+
+// checkKnownFields reports an error if m or any nested message has fields that
+// are not declared in the schema.
+func checkKnownFields(m protoreflect.Message) error {
+	if unknown := m.GetUnknown(); len(unknown) > 0 {
+		return fmt.Errorf("unknown fields in %s: %x", m.Descriptor().FullName(), unknown)
+	}
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+		if fd.IsList() {
+			for i := 0; i < v.List().Len() && err == nil; i++ {
+				err = checkKnownFields(v.List().Get(i).Message())
+			}
+		} else {
+			err = checkKnownFields(v.Message())
+		}
+		return err == nil
+	})
+	return err
+}
+
+func TestSchemaTransaction(t *testing.T) {
+	file := loadSchema(t)
+	for _, tx := range signedTxs(t) {
+		checkSchema(t, file, "Transaction", FromTransaction(tx))
+	}
+	checkSchema(t, file, "AccessTuple", &AccessTuple{Address: common.Address{1}.Bytes(), StorageKeys: [][]byte{{}, {2}}})
+	checkSchema(t, file, "Transaction", &Transaction{To: []byte{}})
+}
+
+func TestSchemaReceipt(t *testing.T) {
+	file := loadSchema(t)
+	r := &types.Receipt{
+		Type:              types.BlobTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 42000,
+		Logs: []*types.Log{{
+			Address: common.Address{0x11},
+			Topics:  []common.Hash{{0x22}, {}},
+			Data:    []byte{1, 2, 3},
+			Index:   3,
+			Removed: true,
+		}},
+		TxHash:            common.Hash{0x33},
+		ContractAddress:   common.Address{0x44},
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(0),
+		BlobGasUsed:       131072,
+		BlobGasPrice:      big.NewInt(1),
+		BlockNumber:       big.NewInt(0),
+		TransactionIndex:  1,
+	}
+	r.Bloom = types.CreateBloom(types.Receipts{r})
+	checkSchema(t, file, "Receipt", FromReceipt(r))
+	checkSchema(t, file, "Receipt", &Receipt{PostState: common.Hash{1}.Bytes()})
+	checkSchema(t, file, "Log", FromLog(r.Logs[0]))
+}
+
+func TestSchemaHeader(t *testing.T) {
+	file := loadSchema(t)
+	zero := uint64(0)
+	checkSchema(t, file, "Header", FromHeader(&types.Header{
+		Difficulty:       big.NewInt(0),
+		Number:           big.NewInt(100),
+		GasLimit:         30_000_000,
+		Time:             1700000000,
+		Extra:            []byte("extra"),
+		BaseFee:          big.NewInt(0),
+		WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+		BlobGasUsed:      &zero,
+		ExcessBlobGas:    &zero,
+		ParentBeaconRoot: &common.Hash{},
+	}))
+	checkSchema(t, file, "Header", FromHeader(&types.Header{Difficulty: big.NewInt(1), Number: big.NewInt(1)}))
+}
@@ -94,12 +94,25 @@ type receiptRLP struct {
 }
 
 // storedReceiptRLP는 영수증의 스토리지 인코딩입니다. (블룸 필드가 생략됨)
+// 버전 2 인코딩은 로그 뒤에 버전 구분자와 유도 비용이 큰 필드를 추가로 담습니다.
 type storedReceiptRLP struct {
 	PostStateOrStatus []byte
 	CumulativeGasUsed uint64
-	Logs              []*Log
+	Logs              []*LogForStorage
+	Version           uint64         `rlp:"optional"`
+	ContractAddress   common.Address `rlp:"optional"`
+	EffectiveGasPrice *big.Int       `rlp:"optional"`
 }
 
+// 영수증 스토리지 인코딩 버전
+const (
+	ReceiptStorageV1 = 1 // [status, cumulativeGasUsed, logs]
+	ReceiptStorageV2 = 2 // [status, cumulativeGasUsed, logs, 2, contractAddress, effectiveGasPrice]
+)
+
+// errUnsupportedReceiptStorage는 알 수 없는 버전의 저장된 영수증을 디코딩할 때 반환됩니다.
+var errUnsupportedReceiptStorage = errors.New("unsupported receipt storage version")
+
 // NewReceipt는 기본 트랜잭션 영수증을 생성하고 초기 필드를 복사합니다.
 // Deprecated: 대신 구조체 리터럴을 사용하여 영수증을 생성하십시오.
 func NewReceipt(root []byte, failed bool, cumulativeGasUsed uint64) *Receipt {
@@ -134,8 +147,7 @@ func (r *Receipt) EncodeRLP(w io.Writer) error {
 
 // encodeTyped는 타입 영수증의 정규 인코딩을 w에 작성합니다.
 func (r *Receipt) encodeTyped(data *receiptRLP, w *bytes.Buffer) error {
-	w.WriteByte(r.Type)
-	return rlp.Encode(w, data) // 타입 필드와 데이터를 인코딩합니다.
+	return encodeTypedEnvelope(w, r.Type, data)
 }
 
 // MarshalBinary은 영수증의 컨센서스 인코딩을 반환합니다.
@@ -198,21 +210,22 @@ func (r *Receipt) UnmarshalBinary(b []byte) error {
 
 // decodeTyped는 정규 형식에서 타입 영수증을 디코딩합니다.
 func (r *Receipt) decodeTyped(b []byte) error {
-	if len(b) <= 1 {
+	typ, payload, ok := splitTypedEnvelope(b)
+	if !ok {
 		return errShortTypedReceipt
 	}
-	switch b[0] { // 첫 번째 바이트는 트랜잭션 유형입니다.
-	case DynamicFeeTxType, AccessListTxType, BlobTxType:
-		var data receiptRLP
-		err := rlp.DecodeBytes(b[1:], &data)
-		if err != nil {
-			return err
-		}
-		r.Type = b[0]
-		return r.setFromRLP(data)
-	default: // 지원되지 않는 트랜잭션 유형
-		return ErrTxTypeNotSupported
+	// 첫 번째 바이트는 트랜잭션 유형입니다. 등록된 트랜잭션 유형의 영수증만 허용하며,
+	// 레거시 영수증은 봉투 없이 인코딩되므로 타입 바이트 0x00은 거부합니다.
+	if typ == LegacyTxType || !IsSupportedTxType(typ) {
+		return &TxTypeError{Type: typ}
+	}
+	var data receiptRLP
+	err := rlp.DecodeBytes(payload, &data)
+	if err != nil {
+		return err
 	}
+	r.Type = typ
+	return r.setFromRLP(data)
 }
 
 func (r *Receipt) setFromRLP(data receiptRLP) error {
@@ -259,37 +272,127 @@ type ReceiptForStorage Receipt
 
 // EncodeRLP는 영수증의 모든 콘텐츠 직렬화하여 RLP 스트림에 작성합니다.
 func (r *ReceiptForStorage) EncodeRLP(_w io.Writer) error {
+	return (*Receipt)(r).encodeStorage(_w, ReceiptStorageV1)
+}
+
+// DecodeRLP는 rlp.Decoder를 구현하며 영수증의 컨센서스 및 구현 필드를 모두 RLP 스트림에서 로드합니다.
+// 버전 1과 버전 2 스토리지 인코딩을 모두 디코딩할 수 있습니다. 버전 2 인코딩에서는 ContractAddress와
+// EffectiveGasPrice도 설정됩니다. 저장된 컨트랙트 주소를 DeriveFields에서 유지하려면 StoredReceipts로
+// 디코딩하십시오.
+func (r *ReceiptForStorage) DecodeRLP(s *rlp.Stream) error {
+	_, err := (*Receipt)(r).decodeStorage(s)
+	return err
+}
+
+// decodeStorage는 스토리지 인코딩의 영수증을 디코딩하고, 컨트랙트 주소가 함께 저장되어
+// 있었는지 여부를 반환합니다.
+func (r *Receipt) decodeStorage(s *rlp.Stream) (addressStored bool, err error) {
+	var stored storedReceiptRLP
+	if err := s.Decode(&stored); err != nil {
+		return false, err
+	}
+	if err := r.setStatus(stored.PostStateOrStatus); err != nil {
+		return false, err
+	}
+	r.CumulativeGasUsed = stored.CumulativeGasUsed
+	r.Logs = make([]*Log, len(stored.Logs))
+	for i, log := range stored.Logs {
+		r.Logs[i] = (*Log)(log)
+	}
+	r.Bloom = CreateBloom(Receipts{r})
+
+	switch stored.Version {
+	case 0:
+		r.ContractAddress, r.EffectiveGasPrice = common.Address{}, nil
+		return false, nil
+	case ReceiptStorageV2:
+		if stored.EffectiveGasPrice == nil {
+			return false, fmt.Errorf("%w: missing effective gas price", errUnsupportedReceiptStorage)
+		}
+		r.ContractAddress, r.EffectiveGasPrice = stored.ContractAddress, stored.EffectiveGasPrice
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: %d", errUnsupportedReceiptStorage, stored.Version)
+	}
+}
+
+// ReceiptForStorageV2는 버전 2 스토리지 인코딩으로 직렬화되는 영수증을 래핑합니다. 이 인코딩은
+// ContractAddress와 EffectiveGasPrice를 함께 저장하므로, StoredReceipts로 읽으면 DeriveFields가
+// 컨트랙트 생성 트랜잭션의 발신자를 서명에서 복구할 필요가 없습니다. 저장된 데이터는
+// ReceiptForStorage로도 디코딩할 수 있습니다. EffectiveGasPrice가 nil이면 버전 1 인코딩으로 직렬화됩니다.
+//
+// 버전 2 인코딩을 지원하지 않는 이전 버전은 추가 필드 때문에 이 영수증을 디코딩하지 못하므로,
+// 버전 2로 저장한 데이터베이스는 이전 버전으로 다운그레이드할 수 없습니다.
+type ReceiptForStorageV2 Receipt
+
+// EncodeRLP는 영수증을 버전 2 스토리지 인코딩으로 RLP 스트림에 작성합니다.
+func (r *ReceiptForStorageV2) EncodeRLP(w io.Writer) error {
+	return (*Receipt)(r).encodeStorage(w, ReceiptStorageV2)
+}
+
+// DecodeRLP는 ReceiptForStorage와 같은 방식으로 저장된 영수증을 디코딩합니다.
+func (r *ReceiptForStorageV2) DecodeRLP(s *rlp.Stream) error {
+	return (*ReceiptForStorage)(r).DecodeRLP(s)
+}
+
+// StoredReceipts는 스토리지 인코딩의 영수증 목록을 디코딩한 결과입니다. 각 영수증의 컨트랙트
+// 주소가 버전 2 인코딩으로 저장되어 있었는지를 함께 기록하므로, DeriveFields는 저장된 주소를
+// 서명 복구로 다시 계산하지 않습니다.
+type StoredReceipts struct {
+	Receipts      Receipts
+	addressStored []bool
+}
+
+// DecodeRLP는 ReceiptForStorage 인코딩의 RLP 리스트에서 영수증을 디코딩합니다.
+func (rs *StoredReceipts) DecodeRLP(s *rlp.Stream) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+	rs.Receipts, rs.addressStored = Receipts{}, nil
+	for {
+		r := new(Receipt)
+		stored, err := r.decodeStorage(s)
+		if err == rlp.EOL {
+			break
+		} else if err != nil {
+			return err
+		}
+		rs.Receipts = append(rs.Receipts, r)
+		rs.addressStored = append(rs.addressStored, stored)
+	}
+	return s.ListEnd()
+}
+
+// DeriveFields는 Receipts.DeriveFields와 같지만, 버전 2 인코딩에서 읽은 컨트랙트 주소는
+// 유지합니다.
+func (rs *StoredReceipts) DeriveFields(config *params.ChainConfig, hash common.Hash, number uint64, time uint64, baseFee *big.Int, blobGasPrice *big.Int, txs []*Transaction) error {
+	return rs.Receipts.deriveFields(config, hash, number, time, baseFee, blobGasPrice, txs, rs.addressStored)
+}
+
+// encodeStorage는 영수증을 주어진 버전의 스토리지 인코딩으로 작성합니다.
+func (r *Receipt) encodeStorage(_w io.Writer, version int) error {
 	w := rlp.NewEncoderBuffer(_w)
 	outerList := w.List()
-	w.WriteBytes((*Receipt)(r).statusEncoding())
+	w.WriteBytes(r.statusEncoding())
 	w.WriteUint64(r.CumulativeGasUsed)
 	logList := w.List()
 	for _, log := range r.Logs {
-		if err := log.EncodeRLP(w); err != nil {
+		if err := (*LogForStorage)(log).EncodeRLP(w); err != nil {
 			return err
 		}
 	}
 	w.ListEnd(logList)
+	if version == ReceiptStorageV2 && r.EffectiveGasPrice != nil {
+		w.WriteUint64(ReceiptStorageV2)
+		w.WriteBytes(r.ContractAddress[:])
+		if err := w.WriteBigIntOrNil(r.EffectiveGasPrice); err != nil {
+			return err
+		}
+	}
 	w.ListEnd(outerList)
 	return w.Flush()
 }
 
-// DecodeRLP는 rlp.Decoder를 구현하며 영수증의 컨센서스 및 구현 필드를 모두 RLP 스트림에서 로드합니다.
-func (r *ReceiptForStorage) DecodeRLP(s *rlp.Stream) error {
-	var stored storedReceiptRLP
-	if err := s.Decode(&stored); err != nil {
-		return err
-	}
-	if err := (*Receipt)(r).setStatus(stored.PostStateOrStatus); err != nil {
-		return err
-	}
-	r.CumulativeGasUsed = stored.CumulativeGasUsed
-	r.Logs = stored.Logs
-	r.Bloom = CreateBloom(Receipts{(*Receipt)(r)})
-
-	return nil
-}
-
 // Receipts는 영수증의 머클루트를 계산하기 위해 필요한 인터페이스를 구현합니다.
 type Receipts []*Receipt
 
@@ -305,17 +408,21 @@ func (rs Receipts) EncodeIndex(i int, w *bytes.Buffer) {
 		return
 	}
 	w.WriteByte(r.Type)
-	switch r.Type {
-	case AccessListTxType, DynamicFeeTxType, BlobTxType:
+	if IsSupportedTxType(r.Type) {
 		rlp.Encode(w, data)
-	default:
-		// 지원되지 않는 유형의 경우 아무것도 작성하지 않습니다.
-		// 이는 DeriveSha를 위한 것이므로 파생된 해시를 블록과 일치시키는 오류가 발생합니다.
 	}
+	// 지원되지 않는 유형의 경우 아무것도 작성하지 않습니다.
+	// 이는 DeriveSha를 위한 것이므로 파생된 해시를 블록과 일치시키는 오류가 발생합니다.
 }
 
 // DeriveFields는 컨센서스 데이터 및 포함된 블록 및 트랜잭션과 같은 맥락 정보를 기반으로 영수증에 계산된 필드를 채웁니다.
 func (rs Receipts) DeriveFields(config *params.ChainConfig, hash common.Hash, number uint64, time uint64, baseFee *big.Int, blobGasPrice *big.Int, txs []*Transaction) error {
+	return rs.deriveFields(config, hash, number, time, baseFee, blobGasPrice, txs, nil)
+}
+
+// deriveFields는 DeriveFields를 구현합니다. addressStored[i]가 참이면 i번째 영수증의 컨트랙트
+// 주소를 다시 계산하지 않습니다.
+func (rs Receipts) deriveFields(config *params.ChainConfig, hash common.Hash, number uint64, time uint64, baseFee *big.Int, blobGasPrice *big.Int, txs []*Transaction, addressStored []bool) error {
 	signer := MakeSigner(config, new(big.Int).SetUint64(number), time)
 
 	logIndex := uint(0)
@@ -340,11 +447,13 @@ func (rs Receipts) DeriveFields(config *params.ChainConfig, hash common.Hash, nu
 		rs[i].TransactionIndex = uint(i)
 
 		// 컨트랙트 주소는 트랜잭션 자체에서 유도할 수 있습니다.
-		if txs[i].To() == nil {
+		// 버전 2 스토리지 인코딩에서 읽은 주소가 있으면 서명 복구를 생략합니다.
+		stored := i < len(addressStored) && addressStored[i]
+		if txs[i].To() == nil && !stored {
 			// 서명자를 유도하는 것은 비용이 많이 들기 때문에 실제로 필요한 경우에만 수행합니다.
 			from, _ := Sender(signer, txs[i])
 			rs[i].ContractAddress = crypto.CreateAddress(from, txs[i].Nonce()) // 서명자의 주소와 트랜잭션의 nonce를 사용하여 컨트랙트 주소를 계산합니다.
-		} else {
+		} else if txs[i].To() != nil {
 			rs[i].ContractAddress = common.Address{}
 		}
 
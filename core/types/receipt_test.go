@@ -19,6 +19,7 @@ package types
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"math"
 	"math/big"
 	"reflect"
@@ -305,6 +306,24 @@ func TestDecodeEmptyTypedReceipt(t *testing.T) {
 	}
 }
 
+// Tests that a legacy receipt wrapped in a typed envelope is rejected.
+func TestDecodeLegacyTypedReceipt(t *testing.T) {
+	enc, err := rlp.EncodeToBytes(&receiptRLP{receiptStatusSuccessfulRLP, 1, Bloom{}, []*Log{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typed := append([]byte{LegacyTxType}, enc...)
+
+	var r Receipt
+	if err := r.UnmarshalBinary(typed); err == nil {
+		t.Fatal("typed legacy receipt accepted by UnmarshalBinary")
+	}
+	input, _ := rlp.EncodeToBytes(typed)
+	if err := rlp.DecodeBytes(input, &r); err == nil {
+		t.Fatal("typed legacy receipt accepted by DecodeRLP")
+	}
+}
+
 // Tests that receipt data can be correctly derived from the contextual infos
 func TestDeriveFields(t *testing.T) {
 	// Re-derive receipts.
@@ -527,3 +546,99 @@ func clearComputedFieldsOnLogs(logs []*Log) []*Log {
 	}
 	return l
 }
+
+func TestReceiptStorageV2(t *testing.T) {
+	receipt := &Receipt{
+		Status:            ReceiptStatusSuccessful,
+		CumulativeGasUsed: 100,
+		Logs:              []*Log{{Address: common.Address{0x11}, Topics: []common.Hash{{0x01}}, Data: []byte{0x02}}},
+		ContractAddress:   common.Address{0xcc},
+		EffectiveGasPrice: big.NewInt(1000),
+	}
+	receipt.Bloom = CreateBloom(Receipts{receipt})
+
+	v1, err := rlp.EncodeToBytes((*ReceiptForStorage)(receipt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := rlp.EncodeToBytes((*ReceiptForStorageV2)(receipt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(v1, v2) {
+		t.Fatal("version 2 encoding equals version 1")
+	}
+	// Both versions decode through ReceiptForStorage.
+	var dec1, dec2 ReceiptForStorage
+	if err := rlp.DecodeBytes(v1, &dec1); err != nil {
+		t.Fatal(err)
+	}
+	if dec1.ContractAddress != (common.Address{}) || dec1.EffectiveGasPrice != nil || dec1.Bloom != receipt.Bloom {
+		t.Fatal("wrong version 1 receipt")
+	}
+	if err := rlp.DecodeBytes(v2, &dec2); err != nil {
+		t.Fatal(err)
+	}
+	if dec2.ContractAddress != receipt.ContractAddress || dec2.EffectiveGasPrice.Cmp(receipt.EffectiveGasPrice) != 0 {
+		t.Fatal("wrong version 2 receipt")
+	}
+	if dec2.CumulativeGasUsed != receipt.CumulativeGasUsed || dec2.Bloom != receipt.Bloom || len(dec2.Logs) != 1 {
+		t.Fatal("wrong consensus fields in version 2 receipt")
+	}
+
+	// Without an effective gas price, the version 1 encoding is used.
+	noPrice := *receipt
+	noPrice.EffectiveGasPrice = nil
+	if enc, _ := rlp.EncodeToBytes((*ReceiptForStorageV2)(&noPrice)); !bytes.Equal(enc, v1) {
+		t.Fatal("receipt without effective gas price not encoded as version 1")
+	}
+
+	// Unknown versions are rejected.
+	var stored storedReceiptRLP
+	rlp.DecodeBytes(v2, &stored)
+	stored.Version = 3
+	enc, _ := rlp.EncodeToBytes(&stored)
+	if err := rlp.DecodeBytes(enc, new(ReceiptForStorage)); !errors.Is(err, errUnsupportedReceiptStorage) {
+		t.Fatalf("wrong error for unknown version: %v", err)
+	}
+}
+
+func TestDeriveFieldsStoredContractAddress(t *testing.T) {
+	// The transaction is not signed, so the sender cannot be recovered.
+	tx := NewContractCreation(0, big.NewInt(0), 21000, big.NewInt(1), nil)
+	stored := &Receipt{Status: ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*Log{}, ContractAddress: common.Address{0xcc}, EffectiveGasPrice: big.NewInt(1)}
+	stored.Bloom = CreateBloom(Receipts{stored})
+	encV2, _ := rlp.EncodeToBytes([]*ReceiptForStorageV2{(*ReceiptForStorageV2)(stored)})
+	encV1, _ := rlp.EncodeToBytes([]*ReceiptForStorage{(*ReceiptForStorage)(stored)})
+
+	var v1, v2 StoredReceipts
+	if err := rlp.DecodeBytes(encV2, &v2); err != nil {
+		t.Fatal(err)
+	}
+	if err := rlp.DecodeBytes(encV1, &v1); err != nil {
+		t.Fatal(err)
+	}
+	if err := v2.DeriveFields(params.TestChainConfig, common.Hash{}, 1, 0, nil, nil, []*Transaction{tx}); err != nil {
+		t.Fatal(err)
+	}
+	want := *stored
+	want.TxHash, want.GasUsed, want.BlockNumber = tx.Hash(), 21000, big.NewInt(1)
+	if !reflect.DeepEqual(v2.Receipts[0], &want) {
+		t.Fatalf("derived version 2 receipt mismatch:\nhave %+v\nwant %+v", v2.Receipts[0], &want)
+	}
+	if err := v1.DeriveFields(params.TestChainConfig, common.Hash{}, 1, 0, nil, nil, []*Transaction{tx}); err != nil {
+		t.Fatal(err)
+	}
+	if v1.Receipts[0].ContractAddress == stored.ContractAddress {
+		t.Fatal("contract address not derived for version 1 receipt")
+	}
+
+	// Receipts decoded on their own always have the contract address derived.
+	rs := append(Receipts{}, v2.Receipts...)
+	if err := rs.DeriveFields(params.TestChainConfig, common.Hash{}, 1, 0, nil, nil, []*Transaction{tx}); err != nil {
+		t.Fatal(err)
+	}
+	if rs[0].ContractAddress == stored.ContractAddress {
+		t.Fatal("contract address not derived for plain receipts")
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	roundTripIterations = 100  // CheckRoundTrip이 생성하는 값의 개수
+	maxShrinkSteps      = 1000 // 실패한 값을 축소하는 최대 단계 수
+)
+
+// TestingT는 CheckRoundTrip이 사용하는 testing.TB의 부분 집합입니다.
+// 이 패키지가 testing 패키지에 의존하지 않도록 인터페이스로 정의합니다.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// roundTripStage는 왕복 검사가 실패한 단계를 나타냅니다.
+type roundTripStage int
+
+const (
+	stageEncode roundTripStage = iota
+	stageDecode
+	stageReencode
+	stageMismatch
+)
+
+// roundTripError는 왕복 검사 실패를 설명합니다.
+type roundTripError struct {
+	stage roundTripStage
+	err   error
+}
+
+func (e *roundTripError) Error() string {
+	switch e.stage {
+	case stageEncode:
+		return fmt.Sprintf("encoding failed: %v", e.err)
+	case stageDecode:
+		return fmt.Sprintf("decoding failed: %v", e.err)
+	case stageReencode:
+		return fmt.Sprintf("re-encoding failed: %v", e.err)
+	default:
+		return fmt.Sprintf("round trip mismatch: %v", e.err)
+	}
+}
+
+// CheckRoundTrip은 gen이 생성한 값들이 RLP 인코딩과 디코딩을 거친 뒤에도 보존되는지 검사하는
+// 속성 기반 테스트 도우미입니다.
+//
+// 각 값은 인코딩된 후 새 값으로 디코딩되며, 디코딩된 값은 원래 값과 의미적으로 같아야 하고
+// 다시 인코딩했을 때 동일한 바이트를 생성해야 합니다. 의미적 비교는 rlp:"-" 필드를 무시하고,
+// nil 포인터 및 빈 슬라이스를 영 값과 같은 것으로 취급합니다. 내보내지 않은 필드를 가진 타입은
+// 인코딩을 비교합니다. 인코딩에 포함되지 않는 필드를 가진 타입의 경우 gen은 해당 필드를 영 값으로 두어야 합니다.
+//
+// 실패한 값은 같은 방식으로 실패하는 더 단순한 값으로 축소된 후 보고됩니다.
+func CheckRoundTrip[T any](t TestingT, gen func() T) {
+	t.Helper()
+	for i := 0; i < roundTripIterations; i++ {
+		val := gen()
+		err := roundTrip(val)
+		if err == nil {
+			continue
+		}
+		val, err = shrinkRoundTrip(val, err)
+		t.Errorf("value %d: %v\nshrunk value: %s", i, err, formatValue(val))
+		return
+	}
+}
+
+// roundTrip은 val을 인코딩하고 디코딩하여 결과를 비교합니다.
+func roundTrip[T any](val T) *roundTripError {
+	enc, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return &roundTripError{stageEncode, err}
+	}
+	dec := new(T)
+	if err := rlp.DecodeBytes(enc, dec); err != nil {
+		return &roundTripError{stageDecode, err}
+	}
+	reenc, err := rlp.EncodeToBytes(*dec)
+	if err != nil {
+		return &roundTripError{stageReencode, err}
+	}
+	if !bytes.Equal(enc, reenc) {
+		return &roundTripError{stageMismatch, fmt.Errorf("encoding %x, re-encoding %x", enc, reenc)}
+	}
+	if !semanticEqual(reflect.ValueOf(val), reflect.ValueOf(*dec)) {
+		return &roundTripError{stageMismatch, fmt.Errorf("decoded value %s differs", formatValue(*dec))}
+	}
+	return nil
+}
+
+// shrinkRoundTrip은 같은 단계에서 실패하는 더 단순한 값을 찾을 때까지 val을 반복적으로 축소합니다.
+func shrinkRoundTrip[T any](val T, err *roundTripError) (T, *roundTripError) {
+	for step := 0; step < maxShrinkSteps; step++ {
+		shrunk := false
+		for _, c := range shrinkCandidates(reflect.ValueOf(&val).Elem()) {
+			cand, ok := c.Interface().(T)
+			if !ok {
+				continue
+			}
+			if cerr := roundTrip(cand); cerr != nil && cerr.stage == err.stage {
+				val, err, shrunk = cand, cerr, true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return val, err
+}
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// shrinkCandidates는 v보다 단순한 값의 후보 목록을 반환합니다. 후보는 v와 메모리를 공유하지 않습니다.
+func shrinkCandidates(v reflect.Value) []reflect.Value {
+	var out []reflect.Value
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem() == bigIntType {
+			b := v.Interface().(*big.Int)
+			if b.Sign() == 0 {
+				return nil
+			}
+			return []reflect.Value{
+				reflect.ValueOf(new(big.Int)),
+				reflect.ValueOf(new(big.Int).Rsh(b, 1)),
+			}
+		}
+		for _, c := range shrinkCandidates(v.Elem()) {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(c)
+			out = append(out, p)
+		}
+	case reflect.Slice:
+		n := v.Len()
+		if n == 0 {
+			return nil
+		}
+		out = append(out, copySlice(v, 0, n/2), copySlice(v, 0, n-1))
+		for i := 0; i < n; i++ {
+			for _, c := range shrinkCandidates(v.Index(i)) {
+				s := copySlice(v, 0, n)
+				s.Index(i).Set(c)
+				out = append(out, s)
+			}
+		}
+	case reflect.Array:
+		if v.IsZero() {
+			return nil
+		}
+		out = append(out, reflect.Zero(v.Type()))
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				for _, c := range shrinkCandidates(v.Index(i)) {
+					a := reflect.New(v.Type()).Elem()
+					a.Set(v)
+					a.Index(i).Set(c)
+					out = append(out, a)
+				}
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			for _, c := range shrinkCandidates(v.Field(i)) {
+				s := reflect.New(v.Type()).Elem()
+				s.Set(v)
+				s.Field(i).Set(c)
+				out = append(out, s)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if x := v.Uint(); x != 0 {
+			out = append(out, reflect.Zero(v.Type()), reflect.ValueOf(x/2).Convert(v.Type()))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if x := v.Int(); x != 0 {
+			out = append(out, reflect.Zero(v.Type()), reflect.ValueOf(x/2).Convert(v.Type()))
+		}
+	case reflect.Bool:
+		if v.Bool() {
+			out = append(out, reflect.Zero(v.Type()))
+		}
+	case reflect.String:
+		if n := v.Len(); n > 0 {
+			out = append(out, reflect.Zero(v.Type()), reflect.ValueOf(v.String()[:n/2]).Convert(v.Type()))
+		}
+	}
+	return out
+}
+
+// copySlice는 v[start:end]의 복사본을 반환합니다.
+func copySlice(v reflect.Value, start, end int) reflect.Value {
+	s := reflect.MakeSlice(v.Type(), end-start, end-start)
+	reflect.Copy(s, v.Slice(start, end))
+	return s
+}
+
+// semanticEqual은 a와 b가 RLP 인코딩 관점에서 같은 값인지 여부를 반환합니다.
+func semanticEqual(a, b reflect.Value) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.Type().Elem() == bigIntType {
+			return bigValue(a).Cmp(bigValue(b)) == 0
+		}
+		switch {
+		case a.IsNil() && b.IsNil():
+			return true
+		case a.IsNil():
+			return semanticEqual(reflect.Zero(b.Type().Elem()), b.Elem())
+		case b.IsNil():
+			return semanticEqual(a.Elem(), reflect.Zero(a.Type().Elem()))
+		}
+		return semanticEqual(a.Elem(), b.Elem())
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !semanticEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		// 내보내지 않은 필드를 가진 타입은 내부 구조를 비교할 수 없으므로 인코딩을 비교합니다.
+		if hasUnexportedFields(a.Type()) {
+			return encodingEqual(a, b)
+		}
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("rlp") == "-" {
+				continue
+			}
+			if !semanticEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return semanticEqual(a.Elem(), b.Elem())
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+// hasUnexportedFields는 구조체 타입 t에 내보내지 않은 필드가 있는지 여부를 반환합니다.
+func hasUnexportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// bigValue는 *big.Int 값을 반환하며, nil은 0으로 취급합니다.
+func bigValue(v reflect.Value) *big.Int {
+	if v.IsNil() {
+		return new(big.Int)
+	}
+	return v.Interface().(*big.Int)
+}
+
+// encodingEqual은 a와 b의 RLP 인코딩이 같은지 여부를 반환합니다.
+// 포인터 수신자에 정의된 인코더가 사용되도록 값은 포인터로 감싸서 인코딩합니다.
+func encodingEqual(a, b reflect.Value) bool {
+	encode := func(v reflect.Value) ([]byte, error) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return rlp.EncodeToBytes(p.Interface())
+	}
+	encA, errA := encode(a)
+	encB, errB := encode(b)
+	return errA == nil && errB == nil && bytes.Equal(encA, encB)
+}
+
+// formatValue는 실패 보고를 위해 값을 사람이 읽을 수 있는 형식으로 변환합니다.
+func formatValue(val interface{}) string {
+	v := reflect.ValueOf(val)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return fmt.Sprintf("&%+v", v.Elem().Interface())
+	}
+	return fmt.Sprintf("%+v", val)
+}
@@ -0,0 +1,236 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+// roundTripGen produces random values for the round trip tests.
+type roundTripGen struct {
+	rand *rand.Rand
+}
+
+func newRoundTripGen(seed int64) *roundTripGen {
+	return &roundTripGen{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (g *roundTripGen) bytes(max int) []byte {
+	b := make([]byte, g.rand.Intn(max+1))
+	g.rand.Read(b)
+	return b
+}
+
+func (g *roundTripGen) hash() (h common.Hash) {
+	g.rand.Read(h[:])
+	return h
+}
+
+func (g *roundTripGen) address() (a common.Address) {
+	g.rand.Read(a[:])
+	return a
+}
+
+func (g *roundTripGen) big() *big.Int {
+	return new(big.Int).SetBytes(g.bytes(32))
+}
+
+func (g *roundTripGen) u256() *uint256.Int {
+	return new(uint256.Int).SetBytes(g.bytes(32))
+}
+
+func (g *roundTripGen) hashes(max int) []common.Hash {
+	hs := make([]common.Hash, g.rand.Intn(max+1))
+	for i := range hs {
+		hs[i] = g.hash()
+	}
+	return hs
+}
+
+func (g *roundTripGen) accessList() AccessList {
+	al := make(AccessList, g.rand.Intn(4))
+	for i := range al {
+		al[i] = AccessTuple{Address: g.address(), StorageKeys: g.hashes(3)}
+	}
+	return al
+}
+
+func (g *roundTripGen) header() *Header {
+	h := &Header{
+		ParentHash:  g.hash(),
+		UncleHash:   g.hash(),
+		Coinbase:    g.address(),
+		Root:        g.hash(),
+		TxHash:      g.hash(),
+		ReceiptHash: g.hash(),
+		Difficulty:  g.big(),
+		Number:      g.big(),
+		GasLimit:    g.rand.Uint64(),
+		GasUsed:     g.rand.Uint64(),
+		Time:        g.rand.Uint64(),
+		Extra:       g.bytes(32),
+		MixDigest:   g.hash(),
+	}
+	g.rand.Read(h.Bloom[:])
+	g.rand.Read(h.Nonce[:])
+
+	// Optional fields can only be set if all preceding ones are set.
+	switch g.rand.Intn(5) {
+	case 4:
+		root := g.hash()
+		h.ParentBeaconRoot = &root
+		fallthrough
+	case 3:
+		used, excess := g.rand.Uint64(), g.rand.Uint64()
+		h.BlobGasUsed, h.ExcessBlobGas = &used, &excess
+		fallthrough
+	case 2:
+		hash := g.hash()
+		h.WithdrawalsHash = &hash
+		fallthrough
+	case 1:
+		h.BaseFee = g.big()
+	}
+	return h
+}
+
+func (g *roundTripGen) log() *Log {
+	return &Log{Address: g.address(), Topics: g.hashes(4), Data: g.bytes(64)}
+}
+
+func (g *roundTripGen) receipt() *Receipt {
+	r := &Receipt{
+		Type:              uint8(g.rand.Intn(BlobTxType + 1)),
+		Status:            uint64(g.rand.Intn(2)),
+		CumulativeGasUsed: g.rand.Uint64(),
+	}
+	if r.Type == LegacyTxType && g.rand.Intn(2) == 0 {
+		r.Status, r.PostState = 0, g.hash().Bytes()
+	}
+	g.rand.Read(r.Bloom[:])
+	for i := g.rand.Intn(4); i > 0; i-- {
+		r.Logs = append(r.Logs, g.log())
+	}
+	return r
+}
+
+func (g *roundTripGen) tx() *Transaction {
+	var to *common.Address
+	if g.rand.Intn(2) == 0 {
+		addr := g.address()
+		to = &addr
+	}
+	switch g.rand.Intn(4) {
+	case LegacyTxType:
+		return NewTx(&LegacyTx{
+			Nonce: g.rand.Uint64(), GasPrice: g.big(), Gas: g.rand.Uint64(), To: to,
+			Value: g.big(), Data: g.bytes(64), V: g.big(), R: g.big(), S: g.big(),
+		})
+	case AccessListTxType:
+		return NewTx(&AccessListTx{
+			ChainID: g.big(), Nonce: g.rand.Uint64(), GasPrice: g.big(), Gas: g.rand.Uint64(), To: to,
+			Value: g.big(), Data: g.bytes(64), AccessList: g.accessList(), V: g.big(), R: g.big(), S: g.big(),
+		})
+	case DynamicFeeTxType:
+		return NewTx(&DynamicFeeTx{
+			ChainID: g.big(), Nonce: g.rand.Uint64(), GasTipCap: g.big(), GasFeeCap: g.big(), Gas: g.rand.Uint64(), To: to,
+			Value: g.big(), Data: g.bytes(64), AccessList: g.accessList(), V: g.big(), R: g.big(), S: g.big(),
+		})
+	default:
+		return NewTx(&BlobTx{
+			ChainID: g.u256(), Nonce: g.rand.Uint64(), GasTipCap: g.u256(), GasFeeCap: g.u256(), Gas: g.rand.Uint64(), To: g.address(),
+			Value: g.u256(), Data: g.bytes(64), AccessList: g.accessList(), BlobFeeCap: g.u256(), BlobHashes: g.hashes(3),
+			V: g.u256(), R: g.u256(), S: g.u256(),
+		})
+	}
+}
+
+func TestRoundTripCoreTypes(t *testing.T) {
+	g := newRoundTripGen(1)
+
+	CheckRoundTrip(t, g.header)
+	CheckRoundTrip(t, g.log)
+	CheckRoundTrip(t, g.receipt)
+	CheckRoundTrip(t, g.tx)
+	CheckRoundTrip(t, func() *Withdrawal {
+		return &Withdrawal{Index: g.rand.Uint64(), Validator: g.rand.Uint64(), Address: g.address(), Amount: g.rand.Uint64()}
+	})
+	CheckRoundTrip(t, func() *StateAccount {
+		return &StateAccount{Nonce: g.rand.Uint64(), Balance: g.big(), Root: g.hash(), CodeHash: g.bytes(32)}
+	})
+	CheckRoundTrip(t, func() *Body {
+		body := new(Body)
+		for i := g.rand.Intn(3); i > 0; i-- {
+			body.Transactions = append(body.Transactions, g.tx())
+		}
+		for i := g.rand.Intn(2); i > 0; i-- {
+			body.Uncles = append(body.Uncles, g.header())
+		}
+		return body
+	})
+}
+
+// lossyValue is an encoder that silently drops its second field when the
+// first one is large, used to check that failures are detected and shrunk.
+type lossyValue struct {
+	A uint64
+	B []byte
+}
+
+func (v *lossyValue) EncodeRLP(w io.Writer) error {
+	if v.A > 100 {
+		return rlp.Encode(w, []interface{}{v.A, []byte{}})
+	}
+	return rlp.Encode(w, []interface{}{v.A, v.B})
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRoundTripShrink(t *testing.T) {
+	g := newRoundTripGen(2)
+	rt := new(recordingT)
+	CheckRoundTrip(rt, func() *lossyValue {
+		return &lossyValue{A: 1000 + g.rand.Uint64()%1000, B: append([]byte{1}, g.bytes(16)...)}
+	})
+	if len(rt.errors) != 1 {
+		t.Fatalf("expected one failure, got %d", len(rt.errors))
+	}
+	// The failing value should be shrunk to a small A which still drops the
+	// field, and a single zero byte B.
+	var a uint64
+	msg := rt.errors[0][strings.Index(rt.errors[0], "shrunk value:"):]
+	if _, err := fmt.Sscanf(msg, "shrunk value: &{A:%d B:[0]}", &a); err != nil || a <= 100 || a > 200 {
+		t.Fatalf("value not shrunk: %s", rt.errors[0])
+	}
+}
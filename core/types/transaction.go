@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"math/big"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -52,8 +53,9 @@ const (
 
 // Transaction은 이더리움 트랜잭션입니다.
 type Transaction struct {
-	inner TxData    // 트랜잭션의 핵심 내용
-	time  time.Time // 로컬에서 처음 확인한 시간 (스팸 방지)
+	inner TxData       // 트랜잭션의 핵심 내용
+	time  time.Time    // 로컬에서 처음 확인한 시간 (스팸 방지)
+	meta  atomic.Value // 로컬 전용 메타데이터 (*TxMeta), 인코딩되지 않습니다
 
 	// 캐시
 	hash atomic.Value
@@ -190,23 +192,45 @@ func (tx *Transaction) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// UnmarshalBinaryStrict는 UnmarshalBinary와 같이 트랜잭션을 디코딩하지만, 트랜잭션 본문 뒤에
+// 남은 바이트가 있으면 어떤 트랜잭션 타입이든 rlp.ErrMoreThanOneValue를 반환합니다. 등록된
+// 트랜잭션 타입의 디코더가 입력의 앞부분만 읽더라도 같은 트랜잭션이 여러 인코딩으로 받아들여지지
+// 않도록, 신뢰할 수 없는 네트워크 입력을 검증할 때 사용합니다.
+func (tx *Transaction) UnmarshalBinaryStrict(b []byte) error {
+	if err := checkTxTrailingBytes(b); err != nil {
+		return err
+	}
+	return tx.UnmarshalBinary(b)
+}
+
+// checkTxTrailingBytes는 b가 레거시 트랜잭션의 RLP 값 하나 또는 타입 바이트와 RLP 값 하나로만
+// 이루어져 있는지 확인합니다. 빈 입력이나 잘못된 값은 일관된 오류를 위해 디코더에 맡깁니다.
+func checkTxTrailingBytes(b []byte) error {
+	payload := b
+	if len(b) > 0 && b[0] <= 0x7f {
+		payload = b[1:]
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+	_, _, rest, err := rlp.Split(payload)
+	if err == nil && len(rest) > 0 {
+		return rlp.ErrMoreThanOneValue
+	}
+	return nil
+}
+
 // decodeTyped는 정규 형식에서 타입 트랜잭션을 디코딩합니다.
 func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
-	if len(b) <= 1 {
+	typ, payload, ok := splitTypedEnvelope(b)
+	if !ok {
 		return nil, errShortTypedTx
 	}
-	var inner TxData
-	switch b[0] {
-	case AccessListTxType:
-		inner = new(AccessListTx)
-	case DynamicFeeTxType:
-		inner = new(DynamicFeeTx)
-	case BlobTxType:
-		inner = new(BlobTx)
-	default:
-		return nil, ErrTxTypeNotSupported
+	inner, err := newTypedTxData(typ)
+	if err != nil {
+		return nil, err
 	}
-	err := inner.decode(b[1:])
+	err = inner.decode(payload)
 	return inner, err
 }
 
@@ -236,7 +260,7 @@ func sanityCheckSignature(v *big.Int, r *big.Int, s *big.Int, maybeProtected boo
 		plainV = byte(v.Uint64())
 	}
 	if !crypto.ValidateSignatureValues(plainV, r, s, false) {
-		return ErrInvalidSig
+		return &SignatureError{V: v, R: r, S: s}
 	}
 
 	return nil
@@ -426,6 +450,9 @@ func (tx *Transaction) WithoutBlobTxSidecar() *Transaction {
 		inner: blobtx.withoutSidecar(),
 		time:  tx.time,
 	}
+	if m := tx.meta.Load(); m != nil {
+		cpy.meta.Store(m)
+	}
 	// 참고: tx.size 캐시는 사이드카가 크기에 포함되기 때문에 복사되지 않습니다!
 	if h := tx.hash.Load(); h != nil {
 		cpy.hash.Store(h)
@@ -464,6 +491,47 @@ func (tx *Transaction) Hash() common.Hash {
 	return h
 }
 
+// HashWithSidecar는 blob 사이드카를 포함한 네트워크 인코딩의 해시를 반환합니다. 사이드카가
+// 없는 트랜잭션의 경우 Hash와 같습니다. 사이드카는 변경될 수 있으므로 결과는 캐시되지 않습니다.
+func (tx *Transaction) HashWithSidecar() common.Hash {
+	if tx.BlobTxSidecar() == nil {
+		return tx.Hash()
+	}
+	sha := hasherPool.Get().(crypto.KeccakState)
+	defer hasherPool.Put(sha)
+	sha.Reset()
+
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	defer encodeBufferPool.Put(buf)
+	buf.Reset()
+	tx.encodeTyped(buf)
+	sha.Write(buf.Bytes())
+
+	var h common.Hash
+	sha.Read(h[:])
+	return h
+}
+
+// EqualContent는 두 트랜잭션의 정규 인코딩이 같은지 여부를 반환합니다. blob 트랜잭션의 경우
+// 사이드카도 비교하며, 한쪽에만 사이드카가 있으면 다른 트랜잭션으로 취급합니다. 수신 시간과
+// 캐시된 값은 비교하지 않습니다.
+func (tx *Transaction) EqualContent(other *Transaction) bool {
+	if tx == other {
+		return true
+	}
+	if tx == nil || other == nil {
+		return false
+	}
+	if tx.Type() != other.Type() || tx.Hash() != other.Hash() {
+		return false
+	}
+	sc, otherSc := tx.BlobTxSidecar(), other.BlobTxSidecar()
+	if sc == nil || otherSc == nil {
+		return sc == nil && otherSc == nil
+	}
+	return tx.HashWithSidecar() == other.HashWithSidecar()
+}
+
 // Size는 트랜잭션의 실제 인코딩된 저장공간 크기를 반환합니다.
 // 인코딩하고 반환하거나, 이전에 캐시된 값을 반환합니다.
 func (tx *Transaction) Size() uint64 {
@@ -503,7 +571,29 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	}
 	cpy := tx.inner.copy()
 	cpy.setSignatureValues(signer.ChainID(), v, r, s)
-	return &Transaction{inner: cpy, time: tx.time}, nil
+	signed := &Transaction{inner: cpy, time: tx.time}
+	if m := tx.meta.Load(); m != nil {
+		signed.meta.Store(m)
+	}
+	return signed, nil
+}
+
+// WithNormalizedSignature는 서명을 NormalizeSignature로 low-S 형식으로 바꾼 새 트랜잭션을
+// 반환합니다. 서명이 이미 정규 형식이면 tx를 그대로 반환합니다. 변환된 서명은 signer로
+// 발신자를 복구하여 검증하며, 복구에 실패하면 오류를 반환합니다.
+func (tx *Transaction) WithNormalizedSignature(signer Signer) (*Transaction, error) {
+	v, r, s := tx.RawSignatureValues()
+	nv, nr, ns, changed := NormalizeSignature(v, r, s)
+	if !changed {
+		return tx, nil
+	}
+	cpy := tx.inner.copy()
+	cpy.setSignatureValues(tx.ChainId(), nv, nr, ns)
+	normalized := &Transaction{inner: cpy, time: tx.time}
+	if _, err := Sender(signer, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
 }
 
 // Transactions는 머클루트를 계산하기 위해 필요한 인터페이스를 구현합니다.
@@ -559,6 +649,59 @@ func HashDifference(a, b []common.Hash) []common.Hash {
 	return keep
 }
 
+// txSenderNonce는 트랜잭션을 발신자와 nonce로 식별합니다.
+type txSenderNonce struct {
+	sender common.Address
+	nonce  uint64
+}
+
+// TxDifferenceBySenderNonce는 같은 발신자와 nonce를 가진 트랜잭션이 b에 없는 a의 트랜잭션을
+// 반환합니다. TxDifference와 달리 해시가 아닌 (발신자, nonce)로 비교하므로, b에 같은 nonce의
+// 대체 트랜잭션이 포함되어 있으면 a의 트랜잭션도 제외됩니다. 리오그 후 다시 주입할 트랜잭션을
+// 고를 때 사용합니다. 발신자를 복구할 수 없는 트랜잭션이 있으면 오류를 반환합니다.
+func TxDifferenceBySenderNonce(a, b Transactions, signer Signer) (Transactions, error) {
+	keep := make(Transactions, 0, len(a))
+
+	remove := make(map[txSenderNonce]struct{}, len(b))
+	for _, tx := range b {
+		from, err := Sender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		remove[txSenderNonce{from, tx.Nonce()}] = struct{}{}
+	}
+
+	for _, tx := range a {
+		from, err := Sender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := remove[txSenderNonce{from, tx.Nonce()}]; !ok {
+			keep = append(keep, tx)
+		}
+	}
+
+	return keep, nil
+}
+
+// GroupTxsBySender는 트랜잭션을 발신자별로 묶고 각 목록을 nonce 순으로 정렬하여 반환합니다.
+// nonce가 같은 트랜잭션은 입력의 순서를 유지합니다. 발신자를 복구할 수 없는 트랜잭션이 있으면
+// 오류를 반환합니다.
+func GroupTxsBySender(txs Transactions, signer Signer) (map[common.Address]Transactions, error) {
+	groups := make(map[common.Address]Transactions)
+	for _, tx := range txs {
+		from, err := Sender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		groups[from] = append(groups[from], tx)
+	}
+	for _, list := range groups {
+		sort.Stable(TxByNonce(list))
+	}
+	return groups, nil
+}
+
 // TxByNonce는 트랜잭션 목록을 nonce로 정렬할 수 있도록 sort 인터페이스를 구현합니다.
 // 이는 일반적으로 하나의 계정에서 트랜잭션을 정렬하는 데만 유용하며, 그렇지 않으면 nonce 비교는 큰 의미가 없습니다.
 type TxByNonce Transactions
@@ -399,7 +399,7 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		}
 
 	default:
-		return ErrTxTypeNotSupported
+		return &TxTypeError{Type: byte(dec.Type)}
 	}
 
 	// innerTx를 설정합니다.
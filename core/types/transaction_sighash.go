@@ -0,0 +1,115 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SigningPayload는 chainID로 tx에 서명할 때 해시되는 서명 전 이미지(pre-image)를 반환합니다.
+// 반환된 바이트의 Keccak256 해시는 해당 트랜잭션 타입을 지원하는 Signer의 Hash와 같습니다.
+//
+// 레거시 트랜잭션은 chainID가 nil이거나 0이면 EIP-155 이전 형식으로, 그렇지 않으면 EIP-155 형식으로
+// 인코딩됩니다. 타입이 있는 트랜잭션은 타입 바이트로 시작하며 chainID가 반드시 필요합니다.
+func SigningPayload(tx *Transaction, chainID *big.Int) ([]byte, error) {
+	if tx.Type() == LegacyTxType {
+		fields := []interface{}{
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+		}
+		if chainID != nil && chainID.Sign() != 0 {
+			fields = append(fields, chainID, uint(0), uint(0))
+		}
+		return rlp.EncodeToBytes(fields)
+	}
+	if chainID == nil {
+		return nil, fmt.Errorf("%w: typed transaction requires a chain ID", ErrInvalidChainId)
+	}
+	var fields []interface{}
+	switch tx.Type() {
+	case AccessListTxType:
+		fields = []interface{}{
+			chainID,
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		}
+	case DynamicFeeTxType:
+		fields = []interface{}{
+			chainID,
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		}
+	case BlobTxType:
+		fields = []interface{}{
+			chainID,
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.Gas(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+			tx.BlobGasFeeCap(),
+			tx.BlobHashes(),
+		}
+	default:
+		return nil, &TxTypeError{Type: tx.Type()}
+	}
+	return rlp.AppendEncoded([]byte{tx.Type()}, fields)
+}
+
+// SigningHash는 chainID로 tx에 서명할 때 서명되는 해시를 반환합니다.
+func SigningHash(tx *Transaction, chainID *big.Int) (common.Hash, error) {
+	payload, err := SigningPayload(tx, chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(payload), nil
+}
+
+// SigningEnvelope는 트랜잭션 자체의 체인 ID로 서명할 때의 서명 전 이미지를 반환합니다.
+// 타입이 있는 트랜잭션은 ChainID 필드를 사용합니다. 레거시 트랜잭션은 서명이 재전송 공격으로부터
+// 보호된 경우에만 서명에서 유도한 체인 ID를 사용하므로, 서명되지 않은 레거시 트랜잭션을 EIP-155
+// 형식으로 서명하려면 SigningPayload에 체인 ID를 직접 전달해야 합니다.
+func (tx *Transaction) SigningEnvelope() ([]byte, error) {
+	var chainID *big.Int
+	if tx.Type() != LegacyTxType || tx.Protected() {
+		chainID = tx.ChainId()
+	}
+	return SigningPayload(tx, chainID)
+}
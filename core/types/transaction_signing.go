@@ -29,6 +29,11 @@ import (
 
 var ErrInvalidChainId = errors.New("invalid chain id for signer")
 
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1halfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
 // sigCache는 서명자와 함께 파생된 발신자를 캐시하는 데 사용됩니다.
 type sigCache struct {
 	signer Signer
@@ -123,6 +128,22 @@ func MustSignNewTx(prv *ecdsa.PrivateKey, s Signer, txdata TxData) *Transaction
 	return tx
 }
 
+// SignTxWithSigner는 개인 키 대신 외부 서명자(예: HSM, 하드웨어 지갑, 원격 서명자)를 사용하여
+// 트랜잭션에 서명합니다. 서명자가 반환한 서명은 서명자의 공개 키로 복구되는지 검증됩니다.
+func SignTxWithSigner(tx *Transaction, s Signer, signer crypto.Signer) (*Transaction, error) {
+	h := s.Hash(tx)
+	sig, err := crypto.SignWithSigner(h[:], signer)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(s, sig)
+}
+
+// SignNewTxWithSigner는 트랜잭션을 생성하고 외부 서명자를 사용하여 서명합니다.
+func SignNewTxWithSigner(signer crypto.Signer, s Signer, txdata TxData) (*Transaction, error) {
+	return SignTxWithSigner(NewTx(txdata), s, signer)
+}
+
 // Sender는 secp256k1 타원 곡선을 사용하여 서명(V, R, S)에서 파생된 주소를 반환하고
 // 이 작업이 실패하거나 서명이 잘못된 경우 오류를 반환합니다.
 //
@@ -189,7 +210,7 @@ func (s cancunSigner) Sender(tx *Transaction) (common.Address, error) {
 	// 27을 더하여 보호되지 않은 Homestead 서명과 동일하게 만듭니다.
 	V = new(big.Int).Add(V, big.NewInt(27))
 	if tx.ChainId().Cmp(s.chainId) != 0 {
-		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.chainId)
+		return common.Address{}, &ChainIDError{Have: tx.ChainId(), Want: s.chainId}
 	}
 	return recoverPlain(s.Hash(tx), R, S, V, true)
 }
@@ -207,7 +228,7 @@ func (s cancunSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 	// txdata의 체인 ID는 0이 아니어야 하며, 서명자의 체인 ID와 일치해야 합니다.
 	// txdata의 체인 ID가 0이라는 것은 tx에서 체인 ID가 지정되지 않았음을 의미합니다.
 	if txdata.ChainID.Sign() != 0 && txdata.ChainID.ToBig().Cmp(s.chainId) != 0 {
-		return nil, nil, nil, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, txdata.ChainID, s.chainId)
+		return nil, nil, nil, &ChainIDError{Have: txdata.ChainID.ToBig(), Want: s.chainId}
 	}
 	R, S, _ = decodeSignature(sig)
 	V = big.NewInt(int64(sig[64]))
@@ -258,7 +279,7 @@ func (s londonSigner) Sender(tx *Transaction) (common.Address, error) {
 	// 27을 더하여 보호되지 않은 Homestead 서명과 동일하게 만듭니다.
 	V = new(big.Int).Add(V, big.NewInt(27))
 	if tx.ChainId().Cmp(s.chainId) != 0 {
-		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.chainId)
+		return common.Address{}, &ChainIDError{Have: tx.ChainId(), Want: s.chainId}
 	}
 	return recoverPlain(s.Hash(tx), R, S, V, true)
 }
@@ -276,7 +297,7 @@ func (s londonSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 	// txdata의 체인 ID는 0이 아니어야 하며, 서명자의 체인 ID와 일치해야 합니다.
 	// txdata의 체인 ID가 0이라는 것은 tx에서 체인 ID가 지정되지 않았음을 의미합니다.
 	if txdata.ChainID.Sign() != 0 && txdata.ChainID.Cmp(s.chainId) != 0 {
-		return nil, nil, nil, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, txdata.ChainID, s.chainId)
+		return nil, nil, nil, &ChainIDError{Have: txdata.ChainID, Want: s.chainId}
 	}
 	R, S, _ = decodeSignature(sig)
 	V = big.NewInt(int64(sig[64]))
@@ -331,10 +352,10 @@ func (s eip2930Signer) Sender(tx *Transaction) (common.Address, error) {
 		// 27을 더하여 보호되지 않은 Homestead 서명과 동일하게 만듭니다.
 		V = new(big.Int).Add(V, big.NewInt(27))
 	default:
-		return common.Address{}, ErrTxTypeNotSupported
+		return common.Address{}, &TxTypeError{Type: tx.Type()}
 	}
 	if tx.ChainId().Cmp(s.chainId) != 0 {
-		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.chainId)
+		return common.Address{}, &ChainIDError{Have: tx.ChainId(), Want: s.chainId}
 	}
 	return recoverPlain(s.Hash(tx), R, S, V, true)
 }
@@ -347,12 +368,12 @@ func (s eip2930Signer) SignatureValues(tx *Transaction, sig []byte) (R, S, V *bi
 		// txdata의 체인 ID는 0이 아니어야 하며, 서명자의 체인 ID와 일치해야 합니다.
 		// txdata의 체인 ID가 0이라는 것은 tx에서 체인 ID가 지정되지 않았음을 의미합니다.
 		if txdata.ChainID.Sign() != 0 && txdata.ChainID.Cmp(s.chainId) != 0 {
-			return nil, nil, nil, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, txdata.ChainID, s.chainId)
+			return nil, nil, nil, &ChainIDError{Have: txdata.ChainID, Want: s.chainId}
 		}
 		R, S, _ = decodeSignature(sig)
 		V = big.NewInt(int64(sig[64]))
 	default:
-		return nil, nil, nil, ErrTxTypeNotSupported
+		return nil, nil, nil, &TxTypeError{Type: tx.Type()}
 	}
 	return R, S, V, nil
 }
@@ -412,13 +433,13 @@ var big8 = big.NewInt(8)
 
 func (s EIP155Signer) Sender(tx *Transaction) (common.Address, error) {
 	if tx.Type() != LegacyTxType {
-		return common.Address{}, ErrTxTypeNotSupported
+		return common.Address{}, &TxTypeError{Type: tx.Type()}
 	}
 	if !tx.Protected() {
 		return HomesteadSigner{}.Sender(tx)
 	}
 	if tx.ChainId().Cmp(s.chainId) != 0 {
-		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.chainId)
+		return common.Address{}, &ChainIDError{Have: tx.ChainId(), Want: s.chainId}
 	}
 	V, R, S := tx.RawSignatureValues()
 	V = new(big.Int).Sub(V, s.chainIdMul)
@@ -429,7 +450,7 @@ func (s EIP155Signer) Sender(tx *Transaction) (common.Address, error) {
 // SignatureValues는 서명 값을 반환합니다. 이 서명은 V가 0 또는 1인 [R || S || V] 형식이어야 합니다.
 func (s EIP155Signer) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big.Int, err error) {
 	if tx.Type() != LegacyTxType {
-		return nil, nil, nil, ErrTxTypeNotSupported
+		return nil, nil, nil, &TxTypeError{Type: tx.Type()}
 	}
 	R, S, V = decodeSignature(sig)
 	if s.chainId.Sign() != 0 {
@@ -472,7 +493,7 @@ func (hs HomesteadSigner) SignatureValues(tx *Transaction, sig []byte) (r, s, v
 
 func (hs HomesteadSigner) Sender(tx *Transaction) (common.Address, error) {
 	if tx.Type() != LegacyTxType {
-		return common.Address{}, ErrTxTypeNotSupported
+		return common.Address{}, &TxTypeError{Type: tx.Type()}
 	}
 	v, r, s := tx.RawSignatureValues()
 	return recoverPlain(hs.Hash(tx), r, s, v, true)
@@ -492,7 +513,7 @@ func (s FrontierSigner) Equal(s2 Signer) bool {
 
 func (fs FrontierSigner) Sender(tx *Transaction) (common.Address, error) {
 	if tx.Type() != LegacyTxType {
-		return common.Address{}, ErrTxTypeNotSupported
+		return common.Address{}, &TxTypeError{Type: tx.Type()}
 	}
 	v, r, s := tx.RawSignatureValues()
 	return recoverPlain(fs.Hash(tx), r, s, v, false)
@@ -501,7 +522,7 @@ func (fs FrontierSigner) Sender(tx *Transaction) (common.Address, error) {
 // SignatureValues는 서명 값을 반환합니다. 이 서명은 V가 0 또는 1인 [R || S || V] 형식이어야 합니다.
 func (fs FrontierSigner) SignatureValues(tx *Transaction, sig []byte) (r, s, v *big.Int, err error) {
 	if tx.Type() != LegacyTxType {
-		return nil, nil, nil, ErrTxTypeNotSupported
+		return nil, nil, nil, &TxTypeError{Type: tx.Type()}
 	}
 	r, s, v = decodeSignature(sig)
 	return r, s, v, nil
@@ -520,6 +541,30 @@ func (fs FrontierSigner) Hash(tx *Transaction) common.Hash {
 	})
 }
 
+// NormalizeSignature는 서명 값을 S가 N/2 이하인 정규 low-S 형식으로 변환한 새 값을 반환합니다.
+// S가 N/2보다 크면 S를 N-S로 바꾸고 복구 ID를 뒤집기 위해 V의 패리티를 바꿉니다. 변환된 서명은
+// 같은 발신자로 복구되지만 트랜잭션 해시는 달라집니다. V는 레거시(27/28), EIP-155
+// (chainID*2+35/36), 타입 트랜잭션(0/1)의 어떤 형식이어도 됩니다. changed는 값이
+// 변환되었는지 여부입니다.
+func NormalizeSignature(v, r, s *big.Int) (nv, nr, ns *big.Int, changed bool) {
+	nv, nr, ns = new(big.Int).Set(v), new(big.Int).Set(r), new(big.Int).Set(s)
+	if s.Cmp(secp256k1halfN) <= 0 {
+		return nv, nr, ns, false
+	}
+	ns.Sub(secp256k1N, s)
+	// 복구 ID 0은 타입 트랜잭션에서 짝수(0), 레거시(27)와 EIP-155(chainID*2+35)에서
+	// 홀수 V에 해당합니다.
+	switch {
+	case v.Cmp(common.Big1) <= 0:
+		nv.SetBit(nv, 0, v.Bit(0)^1)
+	case v.Bit(0) == 1:
+		nv.Add(nv, common.Big1)
+	default:
+		nv.Sub(nv, common.Big1)
+	}
+	return nv, nr, ns, true
+}
+
 func decodeSignature(sig []byte) (r, s, v *big.Int) {
 	if len(sig) != crypto.SignatureLength {
 		panic(fmt.Sprintf("wrong size for signature: got %d, want %d", len(sig), crypto.SignatureLength))
@@ -532,11 +577,11 @@ func decodeSignature(sig []byte) (r, s, v *big.Int) {
 
 func recoverPlain(sighash common.Hash, R, S, Vb *big.Int, homestead bool) (common.Address, error) {
 	if Vb.BitLen() > 8 {
-		return common.Address{}, ErrInvalidSig
+		return common.Address{}, &SignatureError{V: Vb, R: R, S: S}
 	}
 	V := byte(Vb.Uint64() - 27)
 	if !crypto.ValidateSignatureValues(V, R, S, homestead) {
-		return common.Address{}, ErrInvalidSig
+		return common.Address{}, &SignatureError{V: Vb, R: R, S: S}
 	}
 	// 비압축 형식으로 서명을 인코딩합니다.
 	r, s := R.Bytes(), S.Bytes()
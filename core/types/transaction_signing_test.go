@@ -17,6 +17,7 @@
 package types
 
 import (
+	"crypto/ecdsa"
 	"errors"
 	"math/big"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 func TestEIP155Signing(t *testing.T) {
@@ -45,6 +47,41 @@ func TestEIP155Signing(t *testing.T) {
 	}
 }
 
+// mismatchedSigner signs with one key but reports the public key of another.
+type mismatchedSigner struct {
+	crypto.Signer
+	pub *ecdsa.PublicKey
+}
+
+func (s mismatchedSigner) PublicKey() *ecdsa.PublicKey { return s.pub }
+
+func TestSignTxWithSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	signer := NewLondonSigner(big.NewInt(1))
+	txdata := &DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)}
+
+	want := MustSignNewTx(key, signer, txdata)
+	have, err := SignNewTxWithSigner(crypto.NewKeySigner(key), signer, txdata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have.Hash() != want.Hash() {
+		t.Errorf("signed transaction mismatch: have %x, want %x", have.Hash(), want.Hash())
+	}
+	from, err := Sender(signer, have)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("sender mismatch: have %x, want %x", from, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	bad := mismatchedSigner{crypto.NewKeySigner(key), &other.PublicKey}
+	if _, err := SignTxWithSigner(NewTx(txdata), signer, bad); !errors.Is(err, crypto.ErrSignerMismatch) {
+		t.Errorf("expected signer mismatch error, got %v", err)
+	}
+}
+
 func TestEIP155ChainId(t *testing.T) {
 	key, _ := crypto.GenerateKey()
 	addr := crypto.PubkeyToAddress(key.PublicKey)
@@ -136,3 +173,114 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestSigningHash(t *testing.T) {
+	var (
+		chainID = big.NewInt(7)
+		to      = common.Address{0x01}
+		key, _  = crypto.GenerateKey()
+		signer  = NewCancunSigner(chainID)
+	)
+	txs := []TxData{
+		&LegacyTx{Nonce: 1, GasPrice: big.NewInt(2), Gas: 21000, To: &to, Value: big.NewInt(3)},
+		&AccessListTx{ChainID: chainID, Nonce: 1, GasPrice: big.NewInt(2), Gas: 21000, To: &to, AccessList: AccessList{{Address: to}}},
+		&DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000, Data: []byte{0xaa}},
+		&BlobTx{ChainID: uint256.NewInt(7), GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2), BlobFeeCap: uint256.NewInt(3), BlobHashes: []common.Hash{{0x01}}},
+	}
+	for _, inner := range txs {
+		tx := MustSignNewTx(key, signer, inner)
+		hash, err := SigningHash(tx, chainID)
+		if err != nil {
+			t.Fatalf("type %d: %v", tx.Type(), err)
+		}
+		if want := signer.Hash(tx); hash != want {
+			t.Errorf("type %d: signing hash mismatch: have %x, want %x", tx.Type(), hash, want)
+		}
+		envelope, err := tx.SigningEnvelope()
+		if err != nil {
+			t.Fatalf("type %d: %v", tx.Type(), err)
+		}
+		if crypto.Keccak256Hash(envelope) != hash {
+			t.Errorf("type %d: envelope does not hash to signing hash", tx.Type())
+		}
+	}
+
+	// Unprotected legacy transactions use the pre-EIP-155 payload.
+	tx := MustSignNewTx(key, HomesteadSigner{}, txs[0])
+	hash, err := SigningHash(tx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (HomesteadSigner{}).Hash(tx); hash != want {
+		t.Errorf("unprotected signing hash mismatch: have %x, want %x", hash, want)
+	}
+	envelope, _ := tx.SigningEnvelope()
+	if crypto.Keccak256Hash(envelope) != hash {
+		t.Error("unprotected envelope does not hash to signing hash")
+	}
+
+	// Typed transactions cannot be hashed without a chain ID.
+	if _, err := SigningHash(NewTx(txs[2]), nil); !errors.Is(err, ErrInvalidChainId) {
+		t.Errorf("wrong error for missing chain ID: %v", err)
+	}
+}
+
+func TestWithNormalizedSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.Address{0x01}
+
+	tests := []struct {
+		name   string
+		signer Signer
+		txdata TxData
+		vbase  int64 // V value of recovery ID 0
+	}{
+		{"homestead", HomesteadSigner{}, &LegacyTx{Nonce: 1, To: &to, Gas: 21000, GasPrice: big.NewInt(1)}, 27},
+		{"eip155", NewEIP155Signer(big.NewInt(18)), &LegacyTx{Nonce: 1, To: &to, Gas: 21000, GasPrice: big.NewInt(1)}, 18*2 + 35},
+		{"london", NewLondonSigner(big.NewInt(18)), &DynamicFeeTx{ChainID: big.NewInt(18), Nonce: 1, To: &to, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1)}, 0},
+	}
+	for _, test := range tests {
+		tx, err := SignNewTx(key, test.signer, test.txdata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Low-S signatures are returned unchanged.
+		if same, err := tx.WithNormalizedSignature(test.signer); err != nil || same != tx {
+			t.Fatalf("%s: low-S transaction modified: %v", test.name, err)
+		}
+		// Create the malleated high-S form of the same signature, which has the
+		// opposite recovery ID.
+		v, r, s := tx.RawSignatureValues()
+		recid := v.Int64() - test.vbase
+		if recid != 0 && recid != 1 {
+			t.Fatalf("%s: unexpected V %v", test.name, v)
+		}
+		highS := new(big.Int).Sub(secp256k1N, s)
+		highV := big.NewInt(test.vbase + 1 - recid)
+		if from, err := recoverPlain(test.signer.Hash(tx), r, highS, big.NewInt(28-recid), false); err != nil || from != addr {
+			t.Fatalf("%s: high-S form recovers %x: %v", test.name, from, err)
+		}
+		inner := tx.inner.copy()
+		inner.setSignatureValues(tx.ChainId(), highV, r, highS)
+		malleated := NewTx(inner)
+		if _, err := Sender(test.signer, malleated); err == nil {
+			t.Fatalf("%s: high-S signature accepted", test.name)
+		}
+
+		nv, nr, ns, changed := NormalizeSignature(highV, r, highS)
+		if !changed || nv.Cmp(v) != 0 || nr.Cmp(r) != 0 || ns.Cmp(s) != 0 {
+			t.Fatalf("%s: wrong normalized values: v %v r %v s %v", test.name, nv, nr, ns)
+		}
+		normalized, err := malleated.WithNormalizedSignature(test.signer)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if normalized.Hash() != tx.Hash() {
+			t.Fatalf("%s: normalized hash mismatch", test.name)
+		}
+		if from, err := Sender(test.signer, normalized); err != nil || from != addr {
+			t.Fatalf("%s: wrong sender %x: %v", test.name, from, err)
+		}
+	}
+}
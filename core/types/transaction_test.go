@@ -545,3 +545,67 @@ func TestYParityJSONUnmarshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestTxDifferenceBySenderNonce(t *testing.T) {
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	signer := HomesteadSigner{}
+	sign := func(key *ecdsa.PrivateKey, nonce uint64, price int64) *Transaction {
+		return MustSignNewTx(key, signer, &LegacyTx{Nonce: nonce, GasPrice: big.NewInt(price), Gas: 21000})
+	}
+	var (
+		a0 = sign(key1, 0, 1)
+		a1 = sign(key1, 1, 1)
+		a2 = sign(key1, 2, 1)
+		b0 = sign(key2, 0, 1)
+		// a1r replaces a1 with a different price, so it has a different hash.
+		a1r = sign(key1, 1, 2)
+	)
+	diff, err := TxDifferenceBySenderNonce(Transactions{a0, a1, a2, b0}, Transactions{a0, a1r}, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff) != 2 || diff[0] != a2 || diff[1] != b0 {
+		t.Fatalf("wrong difference: %v", diff)
+	}
+	// Comparing by hash keeps the replaced transaction.
+	if diff := TxDifference(Transactions{a0, a1, a2, b0}, Transactions{a0, a1r}); len(diff) != 3 {
+		t.Fatalf("wrong hash difference: %d txs", len(diff))
+	}
+	if _, err := TxDifferenceBySenderNonce(Transactions{emptyTx}, nil, signer); err == nil {
+		t.Fatal("no error for unsigned transaction")
+	}
+}
+
+func TestGroupTxsBySender(t *testing.T) {
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	signer := HomesteadSigner{}
+	sign := func(key *ecdsa.PrivateKey, nonce uint64, price int64) *Transaction {
+		return MustSignNewTx(key, signer, &LegacyTx{Nonce: nonce, GasPrice: big.NewInt(price), Gas: 21000})
+	}
+	var (
+		a2  = sign(key1, 2, 1)
+		a0  = sign(key1, 0, 1)
+		a1  = sign(key1, 1, 1)
+		a1r = sign(key1, 1, 2)
+		b5  = sign(key2, 5, 1)
+	)
+	groups, err := GroupTxsBySender(Transactions{a2, b5, a1, a0, a1r}, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("wrong number of senders: %d", len(groups))
+	}
+	want := Transactions{a0, a1, a1r, a2}
+	if list := groups[crypto.PubkeyToAddress(key1.PublicKey)]; !reflect.DeepEqual(list, want) {
+		t.Fatalf("wrong order for sender 1: %v", list)
+	}
+	if list := groups[crypto.PubkeyToAddress(key2.PublicKey)]; len(list) != 1 || list[0] != b5 {
+		t.Fatalf("wrong list for sender 2: %v", list)
+	}
+	if _, err := GroupTxsBySender(Transactions{emptyTx}, signer); err == nil {
+		t.Fatal("no error for unsigned transaction")
+	}
+}
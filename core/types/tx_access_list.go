@@ -21,6 +21,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -44,6 +45,24 @@ func (al AccessList) StorageKeys() int {
 	return sum
 }
 
+// Size는 접근 목록의 RLP 인코딩 크기를 목록을 인코딩하지 않고 계산하여 반환합니다.
+func (al AccessList) Size() uint64 {
+	var size uint64
+	for _, tuple := range al {
+		keys := rlp.ListSize(uint64(len(tuple.StorageKeys)) * (1 + common.HashLength))
+		size += rlp.ListSize(1 + common.AddressLength + keys)
+	}
+	return rlp.ListSize(size)
+}
+
+// GasCost는 접근 목록이 트랜잭션의 고유 가스에 더하는 비용을 반환합니다. 주소마다
+// params.TxAccessListAddressGas, 스토리지 키마다 params.TxAccessListStorageKeyGas가
+// 부과됩니다. 접근 목록을 지원하는 모든 포크(Berlin 이후)에서 비용이 같으므로 포크 규칙을
+// 받지 않으며, 접근 목록이 포크에서 허용되는지는 트랜잭션 검증에서 확인합니다.
+func (al AccessList) GasCost() uint64 {
+	return uint64(len(al))*params.TxAccessListAddressGas + uint64(al.StorageKeys())*params.TxAccessListStorageKeyGas
+}
+
 // AccessListTx는 EIP-2930 접근 목록 트랜잭션의 데이터입니다.
 type AccessListTx struct {
 	ChainID    *big.Int        // 대상 체인 ID
@@ -0,0 +1,59 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestAccessListSize(t *testing.T) {
+	manyKeys := make([]common.Hash, 10)
+	tests := []AccessList{
+		nil,
+		{},
+		{{Address: common.Address{1}}},
+		{{Address: common.Address{1}, StorageKeys: []common.Hash{{1}}}},
+		{{Address: common.Address{1}, StorageKeys: manyKeys}, {Address: common.Address{2}, StorageKeys: []common.Hash{{2}, {3}}}},
+	}
+	for i, al := range tests {
+		enc, err := rlp.EncodeToBytes(al)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := al.Size(); size != uint64(len(enc)) {
+			t.Errorf("test %d: wrong size %d, want %d", i, size, len(enc))
+		}
+	}
+}
+
+func TestAccessListGasCost(t *testing.T) {
+	al := AccessList{
+		{Address: common.Address{1}, StorageKeys: []common.Hash{{1}, {2}}},
+		{Address: common.Address{2}},
+	}
+	if n := al.StorageKeys(); n != 2 {
+		t.Fatalf("wrong storage key count %d", n)
+	}
+	want := 2*params.TxAccessListAddressGas + 2*params.TxAccessListStorageKeyGas
+	if gas := al.GasCost(); gas != want {
+		t.Fatalf("wrong gas cost %d, want %d", gas, want)
+	}
+}
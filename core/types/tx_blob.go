@@ -19,6 +19,9 @@ package types
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -56,6 +59,65 @@ type BlobTxSidecar struct {
 	Blobs       []kzg4844.Blob       // blob 풀이 필요한 blob
 	Commitments []kzg4844.Commitment // blob 풀이 필요한 Commitments
 	Proofs      []kzg4844.Proof      // blob 풀이 필요한 Proofs
+
+	// CellProofs는 EIP-7594 셀 증명으로, blob마다 kzg4844.CellsPerExtBlob개씩 순서대로 나열됩니다.
+	CellProofs []kzg4844.Proof
+
+	// Version은 네트워크 래퍼 형식입니다. BlobSidecarVersion0은 Proofs를, BlobSidecarVersion1은
+	// CellProofs를 전달합니다.
+	Version byte
+}
+
+// blob 트랜잭션 네트워크 래퍼 버전
+const (
+	BlobSidecarVersion0 = 0 // EIP-4844: [tx, blobs, commitments, proofs]
+	BlobSidecarVersion1 = 1 // EIP-7594: [tx, version, blobs, commitments, cell_proofs]
+)
+
+// ErrUnsupportedSidecarVersion은 알 수 없는 버전의 blob 사이드카를 인코딩하거나 디코딩할 때 반환됩니다.
+var ErrUnsupportedSidecarVersion = errors.New("unsupported blob sidecar version")
+
+// NewSidecarFromBlobs는 주어진 blob으로 version 형식의 사이드카를 생성하고 commitment와
+// 증명을 계산하여 채웁니다. 사이드카는 blobs 슬라이스를 복사하지 않고 그대로 사용합니다.
+func NewSidecarFromBlobs(version byte, blobs []kzg4844.Blob) (*BlobTxSidecar, error) {
+	sc := &BlobTxSidecar{Blobs: blobs, Version: version}
+	if err := sc.ComputeCommitmentsAndProofs(); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// ComputeCommitmentsAndProofs는 Blobs로부터 Commitments를 계산하고, Version에 따라 blob
+// 증명(Proofs) 또는 셀 증명(CellProofs)을 계산하여 채웁니다. 기존 값은 덮어씁니다.
+func (sc *BlobTxSidecar) ComputeCommitmentsAndProofs() error {
+	if sc.Version != BlobSidecarVersion0 && sc.Version != BlobSidecarVersion1 {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSidecarVersion, sc.Version)
+	}
+	commitments := make([]kzg4844.Commitment, len(sc.Blobs))
+	for i := range sc.Blobs {
+		commitment, err := kzg4844.BlobToCommitment(sc.Blobs[i])
+		if err != nil {
+			return fmt.Errorf("blob %d: %w", i, err)
+		}
+		commitments[i] = commitment
+	}
+	if sc.Version == BlobSidecarVersion1 {
+		if err := sc.ComputeCellProofs(); err != nil {
+			return err
+		}
+		sc.Commitments, sc.Proofs = commitments, nil
+		return nil
+	}
+	proofs := make([]kzg4844.Proof, len(sc.Blobs))
+	for i := range sc.Blobs {
+		proof, err := kzg4844.ComputeBlobProof(sc.Blobs[i], commitments[i])
+		if err != nil {
+			return fmt.Errorf("blob %d: %w", i, err)
+		}
+		proofs[i] = proof
+	}
+	sc.Commitments, sc.Proofs, sc.CellProofs = commitments, proofs, nil
+	return nil
 }
 
 // BlobHashes는 주어진 blob의 blob 해시를 계산합니다.
@@ -67,6 +129,17 @@ func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
 	return h
 }
 
+// copy는 사이드카의 깊은 복사본을 반환합니다.
+func (sc *BlobTxSidecar) copy() *BlobTxSidecar {
+	return &BlobTxSidecar{
+		Blobs:       append([]kzg4844.Blob(nil), sc.Blobs...),
+		Commitments: append([]kzg4844.Commitment(nil), sc.Commitments...),
+		Proofs:      append([]kzg4844.Proof(nil), sc.Proofs...),
+		CellProofs:  append([]kzg4844.Proof(nil), sc.CellProofs...),
+		Version:     sc.Version,
+	}
+}
+
 // encodedSize는 사이드카 요소의 RLP 크기를 계산합니다. 이는 BlobTxSidecar의 인코딩된 크기를 반환하지 않습니다.
 // 그저 tx.Size()를 위한 유틸리티 함수입니다.
 func (sc *BlobTxSidecar) encodedSize() uint64 {
@@ -77,13 +150,75 @@ func (sc *BlobTxSidecar) encodedSize() uint64 {
 	for i := range sc.Commitments {
 		commitments += rlp.BytesSize(sc.Commitments[i][:])
 	}
-	for i := range sc.Proofs {
-		proofs += rlp.BytesSize(sc.Proofs[i][:])
+	var size uint64
+	if sc.Version == BlobSidecarVersion1 {
+		for i := range sc.CellProofs {
+			proofs += rlp.BytesSize(sc.CellProofs[i][:])
+		}
+		size = uint64(rlp.IntSize(uint64(sc.Version)))
+	} else {
+		for i := range sc.Proofs {
+			proofs += rlp.BytesSize(sc.Proofs[i][:])
+		}
+	}
+	return size + rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs)
+}
+
+// ToV1은 사이드카를 EIP-7594 래퍼 형식으로 변환합니다. 셀 증명이 없으면 계산하여 채웁니다.
+func (sc *BlobTxSidecar) ToV1() error {
+	if len(sc.CellProofs) != len(sc.Blobs)*kzg4844.CellsPerExtBlob {
+		if err := sc.ComputeCellProofs(); err != nil {
+			return err
+		}
 	}
-	return rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs)
+	sc.Version = BlobSidecarVersion1
+	return nil
 }
 
-// blobTxWithBlobs는 blob이 존재할 때 트랜잭션의 인코딩에 사용됩니다.
+// ToV0은 사이드카를 EIP-4844 래퍼 형식으로 변환합니다. blob 증명이 없으면 계산하여 채웁니다.
+func (sc *BlobTxSidecar) ToV0() error {
+	if len(sc.Proofs) != len(sc.Blobs) {
+		proofs := make([]kzg4844.Proof, len(sc.Blobs))
+		for i := range sc.Blobs {
+			if i >= len(sc.Commitments) {
+				return fmt.Errorf("missing commitment for blob %d", i)
+			}
+			proof, err := kzg4844.ComputeBlobProof(sc.Blobs[i], sc.Commitments[i])
+			if err != nil {
+				return err
+			}
+			proofs[i] = proof
+		}
+		sc.Proofs = proofs
+	}
+	sc.Version = BlobSidecarVersion0
+	return nil
+}
+
+// ComputeCellProofs는 사이드카의 모든 blob에 대한 셀 증명을 계산하여 CellProofs에 채웁니다.
+func (sc *BlobTxSidecar) ComputeCellProofs() error {
+	proofs := make([]kzg4844.Proof, 0, len(sc.Blobs)*kzg4844.CellsPerExtBlob)
+	for i := range sc.Blobs {
+		cellProofs, err := kzg4844.ComputeCellProofs(sc.Blobs[i])
+		if err != nil {
+			return err
+		}
+		proofs = append(proofs, cellProofs...)
+	}
+	sc.CellProofs = proofs
+	return nil
+}
+
+// BlobCellProofs는 index번째 blob의 셀 증명을 반환합니다. 셀 증명이 없으면 nil을 반환합니다.
+func (sc *BlobTxSidecar) BlobCellProofs(index int) []kzg4844.Proof {
+	start, end := index*kzg4844.CellsPerExtBlob, (index+1)*kzg4844.CellsPerExtBlob
+	if index < 0 || end > len(sc.CellProofs) {
+		return nil
+	}
+	return sc.CellProofs[start:end]
+}
+
+// blobTxWithBlobs는 blob이 존재할 때 트랜잭션의 인코딩에 사용됩니다. (버전 0 래퍼)
 type blobTxWithBlobs struct {
 	BlobTx      *BlobTx
 	Blobs       []kzg4844.Blob
@@ -91,6 +226,125 @@ type blobTxWithBlobs struct {
 	Proofs      []kzg4844.Proof
 }
 
+// blobTxWithCellProofs는 셀 증명을 포함하는 버전 1 래퍼의 인코딩에 사용됩니다.
+type blobTxWithCellProofs struct {
+	BlobTx      *BlobTx
+	Version     byte
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	CellProofs  []kzg4844.Proof
+}
+
+// EncodeRLP는 버전 0 래퍼를 out에 씁니다. blob은 인코더 버퍼에 복사하지 않고 스트리밍합니다.
+func (w *blobTxWithBlobs) EncodeRLP(out io.Writer) error {
+	return encodeBlobTxWrapper(out, w.BlobTx, nil, w.Blobs, w.Commitments, w.Proofs)
+}
+
+// EncodeRLP는 버전 1 래퍼를 out에 씁니다. blob은 인코더 버퍼에 복사하지 않고 스트리밍합니다.
+func (w *blobTxWithCellProofs) EncodeRLP(out io.Writer) error {
+	return encodeBlobTxWrapper(out, w.BlobTx, &w.Version, w.Blobs, w.Commitments, w.CellProofs)
+}
+
+// encodeBlobTxWrapper는 네트워크 래퍼를 w에 씁니다. version이 nil이면 버전 필드가 없는 버전 0
+// 형식입니다.
+//
+// 리스트 헤더를 쓰기 위해 각 리스트의 크기를 미리 계산하므로, 128KB의 blob 내용은 rlp 인코더
+// 버퍼를 거치지 않고 사이드카의 배열에서 w로 바로 쓰입니다. w가 rlp.EncoderBuffer이면 blob은
+// EncoderBuffer.Write를 통해 한 번만 복사됩니다.
+func encodeBlobTxWrapper(w io.Writer, tx *BlobTx, version *byte, blobs []kzg4844.Blob, commitments []kzg4844.Commitment, proofs []kzg4844.Proof) error {
+	txenc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	var blobsSize, commitmentsSize, proofsSize uint64
+	for i := range blobs {
+		blobsSize += rlp.BytesSize(blobs[i][:])
+	}
+	for i := range commitments {
+		commitmentsSize += rlp.BytesSize(commitments[i][:])
+	}
+	for i := range proofs {
+		proofsSize += rlp.BytesSize(proofs[i][:])
+	}
+	size := uint64(len(txenc)) + rlp.ListSize(blobsSize) + rlp.ListSize(commitmentsSize) + rlp.ListSize(proofsSize)
+	if version != nil {
+		size += uint64(rlp.IntSize(uint64(*version)))
+	}
+
+	// blob 이전의 모든 내용: 래퍼 리스트 헤더, 트랜잭션, 버전, blob 리스트 헤더
+	buf := rlp.AppendListHeader(make([]byte, 0, len(txenc)+32), size)
+	buf = append(buf, txenc...)
+	if version != nil {
+		buf = rlp.AppendUint64(buf, uint64(*version))
+	}
+	buf = rlp.AppendListHeader(buf, blobsSize)
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	var head []byte
+	for i := range blobs {
+		head = rlp.AppendStringHeader(head[:0], uint64(len(blobs[i])))
+		if _, err := w.Write(head); err != nil {
+			return err
+		}
+		if _, err := w.Write(blobs[i][:]); err != nil {
+			return err
+		}
+	}
+	// commitment와 증명은 작으므로 한 번에 씁니다.
+	buf = rlp.AppendListHeader(buf[:0], commitmentsSize)
+	for i := range commitments {
+		buf = rlp.AppendStringHeader(buf, uint64(len(commitments[i])))
+		buf = append(buf, commitments[i][:]...)
+	}
+	buf = rlp.AppendListHeader(buf, proofsSize)
+	for i := range proofs {
+		buf = rlp.AppendStringHeader(buf, uint64(len(proofs[i])))
+		buf = append(buf, proofs[i][:]...)
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// decodeFixedList는 b의 맨 앞에 있는 고정 크기 바이트 문자열의 리스트를 디코딩하고 나머지 입력을
+// 반환합니다. 요소 수를 먼저 세어 결과 슬라이스를 한 번에 할당하고, 각 요소는 입력에서 바로
+// 복사하므로 리플렉션 디코더처럼 슬라이스가 커지면서 blob이 다시 복사되지 않습니다.
+//
+// 할당 크기가 입력 크기에 비례하도록, 리스트가 n개의 요소를 담기에 너무 짧으면 할당하기 전에
+// 거부합니다. 그렇지 않으면 1바이트짜리 요소로 채운 작은 입력이 blob 크기의 n배를 할당하게 합니다.
+func decodeFixedList[T any](b []byte, bytes func(*T) []byte) ([]T, []byte, error) {
+	content, rest, err := rlp.SplitList(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := rlp.CountValues(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	var zero T
+	if size := rlp.BytesSize(bytes(&zero)); uint64(len(content)) < uint64(n)*size {
+		return nil, nil, fmt.Errorf("rlp: input list of %d bytes too short for %d elements of %d bytes", len(content), n, len(bytes(&zero)))
+	}
+	items := make([]T, n)
+	for i := range items {
+		dst := bytes(&items[i])
+		str, tail, err := rlp.SplitString(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(str) != len(dst) {
+			return nil, nil, fmt.Errorf("rlp: input string has wrong size %d, want %d", len(str), len(dst))
+		}
+		copy(dst, str)
+		content = tail
+	}
+	return items, rest, nil
+}
+
+func blobBytes(b *kzg4844.Blob) []byte             { return b[:] }
+func commitmentBytes(c *kzg4844.Commitment) []byte { return c[:] }
+func proofBytes(p *kzg4844.Proof) []byte           { return p[:] }
+
 // copy는 트랜잭션 데이터의 깊은 복사본을 생성하여 반환합니다.
 func (tx *BlobTx) copy() TxData {
 	cpy := &BlobTx{
@@ -138,11 +392,7 @@ func (tx *BlobTx) copy() TxData {
 		cpy.S.Set(tx.S)
 	}
 	if tx.Sidecar != nil {
-		cpy.Sidecar = &BlobTxSidecar{
-			Blobs:       append([]kzg4844.Blob(nil), tx.Sidecar.Blobs...),
-			Commitments: append([]kzg4844.Commitment(nil), tx.Sidecar.Commitments...),
-			Proofs:      append([]kzg4844.Proof(nil), tx.Sidecar.Proofs...),
-		}
+		cpy.Sidecar = tx.Sidecar.copy()
 	}
 	return cpy
 }
@@ -193,25 +443,28 @@ func (tx *BlobTx) encode(b *bytes.Buffer) error {
 	if tx.Sidecar == nil {
 		return rlp.Encode(b, tx)
 	}
-	inner := &blobTxWithBlobs{
-		BlobTx:      tx,
-		Blobs:       tx.Sidecar.Blobs,
-		Commitments: tx.Sidecar.Commitments,
-		Proofs:      tx.Sidecar.Proofs,
+	switch tx.Sidecar.Version {
+	case BlobSidecarVersion0:
+		return encodeBlobTxWrapper(b, tx, nil, tx.Sidecar.Blobs, tx.Sidecar.Commitments, tx.Sidecar.Proofs)
+	case BlobSidecarVersion1:
+		return encodeBlobTxWrapper(b, tx, &tx.Sidecar.Version, tx.Sidecar.Blobs, tx.Sidecar.Commitments, tx.Sidecar.CellProofs)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedSidecarVersion, tx.Sidecar.Version)
 	}
-	return rlp.Encode(b, inner)
 }
 
 func (tx *BlobTx) decode(input []byte) error {
-	// 두 가지 형식을 지원해야 합니다: blob을 포함하는 tx의 네트워크 프로토콜 인코딩 또는 blob이 없는 정규 인코딩.
+	// 세 가지 형식을 지원해야 합니다: blob이 없는 정규 인코딩과, blob을 포함하는 tx의 두 가지 네트워크
+	// 프로토콜 인코딩(버전 0, 버전 1).
 	//
-	// 두 인코딩은 입력 목록의 첫 번째 요소가 리스트인지 확인하여 구분할 수 있습니다.
+	// 정규 인코딩과 네트워크 인코딩은 입력 목록의 첫 번째 요소가 리스트인지 확인하여 구분할 수 있습니다.
+	// 네트워크 인코딩에서는 두 번째 요소가 blob 리스트이면 버전 0, 문자열(버전 바이트)이면 버전 1입니다.
 
-	outerList, _, err := rlp.SplitList(input)
+	outerList, trailing, err := rlp.SplitList(input)
 	if err != nil {
 		return err
 	}
-	firstElemKind, _, _, err := rlp.Split(outerList)
+	firstElemKind, _, rest, err := rlp.Split(outerList)
 	if err != nil {
 		return err
 	}
@@ -219,17 +472,49 @@ func (tx *BlobTx) decode(input []byte) error {
 	if firstElemKind != rlp.List {
 		return rlp.DecodeBytes(input, tx)
 	}
-	// blob을 포함하는 tx입니다.
-	var inner blobTxWithBlobs
-	if err := rlp.DecodeBytes(input, &inner); err != nil {
+	// blob을 포함하는 tx입니다. 사이드카 리스트는 리플렉션 없이 입력에서 바로 디코딩합니다.
+	if len(trailing) > 0 {
+		return rlp.ErrMoreThanOneValue
+	}
+	var inner BlobTx
+	if err := rlp.DecodeBytes(outerList[:len(outerList)-len(rest)], &inner); err != nil {
 		return err
 	}
-	*tx = *inner.BlobTx
-	tx.Sidecar = &BlobTxSidecar{
-		Blobs:       inner.Blobs,
-		Commitments: inner.Commitments,
-		Proofs:      inner.Proofs,
+	secondElemKind, _, _, err := rlp.Split(rest)
+	if err != nil {
+		return err
+	}
+	sc := &BlobTxSidecar{Version: BlobSidecarVersion0}
+	if secondElemKind != rlp.List {
+		version, tail, err := rlp.SplitUint64(rest)
+		if err != nil {
+			return err
+		}
+		if version != BlobSidecarVersion1 {
+			return fmt.Errorf("%w: %d", ErrUnsupportedSidecarVersion, version)
+		}
+		sc.Version, rest = BlobSidecarVersion1, tail
+	}
+	if sc.Blobs, rest, err = decodeFixedList(rest, blobBytes); err != nil {
+		return err
+	}
+	if sc.Commitments, rest, err = decodeFixedList(rest, commitmentBytes); err != nil {
+		return err
+	}
+	proofs, rest, err := decodeFixedList(rest, proofBytes)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("rlp: input list has too many elements for blob transaction network wrapper")
+	}
+	if sc.Version == BlobSidecarVersion1 {
+		sc.CellProofs = proofs
+	} else {
+		sc.Proofs = proofs
 	}
+	*tx = inner
+	tx.Sidecar = sc
 	return nil
 }
 
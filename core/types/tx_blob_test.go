@@ -1,12 +1,16 @@
 package types
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"errors"
+	"runtime"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/holiman/uint256"
 )
 
@@ -28,6 +32,48 @@ func TestBlobTxHashing(t *testing.T) {
 	}
 }
 
+func TestBlobTxEqualContent(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	withBlobs := createEmptyBlobTx(key, true)
+	stripped := withBlobs.WithoutBlobTxSidecar()
+
+	enc, err := withBlobs.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := withBlobs.HashWithSidecar(); h != crypto.Keccak256Hash(enc) {
+		t.Fatal("wrong network wrapper hash:", h)
+	}
+	if h := stripped.HashWithSidecar(); h != stripped.Hash() {
+		t.Fatal("hash with sidecar differs from hash of tx without sidecar:", h)
+	}
+
+	if !withBlobs.EqualContent(createEmptyBlobTx(key, true)) {
+		t.Fatal("identical transactions not equal")
+	}
+	if withBlobs.EqualContent(stripped) || stripped.EqualContent(withBlobs) {
+		t.Fatal("transaction with sidecar equal to transaction without")
+	}
+	if !stripped.EqualContent(createEmptyBlobTx(key, false)) {
+		t.Fatal("identical transactions without sidecar not equal")
+	}
+	if withBlobs.EqualContent(nil) {
+		t.Fatal("transaction equal to nil")
+	}
+
+	// Converting the sidecar to another version changes the network encoding.
+	var converted Transaction
+	if err := converted.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if err := converted.BlobTxSidecar().ToV1(); err != nil {
+		t.Fatal(err)
+	}
+	if converted.EqualContent(withBlobs) {
+		t.Fatal("transactions with different sidecar versions are equal")
+	}
+}
+
 // This test verifies that tx.Size() takes BlobTxSidecar into account.
 func TestBlobTxSize(t *testing.T) {
 	key, _ := crypto.GenerateKey()
@@ -58,6 +104,241 @@ func TestBlobTxSize(t *testing.T) {
 	}
 }
 
+// This test verifies that both network wrapper versions survive encoding and are
+// accounted for in tx.Size().
+func TestBlobTxSidecarVersions(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	v0 := createEmptyBlobTx(key, true)
+
+	inner := v0.inner.copy().(*BlobTx)
+	inner.Sidecar.Version = BlobSidecarVersion1
+	inner.Sidecar.CellProofs = make([]kzg4844.Proof, kzg4844.CellsPerExtBlob)
+	for i := range inner.Sidecar.CellProofs {
+		inner.Sidecar.CellProofs[i][0] = byte(i)
+	}
+	v1 := NewTx(inner)
+
+	enc, err := v1.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := v1.Size(); size != uint64(len(enc)) {
+		t.Error("wrong size of version 1 wrapper:", size, "encoded length:", len(enc))
+	}
+	var dec Transaction
+	if err := dec.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	sc := dec.BlobTxSidecar()
+	if sc.Version != BlobSidecarVersion1 || sc.Proofs != nil {
+		t.Fatalf("wrong version 1 sidecar: version %d, %d blob proofs", sc.Version, len(sc.Proofs))
+	}
+	if len(sc.CellProofs) != kzg4844.CellsPerExtBlob {
+		t.Fatalf("wrong number of decoded cell proofs: %d", len(sc.CellProofs))
+	}
+	if proofs := sc.BlobCellProofs(0); proofs[5][0] != 5 {
+		t.Fatal("cell proofs mismatch after decoding")
+	}
+	if proofs := sc.BlobCellProofs(1); proofs != nil {
+		t.Fatal("cell proofs returned for missing blob")
+	}
+	if dec.Hash() != v0.Hash() {
+		t.Fatal("wrapper version changed the transaction hash")
+	}
+
+	// Converting back to version 0 restores the blob proofs.
+	if err := sc.ToV0(); err != nil {
+		t.Fatal(err)
+	}
+	if sc.Version != BlobSidecarVersion0 || len(sc.Proofs) != 1 || sc.Proofs[0] != emptyBlobProof {
+		t.Fatal("wrong blob proofs after conversion to version 0")
+	}
+
+	// A version 0 wrapper does not carry cell proofs.
+	enc, _ = v0.MarshalBinary()
+	if size := v0.Size(); size != uint64(len(enc)) {
+		t.Error("wrong size of version 0 wrapper:", size, "encoded length:", len(enc))
+	}
+	if err := dec.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if sc := dec.BlobTxSidecar(); sc.Version != BlobSidecarVersion0 || sc.CellProofs != nil {
+		t.Fatal("wrong version 0 sidecar")
+	}
+
+	// Unknown versions are rejected in both directions.
+	inner.Sidecar.Version = 2
+	if _, err := NewTx(inner).MarshalBinary(); !errors.Is(err, ErrUnsupportedSidecarVersion) {
+		t.Fatalf("wrong error encoding unknown version: %v", err)
+	}
+	unknown, _ := rlp.EncodeToBytes(&blobTxWithCellProofs{BlobTx: inner.withoutSidecar(), Version: 2})
+	if err := dec.UnmarshalBinary(append([]byte{BlobTxType}, unknown...)); !errors.Is(err, ErrUnsupportedSidecarVersion) {
+		t.Fatalf("wrong error decoding unknown version: %v", err)
+	}
+}
+
+func TestNewSidecarFromBlobs(t *testing.T) {
+	sc, err := NewSidecarFromBlobs(BlobSidecarVersion0, []kzg4844.Blob{emptyBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Commitments) != 1 || sc.Commitments[0] != emptyBlobCommit {
+		t.Fatal("wrong commitments")
+	}
+	if len(sc.Proofs) != 1 || sc.Proofs[0] != emptyBlobProof || sc.CellProofs != nil {
+		t.Fatal("wrong version 0 proofs")
+	}
+
+	sc, err = NewSidecarFromBlobs(BlobSidecarVersion1, []kzg4844.Blob{emptyBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Commitments) != 1 || sc.Commitments[0] != emptyBlobCommit {
+		t.Fatal("wrong commitments")
+	}
+	if len(sc.CellProofs) != kzg4844.CellsPerExtBlob || sc.Proofs != nil {
+		t.Fatal("wrong version 1 proofs")
+	}
+
+	// Blobs with non-canonical field elements are rejected.
+	var invalid kzg4844.Blob
+	for i := range invalid {
+		invalid[i] = 0xff
+	}
+	if _, err := NewSidecarFromBlobs(BlobSidecarVersion0, []kzg4844.Blob{emptyBlob, invalid}); err == nil {
+		t.Fatal("no error for invalid blob")
+	}
+	if _, err := NewSidecarFromBlobs(2, nil); !errors.Is(err, ErrUnsupportedSidecarVersion) {
+		t.Fatalf("wrong error for unknown version: %v", err)
+	}
+}
+
+// This test checks that the streaming wrapper encoder produces the same output as
+// plain reflection-based encoding, and that the decoder reads it back.
+func TestBlobTxWrapperEncoding(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	inner := createEmptyBlobTx(key, true).inner.copy().(*BlobTx)
+	sc := inner.Sidecar
+	for i := 1; i < 3; i++ {
+		var blob kzg4844.Blob
+		blob[0], blob[len(blob)-1] = byte(i), byte(i)
+		sc.Blobs = append(sc.Blobs, blob)
+		sc.Commitments = append(sc.Commitments, kzg4844.Commitment{byte(i)})
+		sc.Proofs = append(sc.Proofs, kzg4844.Proof{byte(i)})
+	}
+	// The reference types have the same layout as the wrappers, but no EncodeRLP method.
+	type refV0 struct {
+		BlobTx      *BlobTx
+		Blobs       []kzg4844.Blob
+		Commitments []kzg4844.Commitment
+		Proofs      []kzg4844.Proof
+	}
+	type refV1 struct {
+		BlobTx      *BlobTx
+		Version     byte
+		Blobs       []kzg4844.Blob
+		Commitments []kzg4844.Commitment
+		CellProofs  []kzg4844.Proof
+	}
+	check := func(name string, ref interface{}) {
+		want, err := rlp.EncodeToBytes(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := inner.encode(&buf); err != nil {
+			t.Fatalf("%s: encode error: %v", name, err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Fatalf("%s: encoding mismatch", name)
+		}
+		var dec BlobTx
+		if err := dec.decode(want); err != nil {
+			t.Fatalf("%s: decode error: %v", name, err)
+		}
+		if dec.Sidecar.Version != sc.Version || len(dec.Sidecar.Blobs) != len(sc.Blobs) || dec.Sidecar.Blobs[2] != sc.Blobs[2] {
+			t.Fatalf("%s: sidecar mismatch after decoding", name)
+		}
+		if dec.Sidecar.Commitments[1] != sc.Commitments[1] || cap(dec.Sidecar.Blobs) != len(sc.Blobs) {
+			t.Fatalf("%s: sidecar lists mismatch after decoding", name)
+		}
+		// Truncated and extended inputs must be rejected.
+		if err := new(BlobTx).decode(want[:len(want)-1]); err == nil {
+			t.Errorf("%s: no error for truncated input", name)
+		}
+		if err := new(BlobTx).decode(append(want, 0x80)); err == nil {
+			t.Errorf("%s: no error for trailing data", name)
+		}
+	}
+	check("version 0", &refV0{inner.withoutSidecar(), sc.Blobs, sc.Commitments, sc.Proofs})
+
+	sc.Version = BlobSidecarVersion1
+	sc.CellProofs = make([]kzg4844.Proof, len(sc.Blobs)*kzg4844.CellsPerExtBlob)
+	check("version 1", &refV1{inner.withoutSidecar(), sc.Version, sc.Blobs, sc.Commitments, sc.CellProofs})
+
+	// A blob of the wrong size is rejected.
+	bad, _ := rlp.EncodeToBytes(&struct {
+		BlobTx      *BlobTx
+		Version     byte
+		Blobs       [][]byte
+		Commitments []kzg4844.Commitment
+		CellProofs  []kzg4844.Proof
+	}{inner.withoutSidecar(), sc.Version, [][]byte{make([]byte, 100)}, nil, nil})
+	if err := new(BlobTx).decode(bad); err == nil {
+		t.Error("no error for wrong blob size")
+	}
+
+	// A list of many short items is rejected before the blobs are allocated.
+	bad, _ = rlp.EncodeToBytes(&struct {
+		BlobTx      *BlobTx
+		Version     byte
+		Blobs       [][]byte
+		Commitments []kzg4844.Commitment
+		CellProofs  []kzg4844.Proof
+	}{inner.withoutSidecar(), sc.Version, make([][]byte, 1024), nil, nil})
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	before := stats.TotalAlloc
+	if err := new(BlobTx).decode(bad); err == nil {
+		t.Error("no error for short blobs")
+	}
+	runtime.ReadMemStats(&stats)
+	if alloc := stats.TotalAlloc - before; alloc > 1<<20 {
+		t.Errorf("decoding short blobs allocated %d bytes", alloc)
+	}
+}
+
+func BenchmarkBlobTxWrapperEncoding(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	inner := createEmptyBlobTx(key, true).inner.copy().(*BlobTx)
+	inner.Sidecar.Blobs = make([]kzg4844.Blob, 6)
+	inner.Sidecar.Commitments = make([]kzg4844.Commitment, 6)
+	inner.Sidecar.Proofs = make([]kzg4844.Proof, 6)
+	var buf bytes.Buffer
+	if err := inner.encode(&buf); err != nil {
+		b.Fatal(err)
+	}
+	enc := common.CopyBytes(buf.Bytes())
+
+	b.Run("encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			if err := inner.encode(&buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := new(BlobTx).decode(enc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 var (
 	emptyBlob          = kzg4844.Blob{}
 	emptyBlobCommit, _ = kzg4844.BlobToCommitment(emptyBlob)
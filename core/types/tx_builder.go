@@ -0,0 +1,267 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+var (
+	ErrMissingChainID         = errors.New("missing chain ID")
+	ErrMissingGasPrice        = errors.New("missing gas price")
+	ErrMissingFeeCap          = errors.New("missing max fee per gas")
+	ErrMissingTipCap          = errors.New("missing max priority fee per gas")
+	ErrFeeCapBelowTipCap      = errors.New("max fee per gas less than max priority fee per gas")
+	ErrNegativeTxField        = errors.New("negative value in transaction field")
+	ErrTxFieldTooLarge        = errors.New("transaction field exceeds 256 bits")
+	ErrMissingBlobFeeCap      = errors.New("missing max fee per blob gas")
+	ErrMissingBlobHashes      = errors.New("blob transaction without blob hashes")
+	ErrInvalidBlobHashVersion = errors.New("invalid blob hash version")
+	ErrBlobSidecarMismatch    = errors.New("blob sidecar does not match blob hashes")
+)
+
+// AccessListTxOpts는 NewAccessListTx로 EIP-2930 트랜잭션을 생성할 때 사용하는 필드입니다.
+type AccessListTxOpts struct {
+	ChainID    *big.Int        // 필수
+	Nonce      uint64          // 발신자 계정의 nonce
+	GasPrice   *big.Int        // 필수
+	Gas        uint64          // 가스 한도
+	To         *common.Address // nil이면 컨트랙트 생성 트랜잭션
+	Value      *big.Int        // nil이면 0
+	Data       []byte          // 호출 데이터 또는 생성자의 바이트코드
+	AccessList AccessList      // 접근 목록
+}
+
+// NewAccessListTx는 필수 필드를 검증한 후 서명되지 않은 AccessListTx를 생성합니다.
+func NewAccessListTx(opts AccessListTxOpts) (*AccessListTx, error) {
+	if err := checkChainID(opts.ChainID); err != nil {
+		return nil, err
+	}
+	if opts.GasPrice == nil {
+		return nil, ErrMissingGasPrice
+	}
+	if err := checkBigField("gas price", opts.GasPrice); err != nil {
+		return nil, err
+	}
+	value, err := checkValue(opts.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessListTx{
+		ChainID:    new(big.Int).Set(opts.ChainID),
+		Nonce:      opts.Nonce,
+		GasPrice:   new(big.Int).Set(opts.GasPrice),
+		Gas:        opts.Gas,
+		To:         copyAddressPtr(opts.To),
+		Value:      value,
+		Data:       common.CopyBytes(opts.Data),
+		AccessList: copyAccessList(opts.AccessList),
+	}, nil
+}
+
+// DynamicFeeTxOpts는 NewDynamicFeeTx로 EIP-1559 트랜잭션을 생성할 때 사용하는 필드입니다.
+type DynamicFeeTxOpts struct {
+	ChainID    *big.Int        // 필수
+	Nonce      uint64          // 발신자 계정의 nonce
+	GasTipCap  *big.Int        // 필수, a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *big.Int        // 필수, a.k.a. maxFeePerGas
+	Gas        uint64          // 가스 한도
+	To         *common.Address // nil이면 컨트랙트 생성 트랜잭션
+	Value      *big.Int        // nil이면 0
+	Data       []byte          // 호출 데이터 또는 생성자의 바이트코드
+	AccessList AccessList      // 접근 목록
+}
+
+// NewDynamicFeeTx는 필수 필드를 검증한 후 서명되지 않은 DynamicFeeTx를 생성합니다.
+// 수수료 상한은 우선순위 수수료 상한 이상이어야 합니다.
+func NewDynamicFeeTx(opts DynamicFeeTxOpts) (*DynamicFeeTx, error) {
+	if err := checkChainID(opts.ChainID); err != nil {
+		return nil, err
+	}
+	if opts.GasTipCap == nil {
+		return nil, ErrMissingTipCap
+	}
+	if opts.GasFeeCap == nil {
+		return nil, ErrMissingFeeCap
+	}
+	if err := checkBigField("max priority fee per gas", opts.GasTipCap); err != nil {
+		return nil, err
+	}
+	if err := checkBigField("max fee per gas", opts.GasFeeCap); err != nil {
+		return nil, err
+	}
+	if opts.GasFeeCap.Cmp(opts.GasTipCap) < 0 {
+		return nil, fmt.Errorf("%w: fee cap %v, tip cap %v", ErrFeeCapBelowTipCap, opts.GasFeeCap, opts.GasTipCap)
+	}
+	value, err := checkValue(opts.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &DynamicFeeTx{
+		ChainID:    new(big.Int).Set(opts.ChainID),
+		Nonce:      opts.Nonce,
+		GasTipCap:  new(big.Int).Set(opts.GasTipCap),
+		GasFeeCap:  new(big.Int).Set(opts.GasFeeCap),
+		Gas:        opts.Gas,
+		To:         copyAddressPtr(opts.To),
+		Value:      value,
+		Data:       common.CopyBytes(opts.Data),
+		AccessList: copyAccessList(opts.AccessList),
+	}, nil
+}
+
+// BlobTxOpts는 NewBlobTx로 EIP-4844 트랜잭션을 생성할 때 사용하는 필드입니다.
+type BlobTxOpts struct {
+	ChainID    *uint256.Int   // 필수
+	Nonce      uint64         // 발신자 계정의 nonce
+	GasTipCap  *uint256.Int   // 필수, a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *uint256.Int   // 필수, a.k.a. maxFeePerGas
+	Gas        uint64         // 가스 한도
+	To         common.Address // blob 트랜잭션은 컨트랙트를 생성할 수 없습니다
+	Value      *uint256.Int   // nil이면 0
+	Data       []byte         // 호출 데이터
+	AccessList AccessList     // 접근 목록
+	BlobFeeCap *uint256.Int   // 필수, a.k.a. maxFeePerBlobGas
+	BlobHashes []common.Hash  // 필수, 버전이 지정된 blob 해시
+	Sidecar    *BlobTxSidecar // 선택, 주어지면 BlobHashes와 일치해야 합니다
+}
+
+// NewBlobTx는 필수 필드를 검증한 후 서명되지 않은 BlobTx를 생성합니다. 모든 blob 해시는
+// params.BlobTxHashVersion 버전이어야 하며, 사이드카가 주어지면 그 commitment의 해시와
+// 일치해야 합니다. 사이드카를 포함한 모든 입력은 복사되므로, 생성 후 opts를 수정해도 트랜잭션에
+// 영향을 주지 않습니다.
+func NewBlobTx(opts BlobTxOpts) (*BlobTx, error) {
+	if opts.ChainID == nil {
+		return nil, ErrMissingChainID
+	}
+	if opts.GasTipCap == nil {
+		return nil, ErrMissingTipCap
+	}
+	if opts.GasFeeCap == nil {
+		return nil, ErrMissingFeeCap
+	}
+	if opts.GasFeeCap.Lt(opts.GasTipCap) {
+		return nil, fmt.Errorf("%w: fee cap %v, tip cap %v", ErrFeeCapBelowTipCap, opts.GasFeeCap, opts.GasTipCap)
+	}
+	if opts.BlobFeeCap == nil {
+		return nil, ErrMissingBlobFeeCap
+	}
+	if len(opts.BlobHashes) == 0 {
+		return nil, ErrMissingBlobHashes
+	}
+	for i, hash := range opts.BlobHashes {
+		if hash[0] != params.BlobTxHashVersion {
+			return nil, fmt.Errorf("%w: blob hash %d has version %#x, want %#x", ErrInvalidBlobHashVersion, i, hash[0], params.BlobTxHashVersion)
+		}
+	}
+	if sc := opts.Sidecar; sc != nil {
+		// 버전 0 사이드카는 blob마다 하나의 증명을, 버전 1 사이드카는 blob마다
+		// kzg4844.CellsPerExtBlob개의 셀 증명을 가집니다.
+		var proofs, wantProofs int
+		switch sc.Version {
+		case BlobSidecarVersion0:
+			proofs, wantProofs = len(sc.Proofs), len(opts.BlobHashes)
+		case BlobSidecarVersion1:
+			proofs, wantProofs = len(sc.CellProofs), len(opts.BlobHashes)*kzg4844.CellsPerExtBlob
+		default:
+			return nil, fmt.Errorf("%w: %d", ErrUnsupportedSidecarVersion, sc.Version)
+		}
+		if len(sc.Blobs) != len(opts.BlobHashes) || len(sc.Commitments) != len(opts.BlobHashes) || proofs != wantProofs {
+			return nil, fmt.Errorf("%w: %d hashes, %d blobs, %d commitments, %d proofs (want %d)", ErrBlobSidecarMismatch,
+				len(opts.BlobHashes), len(sc.Blobs), len(sc.Commitments), proofs, wantProofs)
+		}
+		for i, hash := range sc.BlobHashes() {
+			if hash != opts.BlobHashes[i] {
+				return nil, fmt.Errorf("%w: commitment %d hashes to %x, want %x", ErrBlobSidecarMismatch, i, hash, opts.BlobHashes[i])
+			}
+		}
+	}
+	value := new(uint256.Int)
+	if opts.Value != nil {
+		value.Set(opts.Value)
+	}
+	var sidecar *BlobTxSidecar
+	if opts.Sidecar != nil {
+		sidecar = opts.Sidecar.copy()
+	}
+	return &BlobTx{
+		ChainID:    new(uint256.Int).Set(opts.ChainID),
+		Nonce:      opts.Nonce,
+		GasTipCap:  new(uint256.Int).Set(opts.GasTipCap),
+		GasFeeCap:  new(uint256.Int).Set(opts.GasFeeCap),
+		Gas:        opts.Gas,
+		To:         opts.To,
+		Value:      value,
+		Data:       common.CopyBytes(opts.Data),
+		AccessList: copyAccessList(opts.AccessList),
+		BlobFeeCap: new(uint256.Int).Set(opts.BlobFeeCap),
+		BlobHashes: append([]common.Hash(nil), opts.BlobHashes...),
+		Sidecar:    sidecar,
+	}, nil
+}
+
+// checkChainID는 체인 ID가 존재하고 유효한 범위에 있는지 확인합니다.
+func checkChainID(chainID *big.Int) error {
+	if chainID == nil {
+		return ErrMissingChainID
+	}
+	return checkBigField("chain ID", chainID)
+}
+
+// checkValue는 전송액을 검증하고 복사본을 반환합니다. nil은 0으로 취급됩니다.
+func checkValue(value *big.Int) (*big.Int, error) {
+	if value == nil {
+		return new(big.Int), nil
+	}
+	if err := checkBigField("value", value); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(value), nil
+}
+
+// checkBigField는 필드 값이 음수가 아니고 256비트에 들어가는지 확인합니다.
+func checkBigField(name string, v *big.Int) error {
+	if v.Sign() < 0 {
+		return fmt.Errorf("%w: %s %v", ErrNegativeTxField, name, v)
+	}
+	if v.BitLen() > 256 {
+		return fmt.Errorf("%w: %s bit length %d", ErrTxFieldTooLarge, name, v.BitLen())
+	}
+	return nil
+}
+
+// copyAccessList는 접근 목록의 깊은 복사본을 반환합니다.
+func copyAccessList(list AccessList) AccessList {
+	if list == nil {
+		return nil
+	}
+	cpy := make(AccessList, len(list))
+	for i, tuple := range list {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]common.Hash(nil), tuple.StorageKeys...),
+		}
+	}
+	return cpy
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+)
+
+func TestNewAccessListTx(t *testing.T) {
+	if _, err := NewAccessListTx(AccessListTxOpts{GasPrice: big.NewInt(1)}); !errors.Is(err, ErrMissingChainID) {
+		t.Fatalf("wrong error for missing chain ID: %v", err)
+	}
+	if _, err := NewAccessListTx(AccessListTxOpts{ChainID: big.NewInt(1)}); !errors.Is(err, ErrMissingGasPrice) {
+		t.Fatalf("wrong error for missing gas price: %v", err)
+	}
+	inner, err := NewAccessListTx(AccessListTxOpts{ChainID: big.NewInt(1), GasPrice: big.NewInt(1), Gas: 21000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.Value == nil || inner.Value.Sign() != 0 {
+		t.Fatalf("nil value not defaulted to zero: %v", inner.Value)
+	}
+}
+
+func TestNewDynamicFeeTx(t *testing.T) {
+	to := common.Address{0x01}
+	valid := DynamicFeeTxOpts{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+	}
+	tests := []struct {
+		name   string
+		modify func(*DynamicFeeTxOpts)
+		err    error
+	}{
+		{"missing chain ID", func(o *DynamicFeeTxOpts) { o.ChainID = nil }, ErrMissingChainID},
+		{"missing tip cap", func(o *DynamicFeeTxOpts) { o.GasTipCap = nil }, ErrMissingTipCap},
+		{"missing fee cap", func(o *DynamicFeeTxOpts) { o.GasFeeCap = nil }, ErrMissingFeeCap},
+		{"fee cap below tip cap", func(o *DynamicFeeTxOpts) { o.GasTipCap = big.NewInt(11) }, ErrFeeCapBelowTipCap},
+		{"negative value", func(o *DynamicFeeTxOpts) { o.Value = big.NewInt(-1) }, ErrNegativeTxField},
+		{"huge fee cap", func(o *DynamicFeeTxOpts) { o.GasFeeCap = new(big.Int).Lsh(big.NewInt(1), 256) }, ErrTxFieldTooLarge},
+	}
+	for _, test := range tests {
+		opts := valid
+		test.modify(&opts)
+		if _, err := NewDynamicFeeTx(opts); !errors.Is(err, test.err) {
+			t.Errorf("%s: wrong error: have %v, want %v", test.name, err, test.err)
+		}
+	}
+	inner, err := NewDynamicFeeTx(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The built transaction must be signable as is.
+	key, _ := crypto.GenerateKey()
+	tx, err := SignNewTx(key, LatestSignerForChainID(valid.ChainID), inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.GasFeeCap().Cmp(valid.GasFeeCap) != 0 || *tx.To() != to || tx.Value().Cmp(valid.Value) != 0 {
+		t.Fatal("transaction fields mismatch")
+	}
+}
+
+func TestNewBlobTx(t *testing.T) {
+	sidecar := &BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{emptyBlob},
+		Commitments: []kzg4844.Commitment{emptyBlobCommit},
+		Proofs:      []kzg4844.Proof{emptyBlobProof},
+	}
+	valid := BlobTxOpts{
+		ChainID:    uint256.NewInt(1),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(10),
+		Gas:        21000,
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	}
+	tests := []struct {
+		name   string
+		modify func(*BlobTxOpts)
+		err    error
+	}{
+		{"missing chain ID", func(o *BlobTxOpts) { o.ChainID = nil }, ErrMissingChainID},
+		{"fee cap below tip cap", func(o *BlobTxOpts) { o.GasTipCap = uint256.NewInt(11) }, ErrFeeCapBelowTipCap},
+		{"missing blob fee cap", func(o *BlobTxOpts) { o.BlobFeeCap = nil }, ErrMissingBlobFeeCap},
+		{"missing blob hashes", func(o *BlobTxOpts) { o.BlobHashes, o.Sidecar = nil, nil }, ErrMissingBlobHashes},
+		{"wrong hash version", func(o *BlobTxOpts) { o.BlobHashes, o.Sidecar = []common.Hash{{0x02}}, nil }, ErrInvalidBlobHashVersion},
+		{"sidecar mismatch", func(o *BlobTxOpts) { o.BlobHashes = []common.Hash{{0x01}} }, ErrBlobSidecarMismatch},
+		{"sidecar length mismatch", func(o *BlobTxOpts) { o.BlobHashes = append(o.BlobHashes, o.BlobHashes[0]) }, ErrBlobSidecarMismatch},
+		{"v1 sidecar with blob proofs", func(o *BlobTxOpts) { o.Sidecar = withSidecarVersion(sidecar, BlobSidecarVersion1) }, ErrBlobSidecarMismatch},
+		{"unknown sidecar version", func(o *BlobTxOpts) { o.Sidecar = withSidecarVersion(sidecar, 2) }, ErrUnsupportedSidecarVersion},
+	}
+	for _, test := range tests {
+		opts := valid
+		test.modify(&opts)
+		if _, err := NewBlobTx(opts); !errors.Is(err, test.err) {
+			t.Errorf("%s: wrong error: have %v, want %v", test.name, err, test.err)
+		}
+	}
+	inner, err := NewBlobTx(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := crypto.GenerateKey()
+	if _, err := SignNewTx(key, NewCancunSigner(valid.ChainID.ToBig()), inner); err != nil {
+		t.Fatal(err)
+	}
+	// The sidecar is copied like every other input.
+	sidecar.Proofs[0][0]++
+	sidecar.Blobs = append(sidecar.Blobs, kzg4844.Blob{})
+	if inner.Sidecar == sidecar || inner.Sidecar.Proofs[0] != emptyBlobProof || len(inner.Sidecar.Blobs) != 1 {
+		t.Fatal("sidecar shared with options")
+	}
+	sidecar.Proofs[0][0]--
+	sidecar.Blobs = sidecar.Blobs[:1]
+
+	// Version 1 sidecars carry cell proofs instead of blob proofs.
+	v1 := withSidecarVersion(sidecar, BlobSidecarVersion1)
+	v1.Proofs, v1.CellProofs = nil, make([]kzg4844.Proof, kzg4844.CellsPerExtBlob)
+	opts := valid
+	opts.Sidecar = v1
+	if _, err := NewBlobTx(opts); err != nil {
+		t.Fatalf("v1 sidecar rejected: %v", err)
+	}
+}
+
+func withSidecarVersion(sc *BlobTxSidecar, version byte) *BlobTxSidecar {
+	cpy := *sc
+	cpy.Version = version
+	return &cpy
+}
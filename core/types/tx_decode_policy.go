@@ -0,0 +1,285 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	ErrPolicyTxType     = errors.New("transaction type not allowed by decode policy")
+	ErrPolicyDataSize   = errors.New("transaction data exceeds decode policy limit")
+	ErrPolicyAccessList = errors.New("access list exceeds decode policy limit")
+	ErrPolicyBlobCount  = errors.New("blob count exceeds decode policy limit")
+	ErrPolicyTxSize     = errors.New("transaction size exceeds decode policy limit")
+)
+
+// DecodePolicy는 신뢰할 수 없는 입력에서 트랜잭션을 디코딩할 때 적용되는 제한을 정의합니다.
+//
+// 제한은 인코딩된 바이트를 직접 검사하여 트랜잭션 본문이 디코딩되기 전에 강제됩니다.
+// 0 값 필드는 해당 제한이 없음을 의미합니다.
+//
+// DecodeRLPWithPolicy는 스트림에서 본문을 읽어야 나머지 제한을 검사할 수 있으므로, 신뢰할 수
+// 없는 스트림에서 읽을 때는 MaxSize를 설정하여 본문을 읽기 전에 크기를 제한해야 합니다.
+type DecodePolicy struct {
+	AllowedTypes         []byte // 허용되는 트랜잭션 타입 (nil = 모든 타입 허용)
+	MaxSize              uint64 // 인코딩된 트랜잭션(blob 사이드카 포함)의 최대 바이트 크기
+	MaxDataSize          uint64 // 입력 데이터(calldata)의 최대 바이트 크기
+	MaxAccessListEntries uint64 // 액세스 목록의 최대 항목 수 (주소와 스토리지 키 개수의 합)
+	MaxBlobs             uint64 // blob 해시 및 사이드카 blob의 최대 개수
+	Strict               bool   // 트랜잭션 본문 뒤에 남은 바이트를 거부 (UnmarshalBinaryStrict 참조)
+}
+
+// PolicyViolationError는 트랜잭션이 DecodePolicy를 위반했을 때 반환됩니다.
+// errors.Is를 사용하여 위반된 규칙(ErrPolicy*)을 확인할 수 있습니다.
+type PolicyViolationError struct {
+	Err   error  // 위반된 규칙
+	Have  uint64 // 입력에서 발견된 값 (타입 위반의 경우 트랜잭션 타입)
+	Limit uint64 // 정책에 설정된 제한 값
+}
+
+func (err *PolicyViolationError) Error() string {
+	if err.Err == ErrPolicyTxType {
+		return fmt.Sprintf("%v: type %d", err.Err, err.Have)
+	}
+	return fmt.Sprintf("%v: have %d, limit %d", err.Err, err.Have, err.Limit)
+}
+
+// Unwrap은 위반된 규칙에 해당하는 오류를 반환합니다.
+func (err *PolicyViolationError) Unwrap() error {
+	return err.Err
+}
+
+// DecodeWithPolicy는 트랜잭션의 정규 인코딩을 디코딩합니다. 입력 형식은 UnmarshalBinary와 동일하며,
+// 디코딩 전에 policy의 제한을 검사합니다. policy가 nil이면 제한 없이 디코딩합니다.
+func DecodeWithPolicy(b []byte, policy *DecodePolicy) (*Transaction, error) {
+	tx := new(Transaction)
+	if err := tx.UnmarshalBinaryWithPolicy(b, policy); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// UnmarshalBinaryWithPolicy는 policy의 제한을 검사한 뒤 UnmarshalBinary와 같이 트랜잭션을 디코딩합니다.
+func (tx *Transaction) UnmarshalBinaryWithPolicy(b []byte, policy *DecodePolicy) error {
+	if err := policy.check(b); err != nil {
+		return err
+	}
+	return tx.UnmarshalBinary(b)
+}
+
+// DecodeRLPWithPolicy는 policy의 제한을 검사한 뒤 DecodeRLP와 같이 트랜잭션을 디코딩합니다.
+func (tx *Transaction) DecodeRLPWithPolicy(s *rlp.Stream, policy *DecodePolicy) error {
+	if policy == nil {
+		return tx.DecodeRLP(s)
+	}
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	// 본문을 읽어 메모리를 할당하기 전에 크기 제한을 검사합니다. 레거시 트랜잭션의 경우 size는
+	// 리스트 헤더를 제외한 크기이며, 헤더를 포함한 크기는 본문을 읽은 후 check에서 검사합니다.
+	if kind != rlp.Byte && policy.MaxSize > 0 && size > policy.MaxSize {
+		return &PolicyViolationError{Err: ErrPolicyTxSize, Have: size, Limit: policy.MaxSize}
+	}
+	switch {
+	case kind == rlp.List:
+		// 레거시 트랜잭션은 본문을 읽기 전에 타입을 확인할 수 있습니다.
+		if !policy.allowsType(LegacyTxType) {
+			return &PolicyViolationError{Err: ErrPolicyTxType, Have: LegacyTxType}
+		}
+		raw, err := s.Raw()
+		if err != nil {
+			return err
+		}
+		if err := policy.check(raw); err != nil {
+			return err
+		}
+		var inner LegacyTx
+		if err := rlp.DecodeBytes(raw, &inner); err != nil {
+			return err
+		}
+		tx.setDecoded(&inner, uint64(len(raw)))
+		return nil
+	case kind == rlp.Byte:
+		return errShortTypedTx
+	default:
+		b, buf, err := getPooledBuffer(size)
+		if err != nil {
+			return err
+		}
+		defer encodeBufferPool.Put(buf)
+		if err := s.ReadBytes(b); err != nil {
+			return err
+		}
+		if err := policy.check(b); err != nil {
+			return err
+		}
+		inner, err := tx.decodeTyped(b)
+		if err == nil {
+			tx.setDecoded(inner, size)
+		}
+		return err
+	}
+}
+
+// allowsType은 주어진 트랜잭션 타입이 정책에 의해 허용되는지 여부를 반환합니다.
+func (p *DecodePolicy) allowsType(txType byte) bool {
+	if p.AllowedTypes == nil {
+		return true
+	}
+	for _, t := range p.AllowedTypes {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+// check는 인코딩된 트랜잭션 b가 정책을 위반하는지 검사합니다. b는 레거시 트랜잭션의 RLP 리스트이거나
+// 타입 트랜잭션의 정규 인코딩입니다.
+//
+// 검사는 입력의 하위 슬라이스만 사용하므로 메모리를 할당하지 않습니다. 구조적으로 잘못된 입력은
+// 이곳에서 거부하지 않고, 일관된 오류를 반환하도록 일반 디코더에 맡깁니다.
+func (p *DecodePolicy) check(b []byte) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxSize > 0 && uint64(len(b)) > p.MaxSize {
+		return &PolicyViolationError{Err: ErrPolicyTxSize, Have: uint64(len(b)), Limit: p.MaxSize}
+	}
+	if p.Strict {
+		if err := checkTxTrailingBytes(b); err != nil {
+			return err
+		}
+	}
+	var (
+		txType  = byte(LegacyTxType)
+		payload = b
+	)
+	if len(b) > 0 && b[0] <= 0x7f {
+		txType, payload = b[0], b[1:]
+	}
+	if !p.allowsType(txType) {
+		return &PolicyViolationError{Err: ErrPolicyTxType, Have: uint64(txType)}
+	}
+	// 트랜잭션 타입별로 검사할 필드의 위치를 결정합니다.
+	dataIndex, accessListIndex, blobHashesIndex := -1, -1, -1
+	switch txType {
+	case LegacyTxType:
+		dataIndex = 5
+	case AccessListTxType:
+		dataIndex, accessListIndex = 6, 7
+	case DynamicFeeTxType:
+		dataIndex, accessListIndex = 7, 8
+	case BlobTxType:
+		dataIndex, accessListIndex, blobHashesIndex = 7, 8, 10
+	default:
+		return nil
+	}
+	fields, _, err := rlp.SplitList(payload)
+	if err != nil {
+		return nil
+	}
+	// blob 트랜잭션의 네트워크 인코딩은 버전 0에서 [tx, blobs, commitments, proofs],
+	// 버전 1에서 [tx, version, blobs, commitments, cell_proofs] 형식입니다.
+	if txType == BlobTxType {
+		kind, inner, rest, err := rlp.Split(fields)
+		if err != nil {
+			return nil
+		}
+		if kind == rlp.List {
+			if p.MaxBlobs > 0 {
+				// 버전 1 래퍼는 blob 목록 앞에 버전 바이트를 가집니다.
+				if versionKind, _, afterVersion, err := rlp.Split(rest); err == nil && versionKind != rlp.List {
+					rest = afterVersion
+				}
+				blobs, _, err := rlp.SplitList(rest)
+				if err != nil {
+					return nil
+				}
+				n, err := rlp.CountValues(blobs)
+				if err != nil {
+					return nil
+				}
+				if uint64(n) > p.MaxBlobs {
+					return &PolicyViolationError{Err: ErrPolicyBlobCount, Have: uint64(n), Limit: p.MaxBlobs}
+				}
+			}
+			fields = inner
+		}
+	}
+	for i := 0; len(fields) > 0; i++ {
+		kind, content, rest, err := rlp.Split(fields)
+		if err != nil {
+			return nil
+		}
+		switch {
+		case i == dataIndex && p.MaxDataSize > 0:
+			if uint64(len(content)) > p.MaxDataSize {
+				return &PolicyViolationError{Err: ErrPolicyDataSize, Have: uint64(len(content)), Limit: p.MaxDataSize}
+			}
+		case i == accessListIndex && kind == rlp.List && p.MaxAccessListEntries > 0:
+			n, err := countAccessListEntries(content)
+			if err != nil {
+				return nil
+			}
+			if n > p.MaxAccessListEntries {
+				return &PolicyViolationError{Err: ErrPolicyAccessList, Have: n, Limit: p.MaxAccessListEntries}
+			}
+		case i == blobHashesIndex && kind == rlp.List && p.MaxBlobs > 0:
+			n, err := rlp.CountValues(content)
+			if err != nil {
+				return nil
+			}
+			if uint64(n) > p.MaxBlobs {
+				return &PolicyViolationError{Err: ErrPolicyBlobCount, Have: uint64(n), Limit: p.MaxBlobs}
+			}
+		}
+		fields = rest
+	}
+	return nil
+}
+
+// countAccessListEntries는 인코딩된 액세스 목록의 주소와 스토리지 키 개수의 합을 반환합니다.
+func countAccessListEntries(b []byte) (uint64, error) {
+	var count uint64
+	for len(b) > 0 {
+		tuple, rest, err := rlp.SplitList(b)
+		if err != nil {
+			return 0, err
+		}
+		_, _, keys, err := rlp.Split(tuple)
+		if err != nil {
+			return 0, err
+		}
+		keyList, _, err := rlp.SplitList(keys)
+		if err != nil {
+			return 0, err
+		}
+		n, err := rlp.CountValues(keyList)
+		if err != nil {
+			return 0, err
+		}
+		count += 1 + uint64(n)
+		b = rest
+	}
+	return count, nil
+}
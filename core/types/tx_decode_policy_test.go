@@ -0,0 +1,236 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestDecodePolicy(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		legacy = MustSignNewTx(key, HomesteadSigner{}, &LegacyTx{
+			Nonce:    1,
+			To:       &testAddr,
+			Gas:      21000,
+			GasPrice: big.NewInt(1),
+			Data:     make([]byte, 64),
+		})
+		dynamic = MustSignNewTx(key, NewLondonSigner(big.NewInt(1)), &DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     1,
+			To:        &testAddr,
+			Gas:       21000,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1),
+			Data:      make([]byte, 128),
+			AccessList: AccessList{
+				{Address: testAddr, StorageKeys: []common.Hash{{0x01}, {0x02}}},
+				{Address: common.Address{0x01}},
+			},
+		})
+		blob     = createEmptyBlobTx(key, false)
+		blobFull = createEmptyBlobTx(key, true)
+	)
+	tests := []struct {
+		tx     *Transaction
+		policy *DecodePolicy
+		want   error
+	}{
+		{legacy, nil, nil},
+		{legacy, &DecodePolicy{}, nil},
+		{legacy, &DecodePolicy{AllowedTypes: []byte{DynamicFeeTxType}}, ErrPolicyTxType},
+		{legacy, &DecodePolicy{MaxDataSize: 64}, nil},
+		{legacy, &DecodePolicy{MaxDataSize: 63}, ErrPolicyDataSize},
+		{dynamic, &DecodePolicy{AllowedTypes: []byte{LegacyTxType}}, ErrPolicyTxType},
+		{dynamic, &DecodePolicy{AllowedTypes: []byte{LegacyTxType, DynamicFeeTxType}}, nil},
+		{dynamic, &DecodePolicy{MaxDataSize: 127}, ErrPolicyDataSize},
+		{dynamic, &DecodePolicy{MaxAccessListEntries: 4}, nil},
+		{dynamic, &DecodePolicy{MaxAccessListEntries: 3}, ErrPolicyAccessList},
+		{blob, &DecodePolicy{MaxBlobs: 1}, nil},
+		{blob, &DecodePolicy{MaxDataSize: 49}, ErrPolicyDataSize},
+		{blobFull, &DecodePolicy{MaxBlobs: 1, MaxDataSize: 50}, nil},
+		{blobFull, &DecodePolicy{MaxDataSize: 49}, ErrPolicyDataSize},
+		{legacy, &DecodePolicy{MaxSize: 64}, ErrPolicyTxSize},
+		{dynamic, &DecodePolicy{MaxSize: 64}, ErrPolicyTxSize},
+		{dynamic, &DecodePolicy{MaxSize: 1024}, nil},
+		{blobFull, &DecodePolicy{MaxSize: params.BlobTxFieldElementsPerBlob * 32}, ErrPolicyTxSize},
+	}
+	for i, test := range tests {
+		enc, err := test.tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("test %d: encoding failed: %v", i, err)
+		}
+		// Check the canonical binary decoding path.
+		tx, err := DecodeWithPolicy(enc, test.policy)
+		if !errors.Is(err, test.want) {
+			t.Errorf("test %d: binary decode error mismatch: have %v, want %v", i, err, test.want)
+		}
+		if err == nil && tx.Hash() != test.tx.Hash() {
+			t.Errorf("test %d: binary decode hash mismatch", i)
+		}
+		// Check the RLP stream decoding path.
+		rlpenc, err := rlp.EncodeToBytes(test.tx)
+		if err != nil {
+			t.Fatalf("test %d: rlp encoding failed: %v", i, err)
+		}
+		var dec Transaction
+		err = dec.DecodeRLPWithPolicy(rlp.NewStream(bytes.NewReader(rlpenc), 0), test.policy)
+		if !errors.Is(err, test.want) {
+			t.Errorf("test %d: rlp decode error mismatch: have %v, want %v", i, err, test.want)
+		}
+		if err == nil && dec.Hash() != test.tx.Hash() {
+			t.Errorf("test %d: rlp decode hash mismatch", i)
+		}
+	}
+}
+
+func TestDecodePolicyMaxSizeBeforeRead(t *testing.T) {
+	// The stream announces a 256MB transaction but contains only its header. The
+	// size limit must be enforced without reading or allocating the body.
+	for _, header := range []string{"bb10000000", "fb10000000"} {
+		s := rlp.NewStream(struct{ io.Reader }{bytes.NewReader(common.FromHex(header))}, 0)
+		var tx Transaction
+		err := tx.DecodeRLPWithPolicy(s, &DecodePolicy{MaxSize: 1 << 20})
+		if !errors.Is(err, ErrPolicyTxSize) {
+			t.Errorf("header %s: wrong error: %v", header, err)
+		}
+	}
+}
+
+func TestDecodePolicyBlobSidecar(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx := createEmptyBlobTx(key, true)
+
+	// Drop the blob hashes from the transaction, so only the sidecar blob count
+	// can trigger the violation.
+	inner := tx.inner.(*BlobTx).copy().(*BlobTx)
+	inner.BlobHashes = nil
+	enc, err := NewTx(inner).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = DecodeWithPolicy(enc, &DecodePolicy{MaxBlobs: 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var perr *PolicyViolationError
+	_, err = DecodeWithPolicy(enc, &DecodePolicy{AllowedTypes: []byte{BlobTxType}, MaxBlobs: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inner.Sidecar.Blobs = append(inner.Sidecar.Blobs, emptyBlob)
+	enc, _ = NewTx(inner).MarshalBinary()
+	_, err = DecodeWithPolicy(enc, &DecodePolicy{MaxBlobs: 1})
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected policy violation, got %v", err)
+	}
+	if perr.Err != ErrPolicyBlobCount || perr.Have != 2 || perr.Limit != 1 {
+		t.Fatalf("wrong violation: %v", perr)
+	}
+	// The version 1 wrapper carries a version byte in front of the blobs, which
+	// must not hide the blob count from the policy.
+	inner.Sidecar.Commitments = append(inner.Sidecar.Commitments, emptyBlobCommit)
+	inner.Sidecar.Proofs, inner.Sidecar.CellProofs = nil, make([]kzg4844.Proof, 2*kzg4844.CellsPerExtBlob)
+	inner.Sidecar.Version = BlobSidecarVersion1
+	enc, err = NewTx(inner).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = DecodeWithPolicy(enc, &DecodePolicy{MaxBlobs: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = DecodeWithPolicy(enc, &DecodePolicy{MaxBlobs: 1})
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected policy violation for v1 sidecar, got %v", err)
+	}
+	if perr.Err != ErrPolicyBlobCount || perr.Have != 2 || perr.Limit != 1 {
+		t.Fatalf("wrong violation for v1 sidecar: %v", perr)
+	}
+}
+
+const testPrefixTxType = 0x7c
+
+// testPrefixTx is a registered transaction type whose decoder reads only the
+// first value of its input, ignoring any trailing bytes.
+type testPrefixTx struct {
+	DynamicFeeTx
+}
+
+func (tx *testPrefixTx) txType() byte { return testPrefixTxType }
+func (tx *testPrefixTx) copy() TxData {
+	return &testPrefixTx{*tx.DynamicFeeTx.copy().(*DynamicFeeTx)}
+}
+func (tx *testPrefixTx) decode(input []byte) error {
+	return rlp.NewStream(bytes.NewReader(input), 0).Decode(&tx.DynamicFeeTx)
+}
+
+// registerTestTxType registers a transaction type for the duration of a test.
+func registerTestTxType(t *testing.T, typ byte, newTx func() TxData) {
+	t.Helper()
+	registerTxType(typ, newTx)
+	t.Cleanup(func() {
+		txTypesMu.Lock()
+		defer txTypesMu.Unlock()
+		delete(txTypes, typ)
+	})
+}
+
+func TestUnmarshalBinaryStrict(t *testing.T) {
+	registerTestTxType(t, testPrefixTxType, func() TxData { return new(testPrefixTx) })
+
+	fields := DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000}
+	for _, inner := range []TxData{&testPrefixTx{fields}, &fields, &LegacyTx{Gas: 21000, GasPrice: big.NewInt(1)}} {
+		tx := NewTx(inner)
+		enc, _ := tx.MarshalBinary()
+		padded := append(enc, 0x80)
+
+		var dec Transaction
+		if err := dec.UnmarshalBinaryStrict(enc); err != nil || dec.Hash() != tx.Hash() {
+			t.Fatalf("type %d: valid encoding rejected: %v", tx.Type(), err)
+		}
+		if err := dec.UnmarshalBinaryStrict(padded); err != rlp.ErrMoreThanOneValue {
+			t.Fatalf("type %d: wrong error for trailing bytes: %v", tx.Type(), err)
+		}
+		policy := &DecodePolicy{Strict: true}
+		if _, err := DecodeWithPolicy(padded, policy); err != rlp.ErrMoreThanOneValue {
+			t.Fatalf("type %d: wrong policy error for trailing bytes: %v", tx.Type(), err)
+		}
+		if tx.Type() == testPrefixTxType {
+			// The lenient decoder accepts the padded encoding of this type.
+			if err := dec.UnmarshalBinary(padded); err != nil {
+				t.Fatalf("lenient decoding failed: %v", err)
+			}
+			// Trailing bytes inside the RLP string of a typed transaction are
+			// rejected by the strict stream decoder as well.
+			wrapped, _ := rlp.EncodeToBytes(padded)
+			if err := dec.DecodeRLPWithPolicy(rlp.NewStream(bytes.NewReader(wrapped), 0), policy); err != rlp.ErrMoreThanOneValue {
+				t.Fatalf("wrong stream error for trailing bytes: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrGasUintOverflow는 가스 계산이 uint64 범위를 넘을 때 반환됩니다.
+var ErrGasUintOverflow = errors.New("gas uint64 overflow")
+
+// IntrinsicGas는 주어진 데이터와 접근 목록을 가진 메시지의 고유 가스를 계산합니다. rules에 따라
+// 다음 포크별 규칙이 적용됩니다:
+//   - Homestead: 컨트랙트 생성 트랜잭션의 기본 비용 증가
+//   - Istanbul (EIP-2028): 0이 아닌 calldata 바이트의 비용 인하
+//   - Shanghai (EIP-3860): initcode 워드당 비용
+func IntrinsicGas(data []byte, accessList AccessList, isCreation bool, rules params.Rules) (uint64, error) {
+	// 트랜잭션의 기본 가스를 설정합니다.
+	var gas uint64
+	if isCreation && rules.IsHomestead {
+		gas = params.TxGasContractCreation
+	} else {
+		gas = params.TxGas
+	}
+	dataLen := uint64(len(data))
+	// 트랜잭션 데이터의 양에 따라 필요한 가스를 늘립니다.
+	if dataLen > 0 {
+		// 0인 바이트와 0이 아닌 바이트는 가격이 다릅니다.
+		var nz uint64
+		for _, byt := range data {
+			if byt != 0 {
+				nz++
+			}
+		}
+		// 모든 데이터 조합에 대해 uint64를 넘지 않도록 확인합니다.
+		nonZeroGas := params.TxDataNonZeroGasFrontier
+		if rules.IsIstanbul {
+			nonZeroGas = params.TxDataNonZeroGasEIP2028
+		}
+		if (math.MaxUint64-gas)/nonZeroGas < nz {
+			return 0, ErrGasUintOverflow
+		}
+		gas += nz * nonZeroGas
+
+		z := dataLen - nz
+		if (math.MaxUint64-gas)/params.TxDataZeroGas < z {
+			return 0, ErrGasUintOverflow
+		}
+		gas += z * params.TxDataZeroGas
+
+		if isCreation && rules.IsShanghai {
+			lenWords := toWordSize(dataLen)
+			if (math.MaxUint64-gas)/params.InitCodeWordGas < lenWords {
+				return 0, ErrGasUintOverflow
+			}
+			gas += lenWords * params.InitCodeWordGas
+		}
+	}
+	if accessList != nil {
+		gas += accessList.GasCost()
+	}
+	return gas, nil
+}
+
+// toWordSize는 initcode 비용 계산에 필요한 워드 크기를 올림하여 반환합니다.
+func toWordSize(size uint64) uint64 {
+	return math.CeilDiv(size, 32)
+}
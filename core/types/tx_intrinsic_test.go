@@ -0,0 +1,71 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestIntrinsicGas(t *testing.T) {
+	var (
+		frontier = params.Rules{}
+		istanbul = params.Rules{IsHomestead: true, IsIstanbul: true}
+		shanghai = params.Rules{IsHomestead: true, IsIstanbul: true, IsShanghai: true}
+		data     = []byte{0x00, 0x01, 0x00, 0x02}
+		list     = AccessList{{Address: common.Address{1}, StorageKeys: []common.Hash{{1}, {2}}}}
+	)
+	tests := []struct {
+		data     []byte
+		list     AccessList
+		creation bool
+		rules    params.Rules
+		want     uint64
+	}{
+		{nil, nil, false, frontier, params.TxGas},
+		{nil, nil, true, frontier, params.TxGas},
+		{nil, nil, true, istanbul, params.TxGasContractCreation},
+		{data, nil, false, frontier, params.TxGas + 2*params.TxDataNonZeroGasFrontier + 2*params.TxDataZeroGas},
+		{data, nil, false, istanbul, params.TxGas + 2*params.TxDataNonZeroGasEIP2028 + 2*params.TxDataZeroGas},
+		{data, nil, true, istanbul, params.TxGasContractCreation + 2*params.TxDataNonZeroGasEIP2028 + 2*params.TxDataZeroGas},
+		{data, nil, true, shanghai, params.TxGasContractCreation + 2*params.TxDataNonZeroGasEIP2028 + 2*params.TxDataZeroGas + params.InitCodeWordGas},
+		{make([]byte, 33), nil, true, shanghai, params.TxGasContractCreation + 33*params.TxDataZeroGas + 2*params.InitCodeWordGas},
+		{nil, list, false, istanbul, params.TxGas + params.TxAccessListAddressGas + 2*params.TxAccessListStorageKeyGas},
+	}
+	for i, test := range tests {
+		gas, err := IntrinsicGas(test.data, test.list, test.creation, test.rules)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if gas != test.want {
+			t.Errorf("test %d: wrong gas %d, want %d", i, gas, test.want)
+		}
+	}
+}
+
+func TestToWordSize(t *testing.T) {
+	tests := []struct{ size, want uint64 }{
+		{0, 0}, {1, 1}, {32, 1}, {33, 2}, {^uint64(0), ^uint64(0)/32 + 1},
+	}
+	for _, test := range tests {
+		if have := toWordSize(test.size); have != test.want {
+			t.Errorf("toWordSize(%d) = %d, want %d", test.size, have, test.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"time"
+)
+
+// TxMeta는 트랜잭션에 붙일 수 있는 로컬 전용 메타데이터입니다. 합의와 무관하며 RLP, JSON 등
+// 어떤 인코딩에도 포함되지 않고, 트랜잭션 해시에도 영향을 주지 않습니다. 트랜잭션 풀과 릴레이가
+// 트랜잭션의 출처나 만료 시간 등을 기록하는 데 사용합니다.
+type TxMeta struct {
+	Origin    string            // 트랜잭션을 전달한 피어 등 출처
+	FirstSeen time.Time         // 처음 확인한 시간
+	Expiry    time.Time         // 이 시간 이후에는 버려도 되는 트랜잭션 (0이면 만료되지 않음)
+	Labels    map[string]string // 임의의 레이블
+}
+
+// Expired는 now 시점에 메타데이터의 만료 시간이 지났는지 여부를 반환합니다.
+func (m *TxMeta) Expired(now time.Time) bool {
+	return !m.Expiry.IsZero() && !now.Before(m.Expiry)
+}
+
+// copy는 메타데이터의 깊은 복사본을 반환합니다.
+func (m *TxMeta) copy() *TxMeta {
+	cpy := *m
+	if m.Labels != nil {
+		cpy.Labels = make(map[string]string, len(m.Labels))
+		for k, v := range m.Labels {
+			cpy.Labels[k] = v
+		}
+	}
+	return &cpy
+}
+
+// Meta는 트랜잭션 메타데이터의 복사본을 반환합니다. 반환된 값을 수정해도 트랜잭션에는 영향을
+// 주지 않습니다. 메타데이터가 설정되지 않았으면 빈 값을 반환합니다.
+func (tx *Transaction) Meta() TxMeta {
+	if m := tx.meta.Load(); m != nil {
+		return *m.(*TxMeta).copy()
+	}
+	return TxMeta{}
+}
+
+// SetMeta는 트랜잭션의 메타데이터를 m의 복사본으로 교체합니다.
+func (tx *Transaction) SetMeta(m TxMeta) {
+	tx.meta.Store(m.copy())
+}
+
+// UpdateMeta는 현재 메타데이터의 복사본에 fn을 적용하고 그 결과로 메타데이터를 교체합니다.
+// 교체는 원자적으로 이루어지므로, 동시에 Meta를 호출하는 쪽은 변경 전이나 후의 메타데이터만
+// 보게 됩니다. 동시에 다른 갱신이 일어나면 fn이 다시 호출될 수 있습니다.
+//
+// 메타데이터는 쓰기 시 복사되므로, WithoutBlobTxSidecar 등으로 만든 복사본과 공유된 메타데이터는
+// 갱신 이후 서로 독립적입니다.
+func (tx *Transaction) UpdateMeta(fn func(m *TxMeta)) {
+	for {
+		old := tx.meta.Load()
+		next := new(TxMeta)
+		if old != nil {
+			next = old.(*TxMeta).copy()
+		}
+		fn(next)
+		if tx.meta.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
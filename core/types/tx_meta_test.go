@@ -0,0 +1,117 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTxMetaNotEncoded(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx := MustSignNewTx(key, LatestSignerForChainID(big.NewInt(1)), &DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &common.Address{1},
+	})
+	hash := tx.Hash()
+	bin, _ := tx.MarshalBinary()
+	js, _ := json.Marshal(tx)
+
+	meta := TxMeta{Origin: "peer-origin", FirstSeen: time.Unix(1, 0), Labels: map[string]string{"label-key": "label-value"}}
+	tx.SetMeta(meta)
+
+	var fresh Transaction
+	if err := fresh.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	for _, tx := range []*Transaction{tx, &fresh} {
+		if tx.Hash() != hash {
+			t.Fatal("metadata changed the transaction hash")
+		}
+		if enc, _ := tx.MarshalBinary(); !bytes.Equal(enc, bin) {
+			t.Fatal("metadata changed the binary encoding")
+		}
+		enc, _ := json.Marshal(tx)
+		if !bytes.Equal(enc, js) {
+			t.Fatal("metadata changed the JSON encoding")
+		}
+		for _, s := range []string{"peer-origin", "label-key", "label-value"} {
+			if bytes.Contains(enc, []byte(s)) {
+				t.Fatalf("metadata %q leaked into JSON", s)
+			}
+		}
+	}
+	if !reflect.DeepEqual(fresh.Meta(), TxMeta{}) {
+		t.Fatal("decoded transaction has metadata")
+	}
+}
+
+func TestTxMetaCopyOnWrite(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx := createEmptyBlobTx(key, true)
+	tx.SetMeta(TxMeta{Origin: "a", Labels: map[string]string{"k": "v"}})
+
+	// Modifying the returned copy must not affect the transaction.
+	m := tx.Meta()
+	m.Labels["k"] = "changed"
+	if tx.Meta().Labels["k"] != "v" {
+		t.Fatal("metadata modified through returned copy")
+	}
+
+	// Copies share the metadata until one of them is updated.
+	stripped := tx.WithoutBlobTxSidecar()
+	if stripped.Meta().Origin != "a" {
+		t.Fatal("metadata not carried over to copy")
+	}
+	stripped.UpdateMeta(func(m *TxMeta) { m.Origin = "b" })
+	if tx.Meta().Origin != "a" || stripped.Meta().Origin != "b" {
+		t.Fatal("update of copy affected original")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx.UpdateMeta(func(m *TxMeta) { m.Labels["n"] += "x" })
+		}()
+	}
+	wg.Wait()
+	if n := tx.Meta().Labels["n"]; n != "xxxxxxxxxx" {
+		t.Fatalf("lost concurrent updates: %q", n)
+	}
+
+	m = tx.Meta()
+	if m.Expired(time.Now()) {
+		t.Fatal("metadata without expiry is expired")
+	}
+	m.Expiry = time.Unix(100, 0)
+	if !m.Expired(time.Unix(100, 0)) || m.Expired(time.Unix(99, 0)) {
+		t.Fatal("wrong expiry")
+	}
+}
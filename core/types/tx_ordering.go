@@ -0,0 +1,173 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"container/heap"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxWithMinerFee는 트랜잭션을 주어진 base fee에서의 유효 마이너 팁과 함께 감쌉니다. T는
+// *Transaction이나 트랜잭션 풀의 지연 로딩 트랜잭션처럼 정렬할 트랜잭션을 나타내는 타입이며,
+// 정렬에는 Fees, Time, Hash만 사용됩니다.
+type TxWithMinerFee[T any] struct {
+	Tx   T
+	From common.Address
+	Fees *big.Int
+	Time time.Time   // 트랜잭션이 처음 확인된 시간
+	Hash common.Hash // 트랜잭션 해시
+}
+
+// NewTxWithMinerFee는 base fee가 주어지면 유효 마이너 팁을 계산하여 감싼 트랜잭션을 생성합니다.
+// 유효 마이너 팁이 음수이면, 즉 수수료 상한이 base fee보다 낮으면 ErrGasFeeCapTooLow를 반환합니다.
+func NewTxWithMinerFee(tx *Transaction, from common.Address, baseFee *big.Int) (*TxWithMinerFee[*Transaction], error) {
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return nil, err
+	}
+	return &TxWithMinerFee[*Transaction]{Tx: tx, From: from, Fees: tip, Time: tx.Time(), Hash: tx.Hash()}, nil
+}
+
+// TxByPriceAndTime은 sort와 heap 인터페이스를 모두 구현하여 전체 정렬과 개별 원소의 추가 및
+// 제거에 모두 사용할 수 있습니다. 유효 팁이 높은 트랜잭션이 앞에 오며, 팁이 같으면 먼저 확인된
+// 트랜잭션이, 확인된 시간도 같으면 해시가 작은 트랜잭션이 앞에 옵니다.
+type TxByPriceAndTime[T any] []*TxWithMinerFee[T]
+
+func (s TxByPriceAndTime[T]) Len() int { return len(s) }
+func (s TxByPriceAndTime[T]) Less(i, j int) bool {
+	if cmp := s[i].Fees.Cmp(s[j].Fees); cmp != 0 {
+		return cmp > 0
+	}
+	if !s[i].Time.Equal(s[j].Time) {
+		return s[i].Time.Before(s[j].Time)
+	}
+	// 수신 시간까지 같으면 해시로 순서를 정해 항상 같은 결과를 냅니다.
+	return bytes.Compare(s[i].Hash[:], s[j].Hash[:]) < 0
+}
+func (s TxByPriceAndTime[T]) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *TxByPriceAndTime[T]) Push(x interface{}) {
+	*s = append(*s, x.(*TxWithMinerFee[T]))
+}
+
+func (s *TxByPriceAndTime[T]) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// TransactionsByPriceAndNonce는 계정별 nonce 순서를 지키면서 수익이 가장 큰 순서로 트랜잭션을
+// 반환하는 집합입니다. 실행할 수 없는 계정의 트랜잭션을 한꺼번에 제거하는 것도 지원합니다.
+type TransactionsByPriceAndNonce[T any] struct {
+	txs     map[common.Address][]T // 계정별 nonce 순으로 정렬된 트랜잭션 목록
+	heads   TxByPriceAndTime[T]    // 각 계정의 다음 트랜잭션 (가격 힙)
+	wrap    WrapTxFunc[T]          // 트랜잭션의 유효 팁을 계산하여 감싸는 함수
+	baseFee *big.Int               // 현재 base fee
+}
+
+// WrapTxFunc는 트랜잭션을 주어진 base fee에서의 유효 마이너 팁과 함께 감쌉니다. 오류를
+// 반환하면 해당 계정의 남은 트랜잭션은 집합에서 제외됩니다.
+type WrapTxFunc[T any] func(tx T, from common.Address, baseFee *big.Int) (*TxWithMinerFee[T], error)
+
+// NewTransactionsByPriceAndNonce는 nonce 순서를 지키면서 가격 순으로 트랜잭션을 꺼낼 수 있는
+// 집합을 생성합니다. 각 계정의 목록은 nonce 순으로 정렬되어 있어야 합니다. 발신자를 복구할 수
+// 없거나 base fee를 감당할 수 없는 트랜잭션에 이르면 해당 계정의 남은 트랜잭션은 제외됩니다.
+func NewTransactionsByPriceAndNonce(signer Signer, txs map[common.Address]Transactions, baseFee *big.Int) *TransactionsByPriceAndNonce[*Transaction] {
+	lists := make(map[common.Address][]*Transaction, len(txs))
+	for from, accTxs := range txs {
+		lists[from] = accTxs
+	}
+	return NewTransactionsByPriceAndNonceFunc(lists, baseFee, func(tx *Transaction, from common.Address, baseFee *big.Int) (*TxWithMinerFee[*Transaction], error) {
+		acc, err := Sender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		if acc != from {
+			return nil, ErrInvalidSig
+		}
+		return NewTxWithMinerFee(tx, from, baseFee)
+	})
+}
+
+// NewTransactionsByPriceAndNonceFunc는 임의의 트랜잭션 표현에 대해 NewTransactionsByPriceAndNonce와
+// 같은 집합을 생성합니다. wrap은 각 트랜잭션의 유효 마이너 팁을 계산합니다.
+//
+// 입력 맵의 소유권은 집합으로 넘어가므로, 호출자는 생성자에 전달한 후 맵을 더 이상 사용하면 안 됩니다.
+func NewTransactionsByPriceAndNonceFunc[T any](txs map[common.Address][]T, baseFee *big.Int, wrap WrapTxFunc[T]) *TransactionsByPriceAndNonce[T] {
+	heads := make(TxByPriceAndTime[T], 0, len(txs))
+	for from, accTxs := range txs {
+		if len(accTxs) == 0 {
+			delete(txs, from)
+			continue
+		}
+		wrapped, err := wrap(accTxs[0], from, baseFee)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+
+	return &TransactionsByPriceAndNonce[T]{
+		txs:     txs,
+		heads:   heads,
+		wrap:    wrap,
+		baseFee: baseFee,
+	}
+}
+
+// Peek은 가격 순으로 다음 트랜잭션을 반환합니다. 남은 트랜잭션이 없으면 T의 영값을 반환합니다.
+func (t *TransactionsByPriceAndNonce[T]) Peek() T {
+	if len(t.heads) == 0 {
+		var zero T
+		return zero
+	}
+	return t.heads[0].Tx
+}
+
+// Shift는 현재 가장 좋은 트랜잭션을 같은 계정의 다음 트랜잭션으로 교체합니다.
+func (t *TransactionsByPriceAndNonce[T]) Shift() {
+	acc := t.heads[0].From
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := t.wrap(txs[0], acc, t.baseFee); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(&t.heads)
+}
+
+// Pop은 가장 좋은 트랜잭션을 제거하되, 같은 계정의 다음 트랜잭션으로 교체하지 않습니다.
+// 트랜잭션을 실행할 수 없어 같은 계정의 이후 트랜잭션을 모두 버려야 할 때 사용합니다.
+func (t *TransactionsByPriceAndNonce[T]) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// Empty는 남은 트랜잭션이 없는지 여부를 반환합니다.
+func (t *TransactionsByPriceAndNonce[T]) Empty() bool {
+	return len(t.heads) == 0
+}
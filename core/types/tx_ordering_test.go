@@ -0,0 +1,142 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"container/heap"
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that transactions are sorted by effective tip in decreasing order, while
+// keeping nonces increasing for each account.
+func TestTransactionsByPriceAndNonce(t *testing.T) {
+	baseFee := big.NewInt(10)
+	signer := LatestSignerForChainID(common.Big1)
+	keys := make([]*ecdsa.PrivateKey, 10)
+	groups := make(map[common.Address]Transactions)
+	expected := 0
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(keys[i].PublicKey)
+		count := 10
+		for n := 0; n < 10; n++ {
+			feeCap := rand.Intn(50)
+			tx := MustSignNewTx(keys[i], signer, &DynamicFeeTx{
+				ChainID:   common.Big1,
+				Nonce:     uint64(n),
+				Gas:       21000,
+				GasFeeCap: big.NewInt(int64(feeCap)),
+				GasTipCap: big.NewInt(int64(rand.Intn(feeCap + 1))),
+			})
+			if count == 10 && int64(feeCap) < baseFee.Int64() {
+				count = n
+			}
+			groups[addr] = append(groups[addr], tx)
+		}
+		expected += count
+	}
+	set := NewTransactionsByPriceAndNonce(signer, groups, baseFee)
+
+	var txs Transactions
+	for tx := set.Peek(); tx != nil; tx = set.Peek() {
+		txs = append(txs, tx)
+		set.Shift()
+	}
+	if !set.Empty() {
+		t.Fatal("set not empty after draining")
+	}
+	if len(txs) != expected {
+		t.Fatalf("wrong transaction count: have %d, want %d", len(txs), expected)
+	}
+	nonces := make(map[common.Address]uint64)
+	for i, tx := range txs {
+		from, _ := Sender(signer, tx)
+		if tx.Nonce() != nonces[from] {
+			t.Fatalf("tx %d: wrong nonce %d for %x, want %d", i, tx.Nonce(), from, nonces[from])
+		}
+		nonces[from]++
+		if i+1 < len(txs) {
+			next := txs[i+1]
+			nextFrom, _ := Sender(signer, next)
+			if from != nextFrom && tx.EffectiveGasTipCmp(next, baseFee) < 0 {
+				t.Fatalf("tx %d: invalid tip ordering", i)
+			}
+		}
+	}
+}
+
+// Tests that transactions with the same tip are ordered by receive time, and by
+// hash if the times are equal too.
+func TestTxByPriceAndTimeTieBreak(t *testing.T) {
+	signer := HomesteadSigner{}
+	var heads TxByPriceAndTime[*Transaction]
+	for i := 0; i < 5; i++ {
+		key, _ := crypto.GenerateKey()
+		tx := MustSignNewTx(key, signer, &LegacyTx{Nonce: 0, Gas: 21000, GasPrice: big.NewInt(1)})
+		tx.SetTime(time.Unix(int64(5-i), 0))
+		if i >= 3 {
+			tx.SetTime(time.Unix(0, 0))
+		}
+		from, _ := Sender(signer, tx)
+		wrapped, err := NewTxWithMinerFee(tx, from, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		heads = append(heads, wrapped)
+	}
+	for i := 0; i < 2; i++ {
+		ordered := append(TxByPriceAndTime[*Transaction](nil), heads...)
+		rand.Shuffle(len(ordered), ordered.Swap)
+		set := &TransactionsByPriceAndNonce[*Transaction]{heads: ordered, txs: map[common.Address][]*Transaction{}}
+		heap.Init(&set.heads)
+
+		var prev *Transaction
+		for tx := set.Peek(); tx != nil; tx = set.Peek() {
+			if prev != nil {
+				if prev.Time().After(tx.Time()) {
+					t.Fatalf("invalid time ordering: %v before %v", prev.Time(), tx.Time())
+				}
+				if prev.Time().Equal(tx.Time()) && prev.Hash().Big().Cmp(tx.Hash().Big()) > 0 {
+					t.Fatal("invalid hash tie-break ordering")
+				}
+			}
+			prev = tx
+			set.Pop()
+		}
+	}
+}
+
+func TestNewTxWithMinerFee(t *testing.T) {
+	tx := NewTx(&DynamicFeeTx{GasFeeCap: big.NewInt(10), GasTipCap: big.NewInt(3)})
+	if _, err := NewTxWithMinerFee(tx, common.Address{}, big.NewInt(11)); err != ErrGasFeeCapTooLow {
+		t.Fatalf("wrong error: %v", err)
+	}
+	wrapped, err := NewTxWithMinerFee(tx, common.Address{}, big.NewInt(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrapped.Fees.Int64() != 2 {
+		t.Fatalf("wrong effective tip %v", wrapped.Fees)
+	}
+}
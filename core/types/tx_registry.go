@@ -0,0 +1,80 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+	"sync"
+)
+
+// txTypes는 EIP-2718 타입 트랜잭션의 타입 바이트와 빈 TxData 생성자의 레지스트리입니다.
+// 트랜잭션과 영수증 디코딩 모두 이 레지스트리를 참조하므로, 이 패키지에 새 트랜잭션 타입을
+// 추가할 때 여기에 등록하면 해당 타입의 영수증도 자동으로 허용됩니다. TxData의 메서드는
+// 공개되어 있지 않으므로 레지스트리도 패키지 내부에서만 수정합니다.
+var (
+	txTypesMu sync.RWMutex
+	txTypes   = map[byte]func() TxData{
+		AccessListTxType: func() TxData { return new(AccessListTx) },
+		DynamicFeeTxType: func() TxData { return new(DynamicFeeTx) },
+		BlobTxType:       func() TxData { return new(BlobTx) },
+	}
+)
+
+// registerTxType은 타입 바이트 typ에 대한 트랜잭션 타입을 등록합니다. newTx는 디코딩에 사용할
+// 빈 TxData를 반환해야 합니다. 등록 이후 typ 타입의 트랜잭션과 영수증을 디코딩할 수 있습니다.
+//
+// 레거시 타입, 0x7f보다 큰 타입(레거시 RLP 리스트와 구분할 수 없음) 또는 이미 등록된 타입을
+// 등록하려고 하면 패닉이 발생합니다.
+func registerTxType(typ byte, newTx func() TxData) {
+	if typ == LegacyTxType || typ > 0x7f {
+		panic(fmt.Sprintf("types: invalid transaction type %#x", typ))
+	}
+	if newTx == nil {
+		panic(fmt.Sprintf("types: nil constructor for transaction type %#x", typ))
+	}
+	txTypesMu.Lock()
+	defer txTypesMu.Unlock()
+
+	if _, exists := txTypes[typ]; exists {
+		panic(fmt.Sprintf("types: transaction type %#x already registered", typ))
+	}
+	txTypes[typ] = newTx
+}
+
+// IsSupportedTxType은 typ가 레거시 타입이거나 등록된 타입 트랜잭션인지 여부를 반환합니다.
+func IsSupportedTxType(typ byte) bool {
+	if typ == LegacyTxType {
+		return true
+	}
+	txTypesMu.RLock()
+	defer txTypesMu.RUnlock()
+
+	_, ok := txTypes[typ]
+	return ok
+}
+
+// newTypedTxData는 등록된 타입 typ에 대한 빈 TxData를 생성합니다.
+func newTypedTxData(typ byte) (TxData, error) {
+	txTypesMu.RLock()
+	newTx, ok := txTypes[typ]
+	txTypesMu.RUnlock()
+
+	if !ok {
+		return nil, &TxTypeError{Type: typ}
+	}
+	return newTx(), nil
+}
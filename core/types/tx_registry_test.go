@@ -0,0 +1,100 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+const testRegistryTxType = 0x7e
+
+// testRegistryTx is a transaction type registered only in tests. It reuses the
+// dynamic fee layout under a different type byte.
+type testRegistryTx struct {
+	DynamicFeeTx
+}
+
+func (tx *testRegistryTx) txType() byte { return testRegistryTxType }
+func (tx *testRegistryTx) copy() TxData {
+	return &testRegistryTx{*tx.DynamicFeeTx.copy().(*DynamicFeeTx)}
+}
+
+func TestRegisteredTxType(t *testing.T) {
+	registerTestTxType(t, testRegistryTxType, func() TxData { return new(testRegistryTx) })
+
+	if !IsSupportedTxType(testRegistryTxType) || IsSupportedTxType(testRegistryTxType-1) {
+		t.Fatal("wrong registry contents")
+	}
+
+	// Transactions of the registered type round-trip.
+	tx := NewTx(&testRegistryTx{DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000}})
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Transaction
+	if err := dec.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Type() != testRegistryTxType || dec.Hash() != tx.Hash() {
+		t.Fatalf("decoded transaction mismatch: type %#x", dec.Type())
+	}
+
+	// Receipts of the registered type are accepted without further registration.
+	receipt := &Receipt{Type: testRegistryTxType, Status: ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*Log{}}
+	enc, err = receipt.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decReceipt Receipt
+	if err := decReceipt.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if decReceipt.Type != testRegistryTxType || decReceipt.CumulativeGasUsed != 21000 {
+		t.Fatalf("decoded receipt mismatch: %+v", decReceipt)
+	}
+	var buf bytes.Buffer
+	Receipts{receipt}.EncodeIndex(0, &buf)
+	if !bytes.Equal(buf.Bytes(), enc) {
+		t.Fatalf("EncodeIndex mismatch: have %x, want %x", buf.Bytes(), enc)
+	}
+
+	// Unregistered types are still rejected.
+	enc[0] = testRegistryTxType - 1
+	var typeErr *TxTypeError
+	if err := decReceipt.UnmarshalBinary(enc); !errors.As(err, &typeErr) || typeErr.Type != testRegistryTxType-1 {
+		t.Fatalf("wrong error for unregistered receipt type: %v", err)
+	}
+}
+
+func TestRegisterTxTypeInvalid(t *testing.T) {
+	registerTestTxType(t, testRegistryTxType, func() TxData { return new(testRegistryTx) })
+
+	for _, typ := range []byte{LegacyTxType, DynamicFeeTxType, testRegistryTxType, 0x80} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic registering type %#x", typ)
+				}
+			}()
+			registerTxType(typ, func() TxData { return new(DynamicFeeTx) })
+		}()
+	}
+}
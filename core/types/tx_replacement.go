@@ -0,0 +1,80 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	ErrReplaceNonceMismatch = errors.New("replacement transaction has different nonce")
+	ErrReplaceTypeMismatch  = errors.New("blob and non-blob transactions cannot replace each other")
+	ErrReplaceUnderpriced   = errors.New("replacement transaction underpriced")
+)
+
+// CanReplace는 tx가 old를 대체할 수 있는지 여부를 반환합니다. 규칙은 ValidateReplacement를
+// 참고하세요.
+func (tx *Transaction) CanReplace(old *Transaction, priceBumpPercent uint64) bool {
+	return tx.ValidateReplacement(old, priceBumpPercent) == nil
+}
+
+// ValidateReplacement는 tx가 같은 발신자의 대기 중인 트랜잭션 old를 대체할 수 있는지
+// 확인합니다. 발신자가 같은지는 호출자가 확인해야 합니다.
+//
+// 대체 트랜잭션은 old와 nonce가 같아야 하며, blob 트랜잭션은 blob 트랜잭션으로만 대체할 수
+// 있습니다. gas fee cap과 gas tip cap은 모두 old보다 커야 하고, 동시에 old 값에 priceBumpPercent
+// 퍼센트를 더한 값(소수점 이하 버림) 이상이어야 합니다. blob 트랜잭션은 blob fee cap에도 같은
+// 규칙이 적용됩니다. 첫 번째 조건은 wei 단위의 작은 가격에서 버림으로 인해 인상 폭이 0이
+// 되는 것을 막습니다.
+//
+// 조건을 만족하지 못하면 ErrReplaceUnderpriced 등을 감싼 오류를 반환합니다.
+func (tx *Transaction) ValidateReplacement(old *Transaction, priceBumpPercent uint64) error {
+	if tx.Nonce() != old.Nonce() {
+		return fmt.Errorf("%w: have %d, want %d", ErrReplaceNonceMismatch, tx.Nonce(), old.Nonce())
+	}
+	if (tx.Type() == BlobTxType) != (old.Type() == BlobTxType) {
+		return fmt.Errorf("%w: type %d replacing type %d", ErrReplaceTypeMismatch, tx.Type(), old.Type())
+	}
+	multiplier := new(big.Int).Add(big.NewInt(100), new(big.Int).SetUint64(priceBumpPercent))
+	if err := checkPriceBump("gas fee cap", tx.GasFeeCap(), old.GasFeeCap(), multiplier, priceBumpPercent); err != nil {
+		return err
+	}
+	if err := checkPriceBump("gas tip cap", tx.GasTipCap(), old.GasTipCap(), multiplier, priceBumpPercent); err != nil {
+		return err
+	}
+	if tx.Type() == BlobTxType {
+		if err := checkPriceBump("blob gas fee cap", tx.BlobGasFeeCap(), old.BlobGasFeeCap(), multiplier, priceBumpPercent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPriceBump는 have가 old보다 크고 old * multiplier / 100 이상인지 확인합니다.
+func checkPriceBump(name string, have, old, multiplier *big.Int, bump uint64) error {
+	if have.Cmp(old) <= 0 {
+		return fmt.Errorf("%w: new %s %v <= %v", ErrReplaceUnderpriced, name, have, old)
+	}
+	threshold := new(big.Int).Mul(old, multiplier)
+	threshold.Div(threshold, big.NewInt(100))
+	if have.Cmp(threshold) < 0 {
+		return fmt.Errorf("%w: new %s %v < %v + %d%% replacement penalty", ErrReplaceUnderpriced, name, have, old, bump)
+	}
+	return nil
+}
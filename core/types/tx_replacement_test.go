@@ -0,0 +1,61 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func TestValidateReplacement(t *testing.T) {
+	dynTx := func(nonce uint64, feeCap, tip int64) *Transaction {
+		return NewTx(&DynamicFeeTx{Nonce: nonce, GasFeeCap: big.NewInt(feeCap), GasTipCap: big.NewInt(tip)})
+	}
+	blobTx := func(feeCap, tip, blobFeeCap uint64) *Transaction {
+		return NewTx(&BlobTx{GasFeeCap: uint256.NewInt(feeCap), GasTipCap: uint256.NewInt(tip), BlobFeeCap: uint256.NewInt(blobFeeCap)})
+	}
+	tests := []struct {
+		name     string
+		old, new *Transaction
+		bump     uint64
+		err      error
+	}{
+		{"exact bump", dynTx(0, 100, 10), dynTx(0, 110, 11), 10, nil},
+		{"fee cap below bump", dynTx(0, 100, 10), dynTx(0, 109, 20), 10, ErrReplaceUnderpriced},
+		{"tip below bump", dynTx(0, 100, 10), dynTx(0, 200, 10), 10, ErrReplaceUnderpriced},
+		// 1 * 110 / 100 rounds down to 1, so the value must still strictly increase.
+		{"wei-level equal", dynTx(0, 1, 1), dynTx(0, 1, 1), 10, ErrReplaceUnderpriced},
+		{"wei-level bump", dynTx(0, 1, 1), dynTx(0, 2, 2), 10, nil},
+		{"legacy replaced by dynamic", NewTx(&LegacyTx{GasPrice: big.NewInt(100)}), dynTx(0, 110, 110), 10, nil},
+		{"nonce mismatch", dynTx(0, 100, 10), dynTx(1, 200, 20), 10, ErrReplaceNonceMismatch},
+		{"blob replacing dynamic", dynTx(0, 100, 10), blobTx(200, 20, 200), 10, ErrReplaceTypeMismatch},
+		{"blob bump", blobTx(100, 10, 100), blobTx(200, 20, 200), 100, nil},
+		{"blob fee cap below bump", blobTx(100, 10, 100), blobTx(200, 20, 199), 100, ErrReplaceUnderpriced},
+	}
+	for _, test := range tests {
+		err := test.new.ValidateReplacement(test.old, test.bump)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: wrong error: have %v, want %v", test.name, err, test.err)
+		}
+		if can := test.new.CanReplace(test.old, test.bump); can != (test.err == nil) {
+			t.Errorf("%s: CanReplace returned %v", test.name, can)
+		}
+	}
+}
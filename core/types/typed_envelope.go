@@ -0,0 +1,74 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrShortTypedEnvelope는 EIP-2718 타입 엔벨로프가 타입 바이트와 페이로드를 모두 담기에 너무
+// 짧을 때 반환됩니다.
+var ErrShortTypedEnvelope = errors.New("typed envelope too short")
+
+// maxEnvelopeType은 EIP-2718 엔벨로프에서 사용할 수 있는 가장 큰 타입 바이트입니다. 더 큰 값은
+// 레거시 RLP 리스트의 첫 바이트와 구분할 수 없습니다.
+const maxEnvelopeType = 0x7f
+
+// EncodeTyped는 payload의 RLP 인코딩 앞에 타입 바이트 txType을 붙인 EIP-2718 타입 엔벨로프를
+// 반환합니다. txType이 0x7f보다 크면 *TxTypeError를 반환합니다.
+func EncodeTyped(txType byte, payload interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeTypedEnvelope(&buf, txType, payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeTyped는 EIP-2718 타입 엔벨로프를 타입 바이트와 페이로드로 나눕니다. 반환되는 페이로드는
+// b를 참조합니다. 타입 바이트 뒤에 페이로드가 없으면 ErrShortTypedEnvelope를, 타입 바이트가
+// 0x7f보다 크면 *TxTypeError를 반환합니다. 페이로드 자체는 검증하지 않습니다.
+func DecodeTyped(b []byte) (byte, []byte, error) {
+	typ, payload, ok := splitTypedEnvelope(b)
+	if !ok {
+		return 0, nil, ErrShortTypedEnvelope
+	}
+	if typ > maxEnvelopeType {
+		return 0, nil, &TxTypeError{Type: typ}
+	}
+	return typ, payload, nil
+}
+
+// encodeTypedEnvelope는 타입 바이트와 payload의 RLP 인코딩을 w에 작성합니다.
+func encodeTypedEnvelope(w *bytes.Buffer, txType byte, payload interface{}) error {
+	if txType > maxEnvelopeType {
+		return &TxTypeError{Type: txType}
+	}
+	w.WriteByte(txType)
+	return rlp.Encode(w, payload)
+}
+
+// splitTypedEnvelope는 b를 타입 바이트와 페이로드로 나눕니다. 페이로드가 비어 있으면 false를
+// 반환합니다. 트랜잭션과 영수증은 각자의 오류를 보고하기 위해 이 함수를 직접 사용합니다.
+func splitTypedEnvelope(b []byte) (byte, []byte, bool) {
+	if len(b) <= 1 {
+		return 0, nil, false
+	}
+	return b[0], b[1:], true
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTypedEnvelope(t *testing.T) {
+	payload := []uint64{1, 2}
+	enc, err := EncodeTyped(0x05, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.FromHex("05c20102"); !bytes.Equal(enc, want) {
+		t.Fatalf("wrong encoding %x, want %x", enc, want)
+	}
+	typ, rest, err := DecodeTyped(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ != 0x05 || !bytes.Equal(rest, enc[1:]) {
+		t.Fatalf("wrong decoding: type %#x, payload %x", typ, rest)
+	}
+	if _, err := EncodeTyped(0x80, payload); !errors.Is(err, ErrTxTypeNotSupported) {
+		t.Errorf("wrong error for encoding type 0x80: %v", err)
+	}
+	for _, input := range []string{"", "05"} {
+		if _, _, err := DecodeTyped(common.FromHex(input)); err != ErrShortTypedEnvelope {
+			t.Errorf("wrong error for input %q: %v", input, err)
+		}
+	}
+	if _, _, err := DecodeTyped(common.FromHex("c20102")); !errors.Is(err, ErrTxTypeNotSupported) {
+		t.Errorf("wrong error for legacy list: %v", err)
+	}
+}
+
+func TestTypedEnvelopeReceipt(t *testing.T) {
+	// A receipt's consensus encoding must match the shared envelope framing.
+	r := &Receipt{Type: DynamicFeeTxType, Status: ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*Log{}}
+	have, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := EncodeTyped(DynamicFeeTxType, &receiptRLP{r.statusEncoding(), r.CumulativeGasUsed, r.Bloom, r.Logs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Fatalf("receipt encoding mismatch\nhave %x\nwant %x", have, want)
+	}
+}
@@ -18,12 +18,24 @@ package types
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+var (
+	ErrWithdrawalAmountOverflow = errors.New("withdrawal amount overflow")
+	ErrWithdrawalIndexOrder     = errors.New("withdrawal indices not strictly increasing")
+	ErrWithdrawalRootMismatch   = errors.New("withdrawal root mismatch")
+	errNilWithdrawal            = errors.New("nil withdrawal")
+)
+
 //go:generate go run github.com/fjl/gencodec -type Withdrawal -field-override withdrawalMarshaling -out gen_withdrawal_json.go
 //go:generate go run ../../rlp/rlpgen -type Withdrawal -out gen_withdrawal_rlp.go
 
@@ -53,3 +65,50 @@ func (s Withdrawals) Len() int { return len(s) }
 func (s Withdrawals) EncodeIndex(i int, w *bytes.Buffer) {
 	rlp.Encode(w, s[i])
 }
+
+// TotalAmount는 모든 출금액의 합계를 Gwei 단위로 반환합니다. 합계가 uint64 범위를 넘으면
+// ErrWithdrawalAmountOverflow를 반환합니다.
+func (s Withdrawals) TotalAmount() (uint64, error) {
+	var total uint64
+	for i, w := range s {
+		if w == nil {
+			return 0, fmt.Errorf("withdrawal %d: %w", i, errNilWithdrawal)
+		}
+		if w.Amount > math.MaxUint64-total {
+			return 0, fmt.Errorf("withdrawal %d: %w", i, ErrWithdrawalAmountOverflow)
+		}
+		total += w.Amount
+	}
+	return total, nil
+}
+
+// TotalAmountWei는 모든 출금액의 합계를 wei 단위로 반환합니다.
+func (s Withdrawals) TotalAmountWei() (*big.Int, error) {
+	total, err := s.TotalAmount()
+	if err != nil {
+		return nil, err
+	}
+	amount := new(big.Int).SetUint64(total)
+	return amount.Mul(amount, big.NewInt(params.GWei)), nil
+}
+
+// Validate는 출금 목록의 기본적인 유효성을 검사합니다. 각 출금은 nil이 아니어야 하고, 인덱스는
+// 엄격하게 증가해야 하며, 합계가 오버플로되지 않아야 합니다. 마지막으로 hasher로 계산한 목록의
+// 머클루트가 expectedRoot와 일치하는지 확인합니다.
+func (s Withdrawals) Validate(expectedRoot common.Hash, hasher TrieHasher) error {
+	for i, w := range s {
+		if w == nil {
+			return fmt.Errorf("withdrawal %d: %w", i, errNilWithdrawal)
+		}
+		if i > 0 && w.Index <= s[i-1].Index {
+			return fmt.Errorf("%w: withdrawal %d has index %d after %d", ErrWithdrawalIndexOrder, i, w.Index, s[i-1].Index)
+		}
+	}
+	if _, err := s.TotalAmount(); err != nil {
+		return err
+	}
+	if root := DeriveSha(s, hasher); root != expectedRoot {
+		return fmt.Errorf("%w: have %x, want %x", ErrWithdrawalRootMismatch, root, expectedRoot)
+	}
+	return nil
+}
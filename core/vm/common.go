@@ -65,11 +65,7 @@ func getData(data []byte, start uint64, size uint64) []byte {
 
 // toWordSize returns the ceiled word size required for memory expansion.
 func toWordSize(size uint64) uint64 {
-	if size > math.MaxUint64-31 {
-		return math.MaxUint64/32 + 1
-	}
-
-	return (size + 31) / 32
+	return math.CeilDiv(size, 32)
 }
 
 func allZero(b []byte) bool {
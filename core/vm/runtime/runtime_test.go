@@ -235,6 +235,11 @@ func (d *dummyChain) Engine() consensus.Engine {
 	return nil
 }
 
+// Config retrieves the chain's configuration.
+func (d *dummyChain) Config() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
 // GetHeader returns the hash corresponding to their hash.
 func (d *dummyChain) GetHeader(h common.Hash, n uint64) *types.Header {
 	d.counter++
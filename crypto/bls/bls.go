@@ -0,0 +1,260 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// bls 패키지는 이더리움 합의 레이어에서 사용하는 BLS12-381 서명 체계를 구현합니다.
+//
+// 공개 키는 G1(48바이트 압축), 서명은 G2(96바이트 압축)에 위치하는 "minimal-pubkey-size" 변형이며,
+// 메시지는 IETF hash-to-curve 표준과 proof-of-possession 도메인 분리 태그로 G2에 해싱됩니다.
+package bls
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	SecretKeyLength = 32                                            // 비밀 키의 바이트 길이
+	PublicKeyLength = bls12381.SizeOfG1AffineCompressed             // 압축된 공개 키의 바이트 길이
+	SignatureLength = bls12381.SizeOfG2AffineCompressed             // 압축된 서명의 바이트 길이
+	dst             = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_" // 합의 레이어의 서명 도메인 분리 태그
+)
+
+var (
+	errInvalidSecretKey = errors.New("invalid BLS secret key")
+	errInvalidPublicKey = errors.New("invalid BLS public key")
+	errInvalidSignature = errors.New("invalid BLS signature")
+	errEmptyAggregate   = errors.New("cannot aggregate empty set")
+)
+
+// SecretKey는 BLS 비밀 키입니다.
+type SecretKey struct {
+	scalar *big.Int
+}
+
+// PublicKey는 G1 위의 점으로 표현되는 BLS 공개 키입니다.
+type PublicKey struct {
+	point bls12381.G1Affine
+}
+
+// Signature는 G2 위의 점으로 표현되는 BLS 서명입니다.
+type Signature struct {
+	point bls12381.G2Affine
+}
+
+// GenerateKey는 r에서 읽은 난수로 새 비밀 키를 생성합니다. r이 nil이면 crypto/rand를 사용합니다.
+func GenerateKey(r io.Reader) (*SecretKey, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	for {
+		// 모듈러 편향을 줄이기 위해 스칼라보다 넓은 값을 읽어 축소합니다.
+		var buf [48]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, err
+		}
+		k := new(big.Int).SetBytes(buf[:])
+		k.Mod(k, fr.Modulus())
+		if k.Sign() != 0 {
+			return &SecretKey{scalar: k}, nil
+		}
+	}
+}
+
+// SecretKeyFromBytes는 32바이트 빅 엔디언 스칼라를 비밀 키로 디코딩합니다.
+// 0이거나 그룹 위수 이상인 값은 거부됩니다.
+func SecretKeyFromBytes(b []byte) (*SecretKey, error) {
+	if len(b) != SecretKeyLength {
+		return nil, errInvalidSecretKey
+	}
+	k := new(big.Int).SetBytes(b)
+	if k.Sign() == 0 || k.Cmp(fr.Modulus()) >= 0 {
+		return nil, errInvalidSecretKey
+	}
+	return &SecretKey{scalar: k}, nil
+}
+
+// Bytes는 비밀 키의 32바이트 빅 엔디언 표현을 반환합니다.
+func (sk *SecretKey) Bytes() []byte {
+	b := make([]byte, SecretKeyLength)
+	return sk.scalar.FillBytes(b)
+}
+
+// PublicKey는 비밀 키에 대응하는 공개 키를 반환합니다.
+func (sk *SecretKey) PublicKey() *PublicKey {
+	pk := new(PublicKey)
+	pk.point.ScalarMultiplicationBase(sk.scalar)
+	return pk
+}
+
+// Sign은 메시지 msg에 대한 서명을 생성합니다.
+func (sk *SecretKey) Sign(msg []byte) *Signature {
+	h, err := hashToG2(msg)
+	if err != nil {
+		// hash-to-curve는 고정된 DST에 대해 실패하지 않습니다.
+		panic(err)
+	}
+	sig := new(Signature)
+	sig.point.ScalarMultiplication(&h, sk.scalar)
+	return sig
+}
+
+// PublicKeyFromBytes는 48바이트 압축 공개 키를 디코딩합니다. 곡선 위의 점인지와 부분군에 속하는지
+// 검사하며, 무한원점은 거부됩니다.
+func PublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeyLength {
+		return nil, errInvalidPublicKey
+	}
+	pk := new(PublicKey)
+	if _, err := pk.point.SetBytes(b); err != nil {
+		return nil, errInvalidPublicKey
+	}
+	if pk.point.IsInfinity() {
+		return nil, errInvalidPublicKey
+	}
+	return pk, nil
+}
+
+// Bytes는 공개 키의 48바이트 압축 표현을 반환합니다.
+func (pk *PublicKey) Bytes() []byte {
+	b := pk.point.Bytes()
+	return b[:]
+}
+
+// Equal은 두 공개 키가 같은지 여부를 반환합니다.
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return pk.point.Equal(&other.point)
+}
+
+// SignatureFromBytes는 96바이트 압축 서명을 디코딩합니다. 곡선 위의 점인지와 부분군에 속하는지 검사합니다.
+func SignatureFromBytes(b []byte) (*Signature, error) {
+	if len(b) != SignatureLength {
+		return nil, errInvalidSignature
+	}
+	sig := new(Signature)
+	if _, err := sig.point.SetBytes(b); err != nil {
+		return nil, errInvalidSignature
+	}
+	return sig, nil
+}
+
+// Bytes는 서명의 96바이트 압축 표현을 반환합니다.
+func (sig *Signature) Bytes() []byte {
+	b := sig.point.Bytes()
+	return b[:]
+}
+
+// Verify는 sig가 공개 키 pk로 메시지 msg에 대해 생성된 유효한 서명인지 확인합니다.
+func Verify(pk *PublicKey, msg []byte, sig *Signature) bool {
+	if pk.point.IsInfinity() {
+		return false
+	}
+	h, err := hashToG2(msg)
+	if err != nil {
+		return false
+	}
+	// e(pk, H(msg)) == e(g1, sig) <=> e(pk, H(msg)) * e(-g1, sig) == 1
+	return pairingCheck([]bls12381.G1Affine{pk.point}, []bls12381.G2Affine{h}, &sig.point)
+}
+
+// AggregateSignatures는 여러 서명을 하나의 서명으로 집계합니다.
+func AggregateSignatures(sigs []*Signature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, errEmptyAggregate
+	}
+	var acc bls12381.G2Jac
+	acc.FromAffine(&sigs[0].point)
+	for _, sig := range sigs[1:] {
+		acc.AddMixed(&sig.point)
+	}
+	agg := new(Signature)
+	agg.point.FromJacobian(&acc)
+	return agg, nil
+}
+
+// AggregatePublicKeys는 여러 공개 키를 하나의 공개 키로 집계합니다.
+func AggregatePublicKeys(pks []*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, errEmptyAggregate
+	}
+	var acc bls12381.G1Jac
+	acc.FromAffine(&pks[0].point)
+	for _, pk := range pks[1:] {
+		acc.AddMixed(&pk.point)
+	}
+	agg := new(PublicKey)
+	agg.point.FromJacobian(&acc)
+	return agg, nil
+}
+
+// AggregateVerify는 집계 서명 sig가 각 공개 키 pks[i]로 메시지 msgs[i]에 서명한 서명들의 집계인지
+// 확인합니다. 불량 키 공격을 막기 위해 메시지는 서로 달라야 합니다.
+func AggregateVerify(pks []*PublicKey, msgs [][]byte, sig *Signature) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	seen := make(map[string]struct{}, len(msgs))
+	for _, msg := range msgs {
+		if _, ok := seen[string(msg)]; ok {
+			return false
+		}
+		seen[string(msg)] = struct{}{}
+	}
+	var (
+		g1s = make([]bls12381.G1Affine, len(pks))
+		g2s = make([]bls12381.G2Affine, len(msgs))
+	)
+	for i, pk := range pks {
+		if pk.point.IsInfinity() {
+			return false
+		}
+		h, err := hashToG2(msgs[i])
+		if err != nil {
+			return false
+		}
+		g1s[i], g2s[i] = pk.point, h
+	}
+	return pairingCheck(g1s, g2s, &sig.point)
+}
+
+// FastAggregateVerify는 집계 서명 sig가 모든 공개 키 pks로 같은 메시지 msg에 서명한 서명들의
+// 집계인지 확인합니다. 공개 키는 proof-of-possession으로 검증된 것이어야 합니다.
+func FastAggregateVerify(pks []*PublicKey, msg []byte, sig *Signature) bool {
+	agg, err := AggregatePublicKeys(pks)
+	if err != nil {
+		return false
+	}
+	return Verify(agg, msg, sig)
+}
+
+// hashToG2는 메시지를 합의 레이어의 도메인 분리 태그로 G2 위의 점에 해싱합니다.
+func hashToG2(msg []byte) (bls12381.G2Affine, error) {
+	return bls12381.HashToG2(msg, []byte(dst))
+}
+
+// pairingCheck는 prod(e(g1s[i], g2s[i])) == e(g1, sig)인지 확인합니다.
+func pairingCheck(g1s []bls12381.G1Affine, g2s []bls12381.G2Affine, sig *bls12381.G2Affine) bool {
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+
+	ok, err := bls12381.PairingCheck(append(g1s, negG1), append(g2s, *sig))
+	return err == nil && ok
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests signing against a vector from the consensus-spec BLS test suite.
+func TestSignVector(t *testing.T) {
+	sk, err := SecretKeyFromBytes(common.FromHex("0x263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		msg     = make([]byte, 32)
+		wantPub = common.FromHex("0xa491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a")
+		wantSig = common.FromHex("0xb6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55")
+	)
+	if have := sk.PublicKey().Bytes(); !bytes.Equal(have, wantPub) {
+		t.Errorf("public key mismatch: have %x, want %x", have, wantPub)
+	}
+	if have := sk.Sign(msg).Bytes(); !bytes.Equal(have, wantSig) {
+		t.Errorf("signature mismatch: have %x, want %x", have, wantSig)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	sk, err := GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, msg := sk.PublicKey(), []byte("hello")
+	sig := sk.Sign(msg)
+	if !Verify(pk, msg, sig) {
+		t.Fatal("valid signature rejected")
+	}
+	if Verify(pk, []byte("world"), sig) {
+		t.Fatal("signature accepted for wrong message")
+	}
+	// Round trip all the serialized forms.
+	sk2, err := SecretKeyFromBytes(sk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk2, err := PublicKeyFromBytes(pk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2, err := SignatureFromBytes(sig.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pk2.Equal(sk2.PublicKey()) || !Verify(pk2, msg, sig2) {
+		t.Fatal("deserialized keys or signature mismatch")
+	}
+}
+
+func TestInvalidEncodings(t *testing.T) {
+	if _, err := SecretKeyFromBytes(make([]byte, SecretKeyLength)); err == nil {
+		t.Error("zero secret key accepted")
+	}
+	if _, err := SecretKeyFromBytes(bytes.Repeat([]byte{0xff}, SecretKeyLength)); err == nil {
+		t.Error("secret key above group order accepted")
+	}
+	// The compressed point at infinity is a valid encoding, but not a valid key.
+	inf := make([]byte, PublicKeyLength)
+	inf[0] = 0xc0
+	if _, err := PublicKeyFromBytes(inf); err == nil {
+		t.Error("infinity public key accepted")
+	}
+	if _, err := PublicKeyFromBytes(make([]byte, PublicKeyLength-1)); err == nil {
+		t.Error("short public key accepted")
+	}
+	if _, err := SignatureFromBytes(bytes.Repeat([]byte{0xff}, SignatureLength)); err == nil {
+		t.Error("invalid signature accepted")
+	}
+}
+
+func TestAggregateVerify(t *testing.T) {
+	var (
+		pks  []*PublicKey
+		sigs []*Signature
+		msgs [][]byte
+		same []*Signature
+		msg  = []byte("attestation")
+	)
+	for i := 0; i < 4; i++ {
+		sk, err := GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := []byte{byte(i)}
+		pks = append(pks, sk.PublicKey())
+		msgs = append(msgs, m)
+		sigs = append(sigs, sk.Sign(m))
+		same = append(same, sk.Sign(msg))
+	}
+	agg, err := AggregateSignatures(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !AggregateVerify(pks, msgs, agg) {
+		t.Fatal("valid aggregate signature rejected")
+	}
+	if AggregateVerify(pks[1:], msgs[1:], agg) {
+		t.Fatal("aggregate signature accepted for subset")
+	}
+	if AggregateVerify(pks, [][]byte{msgs[0], msgs[0], msgs[2], msgs[3]}, agg) {
+		t.Fatal("aggregate signature accepted with duplicate messages")
+	}
+	fast, err := AggregateSignatures(same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !FastAggregateVerify(pks, msg, fast) {
+		t.Fatal("valid fast aggregate signature rejected")
+	}
+	if FastAggregateVerify(pks[:3], msg, fast) {
+		t.Fatal("fast aggregate signature accepted for subset")
+	}
+	if _, err := AggregateSignatures(nil); err == nil {
+		t.Fatal("empty aggregation accepted")
+	}
+}
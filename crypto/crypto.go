@@ -28,6 +28,7 @@ import (
 	"io"
 	"math/big"
 	"os"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -63,6 +64,11 @@ func NewKeccakState() KeccakState {
 	return sha3.NewLegacyKeccak256().(KeccakState)
 }
 
+// keccakStatePool은 공개 키를 주소로 변환할 때 재사용하는 KeccakState의 풀입니다.
+var keccakStatePool = sync.Pool{
+	New: func() interface{} { return NewKeccakState() },
+}
+
 // HashData는 KeccakState를 사용하여 제공된 데이터를 해시하고 32 바이트 해시를 반환합니다.
 func HashData(kh KeccakState, data []byte) (h common.Hash) {
 	kh.Reset()
@@ -182,6 +188,7 @@ func HexToECDSA(hexkey string) (*ecdsa.PrivateKey, error) {
 	} else if err != nil {
 		return nil, errors.New("invalid hex data for private key")
 	}
+	defer zeroBytes(b)
 	return ToECDSA(b)
 }
 
@@ -195,6 +202,7 @@ func LoadECDSA(file string) (*ecdsa.PrivateKey, error) {
 
 	r := bufio.NewReader(fd)
 	buf := make([]byte, 64)
+	defer zeroBytes(buf)
 	n, err := readASCII(buf, r)
 	if err != nil {
 		return nil, err
@@ -241,8 +249,7 @@ func checkKeyFileEnd(r *bufio.Reader) error {
 
 // SaveECDSA는 제한적인 권한으로 주어진 파일에 secp256k1 개인 키를 저장합니다. 키 데이터는 16진수로 인코딩되어 저장됩니다.
 func SaveECDSA(file string, key *ecdsa.PrivateKey) error {
-	k := hex.EncodeToString(FromECDSA(key))
-	return os.WriteFile(file, []byte(k), 0600)
+	return writeKeyFile(file, key)
 }
 
 // GenerateKey는 새로운 개인 키를 생성합니다.
@@ -270,8 +277,72 @@ func PubkeyToAddress(p ecdsa.PublicKey) common.Address {
 	return common.BytesToAddress(Keccak256(pubBytes[1:])[12:])
 }
 
+// PubkeyBytesToAddress는 직렬화된 공개 키로부터 이더리움 주소를 계산합니다.
+// 압축(33 바이트) 및 비압축(65 바이트) 형식이 모두 허용되며, 형식은 길이로 판별합니다.
+func PubkeyBytesToAddress(pub []byte) (common.Address, error) {
+	kh := keccakStatePool.Get().(KeccakState)
+	defer keccakStatePool.Put(kh)
+
+	return pubkeyBytesToAddress(kh, pub)
+}
+
+// AddressesFromPubkeys는 여러 공개 키를 한 번에 이더리움 주소로 변환합니다.
+// 각 키의 형식은 PubkeyBytesToAddress와 같이 길이로 판별되며, 풀에서 가져온 해시 상태 하나를 모든
+// 키에 대해 재사용합니다. 유효하지 않은 키가 있으면 해당 인덱스를 포함한 오류를 반환합니다.
+func AddressesFromPubkeys(pubs [][]byte) ([]common.Address, error) {
+	kh := keccakStatePool.Get().(KeccakState)
+	defer keccakStatePool.Put(kh)
+
+	addrs := make([]common.Address, len(pubs))
+	for i, pub := range pubs {
+		addr, err := pubkeyBytesToAddress(kh, pub)
+		if err != nil {
+			return nil, fmt.Errorf("public key %d: %w", i, err)
+		}
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
+
+// pubkeyBytesToAddress는 주어진 해시 상태를 사용하여 공개 키로부터 주소를 계산합니다.
+func pubkeyBytesToAddress(kh KeccakState, pub []byte) (common.Address, error) {
+	switch len(pub) {
+	case 65:
+		// 비압축 키는 곡선 위의 점인지만 확인하고, 그대로 해시합니다.
+		if _, err := UnmarshalPubkey(pub); err != nil {
+			return common.Address{}, err
+		}
+	case 33:
+		key, err := DecompressPubkey(pub)
+		if err != nil {
+			return common.Address{}, err
+		}
+		pub = FromECDSAPub(key)
+	default:
+		return common.Address{}, fmt.Errorf("%w: invalid length %d", errInvalidPubkey, len(pub))
+	}
+	var (
+		h    common.Hash
+		addr common.Address
+	)
+	kh.Reset()
+	kh.Write(pub[1:])
+	kh.Read(h[:])
+	copy(addr[:], h[12:])
+	return addr, nil
+}
+
 func zeroBytes(bytes []byte) {
 	for i := range bytes {
 		bytes[i] = 0
 	}
 }
+
+// zeroBigInt는 big.Int의 내부 워드를 덮어쓴 후 값을 0으로 설정합니다.
+func zeroBigInt(x *big.Int) {
+	words := x.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
+}
@@ -297,3 +297,82 @@ func TestPythonIntegration(t *testing.T) {
 	t.Logf("msg: %x, privkey: %s sig: %x\n", msg0, kh, sig0)
 	t.Logf("msg: %x, privkey: %s sig: %x\n", msg1, kh, sig1)
 }
+
+func TestAddressesFromPubkeys(t *testing.T) {
+	key, _ := HexToECDSA(testPrivHex)
+	addr := common.HexToAddress(testAddrHex)
+
+	uncompressed := FromECDSAPub(&key.PublicKey)
+	compressed := CompressPubkey(&key.PublicKey)
+
+	addrs, err := AddressesFromPubkeys([][]byte{uncompressed, compressed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, have := range addrs {
+		if have != addr {
+			t.Errorf("address %d mismatch: have %x, want %x", i, have, addr)
+		}
+	}
+	for _, invalid := range [][]byte{
+		nil,
+		uncompressed[1:],
+		append([]byte{0x05}, uncompressed[1:]...),
+		append([]byte{0x04}, make([]byte, 64)...),
+		append([]byte{0x05}, compressed[1:]...),
+	} {
+		if _, err := AddressesFromPubkeys([][]byte{compressed, invalid}); err == nil {
+			t.Errorf("expected error for key %x", invalid)
+		}
+	}
+}
+
+func BenchmarkAddressesFromPubkeys(b *testing.B) {
+	key, _ := HexToECDSA(testPrivHex)
+	pubs := make([][]byte, 100)
+	for i := range pubs {
+		pubs[i] = FromECDSAPub(&key.PublicKey)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := AddressesFromPubkeys(pubs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTextHash(t *testing.T) {
+	hash := TextHash([]byte("Hello Joe"))
+	want := hexutil.MustDecode("0xa080337ae51c4e064c189e113edd0ba391df9206e2f49db658bb32cf2911730b")
+	if !bytes.Equal(hash, want) {
+		t.Fatalf("wrong hash: have %x, want %x", hash, want)
+	}
+}
+
+// Tests the typed data digest against the "Mail" example of EIP-712.
+func TestTypedDataHash(t *testing.T) {
+	var (
+		domain = hexutil.MustDecode("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f")
+		msg    = hexutil.MustDecode("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e")
+		want   = hexutil.MustDecode("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2")
+	)
+	hash, err := TypedDataHash(domain, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(hash, want) {
+		t.Fatalf("wrong hash: have %x, want %x", hash, want)
+	}
+	// Inputs which are not 32 byte hashes must be rejected.
+	for _, tt := range []struct{ domain, msg []byte }{
+		{domain[:31], msg},
+		{domain, msg[:31]},
+		{append(domain, 0x00), msg},
+		{domain, append(msg, 0x00)},
+		{nil, msg},
+	} {
+		if _, err := TypedDataHash(tt.domain, tt.msg); err == nil {
+			t.Errorf("expected error for domain length %d, message length %d", len(tt.domain), len(tt.msg))
+		}
+	}
+}
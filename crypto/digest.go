@@ -0,0 +1,51 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// textPrefix는 EIP-191 버전 0x45(personal_sign) 메시지의 접두사입니다.
+const textPrefix = "\x19Ethereum Signed Message:\n"
+
+// TextHash는 EIP-191 personal_sign 규칙에 따라 메시지의 서명용 다이제스트를 계산합니다.
+//
+//	keccak256("\x19Ethereum Signed Message:\n"${message length}${message})
+//
+// 접두사는 서명된 메시지에 문맥을 부여하여 트랜잭션으로 해석될 수 없도록 합니다.
+func TextHash(data []byte) []byte {
+	return Keccak256([]byte(textPrefix), []byte(strconv.Itoa(len(data))), data)
+}
+
+// TypedDataHash는 EIP-712 규칙에 따라 도메인 구분자와 메시지 구조체 해시로부터 서명용 다이제스트를
+// 계산합니다.
+//
+//	keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+//
+// domainSeparator는 EIP712Domain 구조체의 hashStruct 값이며, 두 입력 중 하나라도 32바이트가
+// 아니면 오류를 반환합니다. 구조체 인코딩은 signer/core/apitypes 패키지의 TypedData가 담당합니다.
+func TypedDataHash(domainSeparator, structHash []byte) ([]byte, error) {
+	if len(domainSeparator) != DigestLength {
+		return nil, fmt.Errorf("invalid domain separator length %d, want %d", len(domainSeparator), DigestLength)
+	}
+	if len(structHash) != DigestLength {
+		return nil, fmt.Errorf("invalid struct hash length %d, want %d", len(structHash), DigestLength)
+	}
+	return Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash), nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ecies
+
+import (
+	"crypto/cipher"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// StreamChunkSize is the maximum amount of plaintext sealed in a single frame
+// of the streaming format.
+const StreamChunkSize = 16 * 1024
+
+const (
+	frameHeaderSize = 4
+	frameFinalFlag  = 1 << 31
+)
+
+var ErrStreamClosed = errors.New("ecies: write to closed stream")
+
+// EncryptGCM encrypts a message using ECIES with AES-GCM as the symmetric
+// scheme instead of AES-CTR with HMAC. The ciphertext is the ephemeral public
+// key, followed by the GCM nonce and the sealed message.
+//
+// As with Encrypt, s1 is fed into key derivation. s2 is authenticated as
+// additional data. Either may be nil.
+func EncryptGCM(rand io.Reader, pub *PublicKey, m, s1, s2 []byte) ([]byte, error) {
+	Rb, aead, err := newSenderAEAD(rand, pub, s1)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand, nonce); err != nil {
+		return nil, err
+	}
+	ct := make([]byte, 0, len(Rb)+len(nonce)+len(m)+aead.Overhead())
+	ct = append(ct, Rb...)
+	ct = append(ct, nonce...)
+	return aead.Seal(ct, nonce, m, s2), nil
+}
+
+// DecryptGCM decrypts a ciphertext created by EncryptGCM.
+func (prv *PrivateKey) DecryptGCM(c, s1, s2 []byte) ([]byte, error) {
+	rLen := pubkeyLen(&prv.PublicKey)
+	if len(c) < rLen {
+		return nil, ErrInvalidMessage
+	}
+	aead, err := prv.newReceiverAEAD(c[:rLen], s1)
+	if err != nil {
+		return nil, err
+	}
+	c = c[rLen:]
+	if len(c) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidMessage
+	}
+	m, err := aead.Open(nil, c[:aead.NonceSize()], c[aead.NonceSize():], s2)
+	if err != nil {
+		return nil, ErrInvalidMessage
+	}
+	return m, nil
+}
+
+// NewWriter returns a writer which encrypts everything written to it for pub
+// and writes the ciphertext to w. The output starts with the ephemeral public
+// key, followed by AES-GCM sealed frames of at most StreamChunkSize plaintext
+// bytes each. Frames are numbered, and the last frame is marked so that
+// reordering and truncation of the stream are detected by the reader.
+//
+// The writer must be closed to emit the final frame. Close does not close w.
+func NewWriter(rand io.Reader, w io.Writer, pub *PublicKey, s1, s2 []byte) (io.WriteCloser, error) {
+	Rb, aead, err := newSenderAEAD(rand, pub, s1)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(Rb); err != nil {
+		return nil, err
+	}
+	return &streamWriter{
+		w:    w,
+		aead: aead,
+		ad:   s2,
+		buf:  make([]byte, 0, StreamChunkSize),
+	}, nil
+}
+
+type streamWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	ad     []byte
+	buf    []byte // pending plaintext
+	frame  []byte // sealed frame buffer
+	seq    uint64
+	closed bool
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	if sw.closed {
+		return 0, ErrStreamClosed
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(sw.buf) == StreamChunkSize {
+			if err := sw.flush(false); err != nil {
+				return n - len(p), err
+			}
+		}
+		k := copy(sw.buf[len(sw.buf):StreamChunkSize], p)
+		sw.buf = sw.buf[:len(sw.buf)+k]
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// Close seals any buffered plaintext into the final frame.
+func (sw *streamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	return sw.flush(true)
+}
+
+func (sw *streamWriter) flush(final bool) error {
+	size := uint32(len(sw.buf) + sw.aead.Overhead())
+	if final {
+		size |= frameFinalFlag
+	}
+	sw.frame = binary.BigEndian.AppendUint32(sw.frame[:0], size)
+	sw.frame = sw.aead.Seal(sw.frame, frameNonce(sw.aead, sw.seq, final), sw.buf, sw.ad)
+	sw.buf = sw.buf[:0]
+	sw.seq++
+	_, err := sw.w.Write(sw.frame)
+	return err
+}
+
+// NewReader returns a reader which decrypts a stream created by NewWriter. The
+// ephemeral public key is read from r immediately. Reading past the end of the
+// stream returns io.EOF only if the final frame was authenticated; a truncated
+// stream yields io.ErrUnexpectedEOF and a modified one yields ErrInvalidMessage.
+func (prv *PrivateKey) NewReader(r io.Reader, s1, s2 []byte) (io.Reader, error) {
+	Rb := make([]byte, pubkeyLen(&prv.PublicKey))
+	if _, err := io.ReadFull(r, Rb); err != nil {
+		return nil, err
+	}
+	aead, err := prv.newReceiverAEAD(Rb, s1)
+	if err != nil {
+		return nil, err
+	}
+	return &streamReader{r: r, aead: aead, ad: s2}, nil
+}
+
+type streamReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	ad    []byte
+	buf   []byte // decrypted, unread plaintext
+	frame []byte
+	seq   uint64
+	done  bool
+	err   error
+}
+
+func (sr *streamReader) Read(p []byte) (int, error) {
+	for len(sr.buf) == 0 {
+		if sr.err != nil {
+			return 0, sr.err
+		}
+		if sr.done {
+			return 0, io.EOF
+		}
+		sr.err = sr.readFrame()
+	}
+	n := copy(p, sr.buf)
+	sr.buf = sr.buf[n:]
+	return n, nil
+}
+
+func (sr *streamReader) readFrame() error {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(sr.r, header[:]); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	final := size&frameFinalFlag != 0
+	size &^= frameFinalFlag
+	if size < uint32(sr.aead.Overhead()) || size > uint32(StreamChunkSize+sr.aead.Overhead()) {
+		return ErrInvalidMessage
+	}
+	if cap(sr.frame) < int(size) {
+		sr.frame = make([]byte, size)
+	}
+	sr.frame = sr.frame[:size]
+	if _, err := io.ReadFull(sr.r, sr.frame); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	m, err := sr.aead.Open(sr.frame[:0], frameNonce(sr.aead, sr.seq, final), sr.frame, sr.ad)
+	if err != nil {
+		return ErrInvalidMessage
+	}
+	sr.buf = m
+	sr.seq++
+	sr.done = final
+	return nil
+}
+
+// frameNonce computes the nonce of a stream frame from its sequence number and
+// whether it is the final frame. The key is unique to each stream, so nonces
+// never repeat.
+func frameNonce(aead cipher.AEAD, seq uint64, final bool) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-9:], seq)
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// newSenderAEAD generates an ephemeral key and derives the AES-GCM cipher shared
+// with pub. It returns the marshaled ephemeral public key.
+func newSenderAEAD(rand io.Reader, pub *PublicKey, s1 []byte) ([]byte, cipher.AEAD, error) {
+	params, err := pubkeyParams(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	R, err := GenerateKey(rand, pub.Curve, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := R.sharedAEAD(pub, params, s1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return elliptic.Marshal(pub.Curve, R.PublicKey.X, R.PublicKey.Y), aead, nil
+}
+
+// newReceiverAEAD derives the AES-GCM cipher shared with the marshaled ephemeral
+// public key Rb.
+func (prv *PrivateKey) newReceiverAEAD(Rb, s1 []byte) (cipher.AEAD, error) {
+	params, err := pubkeyParams(&prv.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	R := &PublicKey{Curve: prv.PublicKey.Curve}
+	R.X, R.Y = elliptic.Unmarshal(R.Curve, Rb)
+	if R.X == nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return prv.sharedAEAD(R, params, s1)
+}
+
+func (prv *PrivateKey) sharedAEAD(pub *PublicKey, params *ECIESParams, s1 []byte) (cipher.AEAD, error) {
+	z, err := prv.GenerateShared(pub, params.KeyLen, params.KeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := params.Cipher(concatKDF(params.Hash(), z, s1, params.KeyLen))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pubkeyLen returns the length of an uncompressed public key on the curve of pub.
+func pubkeyLen(pub *PublicKey) int {
+	return 1 + 2*((pub.Curve.Params().BitSize+7)/8)
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ecies
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestEncryptDecryptGCM(t *testing.T) {
+	prv, err := GenerateKey(rand.Reader, crypto.S256(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("Hello, world.")
+	ct, err := EncryptGCM(rand.Reader, &prv.PublicKey, msg, []byte("s1"), []byte("s2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt, err := prv.DecryptGCM(ct, []byte("s1"), []byte("s2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pt, msg) {
+		t.Fatalf("wrong plaintext %q", pt)
+	}
+	if _, err := prv.DecryptGCM(ct, []byte("s1"), nil); err != ErrInvalidMessage {
+		t.Fatalf("wrong error for mismatched shared info: %v", err)
+	}
+	ct[len(ct)-1] ^= 1
+	if _, err := prv.DecryptGCM(ct, []byte("s1"), []byte("s2")); err != ErrInvalidMessage {
+		t.Fatalf("wrong error for modified ciphertext: %v", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	prv, err := GenerateKey(rand.Reader, crypto.S256(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{0, 1, StreamChunkSize, 3*StreamChunkSize + 7} {
+		msg := make([]byte, size)
+		rand.Read(msg)
+
+		var buf bytes.Buffer
+		w, err := NewWriter(rand.Reader, &buf, &prv.PublicKey, nil, []byte("ad"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Write in odd-sized pieces to exercise buffering.
+		for rest := msg; len(rest) > 0; {
+			n := 1000
+			if n > len(rest) {
+				n = len(rest)
+			}
+			if _, err := w.Write(rest[:n]); err != nil {
+				t.Fatal(err)
+			}
+			rest = rest[n:]
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte{1}); err != ErrStreamClosed {
+			t.Fatalf("wrong error for write after close: %v", err)
+		}
+		enc := buf.Bytes()
+
+		r, err := prv.NewReader(bytes.NewReader(enc), nil, []byte("ad"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pt, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if !bytes.Equal(pt, msg) {
+			t.Fatalf("size %d: plaintext mismatch", size)
+		}
+
+		// Truncating the stream at a frame boundary must be detected.
+		if size > StreamChunkSize {
+			truncated := enc[:len(enc)-(frameHeaderSize+7+16)]
+			r, _ := prv.NewReader(bytes.NewReader(truncated), nil, []byte("ad"))
+			if _, err := io.ReadAll(r); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("wrong error for truncated stream: %v", err)
+			}
+		}
+	}
+}
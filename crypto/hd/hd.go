@@ -0,0 +1,254 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// hd 패키지는 secp256k1 키에 대한 BIP-32 계층적 결정론적 키 파생과 BIP-44 경로 파싱을
+// 구현합니다. 파생된 키는 crypto 패키지의 ecdsa 키 타입으로 변환할 수 있습니다.
+package hd
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// HardenedOffset는 강화(hardened) 자식 인덱스의 시작 값입니다.
+const HardenedOffset uint32 = 0x80000000
+
+const (
+	// MinSeedLength와 MaxSeedLength는 BIP-32가 허용하는 시드 길이의 범위입니다.
+	MinSeedLength = 16
+	MaxSeedLength = 64
+)
+
+var (
+	ErrInvalidSeedLength = fmt.Errorf("hd: seed length must be between %d and %d bytes", MinSeedLength, MaxSeedLength)
+	ErrInvalidKey        = errors.New("hd: derived key is invalid")
+	ErrHardenedPublic    = errors.New("hd: cannot derive hardened child from public key")
+	ErrNotPrivate        = errors.New("hd: key is not private")
+	ErrDepthExceeded     = errors.New("hd: maximum derivation depth exceeded")
+	ErrInvalidPath       = errors.New("hd: invalid derivation path")
+)
+
+// masterKey는 마스터 키 생성 시 HMAC-SHA512의 키로 사용되는 값입니다.
+var masterKey = []byte("Bitcoin seed")
+
+// DefaultPath는 이더리움 계정의 첫 번째 BIP-44 경로 m/44'/60'/0'/0/0입니다.
+var DefaultPath = Path{HardenedOffset + 44, HardenedOffset + 60, HardenedOffset + 0, 0, 0}
+
+// ExtendedKey는 BIP-32 확장 키입니다. 개인 확장 키는 개인 키와 공개 키 모두의 자식을
+// 파생할 수 있으며, 공개 확장 키는 강화되지 않은 공개 자식만 파생할 수 있습니다.
+type ExtendedKey struct {
+	priv      *big.Int // 공개 확장 키이면 nil
+	pubX      *big.Int
+	pubY      *big.Int
+	chainCode [32]byte
+	depth     uint8
+	parentFP  [4]byte
+	childNum  uint32
+}
+
+// NewMaster는 시드로부터 마스터 확장 키를 생성합니다.
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < MinSeedLength || len(seed) > MaxSeedLength {
+		return nil, ErrInvalidSeedLength
+	}
+	mac := hmac.New(sha512.New, masterKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	key := &ExtendedKey{priv: k}
+	key.pubX, key.pubY = crypto.S256().ScalarBaseMult(sum[:32])
+	copy(key.chainCode[:], sum[32:])
+	return key, nil
+}
+
+// IsPrivate는 키가 개인 확장 키인지 여부를 반환합니다.
+func (k *ExtendedKey) IsPrivate() bool { return k.priv != nil }
+
+// Depth는 마스터 키로부터의 파생 깊이를 반환합니다. 마스터 키는 0입니다.
+func (k *ExtendedKey) Depth() uint8 { return k.depth }
+
+// ChildNumber는 부모로부터 이 키를 파생할 때 사용한 인덱스를 반환합니다.
+func (k *ExtendedKey) ChildNumber() uint32 { return k.childNum }
+
+// ChainCode는 키의 체인 코드를 반환합니다.
+func (k *ExtendedKey) ChainCode() [32]byte { return k.chainCode }
+
+// ParentFingerprint는 부모 키의 지문을 반환합니다. 마스터 키는 0입니다.
+func (k *ExtendedKey) ParentFingerprint() [4]byte { return k.parentFP }
+
+// Fingerprint는 압축된 공개 키의 HASH160 앞 4바이트인 키의 지문을 반환합니다.
+func (k *ExtendedKey) Fingerprint() [4]byte {
+	sha := sha256.Sum256(k.compressedPubkey())
+	h := ripemd160.New()
+	h.Write(sha[:])
+	var fp [4]byte
+	copy(fp[:], h.Sum(nil))
+	return fp
+}
+
+// Neuter는 개인 키 정보를 제거한 공개 확장 키를 반환합니다.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	cpy := *k
+	cpy.priv = nil
+	return &cpy
+}
+
+// Child는 인덱스 i의 자식 키를 파생합니다. i가 HardenedOffset 이상이면 강화 자식을 파생하며,
+// 이는 개인 확장 키에서만 가능합니다. 파생된 키가 유효하지 않으면(확률은 2^127분의 1 미만)
+// ErrInvalidKey를 반환하며, 호출자는 다음 인덱스를 사용해야 합니다.
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	if k.depth == 0xff {
+		return nil, ErrDepthExceeded
+	}
+	hardened := i >= HardenedOffset
+	if hardened && !k.IsPrivate() {
+		return nil, ErrHardenedPublic
+	}
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, math.PaddedBigBytes(k.priv, 32)...)
+	} else {
+		data = append(data, k.compressedPubkey()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var (
+		curve = crypto.S256()
+		n     = curve.Params().N
+		il    = new(big.Int).SetBytes(sum[:32])
+	)
+	if il.Cmp(n) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	child := &ExtendedKey{
+		depth:    k.depth + 1,
+		parentFP: k.Fingerprint(),
+		childNum: i,
+	}
+	copy(child.chainCode[:], sum[32:])
+
+	if k.IsPrivate() {
+		child.priv = il.Add(il, k.priv)
+		child.priv.Mod(child.priv, n)
+		if child.priv.Sign() == 0 {
+			return nil, ErrInvalidKey
+		}
+		child.pubX, child.pubY = curve.ScalarBaseMult(math.PaddedBigBytes(child.priv, 32))
+	} else {
+		x, y := curve.ScalarBaseMult(sum[:32])
+		child.pubX, child.pubY = curve.Add(x, y, k.pubX, k.pubY)
+		if child.pubX.Sign() == 0 && child.pubY.Sign() == 0 {
+			return nil, ErrInvalidKey
+		}
+	}
+	return child, nil
+}
+
+// Derive는 경로의 각 인덱스를 차례로 적용하여 자손 키를 파생합니다.
+func (k *ExtendedKey) Derive(path Path) (*ExtendedKey, error) {
+	key := k
+	for _, i := range path {
+		var err error
+		if key, err = key.Child(i); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// PrivateKey는 개인 확장 키를 ecdsa 개인 키로 변환합니다.
+func (k *ExtendedKey) PrivateKey() (*ecdsa.PrivateKey, error) {
+	if !k.IsPrivate() {
+		return nil, ErrNotPrivate
+	}
+	return crypto.ToECDSA(math.PaddedBigBytes(k.priv, 32))
+}
+
+// PublicKey는 확장 키의 공개 키를 반환합니다.
+func (k *ExtendedKey) PublicKey() *ecdsa.PublicKey {
+	return &ecdsa.PublicKey{
+		Curve: crypto.S256(),
+		X:     new(big.Int).Set(k.pubX),
+		Y:     new(big.Int).Set(k.pubY),
+	}
+}
+
+func (k *ExtendedKey) compressedPubkey() []byte {
+	return crypto.CompressPubkey(&ecdsa.PublicKey{Curve: crypto.S256(), X: k.pubX, Y: k.pubY})
+}
+
+// Path는 BIP-32 파생 경로입니다. 각 요소는 자식 인덱스이며, 강화 인덱스는 HardenedOffset이
+// 더해진 값입니다.
+type Path []uint32
+
+// ParsePath는 "m/44'/60'/0'/0/0" 형식의 경로를 파싱합니다. 경로는 "m"으로 시작해야 하며,
+// 강화 인덱스는 ' 또는 h 접미사로 표시합니다.
+func ParsePath(s string) (Path, error) {
+	components := strings.Split(s, "/")
+	if components[0] != "m" {
+		return nil, fmt.Errorf("%w: %q does not start with \"m\"", ErrInvalidPath, s)
+	}
+	path := make(Path, 0, len(components)-1)
+	for _, c := range components[1:] {
+		var offset uint32
+		if trimmed := strings.TrimRight(c, "'hH"); len(trimmed) == len(c)-1 {
+			c, offset = trimmed, HardenedOffset
+		}
+		index, err := strconv.ParseUint(c, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid component %q in %q", ErrInvalidPath, c, s)
+		}
+		path = append(path, uint32(index)+offset)
+	}
+	return path, nil
+}
+
+// String은 경로를 "m/44'/60'/0'/0/0" 형식으로 반환합니다.
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, i := range p {
+		b.WriteByte('/')
+		if i >= HardenedOffset {
+			b.WriteString(strconv.FormatUint(uint64(i-HardenedOffset), 10))
+			b.WriteByte('\'')
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(i), 10))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Test vector 1 from BIP-32.
+func TestDeriveVector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path      string
+		chainCode string
+		priv      string
+	}{
+		{"m", "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0h/1/2h/2/1000000000", "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, test := range tests {
+		path, err := ParsePath(test.path)
+		if err != nil {
+			t.Fatalf("%s: %v", test.path, err)
+		}
+		key, err := master.Derive(path)
+		if err != nil {
+			t.Fatalf("%s: %v", test.path, err)
+		}
+		if cc := key.ChainCode(); hex.EncodeToString(cc[:]) != test.chainCode {
+			t.Errorf("%s: wrong chain code %x", test.path, cc)
+		}
+		priv, err := key.PrivateKey()
+		if err != nil {
+			t.Fatalf("%s: %v", test.path, err)
+		}
+		if have := hex.EncodeToString(crypto.FromECDSA(priv)); have != test.priv {
+			t.Errorf("%s: wrong private key %s", test.path, have)
+		}
+		if key.Depth() != uint8(len(path)) {
+			t.Errorf("%s: wrong depth %d", test.path, key.Depth())
+		}
+	}
+}
+
+func TestPublicDerivation(t *testing.T) {
+	master, err := NewMaster(bytes.Repeat([]byte{0x42}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.Derive(Path{HardenedOffset + 44, HardenedOffset + 60, HardenedOffset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := account.Neuter()
+	if _, err := pub.Child(HardenedOffset); !errors.Is(err, ErrHardenedPublic) {
+		t.Fatalf("wrong error for hardened public derivation: %v", err)
+	}
+	if _, err := pub.PrivateKey(); !errors.Is(err, ErrNotPrivate) {
+		t.Fatalf("wrong error for private key of public key: %v", err)
+	}
+	privChild, err := account.Derive(Path{0, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubChild, err := pub.Derive(Path{0, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(crypto.FromECDSAPub(privChild.PublicKey()), crypto.FromECDSAPub(pubChild.PublicKey())) {
+		t.Fatal("public and private derivation mismatch")
+	}
+	if privChild.ParentFingerprint() != pubChild.ParentFingerprint() {
+		t.Fatal("parent fingerprint mismatch")
+	}
+	priv, _ := privChild.PrivateKey()
+	if !bytes.Equal(math.PaddedBigBytes(priv.X, 32), math.PaddedBigBytes(pubChild.PublicKey().X, 32)) {
+		t.Fatal("private key does not match derived public key")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := ParsePath("m/44'/60'/0'/0/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != len(DefaultPath) || path.String() != DefaultPath.String() {
+		t.Fatalf("wrong path %v", path)
+	}
+	if path, err := ParsePath("m"); err != nil || len(path) != 0 {
+		t.Fatalf("wrong result for master path: %v, %v", path, err)
+	}
+	for _, invalid := range []string{"", "44'/60'", "m/", "m/-1", "m/2147483648", "m/1''", "m/x"} {
+		if _, err := ParsePath(invalid); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("%q: wrong error %v", invalid, err)
+		}
+	}
+	if _, err := NewMaster(make([]byte, 8)); !errors.Is(err, ErrInvalidSeedLength) {
+		t.Fatalf("wrong error for short seed: %v", err)
+	}
+}
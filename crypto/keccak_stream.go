@@ -0,0 +1,220 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// keccakRate는 Keccak-256 스펀지의 블록 크기(바이트)입니다.
+const keccakRate = 136
+
+// resumableKeccakMagic은 저장된 ResumableKeccak 상태의 접두사입니다. 마지막 바이트는 형식 버전입니다.
+var resumableKeccakMagic = []byte("keccak256\x01")
+
+var errInvalidKeccakSnapshot = errors.New("invalid keccak state snapshot")
+
+// ResumableKeccak은 내부 상태를 저장하고 복원할 수 있는 Keccak-256 해셔입니다. 수백 MB 크기의
+// 파일처럼 한 번에 메모리에 올리기 어려운 입력을 여러 번에 나누어 해시하고, 중간 상태를 Save로
+// 저장해 두었다가 Restore로 이어서 해시할 때 사용합니다. 결과는 Keccak256과 같습니다.
+//
+// 상태를 직렬화해야 하므로 sha3 패키지 대신 이식 가능한 순열 구현을 사용합니다. 상태 저장이
+// 필요하지 않다면 Keccak256Reader가 더 빠릅니다.
+type ResumableKeccak struct {
+	a      [25]uint64
+	buf    [keccakRate]byte
+	nbuf   int
+	length uint64
+}
+
+// NewResumableKeccak은 빈 ResumableKeccak을 생성합니다.
+func NewResumableKeccak() *ResumableKeccak {
+	return new(ResumableKeccak)
+}
+
+// Write는 p를 해시 상태에 흡수합니다. 오류를 반환하지 않습니다.
+func (k *ResumableKeccak) Write(p []byte) (int, error) {
+	n := len(p)
+	k.length += uint64(n)
+	if k.nbuf > 0 {
+		c := copy(k.buf[k.nbuf:], p)
+		k.nbuf += c
+		p = p[c:]
+		if k.nbuf < keccakRate {
+			return n, nil
+		}
+		k.absorb(k.buf[:])
+		k.nbuf = 0
+	}
+	for len(p) >= keccakRate {
+		k.absorb(p[:keccakRate])
+		p = p[keccakRate:]
+	}
+	k.nbuf = copy(k.buf[:], p)
+	return n, nil
+}
+
+// Absorb는 r에서 최대 limit 바이트를 읽어 해시 상태에 흡수하고, 읽은 바이트 수를 반환합니다.
+// r의 끝에 도달하면 io.EOF를 반환합니다. 호출자는 Absorb 호출 사이에 상태를 저장하거나 다른
+// 작업에 실행을 양보할 수 있습니다.
+func (k *ResumableKeccak) Absorb(r io.Reader, limit int64) (int64, error) {
+	n, err := io.CopyN(k, r, limit)
+	if err == nil && n < limit {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Len은 지금까지 흡수한 바이트 수를 반환합니다.
+func (k *ResumableKeccak) Len() uint64 {
+	return k.length
+}
+
+// Sum256은 지금까지 흡수한 데이터의 Keccak-256 해시를 반환합니다. 해시 상태는 변경되지 않으므로
+// 이후에도 계속 데이터를 쓸 수 있습니다.
+func (k *ResumableKeccak) Sum256() (h common.Hash) {
+	a := k.a
+	var block [keccakRate]byte
+	copy(block[:], k.buf[:k.nbuf])
+	block[k.nbuf] = 0x01
+	block[keccakRate-1] ^= 0x80
+	xorBlock(&a, block[:])
+	keccakF1600(&a)
+	for i := 0; i < len(h)/8; i++ {
+		binary.LittleEndian.PutUint64(h[i*8:], a[i])
+	}
+	return h
+}
+
+// Reset은 해시 상태를 초기화합니다.
+func (k *ResumableKeccak) Reset() {
+	*k = ResumableKeccak{}
+}
+
+// Save는 현재 해시 상태를 직렬화하여 반환합니다. 반환된 값은 Restore에 전달하여 해시를 이어갈
+// 수 있습니다. 직렬화된 상태의 크기는 흡수한 데이터의 양과 관계없이 400바이트를 넘지 않습니다.
+func (k *ResumableKeccak) Save() []byte {
+	enc := make([]byte, 0, len(resumableKeccakMagic)+25*8+8+k.nbuf)
+	enc = append(enc, resumableKeccakMagic...)
+	for _, w := range k.a {
+		enc = binary.BigEndian.AppendUint64(enc, w)
+	}
+	enc = binary.BigEndian.AppendUint64(enc, k.length)
+	return append(enc, k.buf[:k.nbuf]...)
+}
+
+// Restore는 Save로 저장한 해시 상태를 복원합니다. 형식이 잘못되었으면 오류를 반환하고 상태를
+// 변경하지 않습니다.
+func (k *ResumableKeccak) Restore(enc []byte) error {
+	if !bytes.HasPrefix(enc, resumableKeccakMagic) {
+		return errInvalidKeccakSnapshot
+	}
+	enc = enc[len(resumableKeccakMagic):]
+	if len(enc) < 25*8+8 {
+		return errInvalidKeccakSnapshot
+	}
+	var restored ResumableKeccak
+	for i := range restored.a {
+		restored.a[i] = binary.BigEndian.Uint64(enc[i*8:])
+	}
+	restored.length = binary.BigEndian.Uint64(enc[25*8:])
+	rest := enc[25*8+8:]
+	if len(rest) >= keccakRate || uint64(len(rest)) != restored.length%keccakRate {
+		return errInvalidKeccakSnapshot
+	}
+	restored.nbuf = copy(restored.buf[:], rest)
+	*k = restored
+	return nil
+}
+
+// absorb는 한 블록을 상태에 XOR하고 순열을 적용합니다.
+func (k *ResumableKeccak) absorb(block []byte) {
+	xorBlock(&k.a, block)
+	keccakF1600(&k.a)
+}
+
+// Keccak256Reader는 r의 모든 데이터를 읽어 Keccak-256 해시를 계산합니다. 입력 전체를 메모리에
+// 올리지 않으므로 큰 파일을 해시할 때 사용할 수 있습니다.
+func Keccak256Reader(r io.Reader) (h common.Hash, err error) {
+	d := NewKeccakState()
+	if _, err := io.Copy(d, r); err != nil {
+		return common.Hash{}, err
+	}
+	d.Read(h[:])
+	return h, nil
+}
+
+// xorBlock은 리틀 엔디언 워드로 해석한 block을 상태의 앞부분에 XOR합니다.
+func xorBlock(a *[25]uint64, block []byte) {
+	for i := 0; i < keccakRate/8; i++ {
+		a[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+	}
+}
+
+// keccakRoundConstants는 ι 단계의 라운드 상수입니다.
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var (
+	// keccakRotations는 ρ 단계의 회전량이고, keccakPiLanes는 π 단계에서 레인을 옮기는 순서입니다.
+	keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+	keccakPiLanes   = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+)
+
+// keccakF1600은 25개의 uint64로 표현된 1600비트 상태에 Keccak 순열을 적용합니다.
+func keccakF1600(a *[25]uint64) {
+	var bc [5]uint64
+	for round := 0; round < 24; round++ {
+		// θ 단계
+		for i := 0; i < 5; i++ {
+			bc[i] = a[i] ^ a[i+5] ^ a[i+10] ^ a[i+15] ^ a[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ bits.RotateLeft64(bc[(i+1)%5], 1)
+			for j := 0; j < 25; j += 5 {
+				a[j+i] ^= t
+			}
+		}
+		// ρ 단계와 π 단계
+		t := a[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPiLanes[i]
+			t, a[j] = a[j], bits.RotateLeft64(t, keccakRotations[i])
+		}
+		// χ 단계
+		for j := 0; j < 25; j += 5 {
+			copy(bc[:], a[j:j+5])
+			for i := 0; i < 5; i++ {
+				a[j+i] ^= ^bc[(i+1)%5] & bc[(i+2)%5]
+			}
+		}
+		// ι 단계
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestResumableKeccak(t *testing.T) {
+	data := make([]byte, 3*keccakRate+17)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	for _, size := range []int{0, 1, keccakRate - 1, keccakRate, keccakRate + 1, len(data)} {
+		want := Keccak256Hash(data[:size])
+		k := NewResumableKeccak()
+		k.Write(data[:size])
+		if have := k.Sum256(); have != want {
+			t.Errorf("size %d: hash mismatch: have %x, want %x", size, have, want)
+		}
+		// Sum256 must not disturb the state.
+		if have := k.Sum256(); have != want {
+			t.Errorf("size %d: second Sum256 mismatch", size)
+		}
+	}
+}
+
+func TestResumableKeccakSaveRestore(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(2)).Read(data)
+	want := Keccak256Hash(data)
+
+	// Hash the input in odd-sized chunks, round-tripping the state between chunks.
+	var (
+		r     = bytes.NewReader(data)
+		saved = NewResumableKeccak().Save()
+	)
+	for {
+		k := NewResumableKeccak()
+		if err := k.Restore(saved); err != nil {
+			t.Fatal(err)
+		}
+		_, err := k.Absorb(r, 333)
+		saved = k.Save()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	k := NewResumableKeccak()
+	if err := k.Restore(saved); err != nil {
+		t.Fatal(err)
+	}
+	if k.Len() != uint64(len(data)) {
+		t.Fatalf("wrong length %d", k.Len())
+	}
+	if have := k.Sum256(); have != want {
+		t.Fatalf("hash mismatch: have %x, want %x", have, want)
+	}
+
+	// Corrupted snapshots must be rejected.
+	for _, enc := range [][]byte{nil, saved[:20], append(saved, 1), append([]byte("x"), saved[1:]...)} {
+		if err := k.Restore(enc); err == nil {
+			t.Errorf("no error for invalid snapshot %x", enc)
+		}
+	}
+}
+
+func TestKeccak256Reader(t *testing.T) {
+	data := make([]byte, 5000)
+	rand.New(rand.NewSource(3)).Read(data)
+	h, err := Keccak256Reader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Keccak256Hash(data); h != want {
+		t.Fatalf("hash mismatch: have %x, want %x", h, want)
+	}
+}
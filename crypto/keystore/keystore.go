@@ -0,0 +1,234 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// keystore 패키지는 Web3 Secret Storage 정의의 V3 JSON 키 저장 형식으로 secp256k1 개인 키를
+// 암호화하고 복호화합니다. 실제 암호화와 MAC 검사는 accounts/keystore 패키지의 구현을
+// 사용하며, 이 패키지는 *ecdsa.PrivateKey를 직접 다루는 API와 신뢰할 수 없는 키 파일에 대한
+// 매개변수 검사를 더합니다.
+//
+// 이 패키지는 계정 관리자와 독립적으로 키 파일을 읽고 쓰는 도구를 위한 것입니다. 디렉터리
+// 단위의 키 관리가 필요하면 accounts/keystore 패키지를 사용하십시오.
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ks "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// Version은 이 패키지가 생성하고 읽는 키 파일 형식의 버전입니다.
+const Version = 3
+
+const (
+	KDFScrypt = "scrypt" // scrypt 키 유도 함수
+	KDFPBKDF2 = "pbkdf2" // hmac-sha256을 사용하는 PBKDF2 키 유도 함수
+
+	derivedKeyLen = 32
+
+	// 키 파일의 매개변수는 신뢰할 수 없으므로, 복호화 전에 메모리와 CPU 사용량의 상한을
+	// 검사합니다. scrypt는 128*N*r 바이트의 메모리를 사용합니다.
+	maxScryptN          = 1 << 20
+	maxScryptR          = 16
+	maxScryptP          = 16
+	maxScryptMemory     = 1 << 30
+	maxPBKDF2Iterations = 1 << 24
+	maxDerivedKeyLen    = 64
+)
+
+var (
+	ErrDecrypt         = ks.ErrDecrypt
+	ErrUnsupportedKDF  = errors.New("unsupported key derivation function")
+	ErrInvalidParams   = errors.New("invalid key derivation parameters")
+	ErrAddressMismatch = errors.New("key does not match address in key file")
+)
+
+// Params는 키를 암호화할 때 사용할 scrypt 매개변수입니다. 블록 크기 r은 accounts/keystore와
+// 같이 8로 고정됩니다.
+type Params struct {
+	ScryptN int // scrypt CPU/메모리 비용, 1보다 큰 2의 거듭제곱
+	ScryptP int // scrypt 병렬화 계수
+}
+
+var (
+	// StandardScryptParams는 약 256MB 메모리와 최신 프로세서에서 약 1초의 CPU 시간을 사용합니다.
+	StandardScryptParams = Params{ScryptN: ks.StandardScryptN, ScryptP: ks.StandardScryptP}
+
+	// LightScryptParams는 약 4MB 메모리와 최신 프로세서에서 약 100ms의 CPU 시간을 사용합니다.
+	LightScryptParams = Params{ScryptN: ks.LightScryptN, ScryptP: ks.LightScryptP}
+)
+
+// Validate는 매개변수가 키 유도에 사용될 수 있는지 확인합니다.
+func (p Params) Validate() error {
+	return validateScrypt(p.ScryptN, 8, p.ScryptP)
+}
+
+// KeyJSON은 V3 키 파일의 JSON 표현입니다.
+type KeyJSON struct {
+	Address string     `json:"address,omitempty"`
+	Crypto  CryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+// CryptoJSON은 키 파일의 암호화된 데이터와 복호화에 필요한 매개변수입니다.
+type CryptoJSON = ks.CryptoJSON
+
+// EncryptKey는 개인 키를 주어진 비밀번호와 매개변수로 암호화하여 V3 JSON 키 파일을 반환합니다.
+// 키 ID로는 무작위 UUID가 생성됩니다.
+func EncryptKey(key *ecdsa.PrivateKey, auth string, params Params) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	k := &ks.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}
+	return ks.EncryptKey(k, auth, params.ScryptN, params.ScryptP)
+}
+
+// DecryptKey는 V3 JSON 키 파일을 주어진 비밀번호로 복호화하여 개인 키를 반환합니다. 키 파일에
+// 주소가 기록되어 있으면 복호화된 키의 주소와 일치하는지도 확인합니다.
+func DecryptKey(keyjson []byte, auth string) (*ecdsa.PrivateKey, error) {
+	var k KeyJSON
+	if err := json.Unmarshal(keyjson, &k); err != nil {
+		return nil, err
+	}
+	if k.Version != Version {
+		return nil, fmt.Errorf("version not supported: %v", k.Version)
+	}
+	keyBytes, err := DecryptData(k.Crypto, auth)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(keyBytes)
+
+	// 일부 오래된 구현은 앞쪽의 0 바이트를 잘라낸 키를 저장합니다.
+	if len(keyBytes) < 32 {
+		padded := common.LeftPadBytes(keyBytes, 32)
+		defer zeroBytes(padded)
+		keyBytes = padded
+	}
+	key, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	if k.Address != "" {
+		want := common.HexToAddress(k.Address)
+		if have := crypto.PubkeyToAddress(key.PublicKey); have != want {
+			return nil, fmt.Errorf("%w: have %x, want %x", ErrAddressMismatch, have, want)
+		}
+	}
+	return key, nil
+}
+
+// DecryptData는 V3 형식으로 암호화된 데이터를 복호화합니다. 키 유도 매개변수가 상한을
+// 넘으면 키를 유도하기 전에 ErrInvalidParams를 반환하고, MAC이 일치하지 않으면, 즉
+// 비밀번호가 틀리거나 데이터가 손상되었으면 ErrDecrypt를 반환합니다.
+func DecryptData(c CryptoJSON, auth string) ([]byte, error) {
+	if err := validateKDFParams(c.KDF, c.KDFParams); err != nil {
+		return nil, err
+	}
+	return ks.DecryptDataV3(c, auth)
+}
+
+// validateKDFParams는 키 파일에 기록된 키 유도 매개변수의 형식과 상한을 검사합니다.
+func validateKDFParams(kdf string, params map[string]interface{}) error {
+	if _, ok := params["salt"].(string); !ok {
+		return fmt.Errorf("%w: missing salt", ErrInvalidParams)
+	}
+	dkLen, err := kdfParam(params, "dklen")
+	if err != nil {
+		return err
+	}
+	if dkLen < derivedKeyLen || dkLen > maxDerivedKeyLen {
+		return fmt.Errorf("%w: derived key length %d out of range [%d, %d]", ErrInvalidParams, dkLen, derivedKeyLen, maxDerivedKeyLen)
+	}
+	switch kdf {
+	case KDFScrypt:
+		var n, r, p int
+		if n, err = kdfParam(params, "n"); err != nil {
+			return err
+		}
+		if r, err = kdfParam(params, "r"); err != nil {
+			return err
+		}
+		if p, err = kdfParam(params, "p"); err != nil {
+			return err
+		}
+		return validateScrypt(n, r, p)
+	case KDFPBKDF2:
+		if _, ok := params["prf"].(string); !ok {
+			return fmt.Errorf("%w: missing PBKDF2 PRF", ErrInvalidParams)
+		}
+		c, err := kdfParam(params, "c")
+		if err != nil {
+			return err
+		}
+		if c <= 0 || c > maxPBKDF2Iterations {
+			return fmt.Errorf("%w: pbkdf2 iteration count %d out of range [1, %d]", ErrInvalidParams, c, maxPBKDF2Iterations)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedKDF, kdf)
+	}
+}
+
+// validateScrypt는 scrypt 매개변수가 유효하고 상한 이내인지 확인합니다.
+func validateScrypt(n, r, p int) error {
+	if n <= 1 || n&(n-1) != 0 || n > maxScryptN {
+		return fmt.Errorf("%w: scrypt N must be a power of two in (1, %d], have %d", ErrInvalidParams, maxScryptN, n)
+	}
+	if r <= 0 || r > maxScryptR || p <= 0 || p > maxScryptP {
+		return fmt.Errorf("%w: scrypt r and p must be in [1, %d] and [1, %d], have r=%d p=%d", ErrInvalidParams, maxScryptR, maxScryptP, r, p)
+	}
+	if 128*uint64(n)*uint64(r) > maxScryptMemory {
+		return fmt.Errorf("%w: scrypt memory use %d exceeds %d bytes", ErrInvalidParams, 128*uint64(n)*uint64(r), maxScryptMemory)
+	}
+	return nil
+}
+
+// kdfParam은 키 유도 매개변수 맵에서 정수 값을 읽습니다. JSON에서 읽은 숫자는 float64로,
+// 같은 프로세스에서 만든 값은 int로 저장되어 있습니다.
+func kdfParam(params map[string]interface{}, name string) (int, error) {
+	switch v := params[name].(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("%w: non-integer %s %v", ErrInvalidParams, name, v)
+		}
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("%w: missing or invalid %s", ErrInvalidParams, name)
+	}
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
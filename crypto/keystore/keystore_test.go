@@ -0,0 +1,160 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var testParams = Params{ScryptN: 2, ScryptP: 1}
+
+func TestDecryptVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/v3_test_vector.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests map[string]struct {
+		JSON     json.RawMessage `json:"json"`
+		Password string          `json:"password"`
+		Priv     string          `json:"priv"`
+	}
+	if err := json.Unmarshal(data, &tests); err != nil {
+		t.Fatal(err)
+	}
+	for name, test := range tests {
+		key, err := DecryptKey(test.JSON, test.Password)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		want, _ := hex.DecodeString(test.Priv)
+		if have := crypto.FromECDSA(key); hex.EncodeToString(have[32-len(want):]) != test.Priv {
+			t.Errorf("%s: wrong key %x, want %s", name, have, test.Priv)
+		}
+		if _, err := DecryptKey(test.JSON, test.Password+"x"); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("%s: wrong error for bad password: %v", name, err)
+		}
+	}
+}
+
+func TestEncryptDecryptKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	keyjson, err := EncryptKey(key, "foo", testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var k KeyJSON
+	if err := json.Unmarshal(keyjson, &k); err != nil {
+		t.Fatal(err)
+	}
+	if k.Crypto.KDF != KDFScrypt || k.Version != Version {
+		t.Fatalf("wrong key file header %s", keyjson)
+	}
+	dec, err := DecryptKey(keyjson, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec.D.Cmp(key.D) != 0 {
+		t.Fatal("decrypted key mismatch")
+	}
+	if _, err := DecryptKey(keyjson, "bar"); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("wrong error for bad password: %v", err)
+	}
+}
+
+func TestDecryptKeyAddressMismatch(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	keyjson, err := EncryptKey(key, "foo", testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var k KeyJSON
+	json.Unmarshal(keyjson, &k)
+	k.Address = "0000000000000000000000000000000000000001"
+	keyjson, _ = json.Marshal(k)
+	if _, err := DecryptKey(keyjson, "foo"); !errors.Is(err, ErrAddressMismatch) {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
+
+func TestParamsValidate(t *testing.T) {
+	tests := []struct {
+		params Params
+		err    error
+	}{
+		{StandardScryptParams, nil},
+		{LightScryptParams, nil},
+		{Params{ScryptN: 3, ScryptP: 1}, ErrInvalidParams},
+		{Params{ScryptN: 2, ScryptP: 0}, ErrInvalidParams},
+		{Params{ScryptN: 1 << 21, ScryptP: 1}, ErrInvalidParams},
+		{Params{ScryptN: 2, ScryptP: 17}, ErrInvalidParams},
+	}
+	for i, test := range tests {
+		if err := test.params.Validate(); !errors.Is(err, test.err) {
+			t.Errorf("test %d: wrong error %v, want %v", i, err, test.err)
+		}
+	}
+	key, _ := crypto.GenerateKey()
+	if _, err := EncryptKey(key, "foo", Params{ScryptN: 3, ScryptP: 1}); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("wrong error encrypting with bad params: %v", err)
+	}
+}
+
+// Tests that key derivation parameters read from a key file are bounded before
+// any key is derived from them.
+func TestDecryptDataParamsLimits(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	keyjson, err := EncryptKey(key, "foo", testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		kdf    string
+		params map[string]interface{}
+		err    error
+	}{
+		{KDFScrypt, map[string]interface{}{"n": float64(1 << 30)}, ErrInvalidParams},
+		{KDFScrypt, map[string]interface{}{"r": float64(1 << 20)}, ErrInvalidParams},
+		{KDFScrypt, map[string]interface{}{"p": float64(1 << 20)}, ErrInvalidParams},
+		{KDFScrypt, map[string]interface{}{"n": float64(1 << 20), "r": float64(16)}, ErrInvalidParams},
+		{KDFScrypt, map[string]interface{}{"n": 2.5}, ErrInvalidParams},
+		{KDFScrypt, map[string]interface{}{"n": "2"}, ErrInvalidParams},
+		{KDFScrypt, map[string]interface{}{"dklen": float64(1 << 30)}, ErrInvalidParams},
+		{KDFPBKDF2, map[string]interface{}{"c": float64(1 << 30), "prf": "hmac-sha256"}, ErrInvalidParams},
+		{KDFPBKDF2, map[string]interface{}{"c": float64(1)}, ErrInvalidParams},
+		{"argon2", nil, ErrUnsupportedKDF},
+	}
+	for i, test := range tests {
+		var k KeyJSON
+		if err := json.Unmarshal(keyjson, &k); err != nil {
+			t.Fatal(err)
+		}
+		k.Crypto.KDF = test.kdf
+		for name, value := range test.params {
+			k.Crypto.KDFParams[name] = value
+		}
+		if _, err := DecryptData(k.Crypto, "foo"); !errors.Is(err, test.err) {
+			t.Errorf("test %d: wrong error %v, want %v", i, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg4844
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// The cell API below implements the polynomial commitment sampling scheme of
+// EIP-7594 (PeerDAS). Neither the Go nor the C backend exposes it in the versions
+// currently in use, so it is implemented directly on top of gnark-crypto and is
+// used regardless of the UseCKZG setting.
+
+const (
+	// FieldElementsPerCell is the number of field elements in a single cell.
+	FieldElementsPerCell = 64
+
+	// CellsPerExtBlob is the number of cells in a blob extended by the erasure code.
+	CellsPerExtBlob = 128
+
+	fieldElementsPerBlob    = 4096
+	fieldElementsPerExtBlob = fieldElementsPerBlob * 2
+	bytesPerFieldElement    = 32
+)
+
+// Cell is a contiguous chunk of the erasure-coded extension of a blob, the unit
+// of data availability sampling.
+type Cell [FieldElementsPerCell * bytesPerFieldElement]byte
+
+var (
+	errCellIndex          = errors.New("cell index out of range")
+	errDuplicateCellIndex = errors.New("duplicate cell index")
+	errNotEnoughCells     = errors.New("not enough cells to recover blob")
+	errInconsistentCells  = errors.New("cells do not belong to a single blob")
+	errInvalidCellProof   = errors.New("invalid cell proof")
+)
+
+// cellContext holds the precomputed parameters of the cell proof scheme.
+type cellContext struct {
+	lagrange []bls12381.G1Affine // Lagrange basis of the blob domain in bit-reversal order
+	g2       bls12381.G2Affine   // [1]_2
+	g2Tau64  bls12381.G2Affine   // [tau^64]_2
+
+	blobDomain *fft.Domain // Roots of unity of order 4096
+	extDomain  *fft.Domain // Roots of unity of order 8192
+	cellDomain *fft.Domain // Roots of unity of order 64
+
+	// cosetShifts[i] is the first point of the coset of cell i; the cell's
+	// evaluation points are cosetShifts[i] times the 64th roots of unity.
+	cosetShifts [CellsPerExtBlob]fr.Element
+}
+
+var (
+	cellCtx    *cellContext
+	cellIniter sync.Once
+)
+
+// cellInit loads the trusted setup and precomputes the cell domain parameters.
+func cellInit() {
+	config, err := content.ReadFile("trusted_setup.json")
+	if err != nil {
+		panic(err)
+	}
+	params := new(gokzg4844.JSONTrustedSetup)
+	if err = json.Unmarshal(config, params); err != nil {
+		panic(err)
+	}
+	ctx := &cellContext{
+		lagrange:   make([]bls12381.G1Affine, fieldElementsPerBlob),
+		blobDomain: fft.NewDomain(fieldElementsPerBlob),
+		extDomain:  fft.NewDomain(fieldElementsPerExtBlob),
+		cellDomain: fft.NewDomain(FieldElementsPerCell),
+	}
+	for i, hex := range params.SetupG1Lagrange {
+		if _, err := ctx.lagrange[i].SetBytes(hexutil.MustDecode(hex)); err != nil {
+			panic(err)
+		}
+	}
+	// The setup lists the Lagrange basis in natural order, but blobs are laid
+	// out in bit-reversal order.
+	bitReversePoints(ctx.lagrange)
+
+	if _, err := ctx.g2.SetBytes(hexutil.MustDecode(params.SetupG2[0])); err != nil {
+		panic(err)
+	}
+	if _, err := ctx.g2Tau64.SetBytes(hexutil.MustDecode(params.SetupG2[FieldElementsPerCell])); err != nil {
+		panic(err)
+	}
+	// The extended domain in bit-reversal order groups the evaluation points of
+	// each cell together, the first one being the shift of the coset.
+	roots := make([]fr.Element, fieldElementsPerExtBlob)
+	roots[0].SetOne()
+	for i := 1; i < len(roots); i++ {
+		roots[i].Mul(&roots[i-1], &ctx.extDomain.Generator)
+	}
+	fft.BitReverse(roots)
+	for i := range ctx.cosetShifts {
+		ctx.cosetShifts[i] = roots[i*FieldElementsPerCell]
+	}
+	cellCtx = ctx
+}
+
+// ComputeCells returns the cells of the erasure-coded extension of the blob.
+func ComputeCells(blob Blob) ([]Cell, error) {
+	cellIniter.Do(cellInit)
+
+	coeffs, err := cellCtx.blobToCoefficients(&blob)
+	if err != nil {
+		return nil, err
+	}
+	return cellCtx.coefficientsToCells(coeffs), nil
+}
+
+// ComputeCellProofs returns the KZG proofs of every cell of the blob, which can
+// be verified against the blob commitment with VerifyCellProofBatch.
+func ComputeCellProofs(blob Blob) ([]Proof, error) {
+	cellIniter.Do(cellInit)
+
+	coeffs, err := cellCtx.blobToCoefficients(&blob)
+	if err != nil {
+		return nil, err
+	}
+	return cellCtx.computeCellProofs(coeffs), nil
+}
+
+// VerifyCellProofBatch verifies that each cells[i] is the cell at cellIndices[i]
+// of the blob committed to by commitments[i], using proofs[i].
+func VerifyCellProofBatch(commitments []Commitment, cellIndices []uint64, cells []Cell, proofs []Proof) error {
+	cellIniter.Do(cellInit)
+
+	n := len(commitments)
+	if len(cellIndices) != n || len(cells) != n || len(proofs) != n {
+		return fmt.Errorf("mismatched input lengths: %d commitments, %d indices, %d cells, %d proofs", n, len(cellIndices), len(cells), len(proofs))
+	}
+	if n == 0 {
+		return nil
+	}
+	// Fold all the claims into a single pairing check using a random linear
+	// combination. With r_k random and h_k the coset shift of cell k:
+	//
+	//   e(sum r_k (C_k - [I_k(tau)] + h_k^64 proof_k), [1]) == e(sum r_k proof_k, [tau^64])
+	var (
+		points   = make([]bls12381.G1Affine, 0, 2*n)
+		scalars  = make([]fr.Element, 0, 2*n)
+		pis      = make([]bls12381.G1Affine, n)
+		rs       = make([]fr.Element, n)
+		interp   = make([]fr.Element, fieldElementsPerBlob)
+		exponent = big.NewInt(FieldElementsPerCell)
+	)
+	for k := 0; k < n; k++ {
+		if cellIndices[k] >= CellsPerExtBlob {
+			return fmt.Errorf("%w: %d", errCellIndex, cellIndices[k])
+		}
+		commitment, err := gokzg4844.DeserializeKZGCommitment((gokzg4844.KZGCommitment)(commitments[k]))
+		if err != nil {
+			return err
+		}
+		if pis[k], err = gokzg4844.DeserializeKZGProof((gokzg4844.KZGProof)(proofs[k])); err != nil {
+			return err
+		}
+		ys, err := deserializeCell(&cells[k])
+		if err != nil {
+			return err
+		}
+		if _, err := rs[k].SetRandom(); err != nil {
+			return err
+		}
+		// The cell is bit-reversal ordered over the coset h*w^j, so an inverse
+		// FFT yields the coefficients of I(h*x). Scale them back into I(x) and
+		// accumulate into the combined interpolation polynomial.
+		shift := cellCtx.cosetShifts[cellIndices[k]]
+		cellCtx.cellDomain.FFTInverse(ys, fft.DIT)
+
+		var shiftInv, scale, tmp fr.Element
+		shiftInv.Inverse(&shift)
+		scale.Set(&rs[k])
+		for j := range ys {
+			tmp.Mul(&ys[j], &scale)
+			interp[j].Add(&interp[j], &tmp)
+			scale.Mul(&scale, &shiftInv)
+		}
+		var hr fr.Element
+		hr.Exp(shift, exponent).Mul(&hr, &rs[k])
+
+		points = append(points, commitment, pis[k])
+		scalars = append(scalars, rs[k], hr)
+	}
+	var lhs, interpCommit, rhs bls12381.G1Affine
+	if _, err := lhs.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	cellCtx.blobDomain.FFT(interp, fft.DIF)
+	if _, err := interpCommit.MultiExp(cellCtx.lagrange, interp, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	lhs.Sub(&lhs, &interpCommit)
+
+	if _, err := rhs.MultiExp(pis, rs, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	rhs.Neg(&rhs)
+
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{lhs, rhs}, []bls12381.G2Affine{cellCtx.g2, cellCtx.g2Tau64})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidCellProof
+	}
+	return nil
+}
+
+// RecoverCellsAndProofs reconstructs all the cells of an extended blob and their
+// proofs from any half of them. The given cells are not verified against their
+// proofs; callers should do so with VerifyCellProofBatch beforehand.
+func RecoverCellsAndProofs(cellIndices []uint64, cells []Cell) ([]Cell, []Proof, error) {
+	cellIniter.Do(cellInit)
+
+	if len(cellIndices) != len(cells) {
+		return nil, nil, fmt.Errorf("mismatched input lengths: %d indices, %d cells", len(cellIndices), len(cells))
+	}
+	if len(cells) < CellsPerExtBlob/2 {
+		return nil, nil, fmt.Errorf("%w: have %d, want at least %d", errNotEnoughCells, len(cells), CellsPerExtBlob/2)
+	}
+	// Assemble the known evaluations of the extended blob, leaving the missing
+	// ones zeroed out.
+	var (
+		evals = make([]fr.Element, fieldElementsPerExtBlob)
+		known [CellsPerExtBlob]bool
+	)
+	for i, index := range cellIndices {
+		if index >= CellsPerExtBlob {
+			return nil, nil, fmt.Errorf("%w: %d", errCellIndex, index)
+		}
+		if known[index] {
+			return nil, nil, fmt.Errorf("%w: %d", errDuplicateCellIndex, index)
+		}
+		known[index] = true
+
+		ys, err := deserializeCell(&cells[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		copy(evals[index*FieldElementsPerCell:], ys)
+	}
+	fft.BitReverse(evals)
+
+	coeffs, err := cellCtx.recoverCoefficients(evals, &known)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cellCtx.coefficientsToCells(coeffs), cellCtx.computeCellProofs(coeffs), nil
+}
+
+// recoverCoefficients interpolates the blob polynomial from the extended
+// evaluations in natural order, of which the cells not marked as known are
+// zeroed out.
+//
+// With Z the polynomial vanishing on the missing cells, E*Z agrees with P*Z on
+// the whole extended domain, both sides being zero on the missing points. As
+// P*Z fits in the extended domain, its coefficients follow from an inverse FFT,
+// and P is obtained by dividing by Z over a coset where Z has no roots.
+func (ctx *cellContext) recoverCoefficients(evals []fr.Element, known *[CellsPerExtBlob]bool) ([]fr.Element, error) {
+	// All points of cell i are roots of x^64 - h_i^64, so Z is a polynomial in
+	// x^64 with one such factor per missing cell.
+	var (
+		zero     = make([]fr.Element, 1, CellsPerExtBlob/2+1)
+		exponent = big.NewInt(FieldElementsPerCell)
+	)
+	zero[0].SetOne()
+	for i := range known {
+		if known[i] {
+			continue
+		}
+		var root fr.Element
+		root.Exp(ctx.cosetShifts[i], exponent).Neg(&root)
+
+		zero = append(zero, fr.Element{})
+		for j := len(zero) - 1; j > 0; j-- {
+			var tmp fr.Element
+			tmp.Mul(&zero[j], &root)
+			zero[j].Set(&zero[j-1]).Add(&zero[j], &tmp)
+		}
+		zero[0].Mul(&zero[0], &root)
+	}
+	vanishing := make([]fr.Element, fieldElementsPerExtBlob)
+	for i := range zero {
+		vanishing[i*FieldElementsPerCell] = zero[i]
+	}
+	// Evaluate (E*Z)(x) on the extended domain and interpolate it.
+	zeval := make([]fr.Element, fieldElementsPerExtBlob)
+	copy(zeval, vanishing)
+	ctx.extDomain.FFT(zeval, fft.DIF)
+	fft.BitReverse(zeval)
+	for i := range evals {
+		evals[i].Mul(&evals[i], &zeval[i])
+	}
+	ctx.extDomain.FFTInverse(evals, fft.DIF)
+	fft.BitReverse(evals)
+
+	// Divide by Z on a coset of the extended domain.
+	var shift, shiftInv fr.Element
+	shift.SetUint64(7)
+	shiftInv.Inverse(&shift)
+
+	scaleCoefficients(evals, &shift)
+	scaleCoefficients(vanishing, &shift)
+	ctx.extDomain.FFT(evals, fft.DIF)
+	ctx.extDomain.FFT(vanishing, fft.DIF)
+
+	vanishing = fr.BatchInvert(vanishing)
+	for i := range evals {
+		evals[i].Mul(&evals[i], &vanishing[i])
+	}
+	ctx.extDomain.FFTInverse(evals, fft.DIT)
+	scaleCoefficients(evals, &shiftInv)
+
+	// A consistent set of cells yields a polynomial of the blob degree.
+	for i := fieldElementsPerBlob; i < len(evals); i++ {
+		if !evals[i].IsZero() {
+			return nil, errInconsistentCells
+		}
+	}
+	return evals[:fieldElementsPerBlob], nil
+}
+
+// blobToCoefficients converts the blob into the coefficient form of its polynomial.
+func (ctx *cellContext) blobToCoefficients(blob *Blob) ([]fr.Element, error) {
+	poly, err := gokzg4844.DeserializeBlob((gokzg4844.Blob)(*blob))
+	if err != nil {
+		return nil, err
+	}
+	// Blobs hold the evaluations over the roots of unity in bit-reversal order.
+	coeffs := make([]fr.Element, fieldElementsPerBlob)
+	copy(coeffs, poly)
+	ctx.blobDomain.FFTInverse(coeffs, fft.DIT)
+	return coeffs, nil
+}
+
+// coefficientsToCells evaluates the blob polynomial over the extended domain and
+// splits the evaluations into cells.
+func (ctx *cellContext) coefficientsToCells(coeffs []fr.Element) []Cell {
+	evals := make([]fr.Element, fieldElementsPerExtBlob)
+	copy(evals, coeffs)
+	ctx.extDomain.FFT(evals, fft.DIF)
+
+	cells := make([]Cell, CellsPerExtBlob)
+	for i := range cells {
+		for j := 0; j < FieldElementsPerCell; j++ {
+			scalar := gokzg4844.SerializeScalar(evals[i*FieldElementsPerCell+j])
+			copy(cells[i][j*bytesPerFieldElement:], scalar[:])
+		}
+	}
+	return cells
+}
+
+// computeCellProofs computes the proof of every cell of the blob polynomial. The
+// proof of cell i is the commitment to the quotient of the polynomial by the
+// vanishing polynomial of the cell's coset, x^64 - h_i^64.
+func (ctx *cellContext) computeCellProofs(coeffs []fr.Element) []Proof {
+	var (
+		proofs   = make([]Proof, CellsPerExtBlob)
+		rem      = make([]fr.Element, fieldElementsPerBlob)
+		quot     = make([]fr.Element, fieldElementsPerBlob)
+		exponent = big.NewInt(FieldElementsPerCell)
+	)
+	for i := range proofs {
+		var c, tmp fr.Element
+		c.Exp(ctx.cosetShifts[i], exponent)
+
+		copy(rem, coeffs)
+		for j := range quot {
+			quot[j].SetZero()
+		}
+		for d := len(rem) - 1; d >= FieldElementsPerCell; d-- {
+			quot[d-FieldElementsPerCell] = rem[d]
+			tmp.Mul(&rem[d], &c)
+			rem[d-FieldElementsPerCell].Add(&rem[d-FieldElementsPerCell], &tmp)
+		}
+		// Commit to the quotient via its evaluations in the Lagrange basis.
+		ctx.blobDomain.FFT(quot, fft.DIF)
+
+		var proof bls12381.G1Affine
+		if _, err := proof.MultiExp(ctx.lagrange, quot, ecc.MultiExpConfig{}); err != nil {
+			panic(err) // only fails on mismatched lengths
+		}
+		proofs[i] = Proof(gokzg4844.SerializeG1Point(proof))
+	}
+	return proofs
+}
+
+// deserializeCell decodes the field elements of a cell.
+func deserializeCell(cell *Cell) ([]fr.Element, error) {
+	ys := make([]fr.Element, FieldElementsPerCell)
+	for j := range ys {
+		var scalar gokzg4844.Scalar
+		copy(scalar[:], cell[j*bytesPerFieldElement:])
+
+		y, err := gokzg4844.DeserializeScalar(scalar)
+		if err != nil {
+			return nil, err
+		}
+		ys[j] = y
+	}
+	return ys, nil
+}
+
+// bitReversePoints permutes a power-of-two sized slice of points into bit-reversal order.
+func bitReversePoints(points []bls12381.G1Affine) {
+	shift := 64 - uint(bits.Len(uint(len(points)))-1)
+	for i := range points {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+}
+
+// scaleCoefficients multiplies the i-th coefficient of the polynomial by factor^i.
+func scaleCoefficients(coeffs []fr.Element, factor *fr.Element) {
+	var scale fr.Element
+	scale.SetOne()
+	for i := range coeffs {
+		coeffs[i].Mul(&coeffs[i], &scale)
+		scale.Mul(&scale, factor)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg4844
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gopkg.in/yaml.v3"
+)
+
+// The testdata directory holds a subset of the EIP-7594 reference tests of the
+// consensus specs (kzg-mainnet preset), as distributed with c-kzg-4844 v2.1.1.
+// A nil output means the inputs are invalid and must be rejected.
+
+// errMalformedInput marks test inputs which cannot even be decoded into the
+// fixed size types of the API.
+var errMalformedInput = errors.New("malformed test input")
+
+// specTests runs fn for every reference test of the given suite.
+func specTests(t *testing.T, suite string, fn func(t *testing.T, data []byte)) {
+	files, err := filepath.Glob(filepath.Join("testdata", suite, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no reference tests found for %s", suite)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ".yaml")
+		t.Run(name, func(t *testing.T) { fn(t, data) })
+	}
+}
+
+// decodeFixed decodes a hex string into dst, reporting whether it had exactly
+// the right length.
+func decodeFixed(input string, dst []byte) bool {
+	b, err := hexutil.Decode(input)
+	if err != nil || len(b) != len(dst) {
+		return false
+	}
+	copy(dst, b)
+	return true
+}
+
+func decodeCells(inputs []string) ([]Cell, bool) {
+	cells := make([]Cell, len(inputs))
+	for i, input := range inputs {
+		if !decodeFixed(input, cells[i][:]) {
+			return nil, false
+		}
+	}
+	return cells, true
+}
+
+func decodeProofs(inputs []string) ([]Proof, bool) {
+	proofs := make([]Proof, len(inputs))
+	for i, input := range inputs {
+		if !decodeFixed(input, proofs[i][:]) {
+			return nil, false
+		}
+	}
+	return proofs, true
+}
+
+func checkCellsAndProofs(t *testing.T, cells []Cell, proofs []Proof, want [][]string) {
+	t.Helper()
+
+	wantCells, ok := decodeCells(want[0])
+	if !ok {
+		t.Fatal("malformed expected cells")
+	}
+	wantProofs, ok := decodeProofs(want[1])
+	if !ok {
+		t.Fatal("malformed expected proofs")
+	}
+	if len(cells) != len(wantCells) || len(proofs) != len(wantProofs) {
+		t.Fatalf("output length mismatch: have %d cells and %d proofs, want %d and %d", len(cells), len(proofs), len(wantCells), len(wantProofs))
+	}
+	for i := range cells {
+		if cells[i] != wantCells[i] {
+			t.Fatalf("cell %d mismatch", i)
+		}
+		if proofs[i] != wantProofs[i] {
+			t.Fatalf("proof %d mismatch: have %x, want %x", i, proofs[i], wantProofs[i])
+		}
+	}
+}
+
+func TestSpecComputeCellsAndProofs(t *testing.T) {
+	specTests(t, "compute_cells_and_kzg_proofs", func(t *testing.T, data []byte) {
+		var test struct {
+			Input struct {
+				Blob string `yaml:"blob"`
+			} `yaml:"input"`
+			Output [][]string `yaml:"output"`
+		}
+		if err := yaml.Unmarshal(data, &test); err != nil {
+			t.Fatal(err)
+		}
+		var (
+			blob   Blob
+			cells  []Cell
+			proofs []Proof
+			err    error
+		)
+		if !decodeFixed(test.Input.Blob, blob[:]) {
+			err = errMalformedInput
+		} else if cells, err = ComputeCells(blob); err == nil {
+			proofs, err = ComputeCellProofs(blob)
+		}
+		if test.Output == nil {
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkCellsAndProofs(t, cells, proofs, test.Output)
+	})
+}
+
+func TestSpecVerifyCellProofBatch(t *testing.T) {
+	specTests(t, "verify_cell_kzg_proof_batch", func(t *testing.T, data []byte) {
+		var test struct {
+			Input struct {
+				Commitments []string `yaml:"commitments"`
+				CellIndices []uint64 `yaml:"cell_indices"`
+				Cells       []string `yaml:"cells"`
+				Proofs      []string `yaml:"proofs"`
+			} `yaml:"input"`
+			Output *bool `yaml:"output"`
+		}
+		if err := yaml.Unmarshal(data, &test); err != nil {
+			t.Fatal(err)
+		}
+		valid := true
+		commitments := make([]Commitment, len(test.Input.Commitments))
+		for i, input := range test.Input.Commitments {
+			valid = valid && decodeFixed(input, commitments[i][:])
+		}
+		cells, ok := decodeCells(test.Input.Cells)
+		valid = valid && ok
+		proofs, ok := decodeProofs(test.Input.Proofs)
+		valid = valid && ok
+
+		var err error
+		if valid {
+			err = VerifyCellProofBatch(commitments, test.Input.CellIndices, cells, proofs)
+		}
+		switch {
+		case test.Output == nil && valid && err == nil:
+			t.Fatal("expected error for invalid input")
+		case test.Output != nil && !valid:
+			t.Fatal("failed to decode well-formed input")
+		case test.Output != nil && *test.Output && err != nil:
+			t.Fatalf("failed to verify valid proofs: %v", err)
+		case test.Output != nil && !*test.Output && err == nil:
+			t.Fatal("verified incorrect proofs")
+		}
+	})
+}
+
+func TestSpecRecoverCellsAndProofs(t *testing.T) {
+	specTests(t, "recover_cells_and_kzg_proofs", func(t *testing.T, data []byte) {
+		var test struct {
+			Input struct {
+				CellIndices []uint64 `yaml:"cell_indices"`
+				Cells       []string `yaml:"cells"`
+			} `yaml:"input"`
+			Output [][]string `yaml:"output"`
+		}
+		if err := yaml.Unmarshal(data, &test); err != nil {
+			t.Fatal(err)
+		}
+		var (
+			recCells  []Cell
+			recProofs []Proof
+			err       = errMalformedInput
+		)
+		if cells, ok := decodeCells(test.Input.Cells); ok {
+			recCells, recProofs, err = RecoverCellsAndProofs(test.Input.CellIndices, cells)
+		}
+		if test.Output == nil {
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkCellsAndProofs(t, recCells, recProofs, test.Output)
+	})
+}
@@ -18,6 +18,7 @@ package kzg4844
 
 import (
 	"crypto/rand"
+	mrand "math/rand"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -94,6 +95,99 @@ func testKZGWithBlob(t *testing.T, ckzg bool) {
 	}
 }
 
+func TestCellProofs(t *testing.T) {
+	blob := randBlob()
+
+	commitment, err := BlobToCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to create KZG commitment from blob: %v", err)
+	}
+	cells, err := ComputeCells(blob)
+	if err != nil {
+		t.Fatalf("failed to compute cells: %v", err)
+	}
+	// The first half of the extension is the blob itself.
+	for i := 0; i < CellsPerExtBlob/2; i++ {
+		if cells[i] != *(*Cell)(blob[i*len(Cell{}):]) {
+			t.Fatalf("cell %d does not match blob data", i)
+		}
+	}
+	proofs, err := ComputeCellProofs(blob)
+	if err != nil {
+		t.Fatalf("failed to compute cell proofs: %v", err)
+	}
+	var (
+		commitments = make([]Commitment, CellsPerExtBlob)
+		indices     = make([]uint64, CellsPerExtBlob)
+	)
+	for i := range indices {
+		commitments[i], indices[i] = commitment, uint64(i)
+	}
+	if err := VerifyCellProofBatch(commitments, indices, cells, proofs); err != nil {
+		t.Fatalf("failed to verify cell proofs: %v", err)
+	}
+	// Swapping two cells must invalidate the batch.
+	indices[3], indices[4] = indices[4], indices[3]
+	if err := VerifyCellProofBatch(commitments, indices, cells, proofs); err == nil {
+		t.Fatal("verified cells at the wrong indices")
+	}
+	indices[3], indices[4] = indices[4], indices[3]
+
+	cells[7][31] ^= 1
+	if err := VerifyCellProofBatch(commitments, indices, cells, proofs); err == nil {
+		t.Fatal("verified a corrupted cell")
+	}
+	if err := VerifyCellProofBatch(commitments[:1], []uint64{CellsPerExtBlob}, cells[:1], proofs[:1]); err == nil {
+		t.Fatal("verified a cell at an out-of-range index")
+	}
+}
+
+func TestRecoverCellsAndProofs(t *testing.T) {
+	blob := randBlob()
+
+	cells, err := ComputeCells(blob)
+	if err != nil {
+		t.Fatalf("failed to compute cells: %v", err)
+	}
+	commitment, err := BlobToCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to create KZG commitment from blob: %v", err)
+	}
+	// Keep a random half of the cells.
+	var (
+		indices []uint64
+		partial []Cell
+	)
+	for _, i := range mrand.Perm(CellsPerExtBlob)[:CellsPerExtBlob/2] {
+		indices = append(indices, uint64(i))
+		partial = append(partial, cells[i])
+	}
+	recCells, recProofs, err := RecoverCellsAndProofs(indices, partial)
+	if err != nil {
+		t.Fatalf("failed to recover cells: %v", err)
+	}
+	var (
+		commitments = make([]Commitment, CellsPerExtBlob)
+		all         = make([]uint64, CellsPerExtBlob)
+	)
+	for i := range cells {
+		if recCells[i] != cells[i] {
+			t.Fatalf("recovered cell %d mismatch", i)
+		}
+		commitments[i], all[i] = commitment, uint64(i)
+	}
+	if err := VerifyCellProofBatch(commitments, all, recCells, recProofs); err != nil {
+		t.Fatalf("failed to verify recovered proofs: %v", err)
+	}
+	if _, _, err := RecoverCellsAndProofs(indices[1:], partial[1:]); err == nil {
+		t.Fatal("recovered from too few cells")
+	}
+	indices[1] = indices[0]
+	if _, _, err := RecoverCellsAndProofs(indices, partial); err == nil {
+		t.Fatal("recovered from duplicate cells")
+	}
+}
+
 func BenchmarkCKZGBlobToCommitment(b *testing.B)  { benchmarkBlobToCommitment(b, true) }
 func BenchmarkGoKZGBlobToCommitment(b *testing.B) { benchmarkBlobToCommitment(b, false) }
 func benchmarkBlobToCommitment(b *testing.B, ckzg bool) {
@@ -0,0 +1,60 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+)
+
+// P256VerifyInputLength는 EIP-7212 P256VERIFY 프리컴파일 입력의 길이입니다.
+// 입력은 hash || r || s || x || y 형식으로, 각 값은 32바이트 빅엔디언 정수입니다.
+const P256VerifyInputLength = 160
+
+// VerifyP256은 secp256r1(P-256) 곡선 위의 공개 키 (x, y)에 대해 32바이트 해시 hash의 ECDSA
+// 서명 (r, s)를 검증합니다. EIP-7212의 프리컴파일과 같은 규칙을 따릅니다:
+//   - r과 s는 0 < r, s < n 범위에 있어야 합니다.
+//   - 공개 키는 무한원점이 아닌 곡선 위의 점이어야 합니다.
+//   - secp256k1 트랜잭션 서명과 달리 S 값의 가변성을 제한하지 않으므로, high-S 서명도 유효합니다.
+func VerifyP256(hash []byte, r, s, x, y *big.Int) bool {
+	if len(hash) != DigestLength || r == nil || s == nil || x == nil || y == nil {
+		return false
+	}
+	curve := elliptic.P256()
+	if !curve.IsOnCurve(x, y) {
+		return false
+	}
+	// ecdsa.Verify는 r과 s의 범위를 검사합니다.
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, hash, r, s)
+}
+
+// VerifyP256Input은 P256VerifyInputLength 바이트의 프리컴파일 입력을 분해하여 VerifyP256으로
+// 검증합니다. 입력의 길이가 다르면 false를 반환합니다.
+func VerifyP256Input(input []byte) bool {
+	if len(input) != P256VerifyInputLength {
+		return false
+	}
+	var (
+		hash = input[:32]
+		r    = new(big.Int).SetBytes(input[32:64])
+		s    = new(big.Int).SetBytes(input[64:96])
+		x    = new(big.Int).SetBytes(input[96:128])
+		y    = new(big.Int).SetBytes(input[128:160])
+	)
+	return VerifyP256(hash, r, s, x, y)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestVerifyP256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := Keccak256([]byte("passkey"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := key.X, key.Y
+	if !VerifyP256(hash, r, s, x, y) {
+		t.Fatal("valid signature rejected")
+	}
+	// The malleated signature (r, n-s) is valid as well.
+	n := elliptic.P256().Params().N
+	if !VerifyP256(hash, r, new(big.Int).Sub(n, s), x, y) {
+		t.Fatal("high-S signature rejected")
+	}
+
+	tests := []struct {
+		name       string
+		hash       []byte
+		r, s, x, y *big.Int
+	}{
+		{"wrong hash", Keccak256([]byte("other")), r, s, x, y},
+		{"short hash", hash[:31], r, s, x, y},
+		{"zero r", hash, new(big.Int), s, x, y},
+		{"zero s", hash, r, new(big.Int), x, y},
+		{"r = n", hash, n, s, x, y},
+		{"s = n", hash, r, n, x, y},
+		{"point at infinity", hash, r, s, new(big.Int), new(big.Int)},
+		{"point not on curve", hash, r, s, x, new(big.Int).Add(y, big.NewInt(1))},
+		{"nil value", hash, nil, s, x, y},
+	}
+	for _, test := range tests {
+		if VerifyP256(test.hash, test.r, test.s, test.x, test.y) {
+			t.Errorf("%s: invalid signature accepted", test.name)
+		}
+	}
+
+	// The precompile input form.
+	input := make([]byte, P256VerifyInputLength)
+	copy(input, hash)
+	r.FillBytes(input[32:64])
+	s.FillBytes(input[64:96])
+	x.FillBytes(input[96:128])
+	y.FillBytes(input[128:160])
+	if !VerifyP256Input(input) {
+		t.Fatal("valid precompile input rejected")
+	}
+	if VerifyP256Input(input[:159]) || VerifyP256Input(append(input, 0)) {
+		t.Fatal("precompile input of wrong length accepted")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// ErrKeyZeroed는 Zero로 지워진 개인 키를 사용하려 할 때 반환됩니다.
+var ErrKeyZeroed = errors.New("private key has been zeroed")
+
+// PrivateKey는 secp256k1 개인 키를 감싸 비밀 값의 수명을 관리합니다. Bytes는 항상 방어적
+// 복사본을 반환하고, Zero는 키가 차지하던 메모리를 지웁니다. 지워진 키로 서명하거나 키를
+// 내보내려 하면 ErrKeyZeroed를 반환합니다.
+//
+// PrivateKey는 동시 사용에 안전하지 않습니다. Zero는 다른 고루틴이 키를 사용하지 않을 때만
+// 호출해야 합니다.
+type PrivateKey struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewPrivateKey는 32바이트 이진 표현으로부터 개인 키를 생성합니다. 입력은 복사되므로 호출자는
+// 반환 후 d를 지워도 됩니다.
+func NewPrivateKey(d []byte) (*PrivateKey, error) {
+	key, err := ToECDSA(d)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{key: key}, nil
+}
+
+// WrapECDSA는 기존 ECDSA 개인 키를 감쌉니다. 키는 복사되지 않으며, 이후 Zero를 호출하면
+// 원래 키도 함께 지워집니다.
+func WrapECDSA(key *ecdsa.PrivateKey) *PrivateKey {
+	return &PrivateKey{key: key}
+}
+
+// GeneratePrivateKey는 새로운 개인 키를 생성합니다.
+func GeneratePrivateKey() (*PrivateKey, error) {
+	key, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{key: key}, nil
+}
+
+// Bytes는 개인 키를 32바이트 이진 형식으로 반환합니다. 반환된 슬라이스는 매번 새로 할당되는
+// 복사본이므로 호출자가 사용 후 지워야 합니다. 키가 지워졌으면 nil을 반환합니다.
+func (k *PrivateKey) Bytes() []byte {
+	if k.IsZeroed() {
+		return nil
+	}
+	return FromECDSA(k.key)
+}
+
+// ECDSA는 감싸진 ECDSA 개인 키를 반환합니다. 키가 지워졌으면 nil을 반환합니다.
+func (k *PrivateKey) ECDSA() *ecdsa.PrivateKey {
+	if k.IsZeroed() {
+		return nil
+	}
+	return k.key
+}
+
+// Public은 개인 키에 대응하는 공개 키를 반환합니다. 키가 지워졌으면 nil을 반환합니다.
+func (k *PrivateKey) Public() *ecdsa.PublicKey {
+	if k.IsZeroed() {
+		return nil
+	}
+	return &k.key.PublicKey
+}
+
+// Address는 개인 키에 대응하는 주소를 반환합니다. 키가 지워졌으면 0 주소를 반환합니다.
+func (k *PrivateKey) Address() common.Address {
+	if k.IsZeroed() {
+		return common.Address{}
+	}
+	return PubkeyToAddress(k.key.PublicKey)
+}
+
+// Sign은 이 키로 다이제스트에 대한 복구 가능한 ECDSA 서명을 계산합니다. 형식은 패키지 수준의
+// Sign 함수와 같습니다.
+func (k *PrivateKey) Sign(digestHash []byte) ([]byte, error) {
+	if k.IsZeroed() {
+		return nil, ErrKeyZeroed
+	}
+	return Sign(digestHash, k.key)
+}
+
+// Equal은 두 키가 같은 비밀 값을 가지는지를 상수 시간에 비교합니다. 지워진 키는 어떤 키와도
+// 같지 않습니다.
+func (k *PrivateKey) Equal(other *PrivateKey) bool {
+	if k.IsZeroed() || other.IsZeroed() {
+		return false
+	}
+	a, b := k.Bytes(), other.Bytes()
+	defer zeroBytes(a)
+	defer zeroBytes(b)
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// IsZeroed는 키가 지워졌는지 여부를 반환합니다.
+func (k *PrivateKey) IsZeroed() bool {
+	return k == nil || k.key == nil || k.key.D == nil
+}
+
+// Zero는 개인 키의 비밀 값이 저장된 메모리를 덮어쓰고 키를 사용할 수 없게 만듭니다.
+// 이미 지워진 키에 대해 호출해도 안전합니다.
+func (k *PrivateKey) Zero() {
+	if k.IsZeroed() {
+		return
+	}
+	zeroBigInt(k.key.D)
+	k.key.D = nil
+	k.key = nil
+}
+
+// LoadPrivateKey는 주어진 파일에서 secp256k1 개인 키를 로드합니다. 파일 형식은 LoadECDSA와 같습니다.
+func LoadPrivateKey(file string) (*PrivateKey, error) {
+	key, err := LoadECDSA(file)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{key: key}, nil
+}
+
+// SavePrivateKey는 SaveECDSA와 같은 형식으로 주어진 파일에 개인 키를 저장합니다.
+func SavePrivateKey(file string, key *PrivateKey) error {
+	if key.IsZeroed() {
+		return ErrKeyZeroed
+	}
+	return SaveECDSA(file, key.key)
+}
+
+// writeKeyFile은 개인 키를 16진수로 인코딩하여 제한적인 권한으로 파일에 씁니다. 인코딩에
+// 사용된 임시 버퍼는 반환 전에 지워집니다.
+func writeKeyFile(file string, key *ecdsa.PrivateKey) error {
+	seckey := math.PaddedBigBytes(key.D, key.Params().BitSize/8)
+	defer zeroBytes(seckey)
+	enc := make([]byte, hex.EncodedLen(len(seckey)))
+	defer zeroBytes(enc)
+	hex.Encode(enc, seckey)
+	return os.WriteFile(file, enc, 0600)
+}
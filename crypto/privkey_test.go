@@ -0,0 +1,111 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPrivateKeyBytes(t *testing.T) {
+	key, err := NewPrivateKey(common.FromHex(testPrivHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Address() != common.HexToAddress(testAddrHex) {
+		t.Fatalf("wrong address %x", key.Address())
+	}
+	// Modifying the returned bytes must not affect the key.
+	b := key.Bytes()
+	zeroBytes(b)
+	if !bytes.Equal(key.Bytes(), common.FromHex(testPrivHex)) {
+		t.Fatal("key modified through Bytes result")
+	}
+}
+
+func TestPrivateKeyZero(t *testing.T) {
+	ecdsaKey, _ := HexToECDSA(testPrivHex)
+	d := ecdsaKey.D
+	words := d.Bits()
+
+	key := WrapECDSA(ecdsaKey)
+	key.Zero()
+	for i, w := range words {
+		if w != 0 {
+			t.Fatalf("key word %d not wiped", i)
+		}
+	}
+	if d.Sign() != 0 || ecdsaKey.D != nil {
+		t.Fatal("wrapped key not cleared")
+	}
+	if !key.IsZeroed() || key.Bytes() != nil || key.ECDSA() != nil || key.Public() != nil {
+		t.Fatal("zeroed key still usable")
+	}
+	if _, err := key.Sign(Keccak256([]byte("foo"))); !errors.Is(err, ErrKeyZeroed) {
+		t.Fatalf("wrong error signing with zeroed key: %v", err)
+	}
+	if err := SavePrivateKey(filepath.Join(t.TempDir(), "key"), key); !errors.Is(err, ErrKeyZeroed) {
+		t.Fatalf("wrong error saving zeroed key: %v", err)
+	}
+	key.Zero() // must not panic
+}
+
+func TestPrivateKeySign(t *testing.T) {
+	key, _ := NewPrivateKey(common.FromHex(testPrivHex))
+	msg := Keccak256([]byte("foo"))
+	sig, err := key.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := SigToPub(msg, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if PubkeyToAddress(*pub) != key.Address() {
+		t.Fatal("signature recovers wrong address")
+	}
+}
+
+func TestPrivateKeySaveLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "key")
+	key, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SavePrivateKey(file, key); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPrivateKey(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(loaded) {
+		t.Fatal("loaded key not equal to saved key")
+	}
+	other, _ := GeneratePrivateKey()
+	if key.Equal(other) {
+		t.Fatal("different keys compare equal")
+	}
+	loaded.Zero()
+	if key.Equal(loaded) {
+		t.Fatal("zeroed key compares equal")
+	}
+}
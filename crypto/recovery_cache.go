@@ -0,0 +1,73 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// recoveryKey는 복구 캐시의 키로, 서명된 해시와 [R || S || V] 서명으로 구성됩니다.
+type recoveryKey struct {
+	hash common.Hash
+	sig  [SignatureLength]byte
+}
+
+// recoveryCache는 프로세스 전역 공개키 복구 캐시입니다. nil이면 캐시가 비활성화됩니다.
+var recoveryCache atomic.Pointer[lru.Cache[recoveryKey, []byte]]
+
+// EnableRecoveryCache는 최대 size개의 항목을 보관하는 프로세스 전역 공개키 복구 캐시를
+// 활성화합니다. 같은 트랜잭션의 발신자를 트랜잭션 풀, 블록 임포트, RPC 등에서 반복해서
+// 복구할 때 비싼 타원 곡선 연산을 다시 하지 않도록 합니다. size가 0 이하이면 캐시를
+// 비활성화합니다. 다시 호출하면 기존 캐시의 내용은 버려집니다.
+//
+// 캐시는 기본적으로 비활성화되어 있으며 동시에 사용해도 안전합니다.
+func EnableRecoveryCache(size int) {
+	if size <= 0 {
+		recoveryCache.Store(nil)
+		return
+	}
+	recoveryCache.Store(lru.NewCache[recoveryKey, []byte](size))
+}
+
+// Ecrecover는 주어진 서명을 만든 비압축 공개키를 반환합니다. 복구 캐시가 활성화되어
+// 있으면 이전에 복구한 결과를 재사용합니다. 실패한 복구는 캐시되지 않습니다.
+func Ecrecover(hash, sig []byte) ([]byte, error) {
+	cache := recoveryCache.Load()
+	if cache == nil || len(hash) != common.HashLength || len(sig) != SignatureLength {
+		return ecrecover(hash, sig)
+	}
+	var key recoveryKey
+	copy(key.hash[:], hash)
+	copy(key.sig[:], sig)
+	if pub, ok := cache.Get(key); ok {
+		return common.CopyBytes(pub), nil
+	}
+	pub, err := ecrecover(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	cache.Add(key, common.CopyBytes(pub))
+	return pub, nil
+}
+
+// recoveryCacheEnabled는 복구 캐시가 활성화되어 있는지 여부를 반환합니다.
+func recoveryCacheEnabled() bool {
+	return recoveryCache.Load() != nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRecoveryCache(t *testing.T) {
+	EnableRecoveryCache(2)
+	defer EnableRecoveryCache(0)
+
+	for i := 0; i < 2; i++ {
+		pub, err := Ecrecover(testmsg, testsig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pub, testpubkey) {
+			t.Fatalf("pubkey mismatch: want: %x have: %x", testpubkey, pub)
+		}
+		// Modifying the result must not corrupt the cached entry.
+		pub[1] ^= 0xff
+	}
+	if n := recoveryCache.Load().Len(); n != 1 {
+		t.Fatalf("wrong cache size: have %d, want 1", n)
+	}
+	key, err := SigToPub(testmsg, testsig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(FromECDSAPub(key), testpubkey) {
+		t.Fatal("SigToPub returned wrong key")
+	}
+	// Failed recoveries are not cached.
+	badsig := common.CopyBytes(testsig)
+	copy(badsig[:32], make([]byte, 32))
+	if _, err := Ecrecover(testmsg, badsig); err == nil {
+		t.Fatal("no error for zero R value")
+	}
+	if n := recoveryCache.Load().Len(); n != 1 {
+		t.Fatalf("failed recovery was cached, cache size %d", n)
+	}
+	EnableRecoveryCache(0)
+	if recoveryCacheEnabled() {
+		t.Fatal("cache still enabled")
+	}
+}
+
+func TestRecoveryCacheConcurrent(t *testing.T) {
+	EnableRecoveryCache(4)
+	defer EnableRecoveryCache(0)
+
+	var (
+		keys = make([][]byte, 8)
+		sigs = make([][]byte, 8)
+	)
+	for i := range keys {
+		key, _ := GenerateKey()
+		sigs[i], _ = Sign(testmsg, key)
+		keys[i] = FromECDSAPub(&key.PublicKey)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 64; i++ {
+				j := (g + i) % len(sigs)
+				pub, err := Ecrecover(testmsg, sigs[j])
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if !bytes.Equal(pub, keys[j]) {
+					t.Errorf("pubkey mismatch for signature %d", j)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
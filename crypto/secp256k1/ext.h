@@ -34,6 +34,34 @@ static int secp256k1_ext_ecdsa_recover(
 	return secp256k1_ec_pubkey_serialize(ctx, pubkey_out, &outputlen, &pubkey, SECP256K1_EC_UNCOMPRESSED);
 }
 
+// secp256k1_ext_ecdsa_recover_batch recovers the public keys of n encoded compact
+// signatures in a single call.
+//
+// Returns: 1: recovery of all signatures was successful
+//          0: recovery of at least one signature was not successful
+// Args:    ctx:         pointer to a context object (cannot be NULL)
+//  Out:    pubkeys_out: pointer to n*65 bytes receiving the serialized public keys (cannot be NULL)
+//          ok_out:      pointer to n bytes, set to 1 for each recovered signature (cannot be NULL)
+//  In:     sigdata:     pointer to n*65 bytes of signatures with recovery ids (cannot be NULL)
+//          msgdata:     pointer to n*32 bytes of messages (cannot be NULL)
+//          n:           number of signatures
+static int secp256k1_ext_ecdsa_recover_batch(
+	const secp256k1_context* ctx,
+	unsigned char *pubkeys_out,
+	unsigned char *ok_out,
+	const unsigned char *sigdata,
+	const unsigned char *msgdata,
+	size_t n
+) {
+	int all = 1;
+	size_t i;
+	for (i = 0; i < n; i++) {
+		ok_out[i] = (unsigned char)secp256k1_ext_ecdsa_recover(ctx, pubkeys_out + 65*i, sigdata + 65*i, msgdata + 32*i);
+		all &= ok_out[i];
+	}
+	return all;
+}
+
 // secp256k1_ext_ecdsa_verify verifies an encoded compact signature.
 //
 // Returns: 1: signature is valid
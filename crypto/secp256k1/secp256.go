@@ -41,7 +41,9 @@ import "C"
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"runtime"
 	"unsafe"
 )
 
@@ -122,6 +124,114 @@ func RecoverPubkey(msg []byte, sig []byte) ([]byte, error) {
 	return pubkey, nil
 }
 
+// Context는 명시적으로 관리되는 libsecp256k1 컨텍스트입니다. 패키지 수준 함수들은 전역
+// 컨텍스트 하나를 공유하지만, 여러 작업자가 서명을 병렬로 복구하는 경우 각 작업자가 자신의
+// 컨텍스트를 가지고 일괄 처리 함수를 호출할 수 있습니다. 하나의 Context는 동시에 여러
+// 고루틴에서 사용할 수 없습니다.
+type Context struct {
+	ctx *C.secp256k1_context
+}
+
+// NewContext는 전역 컨텍스트를 복제하여 새 컨텍스트를 생성합니다. 복제는 사전 계산 테이블을
+// 복사하므로 컨텍스트를 새로 생성하는 것보다 빠릅니다. 더 이상 사용하지 않는 컨텍스트는 Close로
+// 해제해야 하며, 해제되지 않은 컨텍스트는 가비지 컬렉터가 해제합니다.
+func NewContext() *Context {
+	c := &Context{ctx: C.secp256k1_context_clone(context)}
+	runtime.SetFinalizer(c, (*Context).Close)
+	return c
+}
+
+// Close는 컨텍스트가 사용하는 C 메모리를 해제합니다. 여러 번 호출해도 안전합니다.
+func (c *Context) Close() {
+	if c.ctx != nil {
+		C.secp256k1_context_destroy(c.ctx)
+		c.ctx = nil
+	}
+	runtime.SetFinalizer(c, nil)
+}
+
+// RecoverPubkeyBatch는 여러 서명의 공개 키를 한 번의 C 호출로 복구합니다. 입력 조건은
+// RecoverPubkey와 같으며, 복구에 실패한 서명이 있으면 그 인덱스를 포함한 오류를 반환합니다.
+// 서명당 cgo 호출 비용이 들지 않으므로 많은 서명을 복구할 때 RecoverPubkey를 반복 호출하는
+// 것보다 빠릅니다.
+func (c *Context) RecoverPubkeyBatch(msgs, sigs [][]byte) ([][]byte, error) {
+	if len(msgs) != len(sigs) {
+		return nil, fmt.Errorf("message count %d does not match signature count %d", len(msgs), len(sigs))
+	}
+	n := len(msgs)
+	if n == 0 {
+		return [][]byte{}, nil
+	}
+	// C 코드에 Go 포인터를 담은 메모리를 전달할 수 없으므로 입력을 연속된 버퍼로 복사합니다.
+	var (
+		msgbuf = make([]byte, 32*n)
+		sigbuf = make([]byte, 65*n)
+		outbuf = make([]byte, 65*n)
+		okbuf  = make([]byte, n)
+	)
+	for i := range msgs {
+		if len(msgs[i]) != 32 {
+			return nil, fmt.Errorf("signature %d: %w", i, ErrInvalidMsgLen)
+		}
+		if err := checkSignature(sigs[i]); err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		copy(msgbuf[32*i:], msgs[i])
+		copy(sigbuf[65*i:], sigs[i])
+	}
+	ok := C.secp256k1_ext_ecdsa_recover_batch(c.ctx,
+		(*C.uchar)(unsafe.Pointer(&outbuf[0])),
+		(*C.uchar)(unsafe.Pointer(&okbuf[0])),
+		(*C.uchar)(unsafe.Pointer(&sigbuf[0])),
+		(*C.uchar)(unsafe.Pointer(&msgbuf[0])),
+		C.size_t(n))
+	runtime.KeepAlive(c)
+	if ok == 0 {
+		for i, b := range okbuf {
+			if b == 0 {
+				return nil, fmt.Errorf("signature %d: %w", i, ErrRecoverFailed)
+			}
+		}
+	}
+	pubkeys := make([][]byte, n)
+	for i := range pubkeys {
+		pubkeys[i] = outbuf[65*i : 65*(i+1) : 65*(i+1)]
+	}
+	return pubkeys, nil
+}
+
+// contextPool은 RecoverPubkeyBatch가 사용하는 컨텍스트를 보관합니다. sync.Pool과 달리
+// 가비지 컬렉션 중에 비워지지 않으므로 비용이 큰 컨텍스트 복제가 반복되지 않습니다.
+var contextPool = make(chan *Context, runtime.NumCPU())
+
+// getContext는 풀에서 컨텍스트를 가져오며, 풀이 비어 있으면 새 컨텍스트를 생성합니다.
+func getContext() *Context {
+	select {
+	case c := <-contextPool:
+		return c
+	default:
+		return NewContext()
+	}
+}
+
+// putContext는 컨텍스트를 풀에 반환하며, 풀이 가득 차 있으면 컨텍스트를 해제합니다.
+func putContext(c *Context) {
+	select {
+	case contextPool <- c:
+	default:
+		c.Close()
+	}
+}
+
+// RecoverPubkeyBatch는 풀에서 가져온 컨텍스트로 여러 서명의 공개 키를 복구합니다.
+// 여러 고루틴에서 동시에 호출할 수 있습니다. 자세한 내용은 Context.RecoverPubkeyBatch를
+// 참고하세요.
+func RecoverPubkeyBatch(msgs, sigs [][]byte) ([][]byte, error) {
+	c := getContext()
+	defer putContext(c)
+	return c.RecoverPubkeyBatch(msgs, sigs)
+}
+
 // VerifySignature checks that the given pubkey created signature over message.
 // The signature should be in [R || S] format.
 
@@ -13,7 +13,9 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"io"
+	"strings"
 	"testing"
 )
 
@@ -219,6 +221,41 @@ func TestRecoverSanity(t *testing.T) {
 	}
 }
 
+func TestRecoverPubkeyBatch(t *testing.T) {
+	var msgs, sigs, pubkeys [][]byte
+	for i := 0; i < 10; i++ {
+		pubkey, seckey := generateKeyPair()
+		msg := csprngEntropy(32)
+		sig, err := Sign(msg, seckey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs, sigs, pubkeys = append(msgs, msg), append(sigs, sig), append(pubkeys, pubkey)
+	}
+	ctx := NewContext()
+	defer ctx.Close()
+	have, err := ctx.RecoverPubkeyBatch(msgs, sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range have {
+		if !bytes.Equal(have[i], pubkeys[i]) {
+			t.Fatalf("pubkey %d mismatch: want: %x have: %x", i, pubkeys[i], have[i])
+		}
+	}
+
+	// Invalid signatures are reported with their index.
+	sigs[3] = append([]byte{}, sigs[3]...)
+	sigs[3][64] = 4
+	if _, err := RecoverPubkeyBatch(msgs, sigs); !errors.Is(err, ErrInvalidRecoveryID) {
+		t.Fatalf("wrong error for invalid recovery id: %v", err)
+	}
+	sigs[3] = make([]byte, 65)
+	if _, err := RecoverPubkeyBatch(msgs, sigs); !errors.Is(err, ErrRecoverFailed) || !strings.Contains(err.Error(), "signature 3") {
+		t.Fatalf("wrong error for unrecoverable signature: %v", err)
+	}
+}
+
 func BenchmarkSign(b *testing.B) {
 	_, seckey := generateKeyPair()
 	msg := csprngEntropy(32)
@@ -229,6 +266,21 @@ func BenchmarkSign(b *testing.B) {
 	}
 }
 
+func BenchmarkRecoverBatch(b *testing.B) {
+	_, seckey := generateKeyPair()
+	var msgs, sigs [][]byte
+	for i := 0; i < 64; i++ {
+		msg := csprngEntropy(32)
+		sig, _ := Sign(msg, seckey)
+		msgs, sigs = append(msgs, msg), append(sigs, sig)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		RecoverPubkeyBatch(msgs, sigs)
+	}
+}
+
 func BenchmarkRecover(b *testing.B) {
 	msg := csprngEntropy(32)
 	_, seckey := generateKeyPair()
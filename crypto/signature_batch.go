@@ -0,0 +1,91 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// recoverBatchChunk는 RecoverPubkeyBatch가 작업자 하나에게 한 번에 맡기는 서명의 수입니다.
+const recoverBatchChunk = 64
+
+// RecoverPubkeyBatch는 각 해시와 서명 쌍에 대해 서명을 만든 비압축 공개 키를 복구합니다.
+// 결과는 입력과 같은 순서이며, 서명은 GOMAXPROCS개의 작업자에 나뉘어 병렬로 복구됩니다.
+// cgo 빌드에서는 작업자마다 풀에서 가져온 secp256k1 컨텍스트로 여러 서명을 한 번의 C 호출로
+// 복구하므로, Ecrecover를 반복 호출할 때의 서명당 호출 비용이 들지 않습니다.
+//
+// 복구에 실패한 서명이 있으면 가장 작은 인덱스를 포함한 오류를 반환합니다.
+func RecoverPubkeyBatch(hashes, sigs [][]byte) ([][]byte, error) {
+	if len(hashes) != len(sigs) {
+		return nil, fmt.Errorf("hash count %d does not match signature count %d", len(hashes), len(sigs))
+	}
+	var (
+		n       = len(hashes)
+		chunks  = (n + recoverBatchChunk - 1) / recoverBatchChunk
+		pubkeys = make([][]byte, n)
+		errs    = make([]error, chunks)
+		jobs    = make(chan int, chunks)
+		workers = runtime.GOMAXPROCS(0)
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < chunks; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	if workers > chunks {
+		workers = chunks
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				start := chunk * recoverBatchChunk
+				end := start + recoverBatchChunk
+				if end > n {
+					end = n
+				}
+				errs[chunk] = recoverChunk(pubkeys[start:end], hashes[start:end], sigs[start:end], start)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pubkeys, nil
+}
+
+// recoverChunk는 서명들의 공개 키를 out에 복구합니다. 실패하면 서명을 하나씩 다시 복구하여
+// 실패한 서명의 인덱스(offset 기준)를 찾아 오류로 반환합니다.
+func recoverChunk(out, hashes, sigs [][]byte, offset int) error {
+	pubkeys, err := ecrecoverBatch(hashes, sigs)
+	if err == nil {
+		copy(out, pubkeys)
+		return nil
+	}
+	for i := range hashes {
+		if _, err := Ecrecover(hashes[i], sigs[i]); err != nil {
+			return fmt.Errorf("signature %d: %w", offset+i, err)
+		}
+	}
+	return err
+}
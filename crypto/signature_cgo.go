@@ -29,11 +29,16 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 )
 
-// Ecrecover는 주어진 서명을 만든 비압축 공개키를 반환합니다.
-func Ecrecover(hash, sig []byte) ([]byte, error) {
+// ecrecover는 주어진 서명을 만든 비압축 공개키를 캐시 없이 복구합니다.
+func ecrecover(hash, sig []byte) ([]byte, error) {
 	return secp256k1.RecoverPubkey(hash, sig)
 }
 
+// ecrecoverBatch는 여러 서명의 비압축 공개키를 한 번의 C 호출로 복구합니다.
+func ecrecoverBatch(hashes, sigs [][]byte) ([][]byte, error) {
+	return secp256k1.RecoverPubkeyBatch(hashes, sigs)
+}
+
 // SigToPub는 주어진 서명을 만든 공개키를 반환합니다.
 func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	s, err := Ecrecover(hash, sig)
@@ -0,0 +1,88 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+)
+
+// CompactSignatureLength는 EIP-2098 압축 서명의 바이트 길이입니다.
+const CompactSignatureLength = 64
+
+var (
+	errInvalidSignatureLength = errors.New("invalid signature length")
+	errInvalidRecoveryID      = errors.New("invalid signature recovery id")
+	errHighS                  = errors.New("signature s value must be in the lower half of the curve order")
+)
+
+// SigToCompact는 [R || S || V] 형식의 65바이트 서명을 EIP-2098 압축 형식의 64바이트
+// [R || yParityAndS]로 변환합니다. V는 0 또는 1(또는 레거시 27, 28)이어야 하며, S는 EIP-2의
+// 하위 절반 규칙을 만족해야 합니다.
+func SigToCompact(sig []byte) ([]byte, error) {
+	if len(sig) != SignatureLength {
+		return nil, fmt.Errorf("%w: have %d, want %d", errInvalidSignatureLength, len(sig), SignatureLength)
+	}
+	v := sig[RecoveryIDOffset]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, errInvalidRecoveryID
+	}
+	// 하위 절반의 S는 최상위 비트가 항상 0이므로, 그 자리에 yParity를 저장합니다.
+	if sig[32]&0x80 != 0 {
+		return nil, errHighS
+	}
+	compact := make([]byte, CompactSignatureLength)
+	copy(compact, sig[:64])
+	compact[32] |= v << 7
+	return compact, nil
+}
+
+// CompactToSig는 EIP-2098 압축 형식의 64바이트 서명을 [R || S || V] 형식의 65바이트 서명으로
+// 변환합니다. 반환되는 V는 0 또는 1입니다.
+func CompactToSig(compact []byte) ([]byte, error) {
+	if len(compact) != CompactSignatureLength {
+		return nil, fmt.Errorf("%w: have %d, want %d", errInvalidSignatureLength, len(compact), CompactSignatureLength)
+	}
+	sig := make([]byte, SignatureLength)
+	copy(sig, compact)
+	sig[RecoveryIDOffset] = compact[32] >> 7
+	sig[32] &= 0x7f
+	return sig, nil
+}
+
+// CompactSign은 다이제스트에 대한 ECDSA 서명을 계산하여 EIP-2098 압축 형식으로 반환합니다.
+// Sign과 같은 주의 사항이 적용됩니다.
+func CompactSign(digestHash []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := Sign(digestHash, prv)
+	if err != nil {
+		return nil, err
+	}
+	return SigToCompact(sig)
+}
+
+// EcrecoverCompact는 EIP-2098 압축 서명을 만든 비압축 공개키를 반환합니다.
+func EcrecoverCompact(hash, compact []byte) ([]byte, error) {
+	sig, err := CompactToSig(compact)
+	if err != nil {
+		return nil, err
+	}
+	return Ecrecover(hash, sig)
+}
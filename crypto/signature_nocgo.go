@@ -29,8 +29,8 @@ import (
 	btc_ecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
 )
 
-// Ecrecover는 주어진 서명을 만든 비압축 공개키를 반환합니다.
-func Ecrecover(hash, sig []byte) ([]byte, error) {
+// ecrecover는 주어진 서명을 만든 비압축 공개키를 캐시 없이 복구합니다.
+func ecrecover(hash, sig []byte) ([]byte, error) {
 	pub, err := sigToPub(hash, sig)
 	if err != nil {
 		return nil, err
@@ -39,6 +39,19 @@ func Ecrecover(hash, sig []byte) ([]byte, error) {
 	return bytes, err
 }
 
+// ecrecoverBatch는 여러 서명의 비압축 공개키를 차례로 복구합니다.
+func ecrecoverBatch(hashes, sigs [][]byte) ([][]byte, error) {
+	pubkeys := make([][]byte, len(hashes))
+	for i := range hashes {
+		pub, err := Ecrecover(hashes[i], sigs[i])
+		if err != nil {
+			return nil, err
+		}
+		pubkeys[i] = pub
+	}
+	return pubkeys, nil
+}
+
 func sigToPub(hash, sig []byte) (*btcec.PublicKey, error) {
 	if len(sig) != SignatureLength {
 		return nil, errors.New("invalid signature")
@@ -54,6 +67,13 @@ func sigToPub(hash, sig []byte) (*btcec.PublicKey, error) {
 
 // SigToPub는 주어진 서명을 만든 공개키를 반환합니다.
 func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	if recoveryCacheEnabled() {
+		s, err := Ecrecover(hash, sig)
+		if err != nil {
+			return nil, err
+		}
+		return UnmarshalPubkey(s)
+	}
 	pub, err := sigToPub(hash, sig)
 	if err != nil {
 		return nil, err
@@ -19,7 +19,9 @@ package crypto
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -85,6 +87,75 @@ func TestVerifySignatureMalleable(t *testing.T) {
 	}
 }
 
+// Tests the compact signature conversions against the examples in EIP-2098.
+func TestCompactSignatureConversion(t *testing.T) {
+	tests := []struct {
+		sig, compact string
+	}{
+		{
+			sig:     "0x68a020a209d3d56c46f38cc50a33f704f4a9a10a59377f8dd762ac66910e9b907e865ad05c4035ab5792787d4a0297a43617ae897930a6fe4d822b8faea520641b",
+			compact: "0x68a020a209d3d56c46f38cc50a33f704f4a9a10a59377f8dd762ac66910e9b907e865ad05c4035ab5792787d4a0297a43617ae897930a6fe4d822b8faea52064",
+		},
+		{
+			sig:     "0x9328da16089fcba9bececa81663203989f2df5fe1faa6291a45381c81bd17f76139c6d6b623b42da56557e5e734a43dc83345ddfadec52cbe24d0cc64f5507931c",
+			compact: "0x9328da16089fcba9bececa81663203989f2df5fe1faa6291a45381c81bd17f76939c6d6b623b42da56557e5e734a43dc83345ddfadec52cbe24d0cc64f550793",
+		},
+	}
+	for i, test := range tests {
+		sig, want := hexutil.MustDecode(test.sig), hexutil.MustDecode(test.compact)
+		compact, err := SigToCompact(sig)
+		if err != nil {
+			t.Fatalf("test %d: compaction failed: %v", i, err)
+		}
+		if !bytes.Equal(compact, want) {
+			t.Errorf("test %d: compact mismatch: have %x, want %x", i, compact, want)
+		}
+		full, err := CompactToSig(compact)
+		if err != nil {
+			t.Fatalf("test %d: expansion failed: %v", i, err)
+		}
+		sig[RecoveryIDOffset] -= 27
+		if !bytes.Equal(full, sig) {
+			t.Errorf("test %d: signature mismatch: have %x, want %x", i, full, sig)
+		}
+	}
+	// High-s signatures cannot be compacted.
+	high := common.CopyBytes(testsig)
+	high[32] |= 0x80
+	if _, err := SigToCompact(high); err == nil {
+		t.Error("high-s signature compacted")
+	}
+	if _, err := CompactToSig(testsig); err == nil {
+		t.Error("65 byte signature accepted as compact")
+	}
+}
+
+func TestEcrecoverCompact(t *testing.T) {
+	compact, err := SigToCompact(testsig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey, err := EcrecoverCompact(testmsg, compact)
+	if err != nil {
+		t.Fatalf("recover error: %s", err)
+	}
+	if !bytes.Equal(pubkey, testpubkey) {
+		t.Errorf("pubkey mismatch: want: %x have: %x", testpubkey, pubkey)
+	}
+	key, _ := GenerateKey()
+	compact, err = CompactSign(testmsg, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey, err = EcrecoverCompact(testmsg, compact)
+	if err != nil {
+		t.Fatalf("recover error: %s", err)
+	}
+	if !bytes.Equal(pubkey, FromECDSAPub(&key.PublicKey)) {
+		t.Errorf("pubkey mismatch for compact signature")
+	}
+}
+
 func TestDecompressPubkey(t *testing.T) {
 	key, err := DecompressPubkey(testpubkeyc)
 	if err != nil {
@@ -142,6 +213,80 @@ func BenchmarkEcrecoverSignature(b *testing.B) {
 	}
 }
 
+func TestRecoverPubkeyBatch(t *testing.T) {
+	var (
+		n       = 2*recoverBatchChunk + 3
+		hashes  = make([][]byte, n)
+		sigs    = make([][]byte, n)
+		pubkeys = make([][]byte, n)
+	)
+	for i := range hashes {
+		key, _ := GenerateKey()
+		hashes[i] = Keccak256([]byte{byte(i)})
+		sigs[i], _ = Sign(hashes[i], key)
+		pubkeys[i] = FromECDSAPub(&key.PublicKey)
+	}
+	have, err := RecoverPubkeyBatch(hashes, sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, pubkeys) {
+		t.Fatal("recovered public keys mismatch")
+	}
+	if have, err := RecoverPubkeyBatch(nil, nil); err != nil || len(have) != 0 {
+		t.Fatalf("wrong result for empty batch: %v, %v", have, err)
+	}
+
+	// The error must report the first invalid signature.
+	bad := append([][]byte{}, sigs...)
+	bad[recoverBatchChunk+1] = bad[recoverBatchChunk+1][:64]
+	bad[2*recoverBatchChunk] = make([]byte, 65)
+	_, err = RecoverPubkeyBatch(hashes, bad)
+	if err == nil || !strings.HasPrefix(err.Error(), fmt.Sprintf("signature %d:", recoverBatchChunk+1)) {
+		t.Fatalf("wrong error for invalid signature: %v", err)
+	}
+	if _, err := RecoverPubkeyBatch(hashes, sigs[1:]); err == nil {
+		t.Fatal("no error for length mismatch")
+	}
+}
+
+// The batch benchmarks measure the cgo path by default. Run them with
+// CGO_ENABLED=0 to compare against the pure Go implementation.
+func BenchmarkEcrecoverLoop(b *testing.B) {
+	hashes, sigs := benchmarkSignatures(b, 256)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range hashes {
+			if _, err := Ecrecover(hashes[j], sigs[j]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkRecoverPubkeyBatch(b *testing.B) {
+	hashes, sigs := benchmarkSignatures(b, 256)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := RecoverPubkeyBatch(hashes, sigs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkSignatures(b *testing.B, n int) (hashes, sigs [][]byte) {
+	key, _ := GenerateKey()
+	for i := 0; i < n; i++ {
+		hash := Keccak256([]byte{byte(i), byte(i >> 8)})
+		sig, err := Sign(hash, key)
+		if err != nil {
+			b.Fatal(err)
+		}
+		hashes, sigs = append(hashes, hash), append(sigs, sig)
+	}
+	return hashes, sigs
+}
+
 func BenchmarkVerifySignature(b *testing.B) {
 	sig := testsig[:len(testsig)-1] // remove recovery id
 	for i := 0; i < b.N; i++ {
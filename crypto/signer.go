@@ -0,0 +1,81 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+)
+
+// ErrSignerMismatch는 Signer가 반환한 서명이 Signer의 공개 키로 복구되지 않을 때 반환됩니다.
+var ErrSignerMismatch = errors.New("signature does not match signer public key")
+
+// Signer는 개인 키를 메모리에 노출하지 않고 다이제스트에 서명할 수 있는 서명자입니다.
+// HSM, 하드웨어 지갑, 원격 서명자 등이 이 인터페이스를 구현할 수 있습니다.
+type Signer interface {
+	// Sign은 32바이트 다이제스트에 대한 서명을 Sign 함수와 같은 [R || S || V] 형식으로
+	// 반환합니다. V는 0 또는 1이어야 합니다.
+	Sign(digest []byte) ([]byte, error)
+
+	// PublicKey는 서명자의 공개 키를 반환합니다.
+	PublicKey() *ecdsa.PublicKey
+}
+
+// keySigner는 메모리에 있는 개인 키로 서명하는 Signer입니다.
+type keySigner struct {
+	prv *ecdsa.PrivateKey
+}
+
+// NewKeySigner는 주어진 개인 키로 서명하는 Signer를 반환합니다.
+func NewKeySigner(prv *ecdsa.PrivateKey) Signer {
+	return &keySigner{prv: prv}
+}
+
+// Sign은 Signer 인터페이스를 구현합니다.
+func (s *keySigner) Sign(digest []byte) ([]byte, error) {
+	return Sign(digest, s.prv)
+}
+
+// PublicKey는 Signer 인터페이스를 구현합니다.
+func (s *keySigner) PublicKey() *ecdsa.PublicKey {
+	return &s.prv.PublicKey
+}
+
+// SignWithSigner는 signer로 다이제스트에 서명하고, 외부 서명자의 오동작을 막기 위해 반환된 서명이
+// 올바른 형식이며 signer의 공개 키로 복구되는지 확인합니다.
+func SignWithSigner(digest []byte, signer Signer) ([]byte, error) {
+	sig, err := signer.Sign(digest)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != SignatureLength {
+		return nil, fmt.Errorf("%w: have %d, want %d", errInvalidSignatureLength, len(sig), SignatureLength)
+	}
+	if sig[RecoveryIDOffset] > 1 {
+		return nil, errInvalidRecoveryID
+	}
+	pub, err := Ecrecover(digest, sig)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pub, FromECDSAPub(signer.PublicKey())) {
+		return nil, ErrSignerMismatch
+	}
+	return sig, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package vrf는 secp256k1 곡선 위의 검증 가능한 랜덤 함수(VRF)를 구현합니다.
+//
+// 구현은 draft-irtf-cfrg-vrf-06의 ECVRF 구성을 따르며, 스위트는
+// ECVRF-SECP256K1-SHA256-TAI입니다. 이는 초안의 ECVRF-P256-SHA256-TAI 스위트에서 곡선만
+// secp256k1로 바꾼 것으로, 다른 secp256k1 구현에서 널리 쓰이는 스위트 문자열 0xFE를
+// 사용합니다. 이후 RFC 9381에서 바뀐 해시 입력(챌린지의 공개 키와 끝의 0x00 구분자)은
+// 따르지 않으므로, 같은 스위트 문자열을 쓰는 draft-06 구현과 증명 및 출력이 호환됩니다.
+//
+//   - 해시 함수: SHA-256
+//   - encode_to_curve: try-and-increment (TAI)
+//   - nonce 생성: RFC 6979
+//   - 점 인코딩: 33바이트 SEC1 압축 형식
+//   - 증명: Gamma(33바이트) || c(16바이트) || s(32바이트)
+package vrf
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	suite = 0xFE // ECVRF-SECP256K1-SHA256-TAI
+
+	ptLen = 33 // 압축된 점의 길이
+	cLen  = 16 // 챌린지의 길이
+	qLen  = 32 // 스칼라의 길이
+
+	// ProofSize는 증명의 바이트 길이입니다.
+	ProofSize = ptLen + cLen + qLen
+
+	// OutputSize는 VRF 출력(베타)의 바이트 길이입니다.
+	OutputSize = sha256.Size
+)
+
+var (
+	ErrInvalidProof     = errors.New("vrf: invalid proof")
+	ErrInvalidPublicKey = errors.New("vrf: invalid public key")
+	ErrInvalidKey       = errors.New("vrf: invalid private key")
+	errHashToCurve      = errors.New("vrf: no valid point found for input")
+)
+
+// Prove는 비밀 키 key와 입력 alpha에 대한 VRF 증명과 출력을 계산합니다. 같은 키와 입력에
+// 대해서는 항상 같은 결과를 반환합니다.
+func Prove(key *ecdsa.PrivateKey, alpha []byte) (beta, proof []byte, err error) {
+	curve := crypto.S256()
+	if key == nil || key.D == nil || key.D.Sign() <= 0 || key.D.Cmp(curve.Params().N) >= 0 {
+		return nil, nil, ErrInvalidKey
+	}
+	y := scalarBaseMult(key.D)
+	h, err := encodeToCurve(y, alpha)
+	if err != nil {
+		return nil, nil, err
+	}
+	gamma := scalarMult(h, key.D)
+	k := nonce(key.D, h.encode())
+	c := challenge(h, gamma, scalarBaseMult(k), scalarMult(h, k))
+
+	// s = (k + c*x) mod q
+	s := new(big.Int).Mul(c, key.D)
+	s.Add(s, k)
+	s.Mod(s, curve.Params().N)
+
+	proof = make([]byte, 0, ProofSize)
+	proof = append(proof, gamma.encode()...)
+	proof = append(proof, math.PaddedBigBytes(c, cLen)...)
+	proof = append(proof, math.PaddedBigBytes(s, qLen)...)
+	return gammaToHash(gamma), proof, nil
+}
+
+// Verify는 공개 키 pub와 입력 alpha에 대한 증명을 검증하고, 유효하면 VRF 출력을 반환합니다.
+func Verify(pub *ecdsa.PublicKey, alpha, proof []byte) (beta []byte, err error) {
+	curve := crypto.S256()
+	if pub == nil || pub.X == nil || pub.Y == nil || !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, ErrInvalidPublicKey
+	}
+	y := point{pub.X, pub.Y}
+	gamma, c, s, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	h, err := encodeToCurve(y, alpha)
+	if err != nil {
+		return nil, err
+	}
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	negc := new(big.Int).Neg(c)
+	u := add(scalarBaseMult(s), scalarMult(y, negc))
+	v := add(scalarMult(h, s), scalarMult(gamma, negc))
+	if c.Cmp(challenge(h, gamma, u, v)) != 0 {
+		return nil, ErrInvalidProof
+	}
+	return gammaToHash(gamma), nil
+}
+
+// ProofToHash는 증명에서 VRF 출력을 추출합니다. 증명을 검증하지 않으므로, 신뢰할 수 없는
+// 증명에는 Verify를 사용해야 합니다.
+func ProofToHash(proof []byte) ([]byte, error) {
+	gamma, _, _, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	return gammaToHash(gamma), nil
+}
+
+// point는 곡선 위의 아핀 좌표 점입니다. 항등원은 (0, 0)으로 나타냅니다.
+type point struct {
+	x, y *big.Int
+}
+
+func newPoint(x, y *big.Int) point {
+	return point{x, y}
+}
+
+func (p point) isIdentity() bool {
+	return p.x.Sign() == 0 && p.y.Sign() == 0
+}
+
+// encode는 점을 압축 형식으로 인코딩합니다. 항등원은 단일 0 바이트 대신 0으로 채워진
+// 33바이트가 됩니다. 챌린지 계산에서만 나타날 수 있으며 유효한 증명에는 나타나지 않습니다.
+func (p point) encode() []byte {
+	enc := make([]byte, ptLen)
+	if p.isIdentity() {
+		return enc
+	}
+	enc[0] = 0x02 | byte(p.y.Bit(0))
+	p.x.FillBytes(enc[1:])
+	return enc
+}
+
+// decodePoint는 압축 형식의 점을 디코딩합니다. 곡선 위의 점이 아니면 ok는 false입니다.
+func decodePoint(b []byte) (p point, ok bool) {
+	key, err := crypto.DecompressPubkey(b)
+	if err != nil {
+		return point{}, false
+	}
+	return point{key.X, key.Y}, true
+}
+
+func add(a, b point) point {
+	return newPoint(crypto.S256().Add(a.x, a.y, b.x, b.y))
+}
+
+// scalarMult는 k*p를 반환합니다. k는 곡선의 차수로 나눈 나머지로 사용됩니다.
+func scalarMult(p point, k *big.Int) point {
+	k = new(big.Int).Mod(k, crypto.S256().Params().N)
+	if k.Sign() == 0 || p.isIdentity() {
+		return point{new(big.Int), new(big.Int)}
+	}
+	return newPoint(crypto.S256().ScalarMult(p.x, p.y, math.PaddedBigBytes(k, qLen)))
+}
+
+// scalarBaseMult는 k*B를 반환합니다. k는 곡선의 차수로 나눈 나머지로 사용됩니다.
+func scalarBaseMult(k *big.Int) point {
+	params := crypto.S256().Params()
+	return scalarMult(point{params.Gx, params.Gy}, k)
+}
+
+// encodeToCurve는 draft-06 5.4.1.1절의 ECVRF_hash_to_curve_try_and_increment입니다.
+func encodeToCurve(y point, alpha []byte) (point, error) {
+	pk := y.encode()
+	buf := make([]byte, 0, 2+len(pk)+len(alpha)+1)
+	buf = append(buf, suite, 0x01)
+	buf = append(buf, pk...)
+	buf = append(buf, alpha...)
+	for ctr := 0; ctr < 256; ctr++ {
+		hash := sha256.Sum256(append(buf, byte(ctr)))
+		if h, ok := decodePoint(append([]byte{0x02}, hash[:]...)); ok {
+			return h, nil
+		}
+	}
+	return point{}, errHashToCurve
+}
+
+// challenge는 draft-06 5.4.3절의 ECVRF_hash_points입니다.
+func challenge(points ...point) *big.Int {
+	h := sha256.New()
+	h.Write([]byte{suite, 0x02})
+	for _, p := range points {
+		h.Write(p.encode())
+	}
+	return new(big.Int).SetBytes(h.Sum(nil)[:cLen])
+}
+
+// gammaToHash는 draft-06 5.2절의 ECVRF_proof_to_hash에서 증명 디코딩 이후의 단계입니다.
+// secp256k1의 cofactor는 1입니다.
+func gammaToHash(gamma point) []byte {
+	h := sha256.New()
+	h.Write([]byte{suite, 0x03})
+	h.Write(gamma.encode())
+	return h.Sum(nil)
+}
+
+// decodeProof는 draft-06 5.4.4절의 ECVRF_decode_proof입니다. 초안과 달리 s가 곡선의
+// 차수보다 작은지도 확인하여, 같은 증명의 다른 인코딩을 거부합니다.
+func decodeProof(proof []byte) (gamma point, c, s *big.Int, err error) {
+	if len(proof) != ProofSize {
+		return point{}, nil, nil, ErrInvalidProof
+	}
+	gamma, ok := decodePoint(proof[:ptLen])
+	if !ok {
+		return point{}, nil, nil, ErrInvalidProof
+	}
+	c = new(big.Int).SetBytes(proof[ptLen : ptLen+cLen])
+	s = new(big.Int).SetBytes(proof[ptLen+cLen:])
+	if s.Cmp(crypto.S256().Params().N) >= 0 {
+		return point{}, nil, nil, ErrInvalidProof
+	}
+	return gamma, c, s, nil
+}
+
+// nonce는 RFC 6979 3.2절에 따라 비밀 키 d와 메시지 m(h_string)에 대한 결정적 nonce를
+// 생성합니다. 해시 함수는 SHA-256입니다.
+func nonce(d *big.Int, m []byte) *big.Int {
+	n := crypto.S256().Params().N
+	h1 := sha256.Sum256(m)
+	// bits2octets(h1): 곡선의 차수와 해시의 길이가 같으므로 한 번의 나머지 연산으로 충분합니다.
+	z := new(big.Int).SetBytes(h1[:])
+	z.Mod(z, n)
+	x := math.PaddedBigBytes(d, qLen)
+	zb := math.PaddedBigBytes(z, qLen)
+
+	v := bytes.Repeat([]byte{0x01}, sha256.Size)
+	k := make([]byte, sha256.Size)
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(sha256.New, key)
+		for _, d := range data {
+			h.Write(d)
+		}
+		return h.Sum(nil)
+	}
+	k = mac(k, v, []byte{0x00}, x, zb)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, x, zb)
+	v = mac(k, v)
+	for {
+		v = mac(k, v)
+		t := new(big.Int).SetBytes(v)
+		if t.Sign() > 0 && t.Cmp(n) < 0 {
+			return t
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vrf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The known-answer vectors in testdata are the ECVRF-SECP256K1-SHA256-TAI
+// vectors distributed with github.com/vechain/go-ecvrf, an independent
+// implementation of draft-irtf-cfrg-vrf-06.
+func TestVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/secp256k1_sha256_tai.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests []struct {
+		SK, PK, Alpha, Pi, Beta string
+	}
+	if err := json.Unmarshal(data, &tests); err != nil {
+		t.Fatal(err)
+	}
+	for i, test := range tests {
+		var (
+			sk    = common.FromHex(test.SK)
+			pk    = common.FromHex(test.PK)
+			alpha = common.FromHex(test.Alpha)
+			want  = common.FromHex(test.Pi)
+			out   = common.FromHex(test.Beta)
+		)
+		key, err := crypto.ToECDSA(common.LeftPadBytes(sk, 32))
+		if err != nil {
+			t.Fatalf("test %d: invalid key: %v", i, err)
+		}
+		if have := crypto.CompressPubkey(&key.PublicKey); !bytes.Equal(have, pk) {
+			t.Fatalf("test %d: public key mismatch: have %x, want %x", i, have, pk)
+		}
+		beta, pi, err := Prove(key, alpha)
+		if err != nil {
+			t.Fatalf("test %d: prove failed: %v", i, err)
+		}
+		if !bytes.Equal(pi, want) {
+			t.Errorf("test %d: proof mismatch:\nhave %x\nwant %x", i, pi, want)
+		}
+		if !bytes.Equal(beta, out) {
+			t.Errorf("test %d: output mismatch: have %x, want %x", i, beta, out)
+		}
+		pub, _ := crypto.DecompressPubkey(pk)
+		if beta, err := Verify(pub, alpha, want); err != nil || !bytes.Equal(beta, out) {
+			t.Errorf("test %d: verify failed: %x %v", i, beta, err)
+		}
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	alpha := []byte("sample")
+
+	beta, proof, err := Prove(key, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beta) != OutputSize || len(proof) != ProofSize {
+		t.Fatalf("wrong output sizes: beta %d, proof %d", len(beta), len(proof))
+	}
+	beta2, proof2, _ := Prove(key, alpha)
+	if !bytes.Equal(beta, beta2) || !bytes.Equal(proof, proof2) {
+		t.Fatal("proof is not deterministic")
+	}
+	vbeta, err := Verify(&key.PublicKey, alpha, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(vbeta, beta) {
+		t.Fatal("verified output mismatch")
+	}
+	if hbeta, err := ProofToHash(proof); err != nil || !bytes.Equal(hbeta, beta) {
+		t.Fatalf("ProofToHash mismatch: %x %v", hbeta, err)
+	}
+
+	// A different input gives a different output.
+	other, _, _ := Prove(key, []byte("other"))
+	if bytes.Equal(other, beta) {
+		t.Fatal("same output for different inputs")
+	}
+	if _, err := Verify(&key.PublicKey, []byte("other"), proof); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("wrong error for wrong input: %v", err)
+	}
+	key2, _ := crypto.GenerateKey()
+	if _, err := Verify(&key2.PublicKey, alpha, proof); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("wrong error for wrong key: %v", err)
+	}
+	for i := range proof {
+		tampered := common.CopyBytes(proof)
+		tampered[i] ^= 0x01
+		if _, err := Verify(&key.PublicKey, alpha, tampered); err == nil {
+			t.Fatalf("no error for proof modified at byte %d", i)
+		}
+	}
+	if _, err := Verify(&key.PublicKey, alpha, proof[:ProofSize-1]); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("wrong error for short proof: %v", err)
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	if _, _, err := Prove(nil, nil); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("wrong error for nil key: %v", err)
+	}
+	key, _ := crypto.GenerateKey()
+	_, proof, _ := Prove(key, nil)
+	pub := key.PublicKey
+	pub.Y = new(big.Int).Add(pub.Y, big.NewInt(1))
+	if _, err := Verify(&pub, nil, proof); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Fatalf("wrong error for invalid public key: %v", err)
+	}
+}
+
+// The nonce generation is checked against known RFC 6979 vectors for secp256k1 with
+// SHA-256, where the message is hashed with SHA-256 as in the RFC.
+func TestNonceRFC6979(t *testing.T) {
+	tests := []struct {
+		key  int64
+		msg  string
+		want string
+	}{
+		{1, "Satoshi Nakamoto", "8F8A276C19F4149656B280621E358CCE24F5F52542772691EE69063B74F15D15"},
+		{1, "All those moments will be lost in time, like tears in rain. Time to die...", "38AA22D72376B4DBC472E06C3BA403EE0A394DA63FC58D88686C611ABA98D6B3"},
+	}
+	for _, test := range tests {
+		k := nonce(big.NewInt(test.key), []byte(test.msg))
+		want, _ := new(big.Int).SetString(test.want, 16)
+		if k.Cmp(want) != 0 {
+			t.Errorf("nonce(%d, %q) = %x, want %x", test.key, test.msg, k, want)
+		}
+	}
+}
+
+func BenchmarkProve(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	for i := 0; i < b.N; i++ {
+		Prove(key, []byte("sample"))
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	_, proof, _ := Prove(key, []byte("sample"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Verify(&key.PublicKey, []byte("sample"), proof); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
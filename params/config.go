@@ -17,6 +17,7 @@
 package params
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -28,7 +29,6 @@ var (
 	MainnetGenesisHash = common.HexToHash("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3")
 	HoleskyGenesisHash = common.HexToHash("0xb5f7f912443c940f21fd611f12828d75b534364ed9e95ca4e307729a4661bde4")
 	SepoliaGenesisHash = common.HexToHash("0x25a5cc106eea7138acab33231d7160d69cb777ee0c2c553fcddf5138993e6dd9")
-	GoerliGenesisHash  = common.HexToHash("0xbf7e331f7f7c1dd2e05159666b3bf8bc7a8a3a9eb1d518969eab529dd9b88c1a")
 )
 
 func newUint64(val uint64) *uint64 { return &val }
@@ -82,6 +82,8 @@ var (
 		TerminalTotalDifficultyPassed: true,
 		MergeNetsplitBlock:            nil,
 		ShanghaiTime:                  newUint64(1696000704),
+		CancunTime:                    newUint64(1707305664),
+		BlobScheduleConfig:            newCancunBlobSchedule(),
 		Ethash:                        new(EthashConfig),
 	}
 
@@ -107,36 +109,11 @@ var (
 		TerminalTotalDifficultyPassed: true,
 		MergeNetsplitBlock:            big.NewInt(1735371),
 		ShanghaiTime:                  newUint64(1677557088),
+		CancunTime:                    newUint64(1706655072),
+		BlobScheduleConfig:            newCancunBlobSchedule(),
 		Ethash:                        new(EthashConfig),
 	}
 
-	// GoerliChainConfig는 Görli 테스트 네트워크에서 노드를 실행하는 데 사용되는 체인 매개 변수입니다.
-	GoerliChainConfig = &ChainConfig{
-		ChainID:                       big.NewInt(5),
-		HomesteadBlock:                big.NewInt(0),
-		DAOForkBlock:                  nil,
-		DAOForkSupport:                true,
-		EIP150Block:                   big.NewInt(0),
-		EIP155Block:                   big.NewInt(0),
-		EIP158Block:                   big.NewInt(0),
-		ByzantiumBlock:                big.NewInt(0),
-		ConstantinopleBlock:           big.NewInt(0),
-		PetersburgBlock:               big.NewInt(0),
-		IstanbulBlock:                 big.NewInt(1_561_651),
-		MuirGlacierBlock:              nil,
-		BerlinBlock:                   big.NewInt(4_460_644),
-		LondonBlock:                   big.NewInt(5_062_605),
-		ArrowGlacierBlock:             nil,
-		TerminalTotalDifficulty:       big.NewInt(10_790_000),
-		TerminalTotalDifficultyPassed: true,
-		ShanghaiTime:                  newUint64(1678832736),
-		CancunTime:                    newUint64(1705473120),
-		Clique: &CliqueConfig{
-			Period: 15,
-			Epoch:  30000,
-		},
-	}
-
 	// AllEthashProtocolChanges는 Ethereum 코어 개발자가 Ethash 합의에 도입하고 수락한 모든 프로토콜 변경 사항(EIP)을 포함합니다.
 	AllEthashProtocolChanges = &ChainConfig{
 		ChainID:                       big.NewInt(1337),
@@ -166,25 +143,9 @@ var (
 		Clique:                        nil,
 	}
 
-	AllDevChainProtocolChanges = &ChainConfig{
-		ChainID:                       big.NewInt(1337),
-		HomesteadBlock:                big.NewInt(0),
-		EIP150Block:                   big.NewInt(0),
-		EIP155Block:                   big.NewInt(0),
-		EIP158Block:                   big.NewInt(0),
-		ByzantiumBlock:                big.NewInt(0),
-		ConstantinopleBlock:           big.NewInt(0),
-		PetersburgBlock:               big.NewInt(0),
-		IstanbulBlock:                 big.NewInt(0),
-		MuirGlacierBlock:              big.NewInt(0),
-		BerlinBlock:                   big.NewInt(0),
-		LondonBlock:                   big.NewInt(0),
-		ArrowGlacierBlock:             big.NewInt(0),
-		GrayGlacierBlock:              big.NewInt(0),
-		ShanghaiTime:                  newUint64(0),
-		TerminalTotalDifficulty:       big.NewInt(0),
-		TerminalTotalDifficultyPassed: true,
-	}
+	// AllDevChainProtocolChanges는 개발 모드에서 사용하는, 제네시스부터 머지 이후이고 Shanghai까지의
+	// 포크가 활성화된 구성입니다. 다른 조합은 NewDevnetConfig로 생성할 수 있습니다.
+	AllDevChainProtocolChanges = mustNewDevnetConfig(DevnetOptions{LastFork: newFork(ForkShanghai)})
 
 	// AllCliqueProtocolChanges는 Ethereum 코어 개발자가 Clique 합의에 도입하고 수락한 모든 프로토콜 변경 사항(EIP)을 포함합니다.
 	AllCliqueProtocolChanges = &ChainConfig{
@@ -276,12 +237,8 @@ var (
 )
 
 // NetworkNames는 체인 사양 배너에서 사용할 사용자 친화적인 이름입니다.
-var NetworkNames = map[string]string{
-	MainnetChainConfig.ChainID.String(): "mainnet",
-	GoerliChainConfig.ChainID.String():  "goerli",
-	SepoliaChainConfig.ChainID.String(): "sepolia",
-	HoleskyChainConfig.ChainID.String(): "holesky",
-}
+// 빌드에 포함된 네트워크가 등록될 때 함께 채워집니다.
+var NetworkNames = make(map[string]string)
 
 // ChainConfig는 블록 체인 설정을 결정하는 핵심 구성입니다.
 //
@@ -325,11 +282,106 @@ type ChainConfig struct {
 	// 그 목적은 TTD를 로컬로 보지 않고도 레거시 동기화를 비활성화하는 것입니다(장기적으로 안전함).
 	TerminalTotalDifficultyPassed bool `json:"terminalTotalDifficultyPassed,omitempty"`
 
+	// EIP-1559 매개변수 재정의. L2 등 기본값과 다른 수수료 시장을 사용하는 체인을 위한 것이며,
+	// nil인 경우 protocol_params.go에 정의된 기본값이 사용됩니다.
+	EIP1559Denominator *uint64  `json:"baseFeeChangeDenominator,omitempty"` // 블록 간 기본 수수료 변경 폭의 분모 (nil = DefaultBaseFeeChangeDenominator)
+	EIP1559Elasticity  *uint64  `json:"elasticityMultiplier,omitempty"`     // 가스 목표 대비 가스 한도의 배수 (nil = DefaultElasticityMultiplier)
+	EIP1559MinBaseFee  *big.Int `json:"minBaseFee,omitempty"`               // 기본 수수료의 하한 (nil = 하한 없음)
+
+	// 포크별 blob 매개변수 (nil = 기본값 사용)
+	BlobScheduleConfig *BlobScheduleConfig `json:"blobSchedule,omitempty"`
+
+	// Verkle 트리로의 상태 변환 매개변수 (nil = 변환 없음)
+	Verkle *VerkleConfig `json:"verkle,omitempty"`
+
 	// 다양한 컨센서스 엔진
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
 }
 
+// VerkleConfig는 Merkle Patricia 트리에서 Verkle 트리로의 상태 변환에 대한 구성입니다.
+type VerkleConfig struct {
+	ConversionStart  *uint64 `json:"conversionStart,omitempty"` // 상태 변환을 시작하는 시간 (verkleTime 이후여야 함)
+	ConversionStride uint64  `json:"conversionStride"`          // 블록마다 변환할 상태 항목의 수
+}
+
+var (
+	// DefaultCancunBlobConfig는 Cancun 포크의 기본 blob 매개변수입니다.
+	DefaultCancunBlobConfig = &BlobConfig{
+		Target:         3,
+		Max:            6,
+		UpdateFraction: BlobTxBlobGaspriceUpdateFraction,
+	}
+	// DefaultPragueBlobConfig는 Prague 포크의 기본 blob 매개변수입니다.
+	DefaultPragueBlobConfig = &BlobConfig{
+		Target:         6,
+		Max:            9,
+		UpdateFraction: 5007716,
+	}
+)
+
+// BlobConfig는 하나의 포크에서 적용되는 blob 매개변수를 정의합니다.
+type BlobConfig struct {
+	Target         uint64 `json:"target"`                // 블록당 목표 blob 개수
+	Max            uint64 `json:"max"`                   // 블록당 최대 blob 개수
+	UpdateFraction uint64 `json:"baseFeeUpdateFraction"` // blob 기본 수수료의 최대 변화율을 제어하는 값
+}
+
+// TargetBlobGas는 블록당 목표 blob 가스를 반환합니다.
+func (bc *BlobConfig) TargetBlobGas() uint64 {
+	return bc.Target * BlobTxBlobGasPerBlob
+}
+
+// MaxBlobGas는 블록당 소비할 수 있는 최대 blob 가스를 반환합니다.
+func (bc *BlobConfig) MaxBlobGas() uint64 {
+	return bc.Max * BlobTxBlobGasPerBlob
+}
+
+// validate는 blob 매개변수가 유효한지 확인합니다.
+func (bc *BlobConfig) validate() error {
+	switch {
+	case bc.Max == 0:
+		return errors.New("max blob count must be non-zero")
+	case bc.Target > bc.Max:
+		return fmt.Errorf("target blob count %d exceeds max %d", bc.Target, bc.Max)
+	case bc.UpdateFraction == 0:
+		return errors.New("baseFeeUpdateFraction must be non-zero")
+	}
+	return nil
+}
+
+// copyBlobConfig는 bc의 복사본을 반환합니다. nil은 nil로 유지됩니다.
+func copyBlobConfig(bc *BlobConfig) *BlobConfig {
+	if bc == nil {
+		return nil
+	}
+	cpy := *bc
+	return &cpy
+}
+
+// BlobScheduleConfig는 포크별 blob 매개변수를 정의합니다. 항목이 nil인 포크는 기본값을 사용합니다.
+type BlobScheduleConfig struct {
+	Cancun *BlobConfig `json:"cancun,omitempty"`
+	Prague *BlobConfig `json:"prague,omitempty"`
+}
+
+// newCancunBlobSchedule는 기본 Cancun blob 매개변수의 복사본으로 채워진 새 스케줄을 반환합니다.
+// 네트워크마다 별도의 값을 가지므로 한 구성을 수정해도 다른 구성에 영향을 주지 않습니다.
+// Prague 매개변수는 Prague 포크가 예정된 후에 추가합니다.
+func newCancunBlobSchedule() *BlobScheduleConfig {
+	return &BlobScheduleConfig{
+		Cancun: copyBlobConfig(DefaultCancunBlobConfig),
+	}
+}
+
+// String은 stringer 인터페이스를 구현하여 Verkle 변환 세부 정보를 반환합니다.
+func (c *VerkleConfig) String() string {
+	if c.ConversionStart == nil {
+		return fmt.Sprintf("verkle(stride: %d)", c.ConversionStride)
+	}
+	return fmt.Sprintf("verkle(start: @%d, stride: %d)", *c.ConversionStart, c.ConversionStride)
+}
+
 // EthashConfig는 작업 증명(proof-of-work) 기반 합의 엔진에 대한 구성입니다.
 type EthashConfig struct{}
 
@@ -437,6 +489,9 @@ func (c *ChainConfig) Description() string {
 	if c.VerkleTime != nil {
 		banner += fmt.Sprintf(" - Verkle:                      @%-10v\n", *c.VerkleTime)
 	}
+	if c.Verkle != nil && c.Verkle.ConversionStart != nil {
+		banner += fmt.Sprintf(" - Verkle conversion:           @%-10v (stride %d)\n", *c.Verkle.ConversionStart, c.Verkle.ConversionStride)
+	}
 	return banner
 }
 
@@ -539,6 +594,59 @@ func (c *ChainConfig) IsVerkle(num *big.Int, time uint64) bool {
 	return c.IsLondon(num) && isTimestampForked(c.VerkleTime, time)
 }
 
+// IsShanghaiAtGenesis는 제네시스 시간이 genesisTime인 체인의 제네시스 블록에서 Shanghai가
+// 활성화되어 있는지 여부를 반환합니다. 제네시스 블록에 출금 필드를 설정할지 결정하는 데 사용합니다.
+func (c *ChainConfig) IsShanghaiAtGenesis(genesisTime uint64) bool {
+	return c.IsShanghai(common.Big0, genesisTime)
+}
+
+// IsCancunAtGenesis는 제네시스 블록에서 Cancun이 활성화되어 있는지 여부를 반환합니다. 제네시스
+// 블록에 blob 가스 필드와 부모 비콘 루트를 설정할지 결정하는 데 사용합니다.
+func (c *ChainConfig) IsCancunAtGenesis(genesisTime uint64) bool {
+	return c.IsCancun(common.Big0, genesisTime)
+}
+
+// GenesisRules는 제네시스 시간이 genesisTime인 체인의 제네시스 블록에 적용되는 규칙을 반환합니다.
+// 터미널 총 난이도가 0이면 제네시스부터 머지 이후인 것으로 간주합니다.
+func (c *ChainConfig) GenesisRules(genesisTime uint64) Rules {
+	isMerge := c.TerminalTotalDifficulty != nil && c.TerminalTotalDifficulty.Sign() == 0
+	return c.Rules(common.Big0, isMerge, genesisTime)
+}
+
+// ActiveAtGenesis는 fork가 제네시스 블록에서 활성화되어 있는지 여부를 반환합니다.
+func (c *ChainConfig) ActiveAtGenesis(fork Fork, genesisTime uint64) bool {
+	return c.GenesisRules(genesisTime).Forks().Has(fork)
+}
+
+// BlobConfig는 주어진 시간에 활성화된 포크의 blob 매개변수를 반환합니다.
+// 구성에 포크별 값이 없으면 해당 포크의 기본값을 반환하며, Cancun 이전에는 nil을 반환합니다.
+// 반환된 값은 복사본이므로 수정해도 구성이나 기본값에 영향을 주지 않습니다.
+func (c *ChainConfig) BlobConfig(time uint64) *BlobConfig {
+	var schedule BlobScheduleConfig
+	if c.BlobScheduleConfig != nil {
+		schedule = *c.BlobScheduleConfig
+	}
+	switch {
+	case c.IsPrague(c.LondonBlock, time):
+		if schedule.Prague != nil {
+			return copyBlobConfig(schedule.Prague)
+		}
+		return copyBlobConfig(DefaultPragueBlobConfig)
+	case c.IsCancun(c.LondonBlock, time):
+		if schedule.Cancun != nil {
+			return copyBlobConfig(schedule.Cancun)
+		}
+		return copyBlobConfig(DefaultCancunBlobConfig)
+	default:
+		return nil
+	}
+}
+
+// IsVerkleConversion은 time이 Verkle 상태 변환 시작 시간과 같거나 이후인지 여부를 반환합니다.
+func (c *ChainConfig) IsVerkleConversion(num *big.Int, time uint64) bool {
+	return c.IsVerkle(num, time) && c.Verkle != nil && isTimestampForked(c.Verkle.ConversionStart, time)
+}
+
 // CheckCompatible는 예약된 포크 전환을 가져올 때 호환되지 않는 체인 구성이 있는지 확인합니다.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64, time uint64) *ConfigCompatError {
 	var (
@@ -563,6 +671,39 @@ func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64, time u
 	return lasterr
 }
 
+// CheckCompatibleAll은 CheckCompatible과 달리 주어진 head에서 발견되는 모든 호환되지 않는 포크 전환을 반환하며,
+// 모든 충돌을 해소하기 위해 로컬 체인을 되감아야 하는 위치를 함께 제안합니다. 충돌이 없으면 nil, nil을 반환합니다.
+func (c *ChainConfig) CheckCompatibleAll(newcfg *ChainConfig, height uint64, time uint64) ([]*ConfigCompatError, *RewindTarget) {
+	errs := c.compatErrors(newcfg, new(big.Int).SetUint64(height), time)
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	// 블록 기반 포크는 항상 시간 기반 포크보다 앞서므로, 블록 기반 충돌이 하나라도 있으면
+	// 가장 낮은 블록으로 되감는 것으로 모든 충돌이 해소됩니다.
+	var target RewindTarget
+	for _, err := range errs {
+		if err.StoredBlock != nil || err.NewBlock != nil {
+			if target.Block == nil || err.RewindToBlock < *target.Block {
+				target.Block = newUint64(err.RewindToBlock)
+			}
+		} else if target.Time == nil || err.RewindToTime < *target.Time {
+			target.Time = newUint64(err.RewindToTime)
+		}
+	}
+	if target.Block != nil {
+		target.Time = nil
+	}
+	return errs, &target
+}
+
+// RewindTarget은 호환되지 않는 체인 구성을 적용하기 위해 로컬 체인을 되감아야 하는 위치를 나타냅니다.
+// Block과 Time 중 정확히 하나가 설정됩니다. 블록 0(제네시스)으로 되감는 경우도 Block이 0을
+// 가리키는 것으로 구분됩니다.
+type RewindTarget struct {
+	Block *uint64 // 되감아야 하는 블록 번호 (시간 기준으로 되감는 경우 nil)
+	Time  *uint64 // 되감아야 하는 타임스탬프 (블록 번호 기준으로 되감는 경우 nil)
+}
+
 // CheckConfigForkOrder는 포크를 건너뛰지 않도록 체인 구성이 정의되었는지 확인합니다.
 // geth는 공식 네트워크에서와 다른 순서로 포크를 구현할 수 있을만큼 충분히 플러그인되지 않습니다.
 func (c *ChainConfig) CheckConfigForkOrder() error {
@@ -628,90 +769,209 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	if err := c.checkVerkleParams(); err != nil {
+		return err
+	}
+	if err := c.checkBlobSchedule(); err != nil {
+		return err
+	}
+	return c.checkEIP1559Params()
+}
+
+// checkBlobSchedule는 포크별 blob 매개변수가 유효한지 확인합니다.
+func (c *ChainConfig) checkBlobSchedule() error {
+	if c.BlobScheduleConfig == nil {
+		return nil
+	}
+	for _, entry := range []struct {
+		name   string
+		config *BlobConfig
+	}{
+		{name: "cancun", config: c.BlobScheduleConfig.Cancun},
+		{name: "prague", config: c.BlobScheduleConfig.Prague},
+	} {
+		if entry.config == nil {
+			continue
+		}
+		if err := entry.config.validate(); err != nil {
+			return fmt.Errorf("invalid blob schedule for %s: %w", entry.name, err)
+		}
+	}
+	return nil
+}
+
+// checkVerkleParams는 Verkle 상태 변환 구성이 유효한지 확인합니다.
+func (c *ChainConfig) checkVerkleParams() error {
+	if c.Verkle == nil || c.Verkle.ConversionStart == nil {
+		return nil
+	}
+	if c.VerkleTime == nil {
+		return fmt.Errorf("invalid verkle config: conversion scheduled at timestamp %d, but verkleTime not enabled", *c.Verkle.ConversionStart)
+	}
+	if *c.Verkle.ConversionStart < *c.VerkleTime {
+		return fmt.Errorf("invalid verkle config: conversion scheduled at timestamp %d, before verkleTime %d", *c.Verkle.ConversionStart, *c.VerkleTime)
+	}
+	if c.Verkle.ConversionStride == 0 {
+		return errors.New("invalid verkle config: conversionStride must be non-zero")
+	}
+	return nil
+}
+
+// checkEIP1559Params는 EIP-1559 매개변수 재정의 값이 유효한지 확인합니다.
+func (c *ChainConfig) checkEIP1559Params() error {
+	if c.EIP1559Denominator != nil && *c.EIP1559Denominator == 0 {
+		return errors.New("invalid baseFeeChangeDenominator: must be non-zero")
+	}
+	if c.EIP1559Elasticity != nil && *c.EIP1559Elasticity == 0 {
+		return errors.New("invalid elasticityMultiplier: must be non-zero")
+	}
+	if c.EIP1559MinBaseFee != nil && c.EIP1559MinBaseFee.Sign() < 0 {
+		return fmt.Errorf("invalid minBaseFee: %v is negative", c.EIP1559MinBaseFee)
+	}
 	return nil
 }
 
 func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int, headTimestamp uint64) *ConfigCompatError {
+	if errs := c.compatErrors(newcfg, headNumber, headTimestamp); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// compatErrors는 주어진 head에서 발견되는 모든 호환되지 않는 포크 전환을 구성에 정의된 순서대로 반환합니다.
+func (c *ChainConfig) compatErrors(newcfg *ChainConfig, headNumber *big.Int, headTimestamp uint64) []*ConfigCompatError {
+	var errs []*ConfigCompatError
 	if isForkBlockIncompatible(c.HomesteadBlock, newcfg.HomesteadBlock, headNumber) {
-		return newBlockCompatError("Homestead fork block", c.HomesteadBlock, newcfg.HomesteadBlock)
+		errs = append(errs, newBlockCompatError("Homestead fork block", c.HomesteadBlock, newcfg.HomesteadBlock))
 	}
 	if isForkBlockIncompatible(c.DAOForkBlock, newcfg.DAOForkBlock, headNumber) {
-		return newBlockCompatError("DAO fork block", c.DAOForkBlock, newcfg.DAOForkBlock)
+		errs = append(errs, newBlockCompatError("DAO fork block", c.DAOForkBlock, newcfg.DAOForkBlock))
 	}
 	if c.IsDAOFork(headNumber) && c.DAOForkSupport != newcfg.DAOForkSupport {
-		return newBlockCompatError("DAO fork support flag", c.DAOForkBlock, newcfg.DAOForkBlock)
+		errs = append(errs, newBlockCompatError("DAO fork support flag", c.DAOForkBlock, newcfg.DAOForkBlock))
 	}
 	if isForkBlockIncompatible(c.EIP150Block, newcfg.EIP150Block, headNumber) {
-		return newBlockCompatError("EIP150 fork block", c.EIP150Block, newcfg.EIP150Block)
+		errs = append(errs, newBlockCompatError("EIP150 fork block", c.EIP150Block, newcfg.EIP150Block))
 	}
 	if isForkBlockIncompatible(c.EIP155Block, newcfg.EIP155Block, headNumber) {
-		return newBlockCompatError("EIP155 fork block", c.EIP155Block, newcfg.EIP155Block)
+		errs = append(errs, newBlockCompatError("EIP155 fork block", c.EIP155Block, newcfg.EIP155Block))
 	}
 	if isForkBlockIncompatible(c.EIP158Block, newcfg.EIP158Block, headNumber) {
-		return newBlockCompatError("EIP158 fork block", c.EIP158Block, newcfg.EIP158Block)
+		errs = append(errs, newBlockCompatError("EIP158 fork block", c.EIP158Block, newcfg.EIP158Block))
 	}
 	if c.IsEIP158(headNumber) && !configBlockEqual(c.ChainID, newcfg.ChainID) {
-		return newBlockCompatError("EIP158 chain ID", c.EIP158Block, newcfg.EIP158Block)
+		errs = append(errs, newBlockCompatError("EIP158 chain ID", c.EIP158Block, newcfg.EIP158Block))
 	}
 	if isForkBlockIncompatible(c.ByzantiumBlock, newcfg.ByzantiumBlock, headNumber) {
-		return newBlockCompatError("Byzantium fork block", c.ByzantiumBlock, newcfg.ByzantiumBlock)
+		errs = append(errs, newBlockCompatError("Byzantium fork block", c.ByzantiumBlock, newcfg.ByzantiumBlock))
 	}
 	if isForkBlockIncompatible(c.ConstantinopleBlock, newcfg.ConstantinopleBlock, headNumber) {
-		return newBlockCompatError("Constantinople fork block", c.ConstantinopleBlock, newcfg.ConstantinopleBlock)
+		errs = append(errs, newBlockCompatError("Constantinople fork block", c.ConstantinopleBlock, newcfg.ConstantinopleBlock))
 	}
 	if isForkBlockIncompatible(c.PetersburgBlock, newcfg.PetersburgBlock, headNumber) {
 		// the only case where we allow Petersburg to be set in the past is if it is equal to Constantinople
 		// mainly to satisfy fork ordering requirements which state that Petersburg fork be set if Constantinople fork is set
 		if isForkBlockIncompatible(c.ConstantinopleBlock, newcfg.PetersburgBlock, headNumber) {
-			return newBlockCompatError("Petersburg fork block", c.PetersburgBlock, newcfg.PetersburgBlock)
+			errs = append(errs, newBlockCompatError("Petersburg fork block", c.PetersburgBlock, newcfg.PetersburgBlock))
 		}
 	}
 	if isForkBlockIncompatible(c.IstanbulBlock, newcfg.IstanbulBlock, headNumber) {
-		return newBlockCompatError("Istanbul fork block", c.IstanbulBlock, newcfg.IstanbulBlock)
+		errs = append(errs, newBlockCompatError("Istanbul fork block", c.IstanbulBlock, newcfg.IstanbulBlock))
 	}
 	if isForkBlockIncompatible(c.MuirGlacierBlock, newcfg.MuirGlacierBlock, headNumber) {
-		return newBlockCompatError("Muir Glacier fork block", c.MuirGlacierBlock, newcfg.MuirGlacierBlock)
+		errs = append(errs, newBlockCompatError("Muir Glacier fork block", c.MuirGlacierBlock, newcfg.MuirGlacierBlock))
 	}
 	if isForkBlockIncompatible(c.BerlinBlock, newcfg.BerlinBlock, headNumber) {
-		return newBlockCompatError("Berlin fork block", c.BerlinBlock, newcfg.BerlinBlock)
+		errs = append(errs, newBlockCompatError("Berlin fork block", c.BerlinBlock, newcfg.BerlinBlock))
 	}
 	if isForkBlockIncompatible(c.LondonBlock, newcfg.LondonBlock, headNumber) {
-		return newBlockCompatError("London fork block", c.LondonBlock, newcfg.LondonBlock)
+		errs = append(errs, newBlockCompatError("London fork block", c.LondonBlock, newcfg.LondonBlock))
 	}
 	if isForkBlockIncompatible(c.ArrowGlacierBlock, newcfg.ArrowGlacierBlock, headNumber) {
-		return newBlockCompatError("Arrow Glacier fork block", c.ArrowGlacierBlock, newcfg.ArrowGlacierBlock)
+		errs = append(errs, newBlockCompatError("Arrow Glacier fork block", c.ArrowGlacierBlock, newcfg.ArrowGlacierBlock))
 	}
 	if isForkBlockIncompatible(c.GrayGlacierBlock, newcfg.GrayGlacierBlock, headNumber) {
-		return newBlockCompatError("Gray Glacier fork block", c.GrayGlacierBlock, newcfg.GrayGlacierBlock)
+		errs = append(errs, newBlockCompatError("Gray Glacier fork block", c.GrayGlacierBlock, newcfg.GrayGlacierBlock))
 	}
 	if isForkBlockIncompatible(c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock, headNumber) {
-		return newBlockCompatError("Merge netsplit fork block", c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock)
+		errs = append(errs, newBlockCompatError("Merge netsplit fork block", c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock))
 	}
 	if isForkTimestampIncompatible(c.ShanghaiTime, newcfg.ShanghaiTime, headTimestamp) {
-		return newTimestampCompatError("Shanghai fork timestamp", c.ShanghaiTime, newcfg.ShanghaiTime)
+		errs = append(errs, newTimestampCompatError("Shanghai fork timestamp", c.ShanghaiTime, newcfg.ShanghaiTime))
 	}
 	if isForkTimestampIncompatible(c.CancunTime, newcfg.CancunTime, headTimestamp) {
-		return newTimestampCompatError("Cancun fork timestamp", c.CancunTime, newcfg.CancunTime)
+		errs = append(errs, newTimestampCompatError("Cancun fork timestamp", c.CancunTime, newcfg.CancunTime))
 	}
 	if isForkTimestampIncompatible(c.PragueTime, newcfg.PragueTime, headTimestamp) {
-		return newTimestampCompatError("Prague fork timestamp", c.PragueTime, newcfg.PragueTime)
+		errs = append(errs, newTimestampCompatError("Prague fork timestamp", c.PragueTime, newcfg.PragueTime))
 	}
 	if isForkTimestampIncompatible(c.VerkleTime, newcfg.VerkleTime, headTimestamp) {
-		return newTimestampCompatError("Verkle fork timestamp", c.VerkleTime, newcfg.VerkleTime)
+		errs = append(errs, newTimestampCompatError("Verkle fork timestamp", c.VerkleTime, newcfg.VerkleTime))
 	}
-	return nil
+	if isForkTimestampIncompatible(c.verkleConversionStart(), newcfg.verkleConversionStart(), headTimestamp) {
+		errs = append(errs, newTimestampCompatError("Verkle conversion timestamp", c.verkleConversionStart(), newcfg.verkleConversionStart()))
+	}
+	if c.IsVerkleConversion(headNumber, headTimestamp) && c.Verkle.ConversionStride != newcfg.verkleConversionStride() {
+		errs = append(errs, newTimestampCompatError("Verkle conversion stride", c.verkleConversionStart(), newcfg.verkleConversionStart()))
+	}
+	// EIP-1559 매개변수는 포크로 예약되지 않으므로, London 이후에 바꾸면 이미 처리된 모든 London
+	// 블록의 기본 수수료가 달라집니다.
+	if c.IsLondon(headNumber) {
+		if c.BaseFeeChangeDenominator() != newcfg.BaseFeeChangeDenominator() {
+			errs = append(errs, newBlockCompatError("EIP-1559 base fee change denominator", c.LondonBlock, c.LondonBlock))
+		}
+		if c.ElasticityMultiplier() != newcfg.ElasticityMultiplier() {
+			errs = append(errs, newBlockCompatError("EIP-1559 elasticity multiplier", c.LondonBlock, c.LondonBlock))
+		}
+		if c.MinBaseFee().Cmp(newcfg.MinBaseFee()) != 0 {
+			errs = append(errs, newBlockCompatError("EIP-1559 minimum base fee", c.LondonBlock, c.LondonBlock))
+		}
+	}
+	return errs
+}
+
+// verkleConversionStart는 Verkle 상태 변환 시작 시간을 반환하며, 구성되지 않은 경우 nil을 반환합니다.
+func (c *ChainConfig) verkleConversionStart() *uint64 {
+	if c.Verkle == nil {
+		return nil
+	}
+	return c.Verkle.ConversionStart
+}
+
+// verkleConversionStride는 Verkle 상태 변환 보폭을 반환하며, 구성되지 않은 경우 0을 반환합니다.
+func (c *ChainConfig) verkleConversionStride() uint64 {
+	if c.Verkle == nil {
+		return 0
+	}
+	return c.Verkle.ConversionStride
 }
 
 // BaseFeeChangeDenominator는 블록 간 기본 수수료가 변경될 수 있는 양을 제한합니다.
+// 구성에 재정의 값이 있으면 그 값을, 없으면 기본값을 반환합니다.
 func (c *ChainConfig) BaseFeeChangeDenominator() uint64 {
+	if c.EIP1559Denominator != nil {
+		return *c.EIP1559Denominator
+	}
 	return DefaultBaseFeeChangeDenominator
 }
 
 // ElasticityMultiplier는 EIP-1559 블록이 가질 수 있는 최대 가스 한도를 제한합니다.
+// 구성에 재정의 값이 있으면 그 값을, 없으면 기본값을 반환합니다.
 func (c *ChainConfig) ElasticityMultiplier() uint64 {
+	if c.EIP1559Elasticity != nil {
+		return *c.EIP1559Elasticity
+	}
 	return DefaultElasticityMultiplier
 }
 
+// MinBaseFee는 기본 수수료가 내려갈 수 있는 하한을 반환합니다. 하한이 설정되지 않은 경우 0을 반환합니다.
+func (c *ChainConfig) MinBaseFee() *big.Int {
+	if c.EIP1559MinBaseFee != nil {
+		return new(big.Int).Set(c.EIP1559MinBaseFee)
+	}
+	return new(big.Int)
+}
+
 // isForkBlockIncompatible는 블록 s1에서 예약된 포크가 블록 s2로 다시 예약될 수 없는지 여부를 반환합니다.
 // 왜냐하면 head가 이미 포크를 지나쳤기 때문입니다.
 func isForkBlockIncompatible(s1, s2, head *big.Int) bool {
@@ -823,6 +1083,10 @@ func newTimestampCompatError(what string, storedtime, newtime *uint64) *ConfigCo
 	return err
 }
 
+// ErrIncompatibleConfig는 모든 ConfigCompatError가 감싸는 오류로, errors.Is를 통해
+// 구성 호환성 오류를 다른 오류와 구분하는 데 사용할 수 있습니다.
+var ErrIncompatibleConfig = errors.New("incompatible chain config")
+
 func (err *ConfigCompatError) Error() string {
 	if err.StoredBlock != nil {
 		return fmt.Sprintf("mismatching %s in database (have block %d, want block %d, rewindto block %d)", err.What, err.StoredBlock, err.NewBlock, err.RewindToBlock)
@@ -830,6 +1094,11 @@ func (err *ConfigCompatError) Error() string {
 	return fmt.Sprintf("mismatching %s in database (have timestamp %d, want timestamp %d, rewindto timestamp %d)", err.What, err.StoredTime, err.NewTime, err.RewindToTime)
 }
 
+// Unwrap은 ErrIncompatibleConfig를 반환합니다.
+func (err *ConfigCompatError) Unwrap() error {
+	return ErrIncompatibleConfig
+}
+
 // Rules는 ChainConfig를 래핑하며 단순히 문법적 설탕이거나 블록에 대한 정보가 없거나 필요하지 않은 함수에 사용할 수 있습니다.
 //
 // Rules는 일회성 인터페이스이므로 전환 단계 사이에 사용해서는 안 됩니다.
@@ -839,7 +1108,10 @@ type Rules struct {
 	IsByzantium, IsConstantinople, IsPetersburg, IsIstanbul bool
 	IsBerlin, IsLondon                                      bool
 	IsMerge, IsShanghai, IsCancun, IsPrague                 bool
-	IsVerkle                                                bool
+	IsVerkle, IsVerkleConversion                            bool
+
+	// Verkle 상태 변환 매개변수 (IsVerkleConversion이 true인 경우에만 의미가 있습니다)
+	VerkleConversionStart, VerkleConversionStride uint64
 }
 
 // Rules는 c의 ChainID가 nil이 아님을 보장합니다.
@@ -848,6 +1120,13 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 	if chainID == nil {
 		chainID = new(big.Int)
 	}
+	var verkleStart, verkleStride uint64
+	if c.Verkle != nil {
+		if c.Verkle.ConversionStart != nil {
+			verkleStart = *c.Verkle.ConversionStart
+		}
+		verkleStride = c.Verkle.ConversionStride
+	}
 	return Rules{
 		ChainID:          new(big.Int).Set(chainID),
 		IsHomestead:      c.IsHomestead(num),
@@ -865,5 +1144,9 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 		IsCancun:         c.IsCancun(num, timestamp),
 		IsPrague:         c.IsPrague(num, timestamp),
 		IsVerkle:         c.IsVerkle(num, timestamp),
+
+		IsVerkleConversion:     c.IsVerkleConversion(num, timestamp),
+		VerkleConversionStart:  verkleStart,
+		VerkleConversionStride: verkleStride,
 	}
 }
@@ -0,0 +1,159 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var bigIntType = reflect.TypeOf((*big.Int)(nil))
+
+// ConfigDiff는 두 체인 구성 사이에서 값이 다른 필드 하나를 기술합니다.
+type ConfigDiff struct {
+	Field string      // JSON 필드 이름. 중첩된 구성은 "clique.period"처럼 점으로 구분합니다.
+	A     interface{} // 첫 번째 구성의 값 (설정되지 않은 경우 nil)
+	B     interface{} // 두 번째 구성의 값 (설정되지 않은 경우 nil)
+}
+
+// String은 stringer 인터페이스를 구현합니다.
+func (d ConfigDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, formatConfigValue(d.A), formatConfigValue(d.B))
+}
+
+// DiffConfigs는 a와 b에서 값이 다른 모든 필드를 구조체 정의 순서대로 반환합니다. 포인터 필드는
+// 가리키는 값으로 비교되며, 값은 복사되어 반환되므로 결과를 수정해도 구성에 영향을 주지 않습니다.
+// nil 구성은 모든 필드가 설정되지 않은 구성으로 취급됩니다.
+func DiffConfigs(a, b *ChainConfig) []ConfigDiff {
+	if a == nil {
+		a = new(ChainConfig)
+	}
+	if b == nil {
+		b = new(ChainConfig)
+	}
+	return diffConfigStruct("", reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), nil)
+}
+
+// MergeConfig는 base의 깊은 복사본에 override에서 설정된 필드만 적용한 새 구성을 반환합니다.
+// 포인터 필드는 nil이 아닐 때, 값 필드는 0이 아닐 때 설정된 것으로 간주하므로, 이 함수로는
+// 포크를 비활성화하거나 불리언 플래그를 false로 되돌릴 수 없습니다. 중첩된 구성(clique 등)은
+// 필드 단위로 병합됩니다. base와 override는 수정되지 않습니다.
+func MergeConfig(base, override *ChainConfig) *ChainConfig {
+	merged := new(ChainConfig)
+	if base != nil {
+		mergeConfigStruct(reflect.ValueOf(merged).Elem(), reflect.ValueOf(base).Elem())
+	}
+	if override != nil {
+		mergeConfigStruct(reflect.ValueOf(merged).Elem(), reflect.ValueOf(override).Elem())
+	}
+	return merged
+}
+
+func diffConfigStruct(prefix string, a, b reflect.Value, diffs []ConfigDiff) []ConfigDiff {
+	for i := 0; i < a.NumField(); i++ {
+		name := prefix + configFieldName(a.Type().Field(i))
+		fa, fb := a.Field(i), b.Field(i)
+		if isConfigStructPtr(fa.Type()) && !fa.IsNil() && !fb.IsNil() {
+			diffs = diffConfigStruct(name+".", fa.Elem(), fb.Elem(), diffs)
+			continue
+		}
+		if !configValueEqual(fa, fb) {
+			diffs = append(diffs, ConfigDiff{Field: name, A: configValue(fa), B: configValue(fb)})
+		}
+	}
+	return diffs
+}
+
+func mergeConfigStruct(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		d, s := dst.Field(i), src.Field(i)
+		switch {
+		case s.IsZero():
+			continue
+		case isConfigStructPtr(s.Type()) && !d.IsNil():
+			mergeConfigStruct(d.Elem(), s.Elem())
+		default:
+			d.Set(copyConfigValue(s))
+		}
+	}
+}
+
+// configFieldName은 구조체 필드의 JSON 이름을 반환합니다.
+func configFieldName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// isConfigStructPtr는 typ가 필드 단위로 비교 및 병합되는 중첩 구성 타입인지 여부를 반환합니다.
+func isConfigStructPtr(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Ptr && typ != bigIntType && typ.Elem().Kind() == reflect.Struct
+}
+
+func configValueEqual(a, b reflect.Value) bool {
+	switch {
+	case a.Type() == bigIntType:
+		return configBlockEqual(a.Interface().(*big.Int), b.Interface().(*big.Int))
+	case a.Kind() == reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return reflect.DeepEqual(a.Elem().Interface(), b.Elem().Interface())
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+// configValue는 ConfigDiff에 담을 필드 값의 복사본을 반환합니다. 스칼라 포인터는 역참조됩니다.
+func configValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type() != bigIntType && !isConfigStructPtr(v.Type()) {
+			return v.Elem().Interface()
+		}
+	}
+	return copyConfigValue(v).Interface()
+}
+
+// copyConfigValue는 구성 필드 값의 깊은 복사본을 반환합니다.
+func copyConfigValue(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return v
+	}
+	if v.Type() == bigIntType {
+		return reflect.ValueOf(new(big.Int).Set(v.Interface().(*big.Int)))
+	}
+	cpy := reflect.New(v.Type().Elem())
+	if isConfigStructPtr(v.Type()) {
+		mergeConfigStruct(cpy.Elem(), v.Elem())
+	} else {
+		cpy.Elem().Set(v.Elem())
+	}
+	return cpy
+}
+
+func formatConfigValue(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%v", v)
+}
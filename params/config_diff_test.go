@@ -0,0 +1,88 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	if diffs := DiffConfigs(MainnetChainConfig, MainnetChainConfig); len(diffs) != 0 {
+		t.Fatalf("diffs for identical configs: %v", diffs)
+	}
+	a := &ChainConfig{
+		ChainID:      big.NewInt(1),
+		LondonBlock:  big.NewInt(10),
+		ShanghaiTime: newUint64(100),
+		Clique:       &CliqueConfig{Period: 5, Epoch: 30000},
+	}
+	b := &ChainConfig{
+		ChainID:        big.NewInt(1),
+		LondonBlock:    big.NewInt(10),
+		ShanghaiTime:   newUint64(200),
+		DAOForkSupport: true,
+		Ethash:         new(EthashConfig),
+		Clique:         &CliqueConfig{Period: 5, Epoch: 100},
+	}
+	want := []ConfigDiff{
+		{Field: "daoForkSupport", A: false, B: true},
+		{Field: "shanghaiTime", A: uint64(100), B: uint64(200)},
+		{Field: "ethash", A: nil, B: new(EthashConfig)},
+		{Field: "clique.epoch", A: uint64(30000), B: uint64(100)},
+	}
+	if diffs := DiffConfigs(a, b); !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("wrong diffs\nhave %v\nwant %v", diffs, want)
+	}
+	if s := want[1].String(); s != "shanghaiTime: 100 -> 200" {
+		t.Fatalf("wrong string: %q", s)
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	override := &ChainConfig{
+		CancunTime: newUint64(1000),
+		PragueTime: newUint64(2000),
+		BlobScheduleConfig: &BlobScheduleConfig{
+			Prague: &BlobConfig{Target: 4, Max: 8, UpdateFraction: 1},
+		},
+	}
+	merged := MergeConfig(MainnetChainConfig, override)
+	want := []ConfigDiff{
+		{Field: "cancunTime", A: nil, B: uint64(1000)},
+		{Field: "pragueTime", A: nil, B: uint64(2000)},
+		{Field: "blobSchedule", A: nil, B: override.BlobScheduleConfig},
+	}
+	if diffs := DiffConfigs(MainnetChainConfig, merged); !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("wrong diffs after merge\nhave %v\nwant %v", diffs, want)
+	}
+
+	// The merged config must not share memory with its inputs.
+	merged.ChainID.SetUint64(1234)
+	*merged.CancunTime = 1
+	merged.BlobScheduleConfig.Prague.Max = 100
+	if MainnetChainConfig.ChainID.Uint64() != 1 || *override.CancunTime != 1000 || override.BlobScheduleConfig.Prague.Max != 8 {
+		t.Fatal("merged config aliases its inputs")
+	}
+
+	// Nested configs are merged field by field.
+	merged = MergeConfig(&ChainConfig{Clique: &CliqueConfig{Period: 5, Epoch: 30000}}, &ChainConfig{Clique: &CliqueConfig{Period: 2}})
+	if *merged.Clique != (CliqueConfig{Period: 2, Epoch: 30000}) {
+		t.Fatalf("wrong merged clique config: %+v", merged.Clique)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// scheduledFork는 구성에서 블록 번호나 타임스탬프로 예약되는 포크 하나입니다.
+type scheduledFork struct {
+	name     string
+	block    func(*ChainConfig) **big.Int // 블록 기반 포크의 필드
+	time     func(*ChainConfig) **uint64  // 타임스탬프 기반 포크의 필드
+	optional bool                         // 다음 포크의 선행 조건이 아닌 포크 (CheckConfigForkOrder와 같음)
+}
+
+// scheduledForks는 WithFork가 다루는 포크를 활성화 순서대로 나열합니다. 머지는 블록이 아니라
+// 터미널 총 난이도로 예약되므로 따로 처리합니다.
+var scheduledForks = []scheduledFork{
+	{name: "homestead", block: func(c *ChainConfig) **big.Int { return &c.HomesteadBlock }},
+	{name: "eip150", block: func(c *ChainConfig) **big.Int { return &c.EIP150Block }},
+	{name: "eip155", block: func(c *ChainConfig) **big.Int { return &c.EIP155Block }},
+	{name: "eip158", block: func(c *ChainConfig) **big.Int { return &c.EIP158Block }},
+	{name: "byzantium", block: func(c *ChainConfig) **big.Int { return &c.ByzantiumBlock }},
+	{name: "constantinople", block: func(c *ChainConfig) **big.Int { return &c.ConstantinopleBlock }},
+	{name: "petersburg", block: func(c *ChainConfig) **big.Int { return &c.PetersburgBlock }},
+	{name: "istanbul", block: func(c *ChainConfig) **big.Int { return &c.IstanbulBlock }},
+	{name: "muirGlacier", block: func(c *ChainConfig) **big.Int { return &c.MuirGlacierBlock }, optional: true},
+	{name: "berlin", block: func(c *ChainConfig) **big.Int { return &c.BerlinBlock }},
+	{name: "london", block: func(c *ChainConfig) **big.Int { return &c.LondonBlock }},
+	{name: "arrowGlacier", block: func(c *ChainConfig) **big.Int { return &c.ArrowGlacierBlock }, optional: true},
+	{name: "grayGlacier", block: func(c *ChainConfig) **big.Int { return &c.GrayGlacierBlock }, optional: true},
+	{name: "mergeNetsplit", block: func(c *ChainConfig) **big.Int { return &c.MergeNetsplitBlock }, optional: true},
+	{name: "shanghai", time: func(c *ChainConfig) **uint64 { return &c.ShanghaiTime }},
+	{name: "cancun", time: func(c *ChainConfig) **uint64 { return &c.CancunTime }},
+	{name: "prague", time: func(c *ChainConfig) **uint64 { return &c.PragueTime }},
+	{name: "verkle", time: func(c *ChainConfig) **uint64 { return &c.VerkleTime }},
+}
+
+// mergeForkName은 터미널 총 난이도를 설정하는 포크 이름입니다.
+const mergeForkName = "merge"
+
+// TestConfigWithForks는 forks에 지정된 포크만 활성화된 테스트용 구성을 생성합니다. 키는 포크
+// 이름("london", "shanghai" 등)이고 값은 블록 기반 포크의 경우 블록 번호, 타임스탬프 기반 포크의
+// 경우 타임스탬프, "merge"의 경우 터미널 총 난이도입니다. 지정된 포크의 선행 포크는 WithFork와
+// 같은 규칙으로 채워집니다. 체인 ID는 1이고 합의 엔진은 ethash입니다.
+//
+// 알 수 없는 포크 이름이 있거나, 지정된 값들이 유효한 순서를 이루지 않으면 패닉합니다.
+func TestConfigWithForks(forks map[string]uint64) *ChainConfig {
+	config := &ChainConfig{ChainID: big.NewInt(1), Ethash: new(EthashConfig)}
+
+	// 활성화 순서대로 적용하여 나중 포크의 선행 조건 채우기가 앞서 지정된 값을 낮출 때만 충돌이
+	// 발생하도록 합니다.
+	names := make([]string, 0, len(forks))
+	for name := range forks {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return forkOrder(names[i]) < forkOrder(names[j]) })
+	for _, name := range names {
+		config = config.WithFork(name, forks[name])
+	}
+	for _, name := range names {
+		if have := config.forkValue(name); have == nil || *have != forks[name] {
+			panic(fmt.Sprintf("params: conflicting test fork schedule: %s at %d", name, forks[name]))
+		}
+	}
+	return config
+}
+
+// WithFork는 name 포크를 at(블록 번호, 타임스탬프 또는 "merge"의 터미널 총 난이도)에 활성화한
+// 구성의 복사본을 반환합니다. 반환된 구성은 항상 유효한 포크 순서를 가집니다.
+//
+//   - 활성화되지 않았거나 at보다 늦게 예약된 필수 선행 포크는 at으로 설정됩니다.
+//     타임스탬프 기반 포크의 경우 모든 필수 블록 기반 포크가 제네시스에서 활성화되고, 머지가
+//     설정되어 있지 않으면 터미널 총 난이도 0으로 머지가 활성화됩니다.
+//   - "merge"는 터미널 총 난이도가 0인 경우에만 머지를 통과한 것으로 표시합니다. 0이 아니면
+//     체인이 해당 난이도에 도달해야 머지가 일어납니다.
+//   - at보다 이르게 예약된 이후 포크는 at으로 미뤄집니다.
+//
+// 알 수 없는 포크 이름이면 패닉합니다. c는 수정되지 않습니다.
+func (c *ChainConfig) WithFork(name string, at uint64) *ChainConfig {
+	config := MergeConfig(c, nil)
+	if name == mergeForkName {
+		config.TerminalTotalDifficulty = new(big.Int).SetUint64(at)
+		config.TerminalTotalDifficultyPassed = at == 0
+		return config
+	}
+	idx := forkOrder(name)
+	if idx < 0 {
+		panic(fmt.Sprintf("params: unknown fork %q", name))
+	}
+	target := scheduledForks[idx]
+	for i, f := range scheduledForks {
+		switch {
+		case i == idx:
+			f.set(config, at)
+		case i < idx && !f.optional && f.time != nil:
+			// 같은 종류의 선행 포크는 늦어도 at에 활성화되어야 합니다.
+			if v := f.get(config); v == nil || *v > at {
+				f.set(config, at)
+			}
+		case i < idx && !f.optional:
+			if v := f.get(config); target.time != nil && v == nil {
+				f.set(config, 0)
+			} else if target.block != nil && (v == nil || *v > at) {
+				f.set(config, at)
+			}
+		case i < idx && f.block != nil && target.block != nil:
+			// 선택적 선행 포크도 예약되어 있다면 at보다 늦을 수 없습니다.
+			if v := f.get(config); v != nil && *v > at {
+				f.set(config, at)
+			}
+		case i > idx && (f.block != nil) == (target.block != nil):
+			// 같은 종류의 이후 포크는 at보다 이를 수 없습니다.
+			if v := f.get(config); v != nil && *v < at {
+				f.set(config, at)
+			}
+		}
+	}
+	if target.time != nil && config.TerminalTotalDifficulty == nil {
+		config.TerminalTotalDifficulty = new(big.Int)
+		config.TerminalTotalDifficultyPassed = true
+	}
+	return config
+}
+
+// WithoutFork는 name 포크와 그 이후의 모든 포크를 비활성화한 구성의 복사본을 반환합니다.
+// "merge"를 비활성화하면 타임스탬프 기반 포크도 모두 비활성화됩니다. 알 수 없는 포크 이름이면
+// 패닉합니다. c는 수정되지 않습니다.
+func (c *ChainConfig) WithoutFork(name string) *ChainConfig {
+	config := MergeConfig(c, nil)
+	idx := forkOrder(name)
+	if name == mergeForkName {
+		config.TerminalTotalDifficulty = nil
+		config.TerminalTotalDifficultyPassed = false
+		for i, f := range scheduledForks {
+			if f.time != nil {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		panic(fmt.Sprintf("params: unknown fork %q", name))
+	}
+	for _, f := range scheduledForks[idx:] {
+		f.clear(config)
+	}
+	return config
+}
+
+// forkOrder는 scheduledForks에서 name의 위치를 반환합니다. "merge"는 블록 기반 포크와
+// 타임스탬프 기반 포크 사이에 위치합니다. 알 수 없는 이름이면 -1을 반환합니다.
+func forkOrder(name string) int {
+	for i, f := range scheduledForks {
+		if f.name == name {
+			return i
+		}
+		if name == mergeForkName && f.time != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// forkValue는 name 포크가 예약된 값을 반환합니다. 활성화되지 않았으면 nil입니다.
+func (c *ChainConfig) forkValue(name string) *uint64 {
+	if name == mergeForkName {
+		if c.TerminalTotalDifficulty == nil || !c.TerminalTotalDifficulty.IsUint64() {
+			return nil
+		}
+		v := c.TerminalTotalDifficulty.Uint64()
+		return &v
+	}
+	if idx := forkOrder(name); idx >= 0 {
+		return scheduledForks[idx].get(c)
+	}
+	return nil
+}
+
+func (f scheduledFork) get(c *ChainConfig) *uint64 {
+	if f.time != nil {
+		if t := *f.time(c); t != nil {
+			return newUint64(*t)
+		}
+		return nil
+	}
+	if b := *f.block(c); b != nil && b.IsUint64() {
+		return newUint64(b.Uint64())
+	}
+	return nil
+}
+
+func (f scheduledFork) set(c *ChainConfig, at uint64) {
+	if f.time != nil {
+		*f.time(c) = newUint64(at)
+	} else {
+		*f.block(c) = new(big.Int).SetUint64(at)
+	}
+}
+
+func (f scheduledFork) clear(c *ChainConfig) {
+	if f.time != nil {
+		*f.time(c) = nil
+	} else {
+		*f.block(c) = nil
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestWithFork(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *ChainConfig
+		check  func(*ChainConfig) bool
+	}{
+		{
+			// Prerequisites of a block fork are filled in at the same block.
+			name:   "london",
+			config: NonActivatedConfig.WithFork("london", 5),
+			check: func(c *ChainConfig) bool {
+				return c.HomesteadBlock.Uint64() == 5 && c.BerlinBlock.Uint64() == 5 && c.LondonBlock.Uint64() == 5 &&
+					c.MuirGlacierBlock == nil && c.ShanghaiTime == nil
+			},
+		},
+		{
+			// Prerequisites scheduled after the toggled fork are pulled in.
+			name:   "pull-in",
+			config: TestConfigWithForks(map[string]uint64{"byzantium": 2}).WithFork("istanbul", 1),
+			check: func(c *ChainConfig) bool {
+				return c.ByzantiumBlock.Uint64() == 1 && c.IstanbulBlock.Uint64() == 1 && c.BerlinBlock == nil
+			},
+		},
+		{
+			// Time forks enable all block forks at genesis and the merge.
+			name:   "cancun",
+			config: NonActivatedConfig.WithFork("cancun", 100),
+			check: func(c *ChainConfig) bool {
+				return c.LondonBlock.Sign() == 0 && *c.ShanghaiTime == 100 && *c.CancunTime == 100 &&
+					c.TerminalTotalDifficulty.Sign() == 0 && c.TerminalTotalDifficultyPassed
+			},
+		},
+		{
+			// Later forks scheduled before the toggled fork are pushed back.
+			name:   "delay",
+			config: TestConfigWithForks(map[string]uint64{"prague": 10}).WithFork("shanghai", 20),
+			check: func(c *ChainConfig) bool {
+				return *c.ShanghaiTime == 20 && *c.CancunTime == 20 && *c.PragueTime == 20
+			},
+		},
+		{
+			// The merge is only passed at genesis with a zero terminal difficulty.
+			name:   "merge",
+			config: NonActivatedConfig.WithFork("merge", 100),
+			check: func(c *ChainConfig) bool {
+				return c.TerminalTotalDifficulty.Uint64() == 100 && !c.TerminalTotalDifficultyPassed
+			},
+		},
+		{
+			name:   "merge-genesis",
+			config: NonActivatedConfig.WithFork("merge", 0),
+			check: func(c *ChainConfig) bool {
+				return c.TerminalTotalDifficulty.Sign() == 0 && c.TerminalTotalDifficultyPassed
+			},
+		},
+		{
+			name:   "without",
+			config: TestConfigWithForks(map[string]uint64{"prague": 10}).WithoutFork("cancun"),
+			check: func(c *ChainConfig) bool {
+				return *c.ShanghaiTime == 10 && c.CancunTime == nil && c.PragueTime == nil
+			},
+		},
+		{
+			name:   "without-merge",
+			config: TestConfigWithForks(map[string]uint64{"merge": 7, "shanghai": 1}).WithoutFork("merge"),
+			check: func(c *ChainConfig) bool {
+				return c.TerminalTotalDifficulty == nil && c.ShanghaiTime == nil && c.LondonBlock != nil
+			},
+		},
+	}
+	for _, test := range tests {
+		if err := test.config.CheckConfigForkOrder(); err != nil {
+			t.Errorf("%s: invalid config: %v", test.name, err)
+		}
+		if !test.check(test.config) {
+			t.Errorf("%s: wrong config %v", test.name, test.config)
+		}
+	}
+}
+
+func TestWithForkNoMutation(t *testing.T) {
+	base := TestConfigWithForks(map[string]uint64{"london": 0})
+	base.WithFork("shanghai", 5)
+	if base.ShanghaiTime != nil || base.TerminalTotalDifficulty != nil {
+		t.Fatal("WithFork modified its receiver")
+	}
+}
+
+func TestTestConfigWithForks(t *testing.T) {
+	c := TestConfigWithForks(map[string]uint64{"berlin": 3, "london": 7, "merge": 100, "cancun": 50})
+	if c.BerlinBlock.Uint64() != 3 || c.LondonBlock.Uint64() != 7 || c.TerminalTotalDifficulty.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("explicit forks not kept: %v", c)
+	}
+	if c.IstanbulBlock.Uint64() != 3 || *c.ShanghaiTime != 50 || c.PragueTime != nil {
+		t.Fatalf("prerequisites not filled: %v", c)
+	}
+	if err := c.CheckConfigForkOrder(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, forks := range []map[string]uint64{
+		{"berlin": 10, "london": 5},
+		{"unknown": 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic for %v", forks)
+				}
+			}()
+			TestConfigWithForks(forks)
+		}()
+	}
+}
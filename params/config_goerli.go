@@ -0,0 +1,84 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !nogoerli
+
+package params
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Görli 테스트 네트워크는 운영이 종료되었습니다. nogoerli 빌드 태그로 이 파일을 제외하면
+// 네트워크 구성과 부트스트랩 노드가 바이너리에서 빠지며, 체인 ID 5의 조회는 ErrNetworkRetired를
+// 반환합니다.
+
+var GoerliGenesisHash = common.HexToHash("0xbf7e331f7f7c1dd2e05159666b3bf8bc7a8a3a9eb1d518969eab529dd9b88c1a")
+
+// GoerliChainConfig는 Görli 테스트 네트워크에서 노드를 실행하는 데 사용되는 체인 매개 변수입니다.
+var GoerliChainConfig = &ChainConfig{
+	ChainID:                       big.NewInt(5),
+	HomesteadBlock:                big.NewInt(0),
+	DAOForkBlock:                  nil,
+	DAOForkSupport:                true,
+	EIP150Block:                   big.NewInt(0),
+	EIP155Block:                   big.NewInt(0),
+	EIP158Block:                   big.NewInt(0),
+	ByzantiumBlock:                big.NewInt(0),
+	ConstantinopleBlock:           big.NewInt(0),
+	PetersburgBlock:               big.NewInt(0),
+	IstanbulBlock:                 big.NewInt(1_561_651),
+	MuirGlacierBlock:              nil,
+	BerlinBlock:                   big.NewInt(4_460_644),
+	LondonBlock:                   big.NewInt(5_062_605),
+	ArrowGlacierBlock:             nil,
+	TerminalTotalDifficulty:       big.NewInt(10_790_000),
+	TerminalTotalDifficultyPassed: true,
+	ShanghaiTime:                  newUint64(1678832736),
+	CancunTime:                    newUint64(1705473120),
+	Clique: &CliqueConfig{
+		Period: 15,
+		Epoch:  30000,
+	},
+}
+
+// GoerliBootnodes는 Görli 테스트 네트워크에서 실행되는 P2P 부트스트랩 노드의 enode URL입니다.
+var GoerliBootnodes = []string{
+	// Upstream bootnodes
+	"enode://011f758e6552d105183b1761c5e2dea0111bc20fd5f6422bc7f91e0fabbec9a6595caf6239b37feb773dddd3f87240d99d859431891e4a642cf2a0a9e6cbb98a@51.141.78.53:30303",
+	"enode://176b9417f511d05b6b2cf3e34b756cf0a7096b3094572a8f6ef4cdcb9d1f9d00683bf0f83347eebdf3b81c3521c2332086d9592802230bf528eaf606a1d9677b@13.93.54.137:30303",
+	"enode://46add44b9f13965f7b9875ac6b85f016f341012d84f975377573800a863526f4da19ae2c620ec73d11591fa9510e992ecc03ad0751f53cc02f7c7ed6d55c7291@94.237.54.114:30313",
+	"enode://b5948a2d3e9d486c4d75bf32713221c2bd6cf86463302339299bd227dc2e276cd5a1c7ca4f43a0e9122fe9af884efed563bd2a1fd28661f3b5f5ad7bf1de5949@18.218.250.66:30303",
+
+	// Ethereum Foundation bootnode
+	"enode://a61215641fb8714a373c80edbfa0ea8878243193f57c96eeb44d0bc019ef295abd4e044fd619bfc4c59731a73fb79afe84e9ab6da0c743ceb479cbb6d263fa91@3.11.147.67:30303",
+
+	// Goerli Initiative bootnodes
+	"enode://d4f764a48ec2a8ecf883735776fdefe0a3949eb0ca476bd7bc8d0954a9defe8fea15ae5da7d40b5d2d59ce9524a99daedadf6da6283fca492cc80b53689fb3b3@46.4.99.122:32109",
+	"enode://d2b720352e8216c9efc470091aa91ddafc53e222b32780f505c817ceef69e01d5b0b0797b69db254c586f493872352f5a022b4d8479a00fc92ec55f9ad46a27e@88.99.70.182:30303",
+}
+
+func init() {
+	registerNetwork(&Network{
+		Name:            "goerli",
+		GenesisHash:     GoerliGenesisHash,
+		Config:          GoerliChainConfig,
+		Bootnodes:       GoerliBootnodes,
+		DepositContract: common.HexToAddress("0xff50ed3d0ec03aC01D4C79aAd74928BFF48a7b2b"),
+	})
+}
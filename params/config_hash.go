@@ -0,0 +1,132 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+var uint64PtrType = reflect.TypeOf((*uint64)(nil))
+
+// Hash는 구성의 컨센서스 관련 필드에 대한 안정적인 다이제스트를 반환합니다. 같은 규칙을
+// 적용하는 두 구성은 포인터가 달라도 같은 해시를 가지므로, EVM 인스턴스나 서명자 캐시,
+// 테스트 스냅샷의 키로 사용할 수 있습니다.
+//
+// 해시를 계산하기 전에 동작이 같은 설정은 하나로 정규화됩니다. 예를 들어 nil인 EIP-1559
+// 매개변수와 blob 일정은 기본값과, nil인 PetersburgBlock은 ConstantinopleBlock과 같게
+// 취급됩니다. 동기화 방식에만 영향을 주는 TerminalTotalDifficultyPassed는 해시에 포함되지
+// 않습니다. 구성을 수정한 후에는 해시를 다시 계산해야 합니다.
+func (c *ChainConfig) Hash() common.Hash {
+	norm := c.normalized()
+	h := sha3.NewLegacyKeccak256()
+	hashConfigStruct(h, reflect.ValueOf(norm).Elem())
+	var out common.Hash
+	h.Sum(out[:0])
+	return out
+}
+
+// normalized는 동작이 같은 설정을 하나의 형태로 바꾼 구성의 깊은 복사본을 반환합니다.
+func (c *ChainConfig) normalized() *ChainConfig {
+	norm := MergeConfig(c, nil)
+	if norm.PetersburgBlock == nil && norm.ConstantinopleBlock != nil {
+		norm.PetersburgBlock = new(big.Int).Set(norm.ConstantinopleBlock)
+	}
+	if norm.DAOForkBlock == nil {
+		norm.DAOForkSupport = false
+	}
+	norm.TerminalTotalDifficultyPassed = false
+
+	norm.EIP1559Denominator = newUint64(norm.BaseFeeChangeDenominator())
+	norm.EIP1559Elasticity = newUint64(norm.ElasticityMultiplier())
+	if norm.EIP1559MinBaseFee != nil && norm.EIP1559MinBaseFee.Sign() == 0 {
+		norm.EIP1559MinBaseFee = nil
+	}
+
+	if norm.BlobScheduleConfig == nil {
+		norm.BlobScheduleConfig = new(BlobScheduleConfig)
+	}
+	if norm.BlobScheduleConfig.Cancun == nil {
+		cfg := *DefaultCancunBlobConfig
+		norm.BlobScheduleConfig.Cancun = &cfg
+	}
+	if norm.BlobScheduleConfig.Prague == nil {
+		cfg := *DefaultPragueBlobConfig
+		norm.BlobScheduleConfig.Prague = &cfg
+	}
+
+	// 변환 시작 시간이 없으면 변환 보폭은 사용되지 않습니다.
+	if norm.Verkle != nil && norm.Verkle.ConversionStart == nil {
+		norm.Verkle = nil
+	}
+	return norm
+}
+
+// hashConfigStruct는 구조체의 각 필드 이름과 값을 정의 순서대로 h에 씁니다. 필드 이름을
+// 포함하므로 값이 설정되지 않은 필드가 다른 필드의 값과 섞이지 않습니다.
+func hashConfigStruct(h hash.Hash, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		name := configFieldName(v.Type().Field(i))
+		writeHashUint64(h, uint64(len(name)))
+		h.Write([]byte(name))
+		hashConfigValue(h, v.Field(i))
+	}
+}
+
+func hashConfigValue(h hash.Hash, v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			h.Write([]byte{0})
+			return
+		}
+		h.Write([]byte{1})
+	}
+	switch {
+	case v.Type() == bigIntType:
+		b := v.Interface().(*big.Int)
+		h.Write([]byte{byte(b.Sign() + 1)})
+		writeHashUint64(h, uint64(len(b.Bytes())))
+		h.Write(b.Bytes())
+	case v.Type() == uint64PtrType:
+		writeHashUint64(h, v.Elem().Uint())
+	case isConfigStructPtr(v.Type()):
+		hashConfigStruct(h, v.Elem())
+	case v.Kind() == reflect.Uint64:
+		writeHashUint64(h, v.Uint())
+	case v.Kind() == reflect.Bool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	default:
+		// 새로운 타입의 필드가 추가되면 해시에서 조용히 누락되지 않도록 합니다.
+		panic(fmt.Sprintf("params: unhandled config field type %v", v.Type()))
+	}
+}
+
+func writeHashUint64(h hash.Hash, n uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	h.Write(buf[:])
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestChainConfigHash(t *testing.T) {
+	// The hash must cover every field type in the config without panicking.
+	full := MergeConfig(AllDevChainProtocolChanges, &ChainConfig{
+		DAOForkBlock:       big.NewInt(1),
+		EIP1559MinBaseFee:  big.NewInt(7),
+		Verkle:             &VerkleConfig{ConversionStart: newUint64(10), ConversionStride: 5},
+		Ethash:             new(EthashConfig),
+		Clique:             &CliqueConfig{Period: 1, Epoch: 2},
+		BlobScheduleConfig: &BlobScheduleConfig{Cancun: &BlobConfig{Target: 1, Max: 2, UpdateFraction: 3}},
+	})
+	if full.Hash() != MergeConfig(full, nil).Hash() {
+		t.Fatal("hash differs for copied config")
+	}
+	if MainnetChainConfig.Hash() == SepoliaChainConfig.Hash() {
+		t.Fatal("mainnet and sepolia configs have the same hash")
+	}
+
+	base := &ChainConfig{ChainID: big.NewInt(1), ConstantinopleBlock: big.NewInt(5), LondonBlock: big.NewInt(10)}
+	equivalent := []func(*ChainConfig){
+		func(c *ChainConfig) { c.PetersburgBlock = big.NewInt(5) },
+		func(c *ChainConfig) { c.DAOForkSupport = true },
+		func(c *ChainConfig) { c.TerminalTotalDifficultyPassed = true },
+		func(c *ChainConfig) { c.EIP1559Denominator = newUint64(DefaultBaseFeeChangeDenominator) },
+		func(c *ChainConfig) { c.EIP1559Elasticity = newUint64(DefaultElasticityMultiplier) },
+		func(c *ChainConfig) { c.EIP1559MinBaseFee = new(big.Int) },
+		func(c *ChainConfig) { c.BlobScheduleConfig = &BlobScheduleConfig{Prague: DefaultPragueBlobConfig} },
+		func(c *ChainConfig) { c.Verkle = &VerkleConfig{ConversionStride: 10} },
+	}
+	for i, modify := range equivalent {
+		cfg := MergeConfig(base, nil)
+		modify(cfg)
+		if cfg.Hash() != base.Hash() {
+			t.Errorf("equivalent change %d altered the hash", i)
+		}
+	}
+	different := []func(*ChainConfig){
+		func(c *ChainConfig) { c.ChainID = big.NewInt(2) },
+		func(c *ChainConfig) { c.PetersburgBlock = big.NewInt(6) },
+		func(c *ChainConfig) { c.HomesteadBlock = big.NewInt(0) },
+		func(c *ChainConfig) { c.ShanghaiTime = newUint64(0) },
+		func(c *ChainConfig) { c.EIP1559Elasticity = newUint64(4) },
+		func(c *ChainConfig) { c.EIP1559MinBaseFee = big.NewInt(1) },
+		func(c *ChainConfig) { c.Ethash = new(EthashConfig) },
+		func(c *ChainConfig) { c.Clique = &CliqueConfig{} },
+	}
+	for i, modify := range different {
+		cfg := MergeConfig(base, nil)
+		modify(cfg)
+		if cfg.Hash() == base.Hash() {
+			t.Errorf("change %d did not alter the hash", i)
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package params
 
 import (
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -137,3 +138,246 @@ func TestConfigRules(t *testing.T) {
 		t.Errorf("expected %v to be shanghai", stamp)
 	}
 }
+
+func TestEIP1559ParamOverrides(t *testing.T) {
+	c := &ChainConfig{}
+	if have := c.BaseFeeChangeDenominator(); have != DefaultBaseFeeChangeDenominator {
+		t.Errorf("default denominator mismatch: have %d, want %d", have, DefaultBaseFeeChangeDenominator)
+	}
+	if have := c.ElasticityMultiplier(); have != DefaultElasticityMultiplier {
+		t.Errorf("default elasticity mismatch: have %d, want %d", have, DefaultElasticityMultiplier)
+	}
+	if have := c.MinBaseFee(); have.Sign() != 0 {
+		t.Errorf("default min base fee mismatch: have %v, want 0", have)
+	}
+	c = &ChainConfig{
+		EIP1559Denominator: newUint64(250),
+		EIP1559Elasticity:  newUint64(6),
+		EIP1559MinBaseFee:  big.NewInt(100),
+	}
+	if have := c.BaseFeeChangeDenominator(); have != 250 {
+		t.Errorf("denominator override mismatch: have %d, want %d", have, 250)
+	}
+	if have := c.ElasticityMultiplier(); have != 6 {
+		t.Errorf("elasticity override mismatch: have %d, want %d", have, 6)
+	}
+	if have := c.MinBaseFee(); have.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("min base fee override mismatch: have %v, want %v", have, 100)
+	}
+}
+
+func TestCheckConfigEIP1559Params(t *testing.T) {
+	tests := []struct {
+		config *ChainConfig
+		ok     bool
+	}{
+		{config: &ChainConfig{}, ok: true},
+		{config: &ChainConfig{EIP1559Denominator: newUint64(50), EIP1559Elasticity: newUint64(10), EIP1559MinBaseFee: big.NewInt(0)}, ok: true},
+		{config: &ChainConfig{EIP1559Denominator: newUint64(0)}, ok: false},
+		{config: &ChainConfig{EIP1559Elasticity: newUint64(0)}, ok: false},
+		{config: &ChainConfig{EIP1559MinBaseFee: big.NewInt(-1)}, ok: false},
+	}
+	for i, test := range tests {
+		err := test.config.CheckConfigForkOrder()
+		if test.ok && err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+}
+
+func TestCheckCompatibleEIP1559Params(t *testing.T) {
+	stored := &ChainConfig{LondonBlock: big.NewInt(10)}
+	tests := []struct {
+		newcfg *ChainConfig
+		head   uint64
+		what   string
+	}{
+		{newcfg: &ChainConfig{LondonBlock: big.NewInt(10), EIP1559Denominator: newUint64(DefaultBaseFeeChangeDenominator)}, head: 20},
+		{newcfg: &ChainConfig{LondonBlock: big.NewInt(10), EIP1559Denominator: newUint64(250)}, head: 5},
+		{newcfg: &ChainConfig{LondonBlock: big.NewInt(10), EIP1559Denominator: newUint64(250)}, head: 20, what: "EIP-1559 base fee change denominator"},
+		{newcfg: &ChainConfig{LondonBlock: big.NewInt(10), EIP1559Elasticity: newUint64(4)}, head: 20, what: "EIP-1559 elasticity multiplier"},
+		{newcfg: &ChainConfig{LondonBlock: big.NewInt(10), EIP1559MinBaseFee: big.NewInt(7)}, head: 10, what: "EIP-1559 minimum base fee"},
+	}
+	for i, test := range tests {
+		err := stored.CheckCompatible(test.newcfg, test.head, 0)
+		if test.what == "" {
+			if err != nil {
+				t.Errorf("test %d: unexpected error: %v", i, err)
+			}
+			continue
+		}
+		if err == nil || err.What != test.what {
+			t.Errorf("test %d: wrong error %v, want %q", i, err, test.what)
+			continue
+		}
+		if err.RewindToBlock != 9 {
+			t.Errorf("test %d: wrong rewind target %d, want 9", i, err.RewindToBlock)
+		}
+	}
+}
+
+func TestCheckCompatibleAll(t *testing.T) {
+	stored := &ChainConfig{
+		HomesteadBlock: big.NewInt(10),
+		EIP150Block:    big.NewInt(20),
+		ShanghaiTime:   newUint64(100),
+	}
+	newcfg := &ChainConfig{
+		HomesteadBlock: big.NewInt(15),
+		EIP150Block:    big.NewInt(25),
+		ShanghaiTime:   newUint64(200),
+	}
+	// No conflicts before any of the forks activated.
+	if errs, target := stored.CheckCompatibleAll(newcfg, 5, 50); errs != nil || target != nil {
+		t.Fatalf("unexpected conflicts: %v, %v", errs, target)
+	}
+	errs, target := stored.CheckCompatibleAll(newcfg, 30, 150)
+	if len(errs) != 3 {
+		t.Fatalf("conflict count mismatch: have %d, want %d", len(errs), 3)
+	}
+	for i, what := range []string{"Homestead fork block", "EIP150 fork block", "Shanghai fork timestamp"} {
+		if errs[i].What != what {
+			t.Errorf("conflict %d mismatch: have %q, want %q", i, errs[i].What, what)
+		}
+		if !errors.Is(errs[i], ErrIncompatibleConfig) {
+			t.Errorf("conflict %d does not wrap ErrIncompatibleConfig", i)
+		}
+	}
+	if target == nil || target.Block == nil || *target.Block != 9 || target.Time != nil {
+		t.Fatalf("rewind target mismatch: have %+v, want block 9", target)
+	}
+	// A conflict at the first block is a rewind to genesis, not a missing target.
+	_, target = (&ChainConfig{HomesteadBlock: big.NewInt(0)}).CheckCompatibleAll(&ChainConfig{HomesteadBlock: big.NewInt(1)}, 5, 0)
+	if target == nil || target.Block == nil || *target.Block != 0 {
+		t.Fatalf("rewind target mismatch: have %+v, want block 0", target)
+	}
+	// Timestamp-only conflicts rewind by time.
+	_, target = (&ChainConfig{ShanghaiTime: newUint64(100)}).CheckCompatibleAll(&ChainConfig{ShanghaiTime: newUint64(200)}, 5, 150)
+	if target == nil || target.Block != nil || target.Time == nil || *target.Time != 99 {
+		t.Fatalf("rewind target mismatch: have %+v, want time 99", target)
+	}
+	var compatErr *ConfigCompatError
+	if err := error(stored.CheckCompatible(newcfg, 30, 150)); !errors.As(err, &compatErr) {
+		t.Fatalf("expected ConfigCompatError, got %v", err)
+	}
+}
+
+func TestVerkleConfig(t *testing.T) {
+	c := &ChainConfig{
+		LondonBlock:  new(big.Int),
+		ShanghaiTime: newUint64(0),
+		CancunTime:   newUint64(0),
+		PragueTime:   newUint64(0),
+		VerkleTime:   newUint64(100),
+		Verkle: &VerkleConfig{
+			ConversionStart:  newUint64(200),
+			ConversionStride: 1000,
+		},
+	}
+	if err := c.checkVerkleParams(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := c.Rules(big.NewInt(0), true, 150); !r.IsVerkle || r.IsVerkleConversion {
+		t.Errorf("expected verkle without conversion at 150, have %v/%v", r.IsVerkle, r.IsVerkleConversion)
+	}
+	r := c.Rules(big.NewInt(0), true, 200)
+	if !r.IsVerkleConversion {
+		t.Errorf("expected verkle conversion at 200")
+	}
+	if r.VerkleConversionStart != 200 || r.VerkleConversionStride != 1000 {
+		t.Errorf("conversion params mismatch: have %d/%d", r.VerkleConversionStart, r.VerkleConversionStride)
+	}
+	// Invalid configurations
+	for i, verkle := range []struct {
+		time   *uint64
+		config *VerkleConfig
+	}{
+		{nil, &VerkleConfig{ConversionStart: newUint64(200), ConversionStride: 1}},
+		{newUint64(100), &VerkleConfig{ConversionStart: newUint64(50), ConversionStride: 1}},
+		{newUint64(100), &VerkleConfig{ConversionStart: newUint64(200)}},
+	} {
+		c := &ChainConfig{VerkleTime: verkle.time, Verkle: verkle.config}
+		if err := c.checkVerkleParams(); err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+	// Rescheduling a conversion that already started is incompatible
+	newcfg := *c
+	newcfg.Verkle = &VerkleConfig{ConversionStart: newUint64(300), ConversionStride: 1000}
+	if err := c.CheckCompatible(&newcfg, 0, 250); err == nil || err.What != "Verkle conversion timestamp" {
+		t.Errorf("expected verkle conversion incompatibility, have %v", err)
+	}
+	newcfg.Verkle = &VerkleConfig{ConversionStart: newUint64(200), ConversionStride: 500}
+	if err := c.CheckCompatible(&newcfg, 0, 250); err == nil || err.What != "Verkle conversion stride" {
+		t.Errorf("expected verkle stride incompatibility, have %v", err)
+	}
+	if err := c.CheckCompatible(&newcfg, 0, 150); err != nil {
+		t.Errorf("unexpected incompatibility: %v", err)
+	}
+}
+
+func TestBlobConfig(t *testing.T) {
+	c := &ChainConfig{
+		LondonBlock:  new(big.Int),
+		ShanghaiTime: newUint64(0),
+		CancunTime:   newUint64(100),
+		PragueTime:   newUint64(200),
+	}
+	if bc := c.BlobConfig(50); bc != nil {
+		t.Errorf("expected no blob config before cancun, have %v", bc)
+	}
+	if bc := c.BlobConfig(100); *bc != *DefaultCancunBlobConfig {
+		t.Errorf("expected default cancun blob config, have %v", bc)
+	}
+	if bc := c.BlobConfig(200); *bc != *DefaultPragueBlobConfig {
+		t.Errorf("expected default prague blob config, have %v", bc)
+	}
+	// Modifying the returned config must not change the defaults.
+	c.BlobConfig(200).Max = 100
+	if DefaultPragueBlobConfig.Max != 9 {
+		t.Fatal("BlobConfig returned the shared default")
+	}
+	if have, want := DefaultCancunBlobConfig.MaxBlobGas(), uint64(MaxBlobGasPerBlock); have != want {
+		t.Errorf("cancun max blob gas mismatch: have %d, want %d", have, want)
+	}
+	if have, want := DefaultCancunBlobConfig.TargetBlobGas(), uint64(BlobTxTargetBlobGasPerBlock); have != want {
+		t.Errorf("cancun target blob gas mismatch: have %d, want %d", have, want)
+	}
+	custom := &BlobConfig{Target: 12, Max: 16, UpdateFraction: 1000}
+	c.BlobScheduleConfig = &BlobScheduleConfig{Prague: custom}
+	if bc := c.BlobConfig(100); *bc != *DefaultCancunBlobConfig {
+		t.Errorf("expected default cancun blob config, have %v", bc)
+	}
+	if bc := c.BlobConfig(300); *bc != *custom {
+		t.Errorf("expected custom prague blob config, have %v", bc)
+	}
+	if err := c.checkBlobSchedule(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for i, invalid := range []*BlobConfig{
+		{Target: 1, Max: 0, UpdateFraction: 1},
+		{Target: 7, Max: 6, UpdateFraction: 1},
+		{Target: 3, Max: 6, UpdateFraction: 0},
+	} {
+		c.BlobScheduleConfig = &BlobScheduleConfig{Cancun: invalid}
+		if err := c.checkBlobSchedule(); err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+}
+
+func TestDefaultBlobScheduleNotShared(t *testing.T) {
+	holesky, sepolia := HoleskyChainConfig.BlobScheduleConfig, SepoliaChainConfig.BlobScheduleConfig
+	if holesky.Cancun == sepolia.Cancun || holesky.Cancun == DefaultCancunBlobConfig {
+		t.Fatal("cancun blob config shared between networks")
+	}
+	if holesky.Prague != nil || sepolia.Prague != nil {
+		t.Fatal("prague blob config set without a Prague fork")
+	}
+	if *holesky.Cancun != *DefaultCancunBlobConfig || *sepolia.Cancun != *DefaultCancunBlobConfig {
+		t.Fatal("network blob config differs from defaults")
+	}
+}
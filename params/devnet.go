@@ -0,0 +1,154 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// DevnetConsensus는 개발 네트워크에서 사용할 합의 방식입니다.
+type DevnetConsensus uint8
+
+const (
+	DevnetPoS    DevnetConsensus = iota // 제네시스부터 머지 이후인 지분 증명 체인
+	DevnetClique                        // Clique 권한 증명 체인
+	DevnetEthash                        // Ethash 작업 증명 체인
+)
+
+// DevnetOptions는 NewDevnetConfig로 생성할 개발 네트워크의 설정입니다.
+type DevnetOptions struct {
+	ChainID   *big.Int        // 체인 ID (nil이면 1337)
+	Consensus DevnetConsensus // 합의 방식 (기본값 DevnetPoS)
+
+	// LastFork는 제네시스에서 활성화할 마지막 포크입니다. nil이면 합의 방식의 기본값을 사용합니다:
+	// DevnetPoS는 Cancun까지, Clique와 Ethash는 London까지입니다. DevnetPoS는 ForkMerge부터
+	// ForkPrague까지의 값을 사용할 수 있지만, Prague는 아직 확정되지 않았으므로 명시적으로 지정해야
+	// 합니다. Clique와 Ethash는 ForkLondon만 사용할 수 있습니다. 그 밖의 값은 오류입니다.
+	LastFork *Fork
+
+	// BlobSchedule은 Cancun 이후의 blob 매개변수입니다. nil이거나 포크별 값이 nil이면 기본값을
+	// 사용합니다. Cancun이 활성화되는 경우에만 설정할 수 있습니다.
+	BlobSchedule *BlobScheduleConfig
+
+	// Clique 매개변수 (DevnetClique에서만 사용). Epoch가 0이면 30000입니다.
+	CliquePeriod uint64
+	CliqueEpoch  uint64
+}
+
+// NewDevnetConfig는 opts에 따라 모든 포크가 제네시스에서 활성화된 개발 네트워크 구성을 생성합니다.
+// 생성된 구성은 포크 순서와 blob 매개변수를 검증한 뒤 반환됩니다.
+func NewDevnetConfig(opts DevnetOptions) (*ChainConfig, error) {
+	chainID := big.NewInt(1337)
+	if opts.ChainID != nil {
+		if opts.ChainID.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid devnet chain ID %v", opts.ChainID)
+		}
+		chainID = new(big.Int).Set(opts.ChainID)
+	}
+	config := &ChainConfig{
+		ChainID:             chainID,
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		MuirGlacierBlock:    big.NewInt(0),
+		BerlinBlock:         big.NewInt(0),
+		LondonBlock:         big.NewInt(0),
+	}
+	var lastFork Fork
+	switch opts.Consensus {
+	case DevnetPoS:
+		lastFork = ForkCancun
+		if opts.LastFork != nil {
+			lastFork = *opts.LastFork
+		}
+		if lastFork < ForkMerge || lastFork > ForkPrague {
+			return nil, fmt.Errorf("devnet fork %v not supported with proof-of-stake", lastFork)
+		}
+		config.ArrowGlacierBlock = big.NewInt(0)
+		config.GrayGlacierBlock = big.NewInt(0)
+		config.TerminalTotalDifficulty = big.NewInt(0)
+		config.TerminalTotalDifficultyPassed = true
+		if lastFork >= ForkShanghai {
+			config.ShanghaiTime = newUint64(0)
+		}
+		if lastFork >= ForkCancun {
+			config.CancunTime = newUint64(0)
+		}
+		if lastFork >= ForkPrague {
+			config.PragueTime = newUint64(0)
+		}
+	case DevnetClique, DevnetEthash:
+		lastFork = ForkLondon
+		if opts.LastFork != nil {
+			lastFork = *opts.LastFork
+		}
+		if lastFork != ForkLondon {
+			return nil, fmt.Errorf("devnet fork %v not supported without proof-of-stake", lastFork)
+		}
+		if opts.Consensus == DevnetClique {
+			epoch := opts.CliqueEpoch
+			if epoch == 0 {
+				epoch = 30000
+			}
+			config.Clique = &CliqueConfig{Period: opts.CliquePeriod, Epoch: epoch}
+		} else {
+			config.ArrowGlacierBlock = big.NewInt(0)
+			config.GrayGlacierBlock = big.NewInt(0)
+			config.Ethash = new(EthashConfig)
+		}
+	default:
+		return nil, fmt.Errorf("unknown devnet consensus %d", opts.Consensus)
+	}
+	if sched := opts.BlobSchedule; sched != nil {
+		if lastFork < ForkCancun {
+			return nil, errors.New("devnet blob schedule set without Cancun")
+		}
+		if sched.Prague != nil && lastFork < ForkPrague {
+			return nil, errors.New("devnet blob schedule set for inactive fork Prague")
+		}
+		config.BlobScheduleConfig = &BlobScheduleConfig{
+			Cancun: copyBlobConfig(sched.Cancun),
+			Prague: copyBlobConfig(sched.Prague),
+		}
+	}
+	// 포크 순서와 blob 매개변수를 검증합니다.
+	if err := config.CheckConfigForkOrder(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// newFork는 DevnetOptions.LastFork에 사용할 f의 포인터를 반환합니다.
+func newFork(f Fork) *Fork {
+	return &f
+}
+
+// mustNewDevnetConfig는 NewDevnetConfig와 같지만 오류가 발생하면 패닉합니다.
+func mustNewDevnetConfig(opts DevnetOptions) *ChainConfig {
+	config, err := NewDevnetConfig(opts)
+	if err != nil {
+		panic(err)
+	}
+	return config
+}
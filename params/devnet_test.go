@@ -0,0 +1,95 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestNewDevnetConfig(t *testing.T) {
+	// The default options enable every fork up to Cancun at genesis.
+	config, err := NewDevnetConfig(DevnetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ChainID.Uint64() != 1337 {
+		t.Errorf("wrong default chain ID %v", config.ChainID)
+	}
+	if !config.IsCancun(big.NewInt(0), 0) || !config.IsShanghai(big.NewInt(0), 0) {
+		t.Error("post-merge forks not active at genesis")
+	}
+	if config.PragueTime != nil {
+		t.Error("prague enabled by default")
+	}
+	if config.Ethash != nil || config.Clique != nil {
+		t.Error("proof-of-stake devnet has legacy consensus config")
+	}
+
+	// Custom blob schedules are copied into the config.
+	sched := &BlobScheduleConfig{Cancun: &BlobConfig{Target: 1, Max: 2, UpdateFraction: 1}}
+	config, err = NewDevnetConfig(DevnetOptions{ChainID: big.NewInt(7), LastFork: newFork(ForkCancun), BlobSchedule: sched})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.PragueTime != nil || config.ChainID.Uint64() != 7 {
+		t.Error("wrong fork schedule or chain ID")
+	}
+	sched.Cancun.Max = 3
+	if config.BlobScheduleConfig.Cancun.Max != 2 {
+		t.Error("blob schedule not copied")
+	}
+
+	config, err = NewDevnetConfig(DevnetOptions{Consensus: DevnetClique, CliquePeriod: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config.Clique, &CliqueConfig{Period: 5, Epoch: 30000}) || config.ShanghaiTime != nil {
+		t.Errorf("wrong clique devnet config %v", config)
+	}
+
+	// Proof-of-work devnets have not passed the merge.
+	config, err = NewDevnetConfig(DevnetOptions{Consensus: DevnetEthash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Ethash == nil || config.TerminalTotalDifficultyPassed {
+		t.Errorf("wrong ethash devnet config %v", config)
+	}
+}
+
+func TestNewDevnetConfigErrors(t *testing.T) {
+	tests := []DevnetOptions{
+		{ChainID: big.NewInt(0)},
+		{LastFork: newFork(ForkLondon)},
+		{LastFork: newFork(ForkHomestead)},
+		{Consensus: DevnetClique, LastFork: newFork(ForkHomestead)},
+		{Consensus: DevnetEthash, LastFork: newFork(ForkHomestead)},
+		{Consensus: DevnetEthash, LastFork: newFork(ForkShanghai)},
+		{Consensus: DevnetClique, BlobSchedule: &BlobScheduleConfig{}},
+		{LastFork: newFork(ForkShanghai), BlobSchedule: &BlobScheduleConfig{}},
+		{LastFork: newFork(ForkCancun), BlobSchedule: &BlobScheduleConfig{Prague: DefaultPragueBlobConfig}},
+		{BlobSchedule: &BlobScheduleConfig{Cancun: &BlobConfig{Target: 3, Max: 2, UpdateFraction: 1}}},
+		{Consensus: 42},
+	}
+	for i, opts := range tests {
+		if _, err := NewDevnetConfig(opts); err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+}
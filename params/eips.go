@@ -0,0 +1,68 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import "math/big"
+
+// eipForks는 EIP 번호를 그 EIP를 활성화하는 포크에 매핑합니다. 머지와 함께 활성화되는
+// EIP(3675, 4399)는 블록 번호와 시간만으로 판단할 수 없으므로 포함하지 않으며, 이후 포크에서
+// 비활성화된 EIP(1283)와 난이도 폭탄 지연도 포함하지 않습니다.
+var eipForks = map[int]Fork{
+	// Homestead
+	2: ForkHomestead, 7: ForkHomestead,
+	// Tangerine Whistle
+	150: ForkEIP150,
+	// Spurious Dragon
+	155: ForkEIP155, 160: ForkEIP158, 161: ForkEIP158, 170: ForkEIP158,
+	// Byzantium
+	140: ForkByzantium, 196: ForkByzantium, 197: ForkByzantium, 198: ForkByzantium,
+	211: ForkByzantium, 214: ForkByzantium, 658: ForkByzantium,
+	// Constantinople
+	145: ForkConstantinople, 1014: ForkConstantinople, 1052: ForkConstantinople,
+	// Istanbul
+	152: ForkIstanbul, 1108: ForkIstanbul, 1344: ForkIstanbul, 1884: ForkIstanbul,
+	2028: ForkIstanbul, 2200: ForkIstanbul,
+	// Berlin
+	2565: ForkBerlin, 2718: ForkBerlin, 2929: ForkBerlin, 2930: ForkBerlin,
+	// London
+	1559: ForkLondon, 3198: ForkLondon, 3529: ForkLondon, 3541: ForkLondon,
+	// Shanghai
+	3651: ForkShanghai, 3855: ForkShanghai, 3860: ForkShanghai, 4895: ForkShanghai,
+	// Cancun
+	1153: ForkCancun, 4788: ForkCancun, 4844: ForkCancun, 5656: ForkCancun,
+	6780: ForkCancun, 7516: ForkCancun,
+	// Prague
+	2537: ForkPrague, 2935: ForkPrague, 6110: ForkPrague, 7002: ForkPrague,
+	7251: ForkPrague, 7549: ForkPrague, 7623: ForkPrague, 7685: ForkPrague,
+	7691: ForkPrague, 7702: ForkPrague,
+}
+
+// EIPFork는 eip를 활성화하는 포크를 반환합니다. 표에 없는 EIP이면 false를 반환합니다.
+func EIPFork(eip int) (Fork, bool) {
+	f, ok := eipForks[eip]
+	return f, ok
+}
+
+// IsEIPActive는 eip가 블록 번호 num, 시간 time에서 활성화되어 있는지 여부를 반환합니다.
+// EIPFork가 알지 못하는 EIP에 대해서는 false를 반환합니다.
+func (c *ChainConfig) IsEIPActive(eip int, num *big.Int, time uint64) bool {
+	f, ok := eipForks[eip]
+	if !ok {
+		return false
+	}
+	return c.Rules(num, false, time).Forks().Has(f)
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestIsEIPActive(t *testing.T) {
+	config := *MainnetChainConfig
+	config.CancunTime = newUint64(1710338135)
+
+	london := config.LondonBlock
+	before := new(big.Int).Sub(london, big.NewInt(1))
+	cancun := *config.CancunTime
+
+	tests := []struct {
+		eip  int
+		num  *big.Int
+		time uint64
+		want bool
+	}{
+		{1559, before, 0, false},
+		{1559, london, 0, true},
+		{2929, london, 0, true},
+		{3855, london, 0, false},
+		{3855, london, *config.ShanghaiTime, true},
+		{4844, london, cancun - 1, false},
+		{4844, london, cancun, true},
+		{2, big.NewInt(0), 0, false},
+		{2, config.HomesteadBlock, 0, true},
+		{4399, london, cancun, false}, // merge EIPs are not tracked
+		{1, london, cancun, false},    // unknown EIP
+	}
+	for _, test := range tests {
+		if have := config.IsEIPActive(test.eip, test.num, test.time); have != test.want {
+			t.Errorf("EIP-%d at block %v, time %d: have %t, want %t", test.eip, test.num, test.time, have, test.want)
+		}
+	}
+	if f, ok := EIPFork(4844); !ok || f != ForkCancun {
+		t.Errorf("wrong fork for EIP-4844: %v", f)
+	}
+}
+
+func TestIsEIPActiveTestnets(t *testing.T) {
+	// Prague is not scheduled on any public network yet.
+	for name, config := range map[string]*ChainConfig{"holesky": HoleskyChainConfig, "sepolia": SepoliaChainConfig} {
+		if !config.IsEIPActive(4844, config.LondonBlock, *config.CancunTime) {
+			t.Errorf("%s: EIP-4844 not active after Cancun", name)
+		}
+		if config.IsEIPActive(7702, config.LondonBlock, math.MaxUint64) {
+			t.Errorf("%s: EIP-7702 active without Prague", name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrUnknownNetwork는 알려지지 않은 체인 ID나 제네시스 해시를 조회할 때 반환됩니다.
+	ErrUnknownNetwork = errors.New("unknown network")
+
+	// ErrNetworkRetired는 운영이 종료되어 빌드에서 제외된 네트워크를 조회할 때 반환됩니다.
+	ErrNetworkRetired = errors.New("network retired")
+)
+
+// Network는 빌드에 포함된 공개 네트워크 하나를 기술합니다.
+type Network struct {
+	Name            string         // 사용자 친화적인 네트워크 이름
+	GenesisHash     common.Hash    // 제네시스 블록 해시
+	Config          *ChainConfig   // 체인 구성
+	Bootnodes       []string       // P2P 부트스트랩 노드의 enode URL
+	EthDNS          []string       // eth 프로토콜 피어를 위한 DNS 발견 트리 URL
+	SnapDNS         []string       // snap 프로토콜 피어를 위한 DNS 발견 트리 URL
+	DepositContract common.Address // 비콘 체인 예치 컨트랙트 주소
+}
+
+// retiredNetwork는 운영이 종료된 네트워크입니다. 빌드 태그로 구성이 제외되더라도 조회 시
+// 알기 쉬운 오류를 반환할 수 있도록 식별 정보는 항상 포함됩니다.
+type retiredNetwork struct {
+	name        string
+	chainID     uint64
+	genesisHash common.Hash
+}
+
+var retiredNetworks = []retiredNetwork{
+	{"goerli", 5, common.HexToHash("0xbf7e331f7f7c1dd2e05159666b3bf8bc7a8a3a9eb1d518969eab529dd9b88c1a")},
+}
+
+// knownNetworks는 빌드에 포함된 네트워크를 체인 ID로 색인합니다.
+var knownNetworks = make(map[uint64]*Network)
+
+func init() {
+	registerNetwork(&Network{
+		Name:            "mainnet",
+		GenesisHash:     MainnetGenesisHash,
+		Config:          MainnetChainConfig,
+		Bootnodes:       MainnetBootnodes,
+		DepositContract: common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+	})
+	registerNetwork(&Network{
+		Name:            "sepolia",
+		GenesisHash:     SepoliaGenesisHash,
+		Config:          SepoliaChainConfig,
+		Bootnodes:       SepoliaBootnodes,
+		DepositContract: common.HexToAddress("0x7f02C3E3c98b133055B8B348B2Ac625669Ed295D"),
+	})
+	registerNetwork(&Network{
+		Name:            "holesky",
+		GenesisHash:     HoleskyGenesisHash,
+		Config:          HoleskyChainConfig,
+		Bootnodes:       HoleskyBootnodes,
+		DepositContract: common.HexToAddress("0x4242424242424242424242424242424242424242"),
+	})
+}
+
+// registerNetwork는 네트워크를 조회 테이블과 NetworkNames에 추가합니다. DNS 발견 트리가
+// 지정되지 않으면 네트워크 이름으로 공개 트리의 URL을 채웁니다.
+func registerNetwork(n *Network) {
+	if n.EthDNS == nil {
+		n.EthDNS = []string{dnsNetworkURL(n.Name, "all")}
+	}
+	if n.SnapDNS == nil {
+		n.SnapDNS = n.EthDNS
+	}
+	id := n.Config.ChainID.Uint64()
+	if _, exists := knownNetworks[id]; exists {
+		panic(fmt.Sprintf("params: duplicate network with chain ID %d", id))
+	}
+	knownNetworks[id] = n
+	NetworkNames[n.Config.ChainID.String()] = n.Name
+}
+
+// NetworkByChainID는 체인 ID에 해당하는 공개 네트워크를 반환합니다. 운영이 종료되어 빌드에서
+// 제외된 네트워크이면 ErrNetworkRetired를, 알려지지 않은 체인 ID이면 ErrUnknownNetwork를 반환합니다.
+func NetworkByChainID(chainID uint64) (*Network, error) {
+	if n, ok := knownNetworks[chainID]; ok {
+		return n, nil
+	}
+	for _, r := range retiredNetworks {
+		if r.chainID == chainID {
+			return nil, r.err()
+		}
+	}
+	return nil, fmt.Errorf("%w: chain ID %d", ErrUnknownNetwork, chainID)
+}
+
+// NetworkPreset은 체인 ID에 해당하는 공개 네트워크의 노드 구성 묶음을 반환합니다. 체인 구성,
+// 부트스트랩 노드, DNS 발견 트리, 예치 컨트랙트 주소를 한 번에 얻을 수 있습니다. 반환된 값의
+// 목록은 복사본이므로 호출자가 수정해도 내장 구성에 영향을 주지 않지만, 체인 구성은 공유됩니다.
+// 오류는 NetworkByChainID와 같습니다.
+func NetworkPreset(chainID uint64) (Network, error) {
+	n, err := NetworkByChainID(chainID)
+	if err != nil {
+		return Network{}, err
+	}
+	preset := *n
+	preset.Bootnodes = append([]string(nil), n.Bootnodes...)
+	preset.EthDNS = append([]string(nil), n.EthDNS...)
+	preset.SnapDNS = append([]string(nil), n.SnapDNS...)
+	return preset, nil
+}
+
+// NetworkByGenesis는 제네시스 해시에 해당하는 공개 네트워크를 반환합니다. 오류는
+// NetworkByChainID와 같습니다.
+func NetworkByGenesis(hash common.Hash) (*Network, error) {
+	for _, n := range knownNetworks {
+		if n.GenesisHash == hash {
+			return n, nil
+		}
+	}
+	for _, r := range retiredNetworks {
+		if r.genesisHash == hash {
+			return nil, r.err()
+		}
+	}
+	return nil, fmt.Errorf("%w: genesis %x", ErrUnknownNetwork, hash)
+}
+
+func (r retiredNetwork) err() error {
+	return fmt.Errorf("%w: %s (chain ID %d) has been retired and is not included in this build", ErrNetworkRetired, r.name, r.chainID)
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build nogoerli
+
+package params
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRetiredNetworkExcluded(t *testing.T) {
+	if _, err := NetworkByChainID(5); !errors.Is(err, ErrNetworkRetired) {
+		t.Fatalf("wrong error for goerli chain ID: %v", err)
+	}
+	if _, err := NetworkByGenesis(retiredNetworks[0].genesisHash); !errors.Is(err, ErrNetworkRetired) {
+		t.Fatalf("wrong error for goerli genesis: %v", err)
+	}
+	if _, ok := NetworkNames["5"]; ok {
+		t.Fatal("excluded network in network names")
+	}
+}
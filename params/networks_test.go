@@ -0,0 +1,70 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNetworkLookup(t *testing.T) {
+	n, err := NetworkByChainID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Name != "mainnet" || n.Config != MainnetChainConfig {
+		t.Fatalf("wrong network for chain ID 1: %s", n.Name)
+	}
+	if n, err = NetworkByGenesis(SepoliaGenesisHash); err != nil || n.Config != SepoliaChainConfig {
+		t.Fatalf("wrong network for sepolia genesis: %v", err)
+	}
+	if NetworkNames["17000"] != "holesky" {
+		t.Fatal("holesky missing from network names")
+	}
+	if _, err := NetworkByChainID(1337); !errors.Is(err, ErrUnknownNetwork) {
+		t.Fatalf("wrong error for unknown chain ID: %v", err)
+	}
+	if dns := KnownDNSNetwork(MainnetGenesisHash, "all"); dns != dnsPrefix+"all.mainnet.ethdisco.net" {
+		t.Fatalf("wrong DNS network: %s", dns)
+	}
+}
+
+func TestNetworkPreset(t *testing.T) {
+	preset, err := NetworkPreset(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preset.Config != MainnetChainConfig || len(preset.Bootnodes) != len(MainnetBootnodes) {
+		t.Fatal("wrong mainnet preset")
+	}
+	if preset.DepositContract != common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa") {
+		t.Fatalf("wrong deposit contract: %x", preset.DepositContract)
+	}
+	if len(preset.EthDNS) != 1 || preset.EthDNS[0] != KnownDNSNetwork(MainnetGenesisHash, "all") {
+		t.Fatalf("wrong DNS discovery trees: %v", preset.EthDNS)
+	}
+	// Modifying the preset must not affect the built-in lists.
+	preset.Bootnodes[0], preset.SnapDNS[0] = "", ""
+	if MainnetBootnodes[0] == "" || knownNetworks[1].SnapDNS[0] == "" {
+		t.Fatal("preset shares lists with built-in network")
+	}
+	if _, err := NetworkPreset(1337); !errors.Is(err, ErrUnknownNetwork) {
+		t.Fatalf("wrong error for unknown chain ID: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Fork는 Rules의 포크 활성화 플래그 하나를 식별합니다.
+type Fork uint8
+
+const (
+	ForkHomestead Fork = iota
+	ForkEIP150
+	ForkEIP155
+	ForkEIP158
+	ForkByzantium
+	ForkConstantinople
+	ForkPetersburg
+	ForkIstanbul
+	ForkBerlin
+	ForkLondon
+	ForkMerge
+	ForkShanghai
+	ForkCancun
+	ForkPrague
+	ForkVerkle
+	ForkVerkleConversion
+	numForks
+)
+
+// ruleFlags는 각 포크의 이름과 Rules에서 해당하는 필드를 정의합니다. 순서는 Fork 상수와 같습니다.
+var ruleFlags = [numForks]struct {
+	name string
+	flag func(*Rules) *bool
+}{
+	{"homestead", func(r *Rules) *bool { return &r.IsHomestead }},
+	{"eip150", func(r *Rules) *bool { return &r.IsEIP150 }},
+	{"eip155", func(r *Rules) *bool { return &r.IsEIP155 }},
+	{"eip158", func(r *Rules) *bool { return &r.IsEIP158 }},
+	{"byzantium", func(r *Rules) *bool { return &r.IsByzantium }},
+	{"constantinople", func(r *Rules) *bool { return &r.IsConstantinople }},
+	{"petersburg", func(r *Rules) *bool { return &r.IsPetersburg }},
+	{"istanbul", func(r *Rules) *bool { return &r.IsIstanbul }},
+	{"berlin", func(r *Rules) *bool { return &r.IsBerlin }},
+	{"london", func(r *Rules) *bool { return &r.IsLondon }},
+	{"merge", func(r *Rules) *bool { return &r.IsMerge }},
+	{"shanghai", func(r *Rules) *bool { return &r.IsShanghai }},
+	{"cancun", func(r *Rules) *bool { return &r.IsCancun }},
+	{"prague", func(r *Rules) *bool { return &r.IsPrague }},
+	{"verkle", func(r *Rules) *bool { return &r.IsVerkle }},
+	{"verkleConversion", func(r *Rules) *bool { return &r.IsVerkleConversion }},
+}
+
+// String은 포크의 이름을 반환합니다.
+func (f Fork) String() string {
+	if f >= numForks {
+		return fmt.Sprintf("Fork(%d)", uint8(f))
+	}
+	return ruleFlags[f].name
+}
+
+// ForkSet은 활성화된 포크의 비트마스크입니다. Rules의 모든 플래그를 하나의 정수로 담으므로
+// 복사 비용이 낮고 맵의 키로 사용할 수 있습니다.
+type ForkSet uint32
+
+// Has는 f가 집합에 포함되어 있는지 여부를 반환합니다.
+func (s ForkSet) Has(f Fork) bool {
+	return s&(1<<f) != 0
+}
+
+// With는 f를 추가한 집합을 반환합니다.
+func (s ForkSet) With(f Fork) ForkSet {
+	return s | 1<<f
+}
+
+// Names는 집합에 포함된 포크의 이름을 활성화 순서대로 반환합니다.
+func (s ForkSet) Names() []string {
+	var names []string
+	for f := Fork(0); f < numForks; f++ {
+		if s.Has(f) {
+			names = append(names, f.String())
+		}
+	}
+	return names
+}
+
+// Forks는 활성화된 포크의 비트마스크를 반환합니다.
+func (r Rules) Forks() ForkSet {
+	var s ForkSet
+	for f := Fork(0); f < numForks; f++ {
+		if *ruleFlags[f].flag(&r) {
+			s = s.With(f)
+		}
+	}
+	return s
+}
+
+// ActiveForks는 활성화된 포크의 이름을 활성화 순서대로 반환합니다.
+func (r Rules) ActiveForks() []string {
+	return r.Forks().Names()
+}
+
+// String은 체인 ID와 활성화된 포크를 사람이 읽을 수 있는 형태로 반환합니다.
+func (r Rules) String() string {
+	return fmt.Sprintf("chainID=%v forks=[%s]", r.ChainID, strings.Join(r.ActiveForks(), " "))
+}
+
+// RulesKey는 Rules의 비교 가능한 표현입니다. Rules는 체인 ID를 포인터로 담고 있어 == 로
+// 비교할 수 없으므로, 점프 테이블 등 규칙별 캐시의 키로는 RulesKey를 사용합니다.
+type RulesKey struct {
+	ChainID                                       string
+	Forks                                         ForkSet
+	VerkleConversionStart, VerkleConversionStride uint64
+}
+
+// Key는 r의 비교 가능한 키를 반환합니다.
+func (r Rules) Key() RulesKey {
+	key := RulesKey{
+		Forks:                  r.Forks(),
+		VerkleConversionStart:  r.VerkleConversionStart,
+		VerkleConversionStride: r.VerkleConversionStride,
+	}
+	if r.ChainID != nil {
+		key.ChainID = r.ChainID.String()
+	}
+	return key
+}
+
+// RulesFromForks는 주어진 체인 ID와 포크 집합으로 Rules를 생성합니다. Verkle 변환 매개변수는
+// 설정되지 않습니다.
+func RulesFromForks(chainID *big.Int, forks ForkSet) Rules {
+	r := Rules{ChainID: new(big.Int)}
+	if chainID != nil {
+		r.ChainID.Set(chainID)
+	}
+	for f := Fork(0); f < numForks; f++ {
+		*ruleFlags[f].flag(&r) = forks.Has(f)
+	}
+	return r
+}
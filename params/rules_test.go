@@ -0,0 +1,82 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestRulesForks(t *testing.T) {
+	rules := MainnetChainConfig.Rules(MainnetChainConfig.LondonBlock, false, 0)
+	want := []string{"homestead", "eip150", "eip155", "eip158", "byzantium", "constantinople", "petersburg", "istanbul", "berlin", "london"}
+	if have := rules.ActiveForks(); !reflect.DeepEqual(have, want) {
+		t.Fatalf("wrong active forks: %v", have)
+	}
+	if s := rules.String(); s != "chainID=1 forks=[homestead eip150 eip155 eip158 byzantium constantinople petersburg istanbul berlin london]" {
+		t.Fatalf("wrong string: %s", s)
+	}
+	forks := rules.Forks()
+	if !forks.Has(ForkLondon) || forks.Has(ForkMerge) {
+		t.Fatalf("wrong fork set %b", forks)
+	}
+	if back := RulesFromForks(big.NewInt(1), forks); !reflect.DeepEqual(back, rules) {
+		t.Fatalf("round trip mismatch: %v", back)
+	}
+
+	// Keys of equal rules are equal even if the chain ID pointers differ.
+	other := MainnetChainConfig.Rules(MainnetChainConfig.LondonBlock, false, 0)
+	if rules.Key() != other.Key() {
+		t.Fatal("keys of equal rules differ")
+	}
+	other.IsMerge = true
+	if rules.Key() == other.Key() {
+		t.Fatal("keys of different rules are equal")
+	}
+	if ForkVerkleConversion.String() != "verkleConversion" || Fork(100).String() != "Fork(100)" {
+		t.Fatal("wrong fork name")
+	}
+}
+
+func TestActiveAtGenesis(t *testing.T) {
+	var (
+		shanghai = uint64(0)
+		cancun   = uint64(10)
+		config   = *AllEthashProtocolChanges
+	)
+	config.ShanghaiTime = &shanghai
+	config.CancunTime = &cancun
+	config.TerminalTotalDifficulty = new(big.Int)
+
+	if !config.IsShanghaiAtGenesis(0) {
+		t.Error("Shanghai not active at genesis time 0")
+	}
+	if config.IsCancunAtGenesis(0) || !config.IsCancunAtGenesis(cancun) {
+		t.Error("wrong Cancun activation at genesis")
+	}
+	if !config.ActiveAtGenesis(ForkMerge, 0) || !config.ActiveAtGenesis(ForkLondon, 0) {
+		t.Error("merge or London not active at genesis")
+	}
+	if config.ActiveAtGenesis(ForkCancun, cancun-1) {
+		t.Error("Cancun active before its timestamp")
+	}
+	config.TerminalTotalDifficulty = big.NewInt(1)
+	if config.ActiveAtGenesis(ForkMerge, 0) {
+		t.Error("merge active at genesis with non-zero terminal difficulty")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+// defaultArenaChunkSize는 NewArena에 0을 전달했을 때 사용하는 청크 크기입니다.
+const defaultArenaChunkSize = 64 * 1024
+
+// Arena는 디코딩된 바이트 슬라이스를 위한 범프 할당기입니다. 수백만 개의 트라이 노드나 영수증처럼
+// 작은 값을 대량으로 디코딩할 때, 값마다 힙 할당을 하는 대신 큰 청크에서 잘라 쓰므로 GC 부담이
+// 크게 줄어듭니다. Stream.SetArena 또는 DecodeBytesArena로 사용합니다.
+//
+// Reset을 호출하면 이전에 할당된 모든 슬라이스의 메모리가 재사용되므로, 호출자는 Reset 전에
+// 디코딩된 값에 대한 참조를 모두 버려야 합니다. Arena는 동시에 사용하는 것이 안전하지 않습니다.
+type Arena struct {
+	chunkSize int
+	chunks    [][]byte // 할당된 청크. Reset 후에도 재사용을 위해 유지됩니다.
+	cur       int      // 현재 할당 중인 청크의 인덱스
+	off       int      // 현재 청크에서 사용한 바이트 수
+	allocated uint64   // Reset 이후 할당된 바이트 수
+}
+
+// NewArena는 chunkSize 바이트 단위로 메모리를 확보하는 Arena를 생성합니다. chunkSize가 0 이하이면
+// 64KiB를 사용합니다.
+func NewArena(chunkSize int) *Arena {
+	if chunkSize <= 0 {
+		chunkSize = defaultArenaChunkSize
+	}
+	return &Arena{chunkSize: chunkSize}
+}
+
+// Alloc은 길이와 용량이 n인, 0으로 초기화된 바이트 슬라이스를 반환합니다. 용량이 n으로 제한되므로
+// 반환된 슬라이스에 append해도 다른 할당을 덮어쓰지 않습니다. 청크 크기의 1/4보다 큰 요청은
+// 청크의 낭비를 막기 위해 힙에서 직접 할당합니다.
+func (a *Arena) Alloc(n int) []byte {
+	a.allocated += uint64(n)
+	if n > a.chunkSize/4 {
+		return make([]byte, n)
+	}
+	if a.off+n > a.chunkSize {
+		a.cur++
+		a.off = 0
+	}
+	if a.cur == len(a.chunks) {
+		a.chunks = append(a.chunks, make([]byte, a.chunkSize))
+	}
+	b := a.chunks[a.cur][a.off : a.off+n : a.off+n]
+	a.off += n
+	for i := range b {
+		b[i] = 0
+	}
+	return b
+}
+
+// Allocated는 마지막 Reset 이후 Alloc으로 할당된 총 바이트 수를 반환합니다.
+func (a *Arena) Allocated() uint64 {
+	return a.allocated
+}
+
+// Reset은 확보한 청크를 해제하지 않고 모든 할당을 무효화합니다. 이전에 반환된 슬라이스는 이후의
+// 할당에 의해 덮어쓰일 수 있습니다. 배치 사이에 호출하여 메모리를 재사용합니다.
+func (a *Arena) Reset() {
+	a.cur, a.off, a.allocated = 0, 0, 0
+}
+
+// SetArena는 디코딩된 바이트 문자열(Bytes, Raw, []byte와 RawValue 필드)을 a에서 할당하도록
+// 설정합니다. nil을 전달하면 일반 힙 할당으로 돌아갑니다. 이 설정은 Reset 이후에도 유지됩니다.
+//
+// 디코딩된 값은 a가 Reset될 때까지만 유효합니다.
+func (s *Stream) SetArena(a *Arena) {
+	s.arena = a
+}
+
+// DecodeBytesArena는 DecodeBytes와 같지만, 디코딩된 바이트 문자열을 arena에서 할당합니다.
+// 디코딩된 값은 arena가 Reset될 때까지만 유효합니다.
+func DecodeBytesArena(b []byte, val interface{}, arena *Arena) error {
+	stream := streamPool.Get().(*Stream)
+	defer streamPool.Put(stream)
+
+	stream.SetArena(arena)
+	defer stream.SetArena(nil) // 풀에 반환된 스트림이 arena를 참조하지 않도록 합니다.
+	return stream.decodeBytes(b, val)
+}
+
+// alloc은 디코딩된 값을 위한 n 바이트 슬라이스를 할당합니다.
+func (s *Stream) alloc(n uint64) []byte {
+	if s.arena != nil {
+		return s.arena.Alloc(int(n))
+	}
+	return make([]byte, n)
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestArenaAlloc(t *testing.T) {
+	a := NewArena(64)
+	b1 := a.Alloc(10)
+	b2 := a.Alloc(10)
+	if len(b1) != 10 || cap(b1) != 10 {
+		t.Fatalf("wrong slice shape: len %d cap %d", len(b1), cap(b1))
+	}
+	// Appending to an allocation must not clobber the next one.
+	b2[0] = 0xff
+	_ = append(b1, 1)
+	if b2[0] != 0xff {
+		t.Fatal("append overwrote neighbouring allocation")
+	}
+	// Requests larger than a quarter chunk bypass the arena.
+	big := a.Alloc(17)
+	if len(a.chunks) != 1 || len(big) != 17 {
+		t.Fatalf("large allocation taken from arena: %d chunks", len(a.chunks))
+	}
+	for i := 0; i < 10; i++ {
+		a.Alloc(16)
+	}
+	if a.Allocated() != 10+10+17+160 {
+		t.Fatalf("wrong allocated count %d", a.Allocated())
+	}
+	chunks := len(a.chunks)
+
+	// After reset, chunks are reused and memory is zeroed.
+	a.Reset()
+	if b := a.Alloc(10); !bytes.Equal(b, make([]byte, 10)) {
+		t.Fatalf("reused memory not zeroed: %x", b)
+	}
+	for i := 0; i < 10; i++ {
+		a.Alloc(16)
+	}
+	if len(a.chunks) != chunks {
+		t.Fatalf("chunks not reused: have %d, want %d", len(a.chunks), chunks)
+	}
+}
+
+func TestDecodeBytesArena(t *testing.T) {
+	type item struct {
+		A []byte
+		B RawValue
+		C string
+		D []byte
+	}
+	input := unhex("CE8401020304C3010203836162630F")
+	want := item{A: []byte{1, 2, 3, 4}, B: unhex("C3010203"), C: "abc", D: []byte{0x0f}}
+
+	arena := NewArena(0)
+	var have item
+	if err := DecodeBytesArena(input, &have, arena); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("wrong result: %+v", have)
+	}
+	if arena.Allocated() == 0 {
+		t.Fatal("nothing allocated from arena")
+	}
+
+	// Pooled streams must not keep a reference to the arena.
+	s := streamPool.Get().(*Stream)
+	if s.arena != nil {
+		t.Fatal("pooled stream retains arena")
+	}
+	streamPool.Put(s)
+
+	if err := DecodeBytesArena(append(input, 0), &have, arena); err != ErrMoreThanOneValue {
+		t.Fatalf("wrong error for trailing input: %v", err)
+	}
+}
+
+func BenchmarkDecodeBytesArena(b *testing.B) {
+	input := unhex("CE8401020304C3010203836162630F")
+	arena := NewArena(0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v struct {
+			A []byte
+			B RawValue
+			C string
+			D []byte
+		}
+		if err := DecodeBytesArena(input, &v, arena); err != nil {
+			b.Fatal(err)
+		}
+		if i%1024 == 0 {
+			arena.Reset()
+		}
+	}
+}
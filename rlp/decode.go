@@ -27,6 +27,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/ethereum/go-ethereum/rlp/internal/rlpstruct"
 	"github.com/holiman/uint256"
@@ -45,6 +46,9 @@ var (
 	ErrElemTooLarge     = errors.New("rlp: element is larger than containing list")
 	ErrValueTooLarge    = errors.New("rlp: value size exceeds available input length")
 	ErrMoreThanOneValue = errors.New("rlp: input contains more than one value")
+	ErrAllocationLimit  = errors.New("rlp: decoded values exceed allocation limit")
+	ErrStringTooLong    = errors.New("rlp: string exceeds maximum length")
+	ErrInvalidUTF8      = errors.New("rlp: string is not valid UTF-8")
 
 	// internal errors
 	errNotInList     = errors.New("rlp: call of ListEnd outside of any list")
@@ -80,22 +84,35 @@ func Decode(r io.Reader, val interface{}) error {
 	defer streamPool.Put(stream)         // 스트림 풀에 스트림 반환
 
 	stream.Reset(r, 0)        // 스트림을 r로 초기화
-	return stream.Decode(val) // val에 스트림을 디코딩
+	err := stream.Decode(val) // val에 스트림을 디코딩
+	if m := loadMetrics(); m != nil {
+		m.Decoded(stream.pos)
+	}
+	return err
 }
 
 // DecodeBytes는 b에서 RLP 데이터를 val로 구문 분석합니다. 디코딩 규칙에 대한 것은 패키지 수준 문서를 참조하십시오.
 // 입력은 정확히 하나의 값을 포함해야 하며 추가 데이터가 없어야합니다.
 func DecodeBytes(b []byte, val interface{}) error {
-	r := (*sliceReader)(&b)
-
 	stream := streamPool.Get().(*Stream)
 	defer streamPool.Put(stream)
 
-	stream.Reset(r, uint64(len(b)))
-	if err := stream.Decode(val); err != nil {
+	return stream.decodeBytes(b, val)
+}
+
+// decodeBytes는 스트림을 b로 재설정한 후 정확히 하나의 값을 val로 디코딩합니다.
+func (s *Stream) decodeBytes(b []byte, val interface{}) error {
+	s.ResetBytes(b)
+	err := s.Decode(val)
+	rest := len(s.sr)
+	if m := loadMetrics(); m != nil {
+		m.Decoded(s.pos)
+	}
+	s.sr = nil // 풀에 반환된 스트림이 입력을 참조하지 않도록 합니다.
+	if err != nil {
 		return err
 	}
-	if len(b) > 0 {
+	if rest > 0 {
 		return ErrMoreThanOneValue
 	}
 	return nil
@@ -105,6 +122,9 @@ type decodeError struct {
 	msg string
 	typ reflect.Type
 	ctx []string
+
+	offset    uint64 // 디코딩에 실패한 값이 시작되는 입력 위치
+	hasOffset bool
 }
 
 func (err *decodeError) Error() string {
@@ -118,6 +138,23 @@ func (err *decodeError) Error() string {
 	return fmt.Sprintf("rlp: %s for %v%s", err.msg, err.typ, ctx)
 }
 
+// Offset은 디코딩에 실패한 값이 시작되는 입력의 바이트 위치를 반환합니다.
+// 위치를 알 수 없는 경우 ok는 false입니다.
+func (err *decodeError) Offset() (offset uint64, ok bool) {
+	return err.offset, err.hasOffset
+}
+
+// ErrorOffset은 디코딩 오류가 발생한 입력의 바이트 위치를 반환합니다. 오류 메시지에는 위치가
+// 포함되지 않으므로 이 함수로 조회해야 합니다. err(또는 err이 감싼 오류)가 위치 정보를 가진
+// 디코딩 오류가 아니면 ok는 false입니다.
+func ErrorOffset(err error) (offset uint64, ok bool) {
+	var decErr *decodeError
+	if errors.As(err, &decErr) {
+		return decErr.Offset()
+	}
+	return 0, false
+}
+
 func wrapStreamError(err error, typ reflect.Type) error {
 	switch err {
 	case ErrCanonInt:
@@ -162,6 +199,8 @@ func makeDecoder(typ reflect.Type, tags rlpstruct.Tags) (dec decoder, err error)
 		return decodeU256, nil
 	case typ == u256Int:
 		return decodeU256NoPtr, nil
+	case scalarCodecOf(typ) != nil:
+		return makeScalarDecoder(scalarCodecOf(typ)), nil
 	case kind == reflect.Ptr:
 		return makePtrDecoder(typ, tags)
 	case reflect.PtrTo(typ).Implements(decoderInterface):
@@ -216,6 +255,9 @@ func decodeString(s *Stream, val reflect.Value) error {
 	if err != nil {
 		return wrapStreamError(err, val.Type())
 	}
+	if s.utf8Check && !utf8.Valid(b) {
+		return ErrInvalidUTF8
+	}
 	val.SetString(string(b))
 	return nil
 }
@@ -258,7 +300,7 @@ func decodeU256(s *Stream, val reflect.Value) error {
 
 func makeListDecoder(typ reflect.Type, tag rlpstruct.Tags) (decoder, error) {
 	etype := typ.Elem()
-	if etype.Kind() == reflect.Uint8 && !reflect.PtrTo(etype).Implements(decoderInterface) {
+	if etype.Kind() == reflect.Uint8 && !reflect.PtrTo(etype).Implements(decoderInterface) && scalarCodecOf(etype) == nil {
 		if typ.Kind() == reflect.Array {
 			return decodeByteArray, nil
 		}
@@ -313,6 +355,9 @@ func decodeSliceElems(s *Stream, val reflect.Value, elemdec decoder) error {
 			if newcap < 4 {
 				newcap = 4
 			}
+			if err := s.allocate(uint64(newcap-val.Cap()) * elemAllocSize(val.Type().Elem())); err != nil {
+				return err
+			}
 			newv := reflect.MakeSlice(val.Type(), val.Len(), newcap)
 			reflect.Copy(newv, val)
 			val.Set(newv)
@@ -574,16 +619,32 @@ type ByteReader interface {
 type Stream struct {
 	r ByteReader
 
-	remaining uint64   // r에서 읽어야하는 남은 바이트 수
-	size      uint64   // 캐시된 값의 크기
-	kinderr   error    // 지난 readKind에서 발생한 오류
-	stack     []uint64 // 리스트 크기
-	uintbuf   [32]byte // 정수 디코딩을 위한 보조 버퍼
-	kind      Kind     // 캐시된 값의 종류
-	byteval   byte     // 타입 태그의 단일 바이트 값
-	limited   bool     // 입력 제한이 적용되는 경우 true
+	remaining  uint64   // r에서 읽어야하는 남은 바이트 수
+	size       uint64   // 캐시된 값의 크기
+	kinderr    error    // 지난 readKind에서 발생한 오류
+	stack      []uint64 // 리스트 크기
+	uintbuf    [32]byte // 정수 디코딩을 위한 보조 버퍼
+	kind       Kind     // 캐시된 값의 종류
+	byteval    byte     // 타입 태그의 단일 바이트 값
+	limited    bool     // 입력 제한이 적용되는 경우 true
+	pos        uint64   // 입력의 시작부터 읽은 바이트 수
+	nonCanon   bool     // 비정규 정수 인코딩을 허용하는 경우 true
+	valpos     uint64   // 마지막으로 읽기 시작한 값의 입력 위치
+	allocLimit uint64   // 디코딩된 값에 할당할 수 있는 최대 바이트 수 (0이면 제한 없음)
+	allocated  uint64   // Reset 이후 디코딩된 값에 할당한 바이트 수
+	arena      *Arena   // 디코딩된 바이트 문자열을 할당할 arena (nil이면 힙에 할당)
+	utf8Check  bool     // 문자열을 디코딩할 때 UTF-8 유효성을 검사하는 경우 true
+
+	sr sliceReader // ResetBytes로 설정된 입력 (r이 이 필드를 가리킵니다)
+
+	ra         *readerAtReader // NewStreamAt으로 설정된 입력. Seek을 지원합니다
+	inputLimit uint64          // ra의 입력 제한 (limited가 true인 경우)
 }
 
+// maxRetainedStackDepth는 Reset 이후에도 유지되는 리스트 스택의 최대 용량입니다.
+// 깊게 중첩된 입력을 디코딩한 후 스트림이 재사용될 때 커진 스택이 계속 유지되는 것을 방지합니다.
+const maxRetainedStackDepth = 64
+
 // NewStream은 r에서 읽어들이는 새로운 디코딩 스트림을 생성합니다.
 //
 // r이 ByteReader 인터페이스를 구현하는 경우 Stream은 버퍼링을 추가하지 않습니다.
@@ -623,9 +684,14 @@ func (s *Stream) Bytes() ([]byte, error) {
 	switch kind {
 	case Byte:
 		s.kind = -1 // Kind 다시 설정
-		return []byte{s.byteval}, nil
+		b := s.alloc(1)
+		b[0] = s.byteval
+		return b, nil
 	case String:
-		b := make([]byte, size)
+		if err := s.allocate(size); err != nil {
+			return nil, err
+		}
+		b := s.alloc(size)
 		if err = s.readFull(b); err != nil {
 			return nil, err
 		}
@@ -669,6 +735,45 @@ func (s *Stream) ReadBytes(b []byte) error {
 	}
 }
 
+// ReadStringMax는 최대 max 바이트의 RLP 문자열을 읽어 Go 문자열로 반환합니다. 문자열이
+// max보다 길면 메모리를 할당하기 전에 ErrStringTooLong을 반환하며, 이때 값은 소비되지 않습니다.
+// RequireUTF8로 검사가 설정된 경우 유효한 UTF-8이 아닌 문자열에 대해 ErrInvalidUTF8을 반환합니다.
+func (s *Stream) ReadStringMax(max uint64) (string, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return "", err
+	}
+	var b []byte
+	switch kind {
+	case Byte:
+		if max < 1 {
+			return "", ErrStringTooLong
+		}
+		s.kind = -1 // Kind 다시 설정
+		b = []byte{s.byteval}
+	case String:
+		if size > max {
+			return "", ErrStringTooLong
+		}
+		if err := s.allocate(size); err != nil {
+			return "", err
+		}
+		b = make([]byte, size)
+		if err = s.readFull(b); err != nil {
+			return "", err
+		}
+		if size == 1 && b[0] < 128 {
+			return "", ErrCanonSize
+		}
+	default:
+		return "", ErrExpectedString
+	}
+	if s.utf8Check && !utf8.Valid(b) {
+		return "", ErrInvalidUTF8
+	}
+	return string(b), nil
+}
+
 // Raw는 RLP 유형 정보를 포함한 원시 인코딩 된 값을 읽습니다.
 func (s *Stream) Raw() ([]byte, error) {
 	kind, size, err := s.Kind()
@@ -677,12 +782,17 @@ func (s *Stream) Raw() ([]byte, error) {
 	}
 	if kind == Byte {
 		s.kind = -1 // rearm Kind
-		return []byte{s.byteval}, nil
+		b := s.alloc(1)
+		b[0] = s.byteval
+		return b, nil
 	}
 	// 원래 헤더는 이미 사용되었으며 더 이상 사용할 수 없습니다.
 	// 내용을 읽고 그 앞에 새 헤더를 넣습니다.
 	start := headsize(size)
-	buf := make([]byte, uint64(start)+size)
+	if err := s.allocate(uint64(start) + size); err != nil {
+		return nil, err
+	}
+	buf := s.alloc(uint64(start) + size)
 	if err := s.readFull(buf[start:]); err != nil {
 		return nil, err
 	}
@@ -728,12 +838,15 @@ func (s *Stream) uint(maxbits int) (uint64, error) {
 	}
 	switch kind {
 	case Byte:
-		if s.byteval == 0 {
+		if s.byteval == 0 && !s.nonCanon {
 			return 0, ErrCanonInt
 		}
 		s.kind = -1 // Kind 다시 설정
 		return uint64(s.byteval), nil
 	case String:
+		if s.nonCanon {
+			return s.uintNonCanonical(size, maxbits)
+		}
 		if size > uint64(maxbits/8) {
 			return 0, errUintOverflow
 		}
@@ -801,6 +914,8 @@ func (s *Stream) ListEnd() error {
 	if inList, listLimit := s.listLimit(); !inList {
 		return errNotInList
 	} else if listLimit > 0 {
+		// 오류 위치가 남아 있는 첫 번째 요소를 가리키도록 합니다.
+		s.valpos = s.pos
 		return errNotAtEOL
 	}
 	s.stack = s.stack[:len(s.stack)-1] // 제거
@@ -824,6 +939,79 @@ func (s *Stream) BigInt() (*big.Int, error) {
 	return i, nil
 }
 
+// uintNonCanonical은 선행 0 바이트를 허용하여 size 바이트의 정수를 읽습니다.
+func (s *Stream) uintNonCanonical(size uint64, maxbits int) (uint64, error) {
+	if size > uint64(len(s.uintbuf)) {
+		return 0, errUintOverflow
+	}
+	buffer := s.uintbuf[:size]
+	if err := s.readFull(buffer); err != nil {
+		return 0, err
+	}
+	for len(buffer) > 0 && buffer[0] == 0 {
+		buffer = buffer[1:]
+	}
+	if len(buffer) > maxbits/8 {
+		return 0, errUintOverflow
+	}
+	var v uint64
+	for _, b := range buffer {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+// AllowNonCanonical은 정수 디코딩 시 비정규 인코딩을 허용할지 여부를 설정합니다.
+// 허용하면 선행 0 바이트, 0x00 단일 바이트, 문자열로 인코딩된 128 미만의 값을 가진 정수를
+// 오류 없이 디코딩합니다. 크기 정보의 정규성은 여전히 검사됩니다.
+//
+// 이는 오래된 클라이언트가 기록한 데이터를 읽기 위한 것이며, 기본값은 엄격 모드입니다.
+// 이 설정은 Reset 이후에도 유지됩니다.
+func (s *Stream) AllowNonCanonical(allow bool) {
+	s.nonCanon = allow
+}
+
+// RequireUTF8은 문자열을 디코딩할 때 UTF-8 유효성을 검사할지 여부를 설정합니다. 검사가
+// 설정되면 ReadStringMax와 Go string 타입으로의 디코딩이 유효한 UTF-8이 아닌 입력에 대해
+// ErrInvalidUTF8을 반환합니다. 텍스트 문자열을 요구하는 프로토콜을 위한 것이며, 바이트 슬라이스의
+// 디코딩에는 영향을 주지 않습니다. 이 설정은 Reset 이후에도 유지됩니다.
+func (s *Stream) RequireUTF8(require bool) {
+	s.utf8Check = require
+}
+
+// SetAllocationLimit은 디코딩된 슬라이스와 바이트 문자열에 할당할 수 있는 총 바이트 수를
+// 제한합니다. 0은 제한이 없음을 의미합니다.
+//
+// 입력 제한은 입력의 크기만 검사하므로, 작은 입력도 많은 요소를 가진 리스트를 통해 큰 할당을
+// 유발할 수 있습니다. 신뢰할 수 없는 입력을 디코딩할 때 이 제한을 설정하면 할당량이 제한을
+// 초과하는 즉시 ErrAllocationLimit이 반환됩니다. 할당량은 Reset할 때마다 0으로 돌아가며,
+// 제한 자체는 Reset 이후에도 유지됩니다.
+func (s *Stream) SetAllocationLimit(limit uint64) {
+	s.allocLimit = limit
+}
+
+// allocate는 n 바이트의 할당을 기록하고 할당 제한을 초과하면 ErrAllocationLimit을 반환합니다.
+func (s *Stream) allocate(n uint64) error {
+	reportAlloc(n)
+	if s.allocLimit == 0 {
+		return nil
+	}
+	if n > s.allocLimit-s.allocated {
+		return ErrAllocationLimit
+	}
+	s.allocated += n
+	return nil
+}
+
+// elemAllocSize는 할당 제한을 계산할 때 사용하는 슬라이스 요소 하나의 크기입니다.
+// 크기가 0인 타입도 요소 수에 비례하여 제한되도록 최소 1을 반환합니다.
+func elemAllocSize(typ reflect.Type) uint64 {
+	if size := uint64(typ.Size()); size > 0 {
+		return size
+	}
+	return 1
+}
+
 func (s *Stream) decodeBigInt(dst *big.Int) error {
 	var buffer []byte
 	kind, size, err := s.Kind()
@@ -846,11 +1034,12 @@ func (s *Stream) decodeBigInt(dst *big.Int) error {
 			return err
 		}
 		// 단일 바이트 인코딩을 사용해야하는 입력을 거부합니다.
-		if size == 1 && buffer[0] < 128 {
+		if size == 1 && buffer[0] < 128 && !s.nonCanon {
 			return ErrCanonSize
 		}
 	default:
 		// 큰 정수의 경우 임시 버퍼가 필요합니다.
+		reportAlloc(size)
 		buffer = make([]byte, size)
 		if err := s.readFull(buffer); err != nil {
 			return err
@@ -858,7 +1047,7 @@ func (s *Stream) decodeBigInt(dst *big.Int) error {
 	}
 
 	// 선행 0 바이트 거부
-	if len(buffer) > 0 && buffer[0] == 0 {
+	if len(buffer) > 0 && buffer[0] == 0 && !s.nonCanon {
 		return ErrCanonInt
 	}
 	// 정수 바이트를 설정합니다.
@@ -889,7 +1078,7 @@ func (s *Stream) ReadUint256(dst *uint256.Int) error {
 			return err
 		}
 		// 단일 바이트 인코딩을 사용해야하는 입력을 거부합니다.
-		if size == 1 && buffer[0] < 128 {
+		if size == 1 && buffer[0] < 128 && !s.nonCanon {
 			return ErrCanonSize
 		}
 	default:
@@ -897,7 +1086,7 @@ func (s *Stream) ReadUint256(dst *uint256.Int) error {
 	}
 
 	// 선행 0 바이트 거부
-	if len(buffer) > 0 && buffer[0] == 0 {
+	if len(buffer) > 0 && buffer[0] == 0 && !s.nonCanon {
 		return ErrCanonInt
 	}
 	// 정수 바이트를 설정합니다.
@@ -925,9 +1114,15 @@ func (s *Stream) Decode(val interface{}) error {
 	}
 
 	err = decoder(s, rval.Elem()) // 값을 디코딩합니다.
-	if decErr, ok := err.(*decodeError); ok && len(decErr.ctx) > 0 {
-		// 디코딩 대상 유형을 오류에 추가하여 컨텍스트가 더 의미 있도록합니다.
-		decErr.ctx = append(decErr.ctx, fmt.Sprint("(", rtyp.Elem(), ")"))
+	if decErr, ok := err.(*decodeError); ok {
+		// 중첩된 Decode 호출이 이미 위치를 기록하지 않았다면, 실패한 값의 위치를 기록합니다.
+		if !decErr.hasOffset {
+			decErr.offset, decErr.hasOffset = s.valpos, true
+		}
+		if len(decErr.ctx) > 0 {
+			// 디코딩 대상 유형을 오류에 추가하여 컨텍스트가 더 의미 있도록합니다.
+			decErr.ctx = append(decErr.ctx, fmt.Sprint("(", rtyp.Elem(), ")"))
+		}
 	}
 	return err
 }
@@ -936,6 +1131,8 @@ func (s *Stream) Decode(val interface{}) error {
 // 이 메서드는 미리 할당 된 Stream을 많은 디코딩 작업에서 재사용하기위한 것입니다.
 //
 // r이 ByteReader도 구현하지 않으면 Stream은 자체 버퍼링을 수행합니다.
+//
+// 깊게 중첩된 입력으로 인해 리스트 스택이 maxRetainedStackDepth보다 커진 경우, 스택은 재사용되지 않고 해제됩니다.
 func (s *Stream) Reset(r io.Reader, inputLimit uint64) {
 	if inputLimit > 0 { // 입력 제한이 설정된 경우
 		s.remaining = inputLimit
@@ -963,13 +1160,44 @@ func (s *Stream) Reset(r io.Reader, inputLimit uint64) {
 		bufr = bufio.NewReader(r)
 	}
 	s.r = bufr
-	// 디코딩 컨텍스트를 재설정합니다.
-	s.stack = s.stack[:0]
+	s.sr = nil
+	s.ra = nil
+	s.resetState()
+}
+
+// ResetBytes는 스트림이 b에서 읽도록 재설정합니다. 입력 제한은 len(b)로 설정됩니다.
+//
+// Reset(bytes.NewReader(b), 0)과 동일하게 동작하지만, 리더를 할당하지 않으므로
+// 풀링되어 오래 사용되는 스트림에서 바이트 슬라이스를 반복해서 디코딩할 때 유용합니다.
+func (s *Stream) ResetBytes(b []byte) {
+	s.sr = b
+	s.r = &s.sr
+	s.ra = nil
+	s.remaining = uint64(len(b))
+	s.limited = true
+	s.resetState()
+}
+
+// resetState는 입력 리더를 제외한 디코딩 컨텍스트를 재설정합니다.
+func (s *Stream) resetState() {
+	if cap(s.stack) > maxRetainedStackDepth {
+		s.stack = nil
+	} else {
+		s.stack = s.stack[:0]
+	}
 	s.size = 0
 	s.kind = -1
 	s.kinderr = nil
 	s.byteval = 0
 	s.uintbuf = [32]byte{}
+	s.pos = 0
+	s.valpos = 0
+	s.allocated = 0
+}
+
+// Offset은 스트림이 입력의 시작부터 읽은 바이트 수를 반환합니다.
+func (s *Stream) Offset() uint64 {
+	return s.pos
 }
 
 // 반환된 크기는 값을 구성하는 바이트 수입니다.
@@ -984,6 +1212,7 @@ func (s *Stream) Kind() (kind Kind, size uint64, err error) {
 
 	// 리스트의 마지막을 확인합니다.
 	// readKind는 리스트 크기를 확인하고 잘못된 오류를 반환할 수 있으므로 여기에서 수행해야합니다.
+	s.valpos = s.pos
 	inList, listLimit := s.listLimit()
 	if inList && listLimit == 0 {
 		return 0, 0, EOL
@@ -1130,6 +1359,7 @@ func (s *Stream) willRead(n uint64) error {
 		}
 		s.remaining -= n
 	}
+	s.pos += n
 	return nil
 }
 
@@ -963,6 +963,59 @@ func TestDecodeStreamReset(t *testing.T) {
 	})
 }
 
+func TestDecodeStreamResetBytes(t *testing.T) {
+	s := NewStream(nil, 0)
+	runTests(t, func(input []byte, into interface{}) error {
+		s.ResetBytes(input)
+		return s.Decode(into)
+	})
+}
+
+func TestStreamResetReleasesStack(t *testing.T) {
+	// Decode a deeply nested list to grow the list stack.
+	depth := 2 * maxRetainedStackDepth
+	input := bytes.Repeat([]byte{0xC1}, depth)
+	input = append(input, 0xC0)
+
+	s := NewStream(bytes.NewReader(input), 0)
+	for i := 0; i <= depth; i++ {
+		if _, err := s.List(); err != nil {
+			t.Fatalf("List error at depth %d: %v", i, err)
+		}
+	}
+	if cap(s.stack) <= maxRetainedStackDepth {
+		t.Fatalf("stack did not grow: cap %d", cap(s.stack))
+	}
+	s.ResetBytes(unhex("C0"))
+	if cap(s.stack) != 0 {
+		t.Fatalf("oversized stack retained after reset: cap %d", cap(s.stack))
+	}
+	// Small stacks should be retained for reuse.
+	s.List()
+	s.ListEnd()
+	s.Reset(bytes.NewReader(unhex("C0")), 0)
+	if cap(s.stack) == 0 {
+		t.Fatal("small stack released after reset")
+	}
+}
+
+func TestStreamResetBytesAllocs(t *testing.T) {
+	input := unhex("C50583343434")
+	s := NewStream(nil, 0)
+	allocs := testing.AllocsPerRun(100, func() {
+		s.ResetBytes(input)
+		if _, err := s.List(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Uint64(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("ResetBytes allocated %v times", allocs)
+	}
+}
+
 type testDecoder struct{ called bool }
 
 func (t *testDecoder) DecodeRLP(s *Stream) error {
@@ -1284,3 +1337,223 @@ func unhex(str string) []byte {
 	}
 	return b
 }
+
+func TestDecodeErrorOffset(t *testing.T) {
+	type inner struct{ A, B uint }
+	type outer struct {
+		X []byte
+		Y []inner
+	}
+	// The second element of Y has a non-canonical integer at offset 10.
+	input := unhex("CB83010203C6C2010BC20002")
+	var val outer
+	err := DecodeBytes(input, &val)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	offset, ok := ErrorOffset(err)
+	if !ok {
+		t.Fatalf("no offset in error: %v", err)
+	}
+	if offset != 10 {
+		t.Fatalf("wrong offset %d, want 10 (error: %v)", offset, err)
+	}
+	if strings.Contains(err.Error(), "offset") {
+		t.Fatalf("offset leaked into error message: %v", err)
+	}
+	if offset, ok := ErrorOffset(fmt.Errorf("wrapped: %w", err)); !ok || offset != 10 {
+		t.Fatalf("wrong offset for wrapped error: %d, %t", offset, ok)
+	}
+	if _, ok := ErrorOffset(io.EOF); ok {
+		t.Fatal("offset reported for non-decoding error")
+	}
+
+	for _, test := range []struct {
+		input  string
+		ptr    interface{}
+		offset uint64
+	}{
+		{input: "C0", ptr: new(uint32), offset: 0},
+		{input: "C102", ptr: new([5]uint), offset: 2},
+		{input: "C6010203040506", ptr: new([5]uint), offset: 6},
+		{input: "C7C50583343434C0", ptr: new([]*simplestruct), offset: 8},
+		{input: "C3010101", ptr: new(simplestruct), offset: 3},
+		{input: "C501C3C00000", ptr: new(recstruct), offset: 3},
+		{input: "C20180", ptr: new(optionalPtrField), offset: 2},
+	} {
+		err := DecodeBytes(unhex(test.input), test.ptr)
+		if offset, ok := ErrorOffset(err); !ok || offset != test.offset {
+			t.Errorf("input %s: wrong offset %d (ok %t), want %d (error: %v)", test.input, offset, ok, test.offset, err)
+		}
+	}
+
+	s := NewStream(bytes.NewReader(input), 0)
+	if _, err := s.List(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Bytes(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Offset() != 5 {
+		t.Fatalf("wrong stream offset %d, want 5", s.Offset())
+	}
+}
+
+func TestStreamAllowNonCanonical(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{"00", 0},
+		{"8105", 5},
+		{"820004", 4},
+		{"8400000101", 257},
+		{"8900FFFFFFFFFFFFFFFF", math.MaxUint64},
+	}
+	for _, test := range tests {
+		input := unhex(test.input)
+
+		s := NewStream(bytes.NewReader(input), 0)
+		if _, err := s.Uint64(); err == nil {
+			t.Errorf("%s: non-canonical integer accepted in strict mode", test.input)
+		}
+		s.Reset(bytes.NewReader(input), 0)
+		s.AllowNonCanonical(true)
+		if v, err := s.Uint64(); err != nil || v != test.want {
+			t.Errorf("%s: Uint64 = %d, %v; want %d", test.input, v, err, test.want)
+		}
+		s.Reset(bytes.NewReader(input), 0)
+		if v, err := s.BigInt(); err != nil || !v.IsUint64() || v.Uint64() != test.want {
+			t.Errorf("%s: BigInt = %v, %v; want %d", test.input, v, err, test.want)
+		}
+		s.Reset(bytes.NewReader(input), 0)
+		var u uint256.Int
+		if err := s.ReadUint256(&u); err != nil || u.Uint64() != test.want {
+			t.Errorf("%s: ReadUint256 = %v, %v; want %d", test.input, &u, err, test.want)
+		}
+	}
+	// Overflow must still be detected after stripping leading zeros.
+	s := NewStream(bytes.NewReader(unhex("8901FFFFFFFFFFFFFFFF")), 0)
+	s.AllowNonCanonical(true)
+	if _, err := s.Uint64(); err != errUintOverflow {
+		t.Errorf("wrong error for overflowing integer: %v", err)
+	}
+	// Size information must still be canonical.
+	s.Reset(bytes.NewReader(unhex("B80105")), 0)
+	if _, err := s.Uint64(); err != ErrCanonSize {
+		t.Errorf("wrong error for non-canonical size: %v", err)
+	}
+	// Struct decoding goes through the same paths.
+	var val struct {
+		A uint32
+		B *big.Int
+	}
+	s.Reset(bytes.NewReader(unhex("C7820001830000FF")), 0)
+	if err := s.Decode(&val); err != nil {
+		t.Fatal(err)
+	}
+	if val.A != 1 || val.B.Uint64() != 255 {
+		t.Errorf("wrong decoded struct: %+v", val)
+	}
+}
+
+func TestStreamReadStringMax(t *testing.T) {
+	tests := []struct {
+		input string
+		max   uint64
+		want  string
+		err   error
+	}{
+		{input: "80", max: 0, want: ""},
+		{input: "61", max: 1, want: "a"},
+		{input: "61", max: 0, err: ErrStringTooLong},
+		{input: "83646F67", max: 3, want: "dog"},
+		{input: "83646F67", max: 2, err: ErrStringTooLong},
+		{input: "8161", max: 8, err: ErrCanonSize},
+		{input: "C0", max: 8, err: ErrExpectedString},
+		{input: "82C328", max: 8, want: "\xc3("}, // invalid UTF-8 is accepted by default
+	}
+	for _, test := range tests {
+		s := NewStream(bytes.NewReader(unhex(test.input)), 0)
+		v, err := s.ReadStringMax(test.max)
+		if err != test.err {
+			t.Errorf("%s: wrong error %v, want %v", test.input, err, test.err)
+			continue
+		}
+		if err == nil && v != test.want {
+			t.Errorf("%s: wrong value %q, want %q", test.input, v, test.want)
+		}
+	}
+	// A rejected string is not consumed.
+	s := NewStream(bytes.NewReader(unhex("83646F67")), 0)
+	if _, err := s.ReadStringMax(2); err != ErrStringTooLong {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if v, err := s.ReadStringMax(3); err != nil || v != "dog" {
+		t.Fatalf("ReadStringMax after rejection = %q, %v", v, err)
+	}
+	// The length is checked before the content is read.
+	s = NewStream(struct{ io.Reader }{bytes.NewReader(unhex("B9FFFF"))}, 0)
+	if _, err := s.ReadStringMax(1024); err != ErrStringTooLong {
+		t.Fatalf("wrong error for truncated long string: %v", err)
+	}
+}
+
+func TestStreamRequireUTF8(t *testing.T) {
+	input := unhex("82C328")
+	s := NewStream(bytes.NewReader(input), 0)
+	s.RequireUTF8(true)
+	if _, err := s.ReadStringMax(8); err != ErrInvalidUTF8 {
+		t.Fatalf("wrong error for invalid UTF-8: %v", err)
+	}
+	// The setting persists across Reset and applies to string decoding.
+	s.Reset(bytes.NewReader(input), 0)
+	var str string
+	if err := s.Decode(&str); !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("wrong error decoding string: %v", err)
+	}
+	// Byte slices are not checked.
+	s.Reset(bytes.NewReader(input), 0)
+	var b []byte
+	if err := s.Decode(&b); err != nil {
+		t.Fatalf("byte slice decoding failed: %v", err)
+	}
+	s.Reset(bytes.NewReader(unhex("85E284A2C3A9")), 0)
+	if v, err := s.ReadStringMax(8); err != nil || v != "\u2122\u00e9" {
+		t.Fatalf("ReadStringMax = %q, %v", v, err)
+	}
+}
+
+func TestStreamAllocationLimit(t *testing.T) {
+	// A list of 64 single-byte elements decodes into 64 uint64 values.
+	input := make([]byte, 0, 66)
+	input = append(input, 0xF8, 64)
+	for i := 0; i < 64; i++ {
+		input = append(input, 0x01)
+	}
+	s := NewStream(bytes.NewReader(input), 0)
+	s.SetAllocationLimit(64)
+	var out []uint64
+	if err := s.Decode(&out); !errors.Is(err, ErrAllocationLimit) {
+		t.Fatalf("wrong error for list exceeding limit: %v", err)
+	}
+
+	// The limit applies per Reset.
+	s.SetAllocationLimit(1024)
+	for i := 0; i < 2; i++ {
+		s.Reset(bytes.NewReader(input), 0)
+		if err := s.Decode(&out); err != nil {
+			t.Fatalf("decode %d: %v", i, err)
+		}
+	}
+	if len(out) != 64 {
+		t.Fatalf("wrong number of decoded elements: %d", len(out))
+	}
+
+	// Byte strings count towards the limit.
+	s.Reset(bytes.NewReader(unhex("83010203")), 0)
+	s.SetAllocationLimit(2)
+	if _, err := s.Bytes(); err != ErrAllocationLimit {
+		t.Fatalf("wrong error for string exceeding limit: %v", err)
+	}
+}
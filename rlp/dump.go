@@ -0,0 +1,127 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DumpOptions는 Dump의 출력 형식을 설정합니다. 값이 0인 옵션은 rlpdump 도구의 기본 출력과 같습니다.
+type DumpOptions struct {
+	NoASCII    bool // 출력 가능한 ASCII 문자열도 16진수로 출력
+	Single     bool // 첫 번째 값만 출력하고 나머지 입력은 무시
+	MaxBytes   int  // 0보다 크면 더 긴 16진수 문자열을 이 바이트 수로 자르고 전체 길이를 표시
+	ListCounts bool // 비어 있지 않은 리스트의 여는 괄호 뒤에 요소 수를 표시
+}
+
+// Dump는 r에서 RLP 값을 차례로 읽어 사람이 읽을 수 있는 형태로 w에 씁니다. 리스트의 요소는
+// 들여쓰기되며, 출력 가능한 ASCII 문자열은 따옴표로 묶인 문자열로, 그 외의 문자열은 16진수로
+// 출력됩니다. 각 최상위 값 뒤에는 줄바꿈이 붙습니다. opts가 nil이면 기본 옵션을 사용합니다.
+//
+// 기본 옵션의 출력은 rlpdump 도구의 출력과 같으며 -reverse 모드로 다시 RLP로 변환할 수
+// 있습니다. MaxBytes와 ListCounts를 사용한 출력은 되돌릴 수 없습니다.
+func Dump(r io.Reader, w io.Writer, opts *DumpOptions) error {
+	d := dumper{}
+	if opts != nil {
+		d.opts = *opts
+	}
+	s := NewStream(r, 0)
+	for {
+		if err := d.dump(s, 0, w); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		fmt.Fprintln(w)
+		if d.opts.Single {
+			return nil
+		}
+	}
+}
+
+type dumper struct {
+	opts DumpOptions
+}
+
+// dump는 스트림의 다음 값을 depth 단계만큼 들여쓰기하여 out에 씁니다.
+func (d *dumper) dump(s *Stream, depth int, out io.Writer) error {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	indent := strings.Repeat("  ", depth)
+	switch kind {
+	case Byte, String:
+		str, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(str) == 0 || !d.opts.NoASCII && isASCII(str):
+			fmt.Fprintf(out, "%s%q", indent, str)
+		case d.opts.MaxBytes > 0 && len(str) > d.opts.MaxBytes:
+			fmt.Fprintf(out, "%s%x...(%d bytes)", indent, str[:d.opts.MaxBytes], len(str))
+		default:
+			fmt.Fprintf(out, "%s%x", indent, str)
+		}
+	case List:
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		if size == 0 {
+			fmt.Fprint(out, indent+"[]")
+			return s.ListEnd()
+		}
+		// 요소 수를 표시하려면 요소를 모두 읽은 뒤에 여는 줄을 써야 하므로 내용을 따로 모읍니다.
+		body := out
+		if d.opts.ListCounts {
+			body = new(strings.Builder)
+		} else {
+			fmt.Fprintln(out, indent+"[")
+		}
+		count := 0
+		for ; ; count++ {
+			if count > 0 {
+				fmt.Fprint(body, ",\n")
+			}
+			if err := d.dump(s, depth+1, body); err == EOL {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+		if d.opts.ListCounts {
+			fmt.Fprintf(out, "%s[ // %d items\n%s", indent, count, body.(*strings.Builder).String())
+		}
+		fmt.Fprint(out, indent+"]")
+		return s.ListEnd()
+	}
+	return nil
+}
+
+// isASCII는 b가 출력 가능한 ASCII 문자로만 이루어져 있는지 여부를 반환합니다.
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 32 || c > 126 {
+			return false
+		}
+	}
+	return true
+}
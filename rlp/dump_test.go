@@ -0,0 +1,104 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	input := unhex("D5C0D3CB84746573742A2A808213378667617A6F6E6B" + "C3820102")
+	tests := []struct {
+		opts *DumpOptions
+		want string
+	}{
+		{
+			opts: nil,
+			want: `[
+  [],
+  [
+    [
+      "test",
+      "*",
+      "*",
+      "",
+      1337,
+    ],
+    "gazonk",
+  ],
+]
+[
+  0102,
+]
+`,
+		},
+		{
+			opts: &DumpOptions{NoASCII: true, Single: true},
+			want: `[
+  [],
+  [
+    [
+      74657374,
+      2a,
+      2a,
+      "",
+      1337,
+    ],
+    67617a6f6e6b,
+  ],
+]
+`,
+		},
+		{
+			opts: &DumpOptions{NoASCII: true, MaxBytes: 2, ListCounts: true},
+			want: `[ // 2 items
+  [],
+  [ // 2 items
+    [ // 5 items
+      7465...(4 bytes),
+      2a,
+      2a,
+      "",
+      1337,
+    ],
+    6761...(6 bytes),
+  ],
+]
+[ // 1 items
+  0102,
+]
+`,
+		},
+	}
+	for i, test := range tests {
+		var out strings.Builder
+		if err := Dump(bytes.NewReader(input), &out, test.opts); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if out.String() != test.want {
+			t.Errorf("test %d: wrong output\nhave:\n%s\nwant:\n%s", i, out.String(), test.want)
+		}
+	}
+
+	// Malformed input is reported.
+	var out strings.Builder
+	if err := Dump(bytes.NewReader(unhex("C3820102C5")), &out, nil); err == nil {
+		t.Fatal("no error for truncated input")
+	}
+}
@@ -62,6 +62,14 @@ func (buf *encBuffer) makeBytes() []byte {
 	return out
 }
 
+// appendTo는 인코더 출력을 dst에 추가합니다.
+func (buf *encBuffer) appendTo(dst []byte) []byte {
+	size := buf.size()
+	out := append(dst, make([]byte, size)...)
+	buf.copyTo(out[len(dst):])
+	return out
+}
+
 func (buf *encBuffer) copyTo(dst []byte) {
 	strpos := 0
 	pos := 0
@@ -171,6 +179,29 @@ func (buf *encBuffer) writeBigInt(i *big.Int) {
 	}
 }
 
+// writeBigIntPtr는 리플렉션 인코딩과 같은 규칙으로 *big.Int를 씁니다. nil은 빈 문자열로
+// 인코딩되고, 음수는 ErrNegativeBigInt를 반환합니다.
+func (buf *encBuffer) writeBigIntPtr(i *big.Int) error {
+	if i == nil {
+		buf.str = append(buf.str, 0x80) // 빈 문자열 헤더를 씁니다.
+		return nil
+	}
+	if i.Sign() == -1 {
+		return ErrNegativeBigInt
+	}
+	buf.writeBigInt(i)
+	return nil
+}
+
+// writeUint256Ptr는 *uint256.Int를 씁니다. nil은 빈 문자열로 인코딩됩니다.
+func (buf *encBuffer) writeUint256Ptr(z *uint256.Int) {
+	if z == nil {
+		buf.str = append(buf.str, 0x80) // 빈 문자열 헤더를 씁니다.
+		return
+	}
+	buf.writeUint256(z)
+}
+
 // writeUint256 writes z as an integer.
 // writeUint256는 z를 정수로 씁니다.
 func (buf *encBuffer) writeUint256(z *uint256.Int) {
@@ -370,10 +401,13 @@ func (w *EncoderBuffer) ToBytes() []byte {
 
 // AppendToBytes는 인코딩된 바이트를 dst에 추가합니다.
 func (w *EncoderBuffer) AppendToBytes(dst []byte) []byte {
-	size := w.buf.size()
-	out := append(dst, make([]byte, size)...)
-	w.buf.copyTo(out[len(dst):])
-	return out
+	return w.buf.appendTo(dst)
+}
+
+// WriteValue는 임의의 값 val을 인코딩하여 버퍼에 추가합니다. AppendToBytes와 함께 사용하면
+// 호출자가 소유한 버퍼로 출력을 얻을 수 있습니다.
+func (w EncoderBuffer) WriteValue(val interface{}) error {
+	return w.buf.encode(val)
 }
 
 // Write는 b를 직접 인코더 출력에 추가합니다.
@@ -402,6 +436,19 @@ func (w EncoderBuffer) WriteUint256(i *uint256.Int) {
 	w.buf.writeUint256(i)
 }
 
+// WriteBigIntOrNil은 구조체 필드의 *big.Int와 같은 방식으로 i를 인코딩합니다. nil 포인터는
+// 빈 문자열(0x80)로 인코딩되며, 음수이면 아무것도 쓰지 않고 ErrNegativeBigInt를 반환합니다.
+// 직접 작성한 EncodeRLP 구현이 리플렉션 기반 인코딩과 같은 결과를 내도록 할 때 사용합니다.
+func (w EncoderBuffer) WriteBigIntOrNil(i *big.Int) error {
+	return w.buf.writeBigIntPtr(i)
+}
+
+// WriteUint256OrNil은 구조체 필드의 *uint256.Int와 같은 방식으로 i를 인코딩합니다. nil 포인터는
+// 빈 문자열(0x80)로 인코딩됩니다.
+func (w EncoderBuffer) WriteUint256OrNil(i *uint256.Int) {
+	w.buf.writeUint256Ptr(i)
+}
+
 // WriteBytes는 b를 RLP 문자열로 인코딩합니다.
 func (w EncoderBuffer) WriteBytes(b []byte) {
 	w.buf.writeBytes(b)
@@ -422,3 +469,20 @@ func (w EncoderBuffer) List() int {
 func (w EncoderBuffer) ListEnd(index int) {
 	w.buf.listEnd(index)
 }
+
+// ListFunc는 n개의 요소를 가진 리스트를 인코딩합니다. 각 요소는 fn(i, w)를 호출하여 w에
+// 직접 인코딩되므로, 큰 리스트를 모든 요소를 담은 슬라이스를 만들지 않고 인코딩할 수 있습니다.
+// fn은 요소 하나에 해당하는 값을 정확히 하나 써야 합니다.
+//
+// fn이 오류를 반환하면 인코딩을 멈추고 그 오류를 반환합니다. 이때 리스트는 이미 쓴 요소까지로
+// 닫히므로 바깥쪽 리스트의 구조는 유지되지만, 출력은 불완전하므로 사용해서는 안 됩니다.
+func (w EncoderBuffer) ListFunc(n int, fn func(i int, w EncoderBuffer) error) error {
+	index := w.List()
+	defer w.ListEnd(index)
+	for i := 0; i < n; i++ {
+		if err := fn(i, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -81,6 +81,19 @@ func EncodeToBytes(val interface{}) ([]byte, error) {
 	return buf.makeBytes(), nil // 인코딩된 데이터를 반환합니다.
 }
 
+// AppendEncoded는 val의 RLP 인코딩을 dst에 추가하여 반환합니다. dst의 용량이 충분하면 출력을 위한
+// 추가 할당이 발생하지 않으므로, 호출자가 소유한 버퍼를 재사용하는 데 사용할 수 있습니다.
+// 인코딩에 실패하면 dst를 그대로 반환합니다.
+func AppendEncoded(dst []byte, val interface{}) ([]byte, error) {
+	buf := getEncBuffer()
+	defer encBufferPool.Put(buf)
+
+	if err := buf.encode(val); err != nil {
+		return dst, err
+	}
+	return buf.appendTo(dst), nil
+}
+
 // EncodeToReader는 val의 RLP 인코딩을 읽을 수 있는 리더를 반환합니다.
 // 반환된 size는 인코딩된 데이터의 총 크기입니다.
 //
@@ -145,6 +158,8 @@ func makeWriter(typ reflect.Type, ts rlpstruct.Tags) (writer, error) {
 		return writeU256IntPtr, nil
 	case typ == u256Int: // uint256.Int
 		return writeU256IntNoPtr, nil
+	case scalarCodecOf(typ) != nil: // RegisterScalar로 등록된 타입
+		return makeScalarWriter(scalarCodecOf(typ)), nil
 	// 그 외의 타입들
 	case kind == reflect.Ptr: // 포인터 타입
 		return makePtrWriter(typ, ts)
@@ -187,16 +202,7 @@ func writeBool(val reflect.Value, w *encBuffer) error {
 }
 
 func writeBigIntPtr(val reflect.Value, w *encBuffer) error {
-	ptr := val.Interface().(*big.Int)
-	if ptr == nil {
-		w.str = append(w.str, 0x80) // 빈 문자열 헤더를 씁니다.
-		return nil
-	}
-	if ptr.Sign() == -1 { // 음수인 경우 에러를 반환합니다.
-		return ErrNegativeBigInt
-	}
-	w.writeBigInt(ptr)
-	return nil
+	return w.writeBigIntPtr(val.Interface().(*big.Int))
 }
 
 func writeBigIntNoPtr(val reflect.Value, w *encBuffer) error {
@@ -209,12 +215,7 @@ func writeBigIntNoPtr(val reflect.Value, w *encBuffer) error {
 }
 
 func writeU256IntPtr(val reflect.Value, w *encBuffer) error {
-	ptr := val.Interface().(*uint256.Int)
-	if ptr == nil {
-		w.str = append(w.str, 0x80) // 빈 문자열 헤더를 씁니다.
-		return nil
-	}
-	w.writeUint256(ptr)
+	w.writeUint256Ptr(val.Interface().(*uint256.Int))
 	return nil
 }
 
@@ -449,6 +449,46 @@ func TestEncodeAppendToBytes(t *testing.T) {
 	})
 }
 
+func TestAppendEncoded(t *testing.T) {
+	buffer := make([]byte, 20)
+	runEncTests(t, func(val interface{}) ([]byte, error) {
+		return AppendEncoded(buffer[:0], val)
+	})
+}
+
+func TestAppendEncodedPrefix(t *testing.T) {
+	prefix := []byte{0xde, 0xad}
+	output, err := AppendEncoded(prefix, []uint{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := unhex("DEADC3010203"); !bytes.Equal(output, want) {
+		t.Fatalf("output mismatch: got %X, want %X", output, want)
+	}
+	// Encoding into a buffer with enough capacity must not allocate.
+	scratch := make([]byte, 0, 64)
+	val := []uint{1, 2, 3}
+	allocs := testing.AllocsPerRun(100, func() {
+		scratch, _ = AppendEncoded(scratch[:0], &val)
+	})
+	if allocs != 0 {
+		t.Fatalf("AppendEncoded allocated %v times", allocs)
+	}
+}
+
+func TestEncoderBufferWriteValue(t *testing.T) {
+	buffer := make([]byte, 20)
+	runEncTests(t, func(val interface{}) ([]byte, error) {
+		w := NewEncoderBuffer(nil)
+		defer w.Flush()
+
+		if err := w.WriteValue(val); err != nil {
+			return nil, err
+		}
+		return w.AppendToBytes(buffer[:0]), nil
+	})
+}
+
 func TestEncodeToReader(t *testing.T) {
 	runEncTests(t, func(val interface{}) ([]byte, error) {
 		_, r, err := EncodeToReader(val)
@@ -636,3 +676,82 @@ func BenchmarkEncodeStructPtrSlice(b *testing.B) {
 		}
 	}
 }
+
+func TestEncoderBufferOrNil(t *testing.T) {
+	type intStruct struct {
+		B *big.Int
+		U *uint256.Int
+	}
+	tests := []intStruct{
+		{nil, nil},
+		{big.NewInt(0), uint256.NewInt(0)},
+		{big.NewInt(127), uint256.NewInt(128)},
+		{new(big.Int).Lsh(big.NewInt(1), 200), new(uint256.Int).Lsh(uint256.NewInt(1), 200)},
+	}
+	for i, test := range tests {
+		want, err := EncodeToBytes(&test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := NewEncoderBuffer(nil)
+		l := w.List()
+		if err := w.WriteBigIntOrNil(test.B); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteUint256OrNil(test.U)
+		w.ListEnd(l)
+		if have := w.ToBytes(); !bytes.Equal(have, want) {
+			t.Errorf("test %d: encoding mismatch: have %x, want %x", i, have, want)
+		}
+		w.Flush()
+	}
+	w := NewEncoderBuffer(nil)
+	if err := w.WriteBigIntOrNil(big.NewInt(-1)); err != ErrNegativeBigInt {
+		t.Fatalf("wrong error for negative integer: %v", err)
+	}
+	if enc := w.ToBytes(); len(enc) != 0 {
+		t.Fatalf("output written for negative integer: %x", enc)
+	}
+}
+
+func TestEncoderBufferListFunc(t *testing.T) {
+	values := make([][]uint64, 300)
+	for i := range values {
+		values[i] = []uint64{uint64(i), uint64(i) * 1000}
+	}
+	want, _ := EncodeToBytes(values)
+
+	w := NewEncoderBuffer(nil)
+	defer w.Flush()
+	err := w.ListFunc(len(values), func(i int, w EncoderBuffer) error {
+		return w.ListFunc(2, func(j int, w EncoderBuffer) error {
+			w.WriteUint64(values[i][j])
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := w.ToBytes(); !bytes.Equal(have, want) {
+		t.Fatalf("encoding mismatch:\nhave %x\nwant %x", have, want)
+	}
+
+	// Errors stop the encoding, and the enclosing list remains well-formed.
+	w.Reset(nil)
+	outer := w.List()
+	errStop := errors.New("stop")
+	err = w.ListFunc(10, func(i int, w EncoderBuffer) error {
+		if i == 2 {
+			return errStop
+		}
+		w.WriteUint64(uint64(i))
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("wrong error: %v", err)
+	}
+	w.ListEnd(outer)
+	if have := w.ToBytes(); !bytes.Equal(have, unhex("C3C28001")) {
+		t.Fatalf("wrong output after error: %x", have)
+	}
+}
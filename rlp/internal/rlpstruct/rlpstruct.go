@@ -40,13 +40,14 @@ type Type struct {
 	Kind      reflect.Kind
 	IsEncoder bool  // 타입이 rlp.Encoder를 구현하는지 여부
 	IsDecoder bool  // 타입이 rlp.Decoder를 구현하는지 여부
+	IsScalar  bool  // 타입이 rlp.RegisterScalar로 등록되었는지 여부
 	Elem      *Type // Ptr, Slice, Array의 Kind 값에 대해서는 nil이 아니어야 합니다.
 }
 
 // DefaultNilValue는 t의 nil 포인터가 빈 문자열 또는 빈 리스트로 인코딩/디코딩되는지 여부를 결정합니다.
 func (t Type) DefaultNilValue() NilKind {
 	k := t.Kind
-	if isUint(k) || k == reflect.String || k == reflect.Bool || isByteArray(t) {
+	if isUint(k) || k == reflect.String || k == reflect.Bool || isByteArray(t) || t.IsScalar {
 		return NilKindString
 	}
 	return NilKindList
@@ -209,7 +210,7 @@ func isUint(k reflect.Kind) bool {
 }
 
 func isByte(typ Type) bool {
-	return typ.Kind == reflect.Uint8 && !typ.IsEncoder
+	return typ.Kind == reflect.Uint8 && !typ.IsEncoder && !typ.IsScalar
 }
 
 func isByteArray(typ Type) bool {
@@ -0,0 +1,79 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// LargeAllocSize는 Metrics.LargeAlloc으로 보고되는 할당의 최소 크기입니다.
+const LargeAllocSize = 1024
+
+// Metrics는 디코딩 활동을 관찰하기 위한 계측 훅입니다. SetMetrics로 설정하면 모든 호출 지점을
+// 감싸지 않고도 운영 환경에서 디코딩이 집중되는 곳을 확인할 수 있습니다.
+//
+// 메서드는 디코딩 경로에서 여러 고루틴이 동시에 호출하므로, 구현은 동시성에 안전하고 빨라야
+// 합니다.
+type Metrics interface {
+	// Decoded는 Decode 또는 DecodeBytes 호출이 끝날 때마다 입력에서 읽은 바이트 수와 함께
+	// 호출됩니다. 디코딩에 실패한 호출도 포함됩니다.
+	Decoded(bytes uint64)
+
+	// LargeAlloc은 디코더가 바이트 문자열, 슬라이스 또는 큰 정수를 위해 LargeAllocSize
+	// 바이트 이상을 할당할 때 호출됩니다.
+	LargeAlloc(size uint64)
+
+	// TypeCacheMiss는 인코딩 또는 디코딩할 타입의 정보가 캐시되어 있지 않아 새로 생성해야 할 때
+	// 호출됩니다. 여러 고루틴이 같은 타입을 동시에 조회하면 두 번 이상 보고될 수 있습니다.
+	TypeCacheMiss(typ reflect.Type)
+}
+
+// metricsHolder는 인터페이스 값을 atomic.Pointer에 저장하기 위한 래퍼입니다.
+type metricsHolder struct {
+	m Metrics
+}
+
+var currentMetrics atomic.Pointer[metricsHolder]
+
+// SetMetrics는 패키지 전역의 계측 훅을 설정합니다. nil을 전달하면 계측을 끕니다. 계측이 꺼져
+// 있을 때의 비용은 디코딩 호출마다 원자적 읽기 한 번입니다.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		currentMetrics.Store(nil)
+		return
+	}
+	currentMetrics.Store(&metricsHolder{m})
+}
+
+// loadMetrics는 설정된 계측 훅을 반환합니다. 설정되지 않았으면 nil입니다.
+func loadMetrics() Metrics {
+	if h := currentMetrics.Load(); h != nil {
+		return h.m
+	}
+	return nil
+}
+
+// reportAlloc은 n이 LargeAllocSize 이상이면 할당을 보고합니다.
+func reportAlloc(n uint64) {
+	if n < LargeAllocSize {
+		return
+	}
+	if m := loadMetrics(); m != nil {
+		m.LargeAlloc(n)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type testMetrics struct {
+	mu     sync.Mutex
+	calls  int
+	bytes  uint64
+	allocs []uint64
+	misses []reflect.Type
+}
+
+func (m *testMetrics) Decoded(n uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	m.bytes += n
+}
+
+func (m *testMetrics) LargeAlloc(size uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allocs = append(m.allocs, size)
+}
+
+func (m *testMetrics) TypeCacheMiss(typ reflect.Type) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.misses = append(m.misses, typ)
+}
+
+type metricsTestStruct struct {
+	A uint64
+	B []byte
+	C *big.Int
+}
+
+func TestMetrics(t *testing.T) {
+	// Start with an empty type cache so the test also works with -count.
+	theTC.mu.Lock()
+	theTC.cur.Store(make(map[typekey]*typeinfo))
+	theTC.mu.Unlock()
+
+	m := new(testMetrics)
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	input, _ := EncodeToBytes(&metricsTestStruct{
+		A: 1,
+		B: make([]byte, 2000),
+		C: new(big.Int).Lsh(big.NewInt(1), 8*LargeAllocSize),
+	})
+	var v metricsTestStruct
+	if err := DecodeBytes(input, &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := Decode(bytes.NewReader(input), &v); err != nil {
+		t.Fatal(err)
+	}
+	if m.calls != 2 || m.bytes != 2*uint64(len(input)) {
+		t.Errorf("wrong decode counts: %d calls, %d bytes", m.calls, m.bytes)
+	}
+	// Failed decoding is counted as well.
+	if err := DecodeBytes(input[:10], &v); err == nil {
+		t.Fatal("no error for truncated input")
+	}
+	if m.calls != 3 {
+		t.Errorf("failed decoding not counted")
+	}
+	wantAllocs := []uint64{2000, LargeAllocSize + 1, 2000, LargeAllocSize + 1}
+	if !reflect.DeepEqual(m.allocs, wantAllocs) {
+		t.Errorf("wrong allocations: %v, want %v", m.allocs, wantAllocs)
+	}
+	// Encoding the pointer generates the info for both types, only the lookup is a miss.
+	if len(m.misses) != 1 || m.misses[0] != reflect.TypeOf(&v) {
+		t.Errorf("wrong type cache misses: %v", m.misses)
+	}
+
+	// Nothing is reported after disabling.
+	SetMetrics(nil)
+	if err := DecodeBytes(input, &v); err != nil {
+		t.Fatal(err)
+	}
+	if m.calls != 3 {
+		t.Error("metrics reported after SetMetrics(nil)")
+	}
+}
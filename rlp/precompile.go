@@ -0,0 +1,67 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Precompile은 주어진 값들의 타입에 대한 인코더와 디코더를 미리 생성하여 타입 캐시에 넣습니다.
+// 처음 인코딩하거나 디코딩할 때 타입 정보를 생성하느라 캐시 잠금을 기다리는 일이 없도록 서비스
+// 시작 시에 호출하면 됩니다. 인수로 reflect.Type을 전달할 수도 있으며, 포인터 타입이면 가리키는
+// 타입도 함께 생성합니다.
+//
+// 인코딩이나 디코딩을 지원하지 않는 타입이 있으면 첫 번째 오류를 반환합니다. 이 경우에도 다른
+// 타입은 모두 캐시에 추가됩니다.
+func Precompile(types ...interface{}) error {
+	rtypes := make([]reflect.Type, 0, len(types))
+	for _, v := range types {
+		typ, ok := v.(reflect.Type)
+		if !ok {
+			typ = reflect.TypeOf(v)
+		}
+		if typ == nil {
+			return fmt.Errorf("rlp: cannot precompile nil type")
+		}
+		rtypes = append(rtypes, typ)
+		if typ.Kind() == reflect.Ptr {
+			rtypes = append(rtypes, typ.Elem())
+		}
+	}
+	for i, info := range theTC.generateAll(rtypes) {
+		if info.writerErr != nil {
+			return fmt.Errorf("rlp: type %v: %w", rtypes[i], info.writerErr)
+		}
+		if info.decoderErr != nil {
+			return fmt.Errorf("rlp: type %v: %w", rtypes[i], info.decoderErr)
+		}
+	}
+	return nil
+}
+
+// CachedTypes는 현재 타입 캐시에 있는 모든 타입을 이름 순으로 정렬하여 반환합니다. 구조체
+// 필드로만 사용되어 태그별로 생성된 항목은 포함하지 않습니다. 캐시의 크기를 모니터링하는 데
+// 사용할 수 있습니다.
+func CachedTypes() []reflect.Type {
+	types := theTC.types()
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+	return types
+}
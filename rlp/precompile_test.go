@@ -0,0 +1,77 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type precompileTestStruct struct {
+	A uint
+	B []byte
+}
+
+type precompileTestPtrStruct struct {
+	C string
+}
+
+func TestPrecompile(t *testing.T) {
+	if err := Precompile(precompileTestStruct{}, reflect.TypeOf(new(precompileTestPtrStruct))); err != nil {
+		t.Fatal(err)
+	}
+	cached := make(map[reflect.Type]bool)
+	for _, typ := range CachedTypes() {
+		cached[typ] = true
+	}
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(precompileTestStruct{}),
+		reflect.TypeOf(new(precompileTestPtrStruct)),
+		reflect.TypeOf(precompileTestPtrStruct{}),
+	} {
+		if !cached[typ] {
+			t.Errorf("type %v not cached", typ)
+		}
+	}
+	if err := Precompile(struct{ F float64 }{}); err == nil {
+		t.Fatal("no error for unsupported type")
+	}
+	if err := Precompile(nil); err == nil {
+		t.Fatal("no error for nil type")
+	}
+}
+
+func TestPrecompileConcurrent(t *testing.T) {
+	type concurrentStruct struct{ A, B uint64 }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := Precompile(concurrentStruct{}); err != nil {
+				t.Error(err)
+			}
+			var out concurrentStruct
+			if err := DecodeBytes([]byte{0xC2, 0x01, 0x02}, &out); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
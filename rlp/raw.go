@@ -287,3 +287,17 @@ func AppendUint64(b []byte, i uint64) []byte {
 		)
 	}
 }
+
+// AppendListHeader는 내용의 크기가 contentSize인 RLP 리스트의 헤더를 b에 추가하고 결과 슬라이스를
+// 반환합니다. 내용은 호출자가 이어서 써야 합니다.
+func AppendListHeader(b []byte, contentSize uint64) []byte {
+	var buf [9]byte
+	return append(b, buf[:puthead(buf[:], 0xC0, 0xF7, contentSize)]...)
+}
+
+// AppendStringHeader는 길이가 size인 RLP 문자열의 헤더를 b에 추가하고 결과 슬라이스를 반환합니다.
+// 길이가 1이고 값이 0x80 미만인 문자열은 헤더 없이 인코딩되므로 이 함수를 사용할 수 없습니다.
+func AppendStringHeader(b []byte, size uint64) []byte {
+	var buf [9]byte
+	return append(b, buf[:puthead(buf[:], 0x80, 0xB7, size)]...)
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// copyBufferSize는 CopyValue가 값의 내용을 전달할 때 사용하는 버퍼의 최대 크기입니다.
+const copyBufferSize = 32 * 1024
+
+var (
+	_ io.WriterTo   = RawValue(nil)
+	_ io.ReaderFrom = (*RawValue)(nil)
+)
+
+// WriteTo는 io.WriterTo를 구현합니다. 인코딩된 값을 그대로 w에 씁니다.
+func (v RawValue) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(v)
+	return int64(n), err
+}
+
+// ReadFrom은 io.ReaderFrom을 구현합니다. r에서 정확히 하나의 RLP 값을 읽어 v에 저장하며,
+// 값 이후의 바이트는 읽지 않습니다. 값의 크기는 헤더에서 결정되고, 버퍼는 실제로 도착한
+// 데이터만큼만 커지므로 헤더에 큰 크기가 적혀 있더라도 미리 할당하지 않습니다.
+//
+// 헤더의 크기 정보는 Stream과 같은 규칙으로 정규 형식인지 검사하지만, 리스트의 내용은
+// 검증하지 않습니다. 입력이 값의 끝 이전에 끝나면 io.ErrUnexpectedEOF를 반환합니다.
+func (v *RawValue) ReadFrom(r io.Reader) (int64, error) {
+	var head [9]byte
+	if _, err := io.ReadFull(r, head[:1]); err != nil {
+		return 0, err
+	}
+	b := head[0]
+	var size uint64
+	hs := 1
+	switch {
+	case b < 0x80:
+		*v = append((*v)[:0], b)
+		return 1, nil
+	case b < 0xB8:
+		size = uint64(b - 0x80)
+	case b < 0xC0:
+		hs += int(b - 0xB7)
+	case b < 0xF8:
+		size = uint64(b - 0xC0)
+	default:
+		hs += int(b - 0xF7)
+	}
+	if hs > 1 {
+		if n, err := io.ReadFull(r, head[1:hs]); err != nil {
+			return int64(1 + n), unexpectedEOF(err)
+		}
+		if head[1] == 0 {
+			return int64(hs), ErrCanonSize
+		}
+		var sbuf [8]byte
+		copy(sbuf[8-(hs-1):], head[1:hs])
+		if size = binary.BigEndian.Uint64(sbuf[:]); size < 56 {
+			return int64(hs), ErrCanonSize
+		}
+		if size > math.MaxInt64 {
+			return int64(hs), ErrValueTooLarge
+		}
+	}
+
+	buf := bytes.NewBuffer((*v)[:0])
+	buf.Write(head[:hs])
+	n, err := io.CopyN(buf, r, int64(size))
+	total := int64(hs) + n
+	if err != nil {
+		return total, unexpectedEOF(err)
+	}
+	if b == 0x81 && buf.Bytes()[1] < 0x80 {
+		return total, ErrCanonSize
+	}
+	*v = buf.Bytes()
+	return total, nil
+}
+
+// CopyValue는 src의 다음 값을 디코딩하지 않고 인코딩된 그대로 dst에 씁니다. 값의 내용은
+// 고정 크기 버퍼를 통해 전달되므로 큰 값을 전달할 때에도 값 전체를 메모리에 올리지 않습니다.
+// 반환 값은 dst에 쓴 바이트 수입니다.
+//
+// Raw와 마찬가지로 원래 헤더는 이미 사용되었으므로 같은 형식의 헤더를 다시 만들어 씁니다.
+// 스트림의 입력 제한과 리스트 크기는 값을 읽을 때와 동일하게 검사됩니다.
+func CopyValue(dst io.Writer, src *Stream) (int64, error) {
+	kind, size, err := src.Kind()
+	if err != nil {
+		return 0, err
+	}
+	var head [9]byte
+	switch kind {
+	case Byte:
+		src.kind = -1 // Kind 재설정
+		head[0] = src.byteval
+		n, err := dst.Write(head[:1])
+		return int64(n), err
+	case String:
+		hs := puthead(head[:], 0x80, 0xB7, size)
+		return copyContent(dst, src, head[:hs], size)
+	default:
+		hs := puthead(head[:], 0xC0, 0xF7, size)
+		return copyContent(dst, src, head[:hs], size)
+	}
+}
+
+// copyContent는 head를 dst에 쓴 다음 src에서 size 바이트의 내용을 읽어 dst로 전달합니다.
+func copyContent(dst io.Writer, src *Stream, head []byte, size uint64) (int64, error) {
+	if size == 0 {
+		src.kind = -1 // 읽을 내용이 없으므로 Kind를 직접 재설정합니다.
+	}
+	n, err := dst.Write(head)
+	total := int64(n)
+	if err != nil {
+		return total, err
+	}
+	bufsize := uint64(copyBufferSize)
+	if size < bufsize {
+		bufsize = size
+	}
+	buf := make([]byte, bufsize)
+	for size > 0 {
+		chunk := buf
+		if size < uint64(len(chunk)) {
+			chunk = chunk[:size]
+		}
+		if err := src.readFull(chunk); err != nil {
+			return total, err
+		}
+		n, err := dst.Write(chunk)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+		size -= uint64(len(chunk))
+	}
+	return total, nil
+}
+
+// unexpectedEOF는 값의 중간에서 발생한 io.EOF를 io.ErrUnexpectedEOF로 바꿉니다.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
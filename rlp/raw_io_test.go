@@ -0,0 +1,142 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var rawIOTests = []string{
+	"0x00",
+	"0x7F",
+	"0x80",
+	"0x8180",
+	"0x83646F67",
+	"0xC0",
+	"0xC3010203",
+	"0xB838" + strings.Repeat("61", 56),
+	"0xF83C" + "B83A" + strings.Repeat("61", 58),
+}
+
+func TestRawValueReadFrom(t *testing.T) {
+	for _, test := range rawIOTests {
+		input := hexutil.MustDecode(test)
+		// Append trailing data which must not be consumed.
+		r := bytes.NewReader(append(append([]byte(nil), input...), 0xFF, 0xFF))
+		var v RawValue
+		n, err := v.ReadFrom(r)
+		if err != nil {
+			t.Errorf("%s: error %v", test, err)
+			continue
+		}
+		if n != int64(len(input)) || !bytes.Equal(v, input) {
+			t.Errorf("%s: wrong result: n=%d value=%x", test, n, v)
+		}
+		if r.Len() != 2 {
+			t.Errorf("%s: read past end of value, %d bytes left", test, r.Len())
+		}
+		var out bytes.Buffer
+		if n, err := v.WriteTo(&out); err != nil || n != int64(len(input)) || !bytes.Equal(out.Bytes(), input) {
+			t.Errorf("%s: WriteTo mismatch: n=%d err=%v out=%x", test, n, err, out.Bytes())
+		}
+	}
+}
+
+func TestRawValueReadFromErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"0x", io.EOF},
+		{"0x83646F", io.ErrUnexpectedEOF},
+		{"0xB9", io.ErrUnexpectedEOF},
+		{"0x8105", ErrCanonSize},
+		{"0xB801", ErrCanonSize},
+		{"0xB90002", ErrCanonSize},
+		{"0xF801", ErrCanonSize},
+		{"0xBFFFFFFFFFFFFFFFFF", ErrValueTooLarge},
+	}
+	for _, test := range tests {
+		var v RawValue
+		_, err := v.ReadFrom(bytes.NewReader(hexutil.MustDecode(test.input)))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: wrong error: have %v, want %v", test.input, err, test.err)
+		}
+	}
+}
+
+func TestCopyValue(t *testing.T) {
+	var input []byte
+	for _, test := range rawIOTests {
+		input = append(input, hexutil.MustDecode(test)...)
+	}
+	s := NewStream(bytes.NewReader(input), 0)
+	var out bytes.Buffer
+	for i, test := range rawIOTests {
+		n, err := CopyValue(&out, s)
+		if err != nil {
+			t.Fatalf("value %d (%s): error %v", i, test, err)
+		}
+		if want := len(test)/2 - 1; n != int64(want) {
+			t.Errorf("value %d (%s): wrote %d bytes, want %d", i, test, n, want)
+		}
+	}
+	if _, err := CopyValue(&out, s); err != io.EOF {
+		t.Fatalf("wrong error at end of input: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), input) {
+		t.Fatalf("output mismatch:\nhave %x\nwant %x", out.Bytes(), input)
+	}
+}
+
+func TestCopyValueInList(t *testing.T) {
+	input := hexutil.MustDecode("0xC58180C20102")
+	s := NewStream(bytes.NewReader(input), 0)
+	if _, err := s.List(); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	for {
+		if _, err := CopyValue(&out, s); err == EOL {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.ListEnd(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), input[1:]) {
+		t.Fatalf("output mismatch: have %x, want %x", out.Bytes(), input[1:])
+	}
+
+	// Values exceeding the input limit are rejected before anything is written.
+	s = NewStream(bytes.NewReader(hexutil.MustDecode("0x83646F67")), 3)
+	out.Reset()
+	if _, err := CopyValue(&out, s); err != ErrValueTooLarge {
+		t.Fatalf("wrong error for oversized value: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("output written for oversized value: %x", out.Bytes())
+	}
+}
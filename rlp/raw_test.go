@@ -336,3 +336,22 @@ func TestBytesSize(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendHeaders(t *testing.T) {
+	for _, size := range []uint64{0, 1, 55, 56, 255, 256, 131072, 1 << 32} {
+		list := AppendListHeader([]byte{1}, size)
+		if want := ListSize(size) - size + 1; uint64(len(list)) != want {
+			t.Errorf("AppendListHeader(%d): wrong length %d, want %d", size, len(list), want)
+		}
+		str := AppendStringHeader(nil, size)
+		if len(str) != len(list)-1 || str[0]-0x80 != list[1]-0xC0 || !bytes.Equal(str[1:], list[2:]) {
+			t.Errorf("AppendStringHeader(%d): got %x, list header %x", size, str, list[1:])
+		}
+		if size > 1 && size <= 1024 {
+			enc, _ := EncodeToBytes(make([]byte, size))
+			if !bytes.Equal(enc[:len(str)], str) {
+				t.Errorf("AppendStringHeader(%d): got %x, want %x", size, str, enc[:len(str)])
+			}
+		}
+	}
+}
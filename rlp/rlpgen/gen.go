@@ -181,10 +181,12 @@ func (*buildContext) makeBasicOp(typ *types.Basic) (op, error) {
 		op.decResultType = typ
 		op.decUseBitSize = true
 	case kind == types.String:
+		// rlp.Stream has no string decoding method, so strings are decoded
+		// as byte slices and converted.
 		op.writeMethod = "WriteString"
 		op.writeArgType = types.Typ[types.String]
-		op.decMethod = "String"
-		op.decResultType = types.Typ[types.String]
+		op.decMethod = "Bytes"
+		op.decResultType = types.NewSlice(types.Typ[types.Uint8])
 	default:
 		return nil, fmt.Errorf("unhandled basic type: %v", typ)
 	}
@@ -221,7 +223,9 @@ func (op basicOp) writeNeedsConversion() bool {
 }
 
 func (op basicOp) decodeNeedsConversion() bool {
-	return !types.AssignableTo(op.decResultType, op.typ)
+	// Assignability is not enough here because the result may be used through
+	// a pointer, e.g. for *rlp.RawValue.
+	return !types.Identical(op.decResultType, op.typ)
 }
 
 func (op basicOp) genWrite(ctx *genContext, v string) string {
@@ -378,11 +382,32 @@ func (op uint256Op) genDecode(ctx *genContext) (string, string) {
 // This restriction may be lifted in the future by creating separate ops for
 // encoding and decoding.
 type encoderDecoderOp struct {
-	typ types.Type
+	typ      types.Type
+	nilOK    bool
+	nilValue rlpstruct.NilKind
+}
+
+func (bctx *buildContext) makeEncoderDecoderOp(typ *types.Pointer, tags rlpstruct.Tags) op {
+	op := encoderDecoderOp{typ: typ}
+	if tags.NilOK {
+		op.nilOK = true
+		op.nilValue = tags.NilKind
+	} else {
+		op.nilValue = bctx.typeToStructType(typ.Elem()).DefaultNilValue()
+	}
+	return op
 }
 
 func (op encoderDecoderOp) genWrite(ctx *genContext, v string) string {
-	return fmt.Sprintf("if err := %s.EncodeRLP(w); err != nil { return err }\n", v)
+	// Like the reflection encoder, nil pointers are written as the empty value
+	// instead of calling EncodeRLP on them.
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "if %s == nil {\n", v)
+	fmt.Fprintf(&b, "  w.Write([]byte{0x%X})\n", op.nilValue)
+	fmt.Fprintf(&b, "} else {\n")
+	fmt.Fprintf(&b, "  if err := %s.EncodeRLP(w); err != nil { return err }\n", v)
+	fmt.Fprintf(&b, "}\n")
+	return b.String()
 }
 
 func (op encoderDecoderOp) genDecode(ctx *genContext) (string, string) {
@@ -393,7 +418,10 @@ func (op encoderDecoderOp) genDecode(ctx *genContext) (string, string) {
 	var b bytes.Buffer
 	fmt.Fprintf(&b, "%s := new(%s)\n", resultV, types.TypeString(etyp, ctx.qualify))
 	fmt.Fprintf(&b, "if err := %s.DecodeRLP(dec); err != nil { return err }\n", resultV)
-	return resultV, b.String()
+	if !op.nilOK {
+		return resultV, b.String()
+	}
+	return genNilCheckDecode(ctx, op.typ, op.nilValue, resultV, b.String())
 }
 
 // ptrOp handles pointer types.
@@ -453,28 +481,38 @@ func (op ptrOp) genDecode(ctx *genContext) (string, string) {
 		// If nil pointers are not allowed, we can just decode the element.
 		return "&" + result, code
 	}
+	return genNilCheckDecode(ctx, types.NewPointer(op.elemTyp), op.nilValue, "&"+result, code)
+}
 
-	// nil is allowed, so check the kind and size first.
-	// If size is zero and kind matches the nilKind of the type,
-	// the value decodes as a nil pointer.
+// genNilCheckDecode wraps the decoder code of a pointer-typed value, which stores
+// its result in the expression 'result', with a check for the nil value.
+// If size is zero and kind matches the nilKind of the type, the value decodes
+// as a nil pointer. The empty value must still be consumed from the input.
+func genNilCheckDecode(ctx *genContext, ptrTyp types.Type, nilValue rlpstruct.NilKind, result, code string) (string, string) {
 	var (
 		resultV  = ctx.temp()
 		kindV    = ctx.temp()
 		sizeV    = ctx.temp()
 		wantKind string
+		skipCode string
 	)
-	if op.nilValue == rlpstruct.NilKindList {
+	if nilValue == rlpstruct.NilKindList {
 		wantKind = "rlp.List"
+		skipCode = "if _, err := dec.List(); err != nil { return err }\n" +
+			"if err := dec.ListEnd(); err != nil { return err }\n"
 	} else {
 		wantKind = "rlp.String"
+		skipCode = "if _, err := dec.Bytes(); err != nil { return err }\n"
 	}
 	var b bytes.Buffer
-	fmt.Fprintf(&b, "var %s %s\n", resultV, types.TypeString(types.NewPointer(op.elemTyp), ctx.qualify))
+	fmt.Fprintf(&b, "var %s %s\n", resultV, types.TypeString(ptrTyp, ctx.qualify))
 	fmt.Fprintf(&b, "if %s, %s, err := dec.Kind(); err != nil {\n", kindV, sizeV)
 	fmt.Fprintf(&b, "  return err\n")
 	fmt.Fprintf(&b, "} else if %s != 0 || %s != %s {\n", sizeV, kindV, wantKind)
 	fmt.Fprint(&b, code)
-	fmt.Fprintf(&b, "  %s = &%s\n", resultV, result)
+	fmt.Fprintf(&b, "  %s = %s\n", resultV, result)
+	fmt.Fprintf(&b, "} else {\n")
+	fmt.Fprint(&b, skipCode)
 	fmt.Fprintf(&b, "}\n")
 	return resultV, b.String()
 }
@@ -485,6 +523,7 @@ type structOp struct {
 	typ            *types.Struct
 	fields         []*structField
 	optionalFields []*structField
+	tailField      *structField // slice holding all remaining list elements
 }
 
 type structField struct {
@@ -516,33 +555,26 @@ func (bctx *buildContext) makeStructOp(named *types.Named, typ *types.Struct) (o
 	// Create field ops.
 	var op = structOp{named: named, typ: typ}
 	for i, field := range fields {
-		// Advanced struct tags are not supported yet.
 		tag := tags[i]
-		if err := checkUnsupportedTags(field.Name, tag); err != nil {
-			return nil, err
-		}
 		typ := typ.Field(field.Index).Type()
-		elem, err := bctx.makeOp(nil, typ, tags[i])
+		elem, err := bctx.makeOp(nil, typ, tag)
 		if err != nil {
 			return nil, fmt.Errorf("field %s: %v", field.Name, err)
 		}
 		f := &structField{name: field.Name, typ: typ, elem: elem}
-		if tag.Optional {
+		switch {
+		case tag.Tail:
+			// ProcessFields ensures this is the last field.
+			op.tailField = f
+		case tag.Optional:
 			op.optionalFields = append(op.optionalFields, f)
-		} else {
+		default:
 			op.fields = append(op.fields, f)
 		}
 	}
 	return op, nil
 }
 
-func checkUnsupportedTags(field string, tag rlpstruct.Tags) error {
-	if tag.Tail {
-		return fmt.Errorf(`field %s has unsupported struct tag "tail"`, field)
-	}
-	return nil
-}
-
 func (op structOp) genWrite(ctx *genContext, v string) string {
 	var b bytes.Buffer
 	var listMarker = ctx.temp()
@@ -552,6 +584,11 @@ func (op structOp) genWrite(ctx *genContext, v string) string {
 		fmt.Fprint(&b, field.elem.genWrite(ctx, selector))
 	}
 	op.writeOptionalFields(&b, ctx, v)
+	if op.tailField != nil {
+		// The tail slice is written without a list header, so writing an empty
+		// slice doesn't output anything.
+		fmt.Fprint(&b, op.tailField.elem.genWrite(ctx, v+"."+op.tailField.name))
+	}
 	fmt.Fprintf(&b, "w.ListEnd(%s)\n", listMarker)
 	return b.String()
 }
@@ -560,9 +597,14 @@ func (op structOp) writeOptionalFields(b *bytes.Buffer, ctx *genContext, v strin
 	if len(op.optionalFields) == 0 {
 		return
 	}
-	// First check zero-ness of all optional fields.
-	var zeroV = make([]string, len(op.optionalFields))
-	for i, field := range op.optionalFields {
+	// First check zero-ness of all optional fields. A non-empty tail also
+	// requires all optional fields to be present.
+	checked := op.optionalFields
+	if op.tailField != nil {
+		checked = append(checked[:len(checked):len(checked)], op.tailField)
+	}
+	var zeroV = make([]string, len(checked))
+	for i, field := range checked {
 		selector := v + "." + field.name
 		zeroV[i] = ctx.temp()
 		fmt.Fprintf(b, "%s := %s\n", zeroV[i], nonZeroCheck(selector, field.typ, ctx.qualify))
@@ -571,7 +613,7 @@ func (op structOp) writeOptionalFields(b *bytes.Buffer, ctx *genContext, v strin
 	for i, field := range op.optionalFields {
 		selector := v + "." + field.name
 		cond := ""
-		for j := i; j < len(op.optionalFields); j++ {
+		for j := i; j < len(checked); j++ {
 			if j > i {
 				cond += " || "
 			}
@@ -609,6 +651,12 @@ func (op structOp) genDecode(ctx *genContext) (string, string) {
 		fmt.Fprintf(&b, "%s.%s = %s\n", resultV, field.name, result)
 	}
 	op.decodeOptionalFields(&b, ctx, resultV)
+	if op.tailField != nil {
+		result, code := op.tailField.elem.genDecode(ctx)
+		fmt.Fprintf(&b, "// %s:\n", op.tailField.name)
+		fmt.Fprint(&b, code)
+		fmt.Fprintf(&b, "%s.%s = %s\n", resultV, op.tailField.name, result)
+	}
 	fmt.Fprintf(&b, "if err := dec.ListEnd(); err != nil { return err }\n")
 	fmt.Fprintf(&b, "}\n")
 	return resultV, b.String()
@@ -631,14 +679,15 @@ func (op structOp) decodeOptionalFields(b *bytes.Buffer, ctx *genContext, result
 type sliceOp struct {
 	typ    *types.Slice
 	elemOp op
+	tail   bool // elements are part of the enclosing struct list ("tail" tag)
 }
 
-func (bctx *buildContext) makeSliceOp(typ *types.Slice) (op, error) {
+func (bctx *buildContext) makeSliceOp(typ *types.Slice, tags rlpstruct.Tags) (op, error) {
 	elemOp, err := bctx.makeOp(nil, typ.Elem(), rlpstruct.Tags{})
 	if err != nil {
 		return nil, err
 	}
-	return sliceOp{typ: typ, elemOp: elemOp}, nil
+	return sliceOp{typ: typ, elemOp: elemOp, tail: tags.Tail}, nil
 }
 
 func (op sliceOp) genWrite(ctx *genContext, v string) string {
@@ -649,11 +698,15 @@ func (op sliceOp) genWrite(ctx *genContext, v string) string {
 	)
 
 	var b bytes.Buffer
-	fmt.Fprintf(&b, "%s := w.List()\n", listMarker)
+	if !op.tail {
+		fmt.Fprintf(&b, "%s := w.List()\n", listMarker)
+	}
 	fmt.Fprintf(&b, "for _, %s := range %s {\n", iterElemV, v)
 	fmt.Fprint(&b, elemCode)
 	fmt.Fprintf(&b, "}\n")
-	fmt.Fprintf(&b, "w.ListEnd(%s)\n", listMarker)
+	if !op.tail {
+		fmt.Fprintf(&b, "w.ListEnd(%s)\n", listMarker)
+	}
 	return b.String()
 }
 
@@ -662,13 +715,19 @@ func (op sliceOp) genDecode(ctx *genContext) (string, string) {
 	elemResult, elemCode := op.elemOp.genDecode(ctx)
 
 	var b bytes.Buffer
-	fmt.Fprintf(&b, "var %s %s\n", sliceV, types.TypeString(op.typ, ctx.qualify))
-	fmt.Fprintf(&b, "if _, err := dec.List(); err != nil { return err }\n")
+	// The reflection decoder always produces a non-nil slice, even when the
+	// list is empty. Do the same here so decoded values compare equal.
+	fmt.Fprintf(&b, "%s := make(%s, 0)\n", sliceV, types.TypeString(op.typ, ctx.qualify))
+	if !op.tail {
+		fmt.Fprintf(&b, "if _, err := dec.List(); err != nil { return err }\n")
+	}
 	fmt.Fprintf(&b, "for dec.MoreDataInList() {\n")
 	fmt.Fprintf(&b, "  %s", elemCode)
 	fmt.Fprintf(&b, "  %s = append(%s, %s)\n", sliceV, sliceV, elemResult)
 	fmt.Fprintf(&b, "}\n")
-	fmt.Fprintf(&b, "if err := dec.ListEnd(); err != nil { return err }\n")
+	if !op.tail {
+		fmt.Fprintf(&b, "if err := dec.ListEnd(); err != nil { return err }\n")
+	}
 	return sliceV, b.String()
 }
 
@@ -699,7 +758,7 @@ func (bctx *buildContext) makeOp(name *types.Named, typ types.Type, tags rlpstru
 		// Encoder/Decoder interfaces.
 		if bctx.isEncoder(typ) {
 			if bctx.isDecoder(typ) {
-				return encoderDecoderOp{typ}, nil
+				return bctx.makeEncoderDecoderOp(typ, tags), nil
 			}
 			return nil, fmt.Errorf("type %v implements rlp.Encoder but not rlp.Decoder", typ)
 		}
@@ -717,7 +776,7 @@ func (bctx *buildContext) makeOp(name *types.Named, typ types.Type, tags rlpstru
 		if isByte(etyp) && !bctx.isEncoder(etyp) {
 			return bctx.makeByteSliceOp(typ), nil
 		}
-		return bctx.makeSliceOp(typ)
+		return bctx.makeSliceOp(typ, tags)
 	case *types.Array:
 		etyp := typ.Elem()
 		if isByte(etyp) && !bctx.isEncoder(etyp) {
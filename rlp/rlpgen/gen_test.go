@@ -25,6 +25,7 @@ import (
 	"go/token"
 	"go/types"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -47,7 +48,7 @@ func init() {
 	}
 }
 
-var tests = []string{"uints", "nil", "rawvalue", "optional", "bigint", "uint256"}
+var tests = []string{"uints", "nil", "rawvalue", "optional", "bigint", "uint256", "tail", "encoder"}
 
 func TestOutput(t *testing.T) {
 	for _, test := range tests {
@@ -81,6 +82,57 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+// TestOutputMatchesReflection compiles the generated test outputs and checks them
+// against the reflection-based encoder and decoder using package rlptest.
+func TestOutputMatchesReflection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of generated code in short mode")
+	}
+	// The packages are created inside the module, so they can import package rlp.
+	// The leading underscore hides them from ./... patterns.
+	dir, err := os.MkdirTemp(".", "_rlpgencheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	driver, err := os.ReadFile(filepath.Join("testdata", "check_test.go.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"test"}
+	for _, test := range tests {
+		if test == "rawvalue" {
+			continue // zero and random RawValues are not valid RLP
+		}
+		pkgdir := filepath.Join(dir, test)
+		if err := os.Mkdir(pkgdir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		files := map[string]string{
+			filepath.Join("testdata", test+".in.txt"):  "input.go",
+			filepath.Join("testdata", test+".out.txt"): "output.go",
+		}
+		for src, dst := range files {
+			content, err := os.ReadFile(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(pkgdir, dst), content, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(pkgdir, "check_test.go"), driver, 0644); err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, "./"+filepath.ToSlash(pkgdir))
+	}
+	cmd := exec.Command("go", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated code check failed: %v\n%s", err, output)
+	}
+}
+
 func loadTestSource(file string, typeName string) (*buildContext, *types.Named, error) {
 	// Load the test input.
 	content, err := os.ReadFile(file)
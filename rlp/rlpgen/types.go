@@ -81,7 +81,9 @@ func nonZeroCheck(v string, vtyp types.Type, qualify types.Qualifier) string {
 	case *types.Interface, *types.Pointer, *types.Signature:
 		return fmt.Sprintf("%s != nil", v)
 	case *types.Slice, *types.Map:
-		return fmt.Sprintf("len(%s) > 0", v)
+		// Like reflect.Value.IsZero, which is used by the reflection encoder,
+		// an empty non-nil slice is not zero.
+		return fmt.Sprintf("%s != nil", v)
 	default:
 		panic(fmt.Errorf("unhandled type %T", typ))
 	}
@@ -0,0 +1,73 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlptest
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// divergentEncoder has an EncodeRLP method which does not match its struct layout.
+type divergentEncoder struct {
+	A, B uint64
+}
+
+func (d *divergentEncoder) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []uint64{d.B, d.A})
+}
+
+func TestCheck(t *testing.T) {
+	baseFee := big.NewInt(7)
+	values := []interface{}{
+		uint64(5),
+		[]byte{1, 2, 3},
+		&types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(2), BaseFee: baseFee},
+		types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(2)},
+		&types.Withdrawal{Index: 1, Validator: 2, Address: common.Address{3}, Amount: 4},
+		&types.Log{Address: common.Address{1}, Topics: []common.Hash{{2}}, Data: []byte{3}, BlockNumber: 100},
+	}
+	for _, val := range values {
+		if err := Check(val); err != nil {
+			t.Errorf("%T: %v", val, err)
+		}
+	}
+
+	var mismatch *MismatchError
+	if err := Check(&divergentEncoder{A: 1, B: 2}); !errors.As(err, &mismatch) || mismatch.Path != "reflection" {
+		t.Fatalf("divergent encoder not detected: %v", err)
+	}
+	if err := Check(nil); err == nil {
+		t.Fatal("no error for nil value")
+	}
+}
+
+func FuzzHeader(f *testing.F) {
+	enc, _ := rlp.EncodeToBytes(&types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)})
+	f.Add(enc)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := Fuzz(data, reflect.TypeOf(types.Header{})); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
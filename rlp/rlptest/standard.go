@@ -0,0 +1,123 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlptest
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// standardVector는 이더리움 공통 테스트(RLPTests/rlptest.json)의 항목 하나입니다.
+type standardVector struct {
+	name string
+	in   func() interface{}
+	out  string
+}
+
+var standardVectors = []standardVector{
+	{"emptystring", str(""), "0x80"},
+	{"bytestring00", str("\x00"), "0x00"},
+	{"bytestring01", str("\x01"), "0x01"},
+	{"bytestring7F", str("\x7f"), "0x7f"},
+	{"shortstring", str("dog"), "0x83646f67"},
+	{"shortstring2", str("Lorem ipsum dolor sit amet, consectetur adipisicing eli"), "0xb74c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c69"},
+	{"longstring", str("Lorem ipsum dolor sit amet, consectetur adipisicing elit"), "0xb8384c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c6974"},
+	{"zero", num(0), "0x80"},
+	{"smallint", num(1), "0x01"},
+	{"smallint2", num(16), "0x10"},
+	{"smallint3", num(79), "0x4f"},
+	{"smallint4", num(127), "0x7f"},
+	{"mediumint1", num(128), "0x8180"},
+	{"mediumint2", num(1000), "0x8203e8"},
+	{"mediumint3", num(100000), "0x830186a0"},
+	{"mediumint4", bignum("83729609699884896815286331701780722"), "0x8f102030405060708090a0b0c0d0e0f2"},
+	{"mediumint5", bignum("105315505618206987246253880190783558935785933862974822347068935681"), "0x9c0100020003000400050006000700080009000a000b000c000d000e01"},
+	{"emptylist", list(), "0xc0"},
+	{"stringlist", list(str("dog"), str("god"), str("cat")), "0xcc83646f6783676f6483636174"},
+	{"multilist", list(str("zw"), list(num(4)), num(1)), "0xc6827a77c10401"},
+	{"shortListMax1", list(str("asdf"), str("qwer"), str("zxcv"), str("asdf"), str("qwer"), str("zxcv"), str("asdf"), str("qwer"), str("zxcv"), str("asdf"), str("qwer")),
+		"0xf784617364668471776572847a78637684617364668471776572847a78637684617364668471776572847a78637684617364668471776572"},
+	{"longList1", repeat(4, list(str("asdf"), str("qwer"), str("zxcv"))), "0xf840" + strings.Repeat("cf84617364668471776572847a786376", 4)},
+	{"longList2", repeat(32, list(str("asdf"), str("qwer"), str("zxcv"))), "0xf90200" + strings.Repeat("cf84617364668471776572847a786376", 32)},
+	{"listsoflists", list(list(list(), list()), list()), "0xc4c2c0c0c0"},
+	{"listsoflists2", list(list(), list(list()), list(list(), list(list()))), "0xc7c0c1c0c3c0c1c0"},
+	{"dictTest1", list(list(str("key1"), str("val1")), list(str("key2"), str("val2")), list(str("key3"), str("val3")), list(str("key4"), str("val4"))),
+		"0xecca846b6579318476616c31ca846b6579328476616c32ca846b6579338476616c33ca846b6579348476616c34"},
+	{"bigint", bignum("115792089237316195423570985008687907853269984665640564039457584007913129639936"), "0xa1010000000000000000000000000000000000000000000000000000000000000000"},
+}
+
+// StandardCorpus는 이더리움 공통 테스트의 RLP 테스트 벡터에 포함된 값들을 담은 Corpus를
+// 반환합니다. 문자열은 string, 정수는 uint64 또는 *big.Int, 리스트는 []interface{}로
+// 표현됩니다.
+func StandardCorpus() *Corpus {
+	c := NewCorpus()
+	for _, v := range standardVectors {
+		if err := c.Add(v.name, v.in()); err != nil {
+			panic(err)
+		}
+	}
+	return c
+}
+
+// StandardVectors는 StandardCorpus의 값들에 대한 이더리움 공통 테스트의 기대 인코딩을
+// 반환합니다.
+func StandardVectors() []Vector {
+	vectors := make([]Vector, len(standardVectors))
+	for i, v := range standardVectors {
+		vectors[i] = Vector{Name: v.name, Type: fmt.Sprintf("%T", v.in()), Out: hexutil.MustDecode(v.out)}
+	}
+	return vectors
+}
+
+func str(s string) func() interface{} {
+	return func() interface{} { return s }
+}
+
+func num(n uint64) func() interface{} {
+	return func() interface{} { return n }
+}
+
+func bignum(s string) func() interface{} {
+	return func() interface{} {
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			panic("invalid number " + s)
+		}
+		return n
+	}
+}
+
+func list(elems ...func() interface{}) func() interface{} {
+	return func() interface{} {
+		l := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			l[i] = elem()
+		}
+		return l
+	}
+}
+
+func repeat(n int, elem func() interface{}) func() interface{} {
+	elems := make([]func() interface{}, n)
+	for i := range elems {
+		elems[i] = elem
+	}
+	return list(elems...)
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Vector는 이름이 지정된 샘플 값의 골든 인코딩입니다.
+type Vector struct {
+	Name string        `json:"name"`
+	Type string        `json:"type"` // 샘플 값의 Go 타입 (참고용)
+	Out  hexutil.Bytes `json:"out"`  // 기대하는 RLP 인코딩
+}
+
+// Corpus는 골든 벡터로 내보내고 검증할 샘플 값의 모음입니다. 값은 등록 순서대로 유지되므로
+// 내보낸 벡터 파일은 항상 같은 내용을 가집니다.
+//
+// 릴리스마다 벡터 파일을 내보내 저장소에 보관하고, 테스트에서 VerifyFile로 현재 인코딩과
+// 비교하면 의도하지 않은 인코딩 변경을 찾을 수 있습니다.
+type Corpus struct {
+	names  []string
+	values map[string]interface{}
+}
+
+// NewCorpus는 빈 Corpus를 생성합니다.
+func NewCorpus() *Corpus {
+	return &Corpus{values: make(map[string]interface{})}
+}
+
+// Add는 name으로 샘플 값을 등록합니다. 같은 이름이 이미 등록되어 있으면 오류를 반환합니다.
+func (c *Corpus) Add(name string, val interface{}) error {
+	if _, ok := c.values[name]; ok {
+		return fmt.Errorf("rlptest: duplicate vector name %q", name)
+	}
+	if val == nil {
+		return fmt.Errorf("rlptest: nil value for vector %q", name)
+	}
+	c.names = append(c.names, name)
+	c.values[name] = val
+	return nil
+}
+
+// Names는 등록된 값의 이름을 등록 순서대로 반환합니다.
+func (c *Corpus) Names() []string {
+	return append([]string(nil), c.names...)
+}
+
+// Vectors는 등록된 모든 값을 인코딩하여 등록 순서대로 반환합니다.
+func (c *Corpus) Vectors() ([]Vector, error) {
+	vectors := make([]Vector, len(c.names))
+	for i, name := range c.names {
+		val := c.values[name]
+		enc, err := rlp.EncodeToBytes(val)
+		if err != nil {
+			return nil, fmt.Errorf("rlptest: vector %q: %w", name, err)
+		}
+		vectors[i] = Vector{Name: name, Type: fmt.Sprintf("%T", val), Out: enc}
+	}
+	return vectors, nil
+}
+
+// WriteFile은 등록된 값의 벡터를 JSON 형식으로 file에 씁니다.
+func (c *Corpus) WriteFile(file string) error {
+	vectors, err := c.Vectors()
+	if err != nil {
+		return err
+	}
+	enc, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(enc, '\n'), 0644)
+}
+
+// ReadVectorFile은 WriteFile로 내보낸 벡터 파일을 읽습니다.
+func ReadVectorFile(file string) ([]Vector, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var vectors []Vector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return nil, fmt.Errorf("rlptest: invalid vector file %s: %w", file, err)
+	}
+	return vectors, nil
+}
+
+// VectorError는 Verify가 발견한 벡터와 현재 인코딩 사이의 모든 차이를 담습니다.
+type VectorError struct {
+	Mismatch   []string // 인코딩 또는 디코딩 결과가 벡터와 다른 값의 이름과 원인
+	Missing    []string // 벡터 파일에 없는 등록된 값의 이름
+	Unexpected []string // 등록되지 않은 벡터의 이름
+}
+
+func (err *VectorError) Error() string {
+	var parts []string
+	if len(err.Mismatch) > 0 {
+		parts = append(parts, "mismatch: "+strings.Join(err.Mismatch, "; "))
+	}
+	if len(err.Missing) > 0 {
+		parts = append(parts, "missing vectors: "+strings.Join(err.Missing, ", "))
+	}
+	if len(err.Unexpected) > 0 {
+		parts = append(parts, "unknown vectors: "+strings.Join(err.Unexpected, ", "))
+	}
+	return "rlptest: " + strings.Join(parts, "\n")
+}
+
+// Verify는 등록된 값을 vectors와 비교합니다. 각 값은 벡터의 인코딩과 같게 인코딩되어야 하며,
+// 벡터의 인코딩을 값과 같은 타입으로 디코딩한 후 다시 인코딩한 결과도 같아야 합니다. 차이가
+// 있으면 모든 차이를 담은 *VectorError를 반환합니다.
+func (c *Corpus) Verify(vectors []Vector) error {
+	var (
+		verr = new(VectorError)
+		seen = make(map[string]bool, len(vectors))
+	)
+	for _, vec := range vectors {
+		val, ok := c.values[vec.Name]
+		if !ok {
+			verr.Unexpected = append(verr.Unexpected, vec.Name)
+			continue
+		}
+		seen[vec.Name] = true
+		if err := verifyVector(val, vec.Out); err != nil {
+			verr.Mismatch = append(verr.Mismatch, fmt.Sprintf("%s: %v", vec.Name, err))
+		}
+	}
+	for _, name := range c.names {
+		if !seen[name] {
+			verr.Missing = append(verr.Missing, name)
+		}
+	}
+	if len(verr.Mismatch) > 0 || len(verr.Missing) > 0 || len(verr.Unexpected) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// VerifyFile은 file의 벡터를 읽어 Verify를 수행합니다.
+func (c *Corpus) VerifyFile(file string) error {
+	vectors, err := ReadVectorFile(file)
+	if err != nil {
+		return err
+	}
+	return c.Verify(vectors)
+}
+
+// verifyVector는 val의 인코딩과 want의 디코딩 후 재인코딩 결과가 want와 같은지 확인합니다.
+func verifyVector(val interface{}, want []byte) error {
+	have, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return fmt.Errorf("encoding failed: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		return fmt.Errorf("encoding changed: have %x, want %x", have, want)
+	}
+	dec := reflect.New(reflect.TypeOf(val))
+	if err := rlp.DecodeBytes(want, dec.Interface()); err != nil {
+		return fmt.Errorf("decoding failed: %v", err)
+	}
+	reenc, err := rlp.EncodeToBytes(dec.Elem().Interface())
+	if err != nil {
+		return fmt.Errorf("re-encoding failed: %v", err)
+	}
+	if !bytes.Equal(reenc, want) {
+		return fmt.Errorf("round trip changed encoding: have %x, want %x", reenc, want)
+	}
+	return nil
+}
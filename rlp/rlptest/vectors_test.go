@@ -0,0 +1,74 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlptest
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestStandardVectors(t *testing.T) {
+	if err := StandardCorpus().Verify(StandardVectors()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCorpusFile(t *testing.T) {
+	c := NewCorpus()
+	c.Add("header", &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(2)})
+	c.Add("withdrawal", &types.Withdrawal{Index: 1, Validator: 2, Address: common.Address{3}, Amount: 4})
+	c.Add("uint", uint64(1024))
+	if err := c.Add("uint", uint64(1)); err == nil {
+		t.Fatal("no error for duplicate name")
+	}
+
+	file := filepath.Join(t.TempDir(), "vectors.json")
+	if err := c.WriteFile(file); err != nil {
+		t.Fatal(err)
+	}
+	vectors, err := ReadVectorFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := c.Vectors()
+	if !reflect.DeepEqual(vectors, want) {
+		t.Fatalf("vectors changed after writing to file\nhave %v\nwant %v", vectors, want)
+	}
+	if err := c.VerifyFile(file); err != nil {
+		t.Fatal(err)
+	}
+
+	// Modify the corpus to simulate encoding changes.
+	changed := NewCorpus()
+	changed.Add("header", &types.Header{Number: big.NewInt(2), Difficulty: big.NewInt(2)})
+	changed.Add("withdrawal", &types.Withdrawal{Index: 1, Validator: 2, Address: common.Address{3}, Amount: 4})
+	changed.Add("new", uint64(1))
+	err = changed.VerifyFile(file)
+	var verr *VectorError
+	if !errors.As(err, &verr) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if len(verr.Mismatch) != 1 || !reflect.DeepEqual(verr.Missing, []string{"new"}) || !reflect.DeepEqual(verr.Unexpected, []string{"uint"}) {
+		t.Fatalf("wrong differences: %+v", verr)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// scalarCodec은 RegisterScalar로 등록된 타입의 인코딩 및 디코딩 함수입니다.
+type scalarCodec struct {
+	encode func(reflect.Value) ([]byte, error)
+	decode func([]byte, reflect.Value) error
+}
+
+// scalars는 등록된 스칼라 타입의 map[reflect.Type]*scalarCodec을 담습니다. 등록은 드물고
+// 조회는 인코딩할 때마다 일어나므로 기록 시 복사 방식을 사용합니다.
+var scalars atomic.Value
+
+func init() {
+	scalars.Store(make(map[reflect.Type]*scalarCodec))
+}
+
+// RegisterScalar는 T를 스칼라 타입으로 등록합니다. 등록된 타입의 값은 encode가 반환하는
+// 바이트열을 내용으로 하는 RLP 문자열로 인코딩되며, 디코딩할 때는 문자열의 내용이 decode에
+// 전달됩니다. 등록 후에는 T와 *T가 구조체 필드, 슬라이스 요소 등 모든 위치에서 이 함수들로
+// 처리되므로, 고정 소수점 숫자나 초 단위 time.Time 같은 외부 타입을 감싸는 타입마다 Encoder와
+// Decoder를 구현할 필요가 없습니다. *T의 nil 값은 빈 문자열로 인코딩됩니다.
+//
+// T는 패키지에서 정의된 이름 있는 타입이어야 하며, 인터페이스이거나 Encoder 또는 Decoder를
+// 구현해서는 안 됩니다. 같은 타입을 두 번 등록할 수 없습니다. 등록은 보통 init 함수에서
+// 수행하며, 이미 캐시된 타입 정보는 등록 시 모두 삭제되어 다시 생성됩니다. rlpgen이 생성한
+// 코드는 등록된 스칼라를 사용하지 않습니다.
+func RegisterScalar[T any](encode func(T) ([]byte, error), decode func([]byte) (T, error)) error {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if encode == nil || decode == nil {
+		return fmt.Errorf("rlp: nil scalar function for %v", typ)
+	}
+	switch {
+	case typ.PkgPath() == "" || typ.Name() == "":
+		return fmt.Errorf("rlp: scalar type %v is not a defined type", typ)
+	case typ.Kind() == reflect.Interface:
+		return fmt.Errorf("rlp: scalar type %v is an interface", typ)
+	case typ == rawValueType || typ == bigInt || typ == u256Int:
+		return fmt.Errorf("rlp: type %v has built-in encoding", typ)
+	case reflect.PtrTo(typ).Implements(encoderInterface), reflect.PtrTo(typ).Implements(decoderInterface):
+		return fmt.Errorf("rlp: scalar type %v implements Encoder or Decoder", typ)
+	}
+	codec := &scalarCodec{
+		encode: func(val reflect.Value) ([]byte, error) {
+			return encode(val.Interface().(T))
+		},
+		decode: func(b []byte, val reflect.Value) error {
+			v, err := decode(b)
+			if err != nil {
+				return err
+			}
+			val.Set(reflect.ValueOf(&v).Elem())
+			return nil
+		},
+	}
+
+	// 타입 캐시의 뮤텍스는 타입 정보 생성과 등록을 직렬화합니다.
+	theTC.mu.Lock()
+	defer theTC.mu.Unlock()
+
+	cur := scalars.Load().(map[reflect.Type]*scalarCodec)
+	if cur[typ] != nil {
+		return fmt.Errorf("rlp: scalar type %v already registered", typ)
+	}
+	next := make(map[reflect.Type]*scalarCodec, len(cur)+1)
+	for k, v := range cur {
+		next[k] = v
+	}
+	next[typ] = codec
+	scalars.Store(next)
+
+	// T를 포함하는 타입의 캐시된 인코더와 디코더는 등록 이전의 방식을 사용하므로 삭제합니다.
+	theTC.cur.Store(make(map[typekey]*typeinfo))
+	return nil
+}
+
+// scalarCodecOf는 typ에 대해 등록된 스칼라 함수를 반환하며, 등록되지 않았으면 nil을 반환합니다.
+func scalarCodecOf(typ reflect.Type) *scalarCodec {
+	return scalars.Load().(map[reflect.Type]*scalarCodec)[typ]
+}
+
+func makeScalarWriter(codec *scalarCodec) writer {
+	return func(val reflect.Value, w *encBuffer) error {
+		b, err := codec.encode(val)
+		if err != nil {
+			return err
+		}
+		w.writeBytes(b)
+		return nil
+	}
+}
+
+func makeScalarDecoder(codec *scalarCodec) decoder {
+	return func(s *Stream, val reflect.Value) error {
+		b, err := s.Bytes()
+		if err != nil {
+			return wrapStreamError(err, val.Type())
+		}
+		return codec.decode(b, val)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scalarTime is encoded as big-endian uint64 seconds once registered.
+type scalarTime struct{ t time.Time }
+
+// scalarByte is a byte-sized scalar. Slices of it must not be treated as byte strings.
+type scalarByte uint8
+
+type scalarStruct struct {
+	Time  scalarTime
+	Ptr   *scalarTime `rlp:"nil"`
+	Bytes []scalarByte
+	Tail  []scalarTime `rlp:"tail"`
+}
+
+var (
+	errScalarTooLong    = errors.New("scalar too long")
+	registerScalarsOnce sync.Once
+)
+
+// registerTestScalars registers the test scalar types. Registration is global,
+// so it happens only once per test binary.
+func registerTestScalars(t *testing.T) {
+	before, err := EncodeToBytes(scalarByte(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, []byte{0x05}) {
+		t.Fatalf("wrong encoding before registration: %x", before)
+	}
+	err = RegisterScalar(func(v scalarTime) ([]byte, error) {
+		return binary.BigEndian.AppendUint64(nil, uint64(v.t.Unix())), nil
+	}, func(b []byte) (scalarTime, error) {
+		if len(b) != 8 {
+			return scalarTime{}, errScalarTooLong
+		}
+		return scalarTime{time.Unix(int64(binary.BigEndian.Uint64(b)), 0).UTC()}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = RegisterScalar(func(v scalarByte) ([]byte, error) {
+		return []byte{byte(v), byte(v)}, nil
+	}, func(b []byte) (scalarByte, error) {
+		return scalarByte(b[0]), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterScalar(t *testing.T) {
+	registerScalarsOnce.Do(func() { registerTestScalars(t) })
+
+	// Types cached before registration must use the registered functions afterwards.
+	after, _ := EncodeToBytes(scalarByte(5))
+	if !bytes.Equal(after, []byte{0x82, 0x05, 0x05}) {
+		t.Fatalf("wrong encoding after registration: %x", after)
+	}
+	if err := RegisterScalar(func(scalarByte) ([]byte, error) { return nil, nil }, func([]byte) (scalarByte, error) { return 0, nil }); err == nil {
+		t.Fatal("no error for duplicate registration")
+	}
+
+	ts := scalarTime{time.Unix(0x0102030405, 0).UTC()}
+	val := scalarStruct{Time: ts, Bytes: []scalarByte{1}, Tail: []scalarTime{ts}}
+	enc, err := EncodeToBytes(&val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := unhex("D7880000000102030405" + "80" + "C3820101" + "880000000102030405")
+	if !bytes.Equal(enc, want) {
+		t.Fatalf("wrong encoding\nhave %x\nwant %x", enc, want)
+	}
+	var dec scalarStruct
+	if err := DecodeBytes(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, val) {
+		t.Fatalf("decoded value mismatch: %+v", dec)
+	}
+
+	// Errors from the decode function are returned.
+	if err := DecodeBytes(unhex("820102"), new(scalarTime)); err != errScalarTooLong {
+		t.Fatalf("wrong decode error: %v", err)
+	}
+	if err := DecodeBytes(unhex("C0"), new(scalarTime)); err == nil {
+		t.Fatal("no error for list input")
+	}
+
+	schema, err := SchemaOf(reflect.TypeOf(scalarTime{}))
+	if err != nil || schema.Kind != SchemaScalar {
+		t.Fatalf("wrong schema: %+v, %v", schema, err)
+	}
+}
+
+func TestRegisterScalarErrors(t *testing.T) {
+	enc := func(uint64) ([]byte, error) { return nil, nil }
+	dec := func([]byte) (uint64, error) { return 0, nil }
+	if err := RegisterScalar(enc, dec); err == nil {
+		t.Error("no error for predeclared type")
+	}
+	if err := RegisterScalar(func(big.Int) ([]byte, error) { return nil, nil }, func([]byte) (big.Int, error) { return big.Int{}, nil }); err == nil {
+		t.Error("no error for big.Int")
+	}
+	if err := RegisterScalar(func(testEncoder) ([]byte, error) { return nil, nil }, func([]byte) (testEncoder, error) { return testEncoder{}, nil }); err == nil {
+		t.Error("no error for Encoder type")
+	}
+	if err := RegisterScalar[scalarTime](nil, nil); err == nil {
+		t.Error("no error for nil functions")
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/rlp/internal/rlpstruct"
+)
+
+// SchemaKind는 스키마 노드가 나타내는 RLP 값의 종류입니다.
+type SchemaKind string
+
+const (
+	SchemaUint      SchemaKind = "uint"      // 정규 형식의 부호 없는 정수, Bits에 비트 크기
+	SchemaBool      SchemaKind = "bool"      // 0x01 또는 0x80으로 인코딩되는 부울
+	SchemaBigInt    SchemaKind = "bigint"    // 크기 제한이 없는 음이 아닌 정수 (big.Int)
+	SchemaUint256   SchemaKind = "uint256"   // 256비트 부호 없는 정수 (uint256.Int)
+	SchemaString    SchemaKind = "string"    // Go 문자열로 디코딩되는 RLP 문자열
+	SchemaBytes     SchemaKind = "bytes"     // 가변 길이 바이트 문자열
+	SchemaByteArray SchemaKind = "bytearray" // Size 바이트의 고정 길이 바이트 문자열
+	SchemaList      SchemaKind = "list"      // Elem 타입 요소의 가변 길이 리스트
+	SchemaArray     SchemaKind = "array"     // Elem 타입 요소 Size개의 고정 길이 리스트
+	SchemaStruct    SchemaKind = "struct"    // Fields 순서대로 인코딩되는 리스트
+	SchemaRaw       SchemaKind = "raw"       // 이미 인코딩된 임의의 RLP 값 (RawValue)
+	SchemaCustom    SchemaKind = "custom"    // Encoder를 구현하여 레이아웃을 알 수 없는 타입
+	SchemaScalar    SchemaKind = "scalar"    // RegisterScalar로 등록되어 RLP 문자열로 인코딩되는 타입
+	SchemaInterface SchemaKind = "interface" // 동적 타입의 값. 디코딩 시에는 []interface{} 또는 []byte
+	SchemaRef       SchemaKind = "ref"       // Type에 이름이 지정된, 바깥쪽에서 정의 중인 재귀 타입에 대한 참조
+)
+
+// Schema는 Go 타입의 RLP 레이아웃을 기계가 읽을 수 있는 형태로 기술합니다. JSON으로 직렬화하여 다른
+// 언어의 인코더와 디코더를 생성하는 데 사용할 수 있습니다.
+type Schema struct {
+	Kind   SchemaKind    `json:"kind"`
+	Type   string        `json:"type"`             // Go 타입 이름
+	Bits   int           `json:"bits,omitempty"`   // SchemaUint의 비트 크기
+	Size   int           `json:"size,omitempty"`   // SchemaByteArray, SchemaArray의 길이
+	Elem   *Schema       `json:"elem,omitempty"`   // SchemaList, SchemaArray의 요소 타입
+	Fields []SchemaField `json:"fields,omitempty"` // SchemaStruct의 인코딩되는 필드
+}
+
+// SchemaField는 구조체 스키마의 필드 하나를 기술합니다.
+type SchemaField struct {
+	Name     string  `json:"name"`
+	Optional bool    `json:"optional,omitempty"` // "optional" 태그: 입력의 끝에서 생략될 수 있음
+	Tail     bool    `json:"tail,omitempty"`     // "tail" 태그: 남은 모든 리스트 요소를 담는 슬라이스
+	Nil      string  `json:"nil,omitempty"`      // "nil" 태그: 빈 값이 nil 포인터로 디코딩됨. 빈 값의 종류("string" 또는 "list")
+	Schema   *Schema `json:"schema"`
+}
+
+// SchemaOf는 typ의 RLP 레이아웃에 대한 스키마를 반환합니다. typ가 RLP로 직렬화할 수 없는
+// 타입이면 오류를 반환합니다. 포인터 타입은 가리키는 타입의 스키마로 기술됩니다.
+func SchemaOf(typ reflect.Type) (*Schema, error) {
+	return schemaOf(typ, make(map[reflect.Type]bool))
+}
+
+// SchemaJSON은 val의 타입에 대한 스키마를 JSON으로 인코딩하여 반환합니다.
+func SchemaJSON(val interface{}) ([]byte, error) {
+	schema, err := SchemaOf(reflect.TypeOf(val))
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaOf는 makeWriter와 같은 순서로 타입을 분류하여 스키마를 생성합니다. inProgress는
+// 재귀 타입을 감지하기 위해 현재 생성 중인 구조체 타입을 추적합니다.
+func schemaOf(typ reflect.Type, inProgress map[reflect.Type]bool) (*Schema, error) {
+	if typ == nil {
+		return nil, fmt.Errorf("rlp: cannot derive schema of nil type")
+	}
+	for typ.Kind() == reflect.Ptr && !isSpecialType(typ) {
+		typ = typ.Elem()
+	}
+	s := &Schema{Type: typ.String()}
+	kind := typ.Kind()
+	switch {
+	case typ == rawValueType:
+		s.Kind = SchemaRaw
+	case typ.AssignableTo(reflect.PtrTo(bigInt)), typ.AssignableTo(bigInt):
+		s.Kind = SchemaBigInt
+	case typ == reflect.PtrTo(u256Int), typ == u256Int:
+		s.Kind = SchemaUint256
+	case scalarCodecOf(typ) != nil:
+		s.Kind = SchemaScalar
+	case reflect.PtrTo(typ).Implements(encoderInterface):
+		s.Kind = SchemaCustom
+	case isUint(kind):
+		s.Kind, s.Bits = SchemaUint, typ.Bits()
+	case kind == reflect.Bool:
+		s.Kind = SchemaBool
+	case kind == reflect.String:
+		s.Kind = SchemaString
+	case kind == reflect.Slice && isByte(typ.Elem()):
+		s.Kind = SchemaBytes
+	case kind == reflect.Array && isByte(typ.Elem()):
+		s.Kind, s.Size = SchemaByteArray, typ.Len()
+	case kind == reflect.Slice || kind == reflect.Array:
+		elem, err := schemaOf(typ.Elem(), inProgress)
+		if err != nil {
+			return nil, err
+		}
+		s.Kind, s.Elem = SchemaList, elem
+		if kind == reflect.Array {
+			s.Kind, s.Size = SchemaArray, typ.Len()
+		}
+	case kind == reflect.Struct:
+		if inProgress[typ] {
+			s.Kind = SchemaRef
+			return s, nil
+		}
+		inProgress[typ] = true
+		defer delete(inProgress, typ)
+
+		fields, err := structSchemaFields(typ, inProgress)
+		if err != nil {
+			return nil, err
+		}
+		s.Kind, s.Fields = SchemaStruct, fields
+	case kind == reflect.Interface:
+		s.Kind = SchemaInterface
+	default:
+		return nil, fmt.Errorf("rlp: type %v is not RLP-serializable", typ)
+	}
+	return s, nil
+}
+
+// structSchemaFields는 구조체 타입의 인코딩되는 필드에 대한 스키마를 생성합니다.
+func structSchemaFields(typ reflect.Type, inProgress map[reflect.Type]bool) ([]SchemaField, error) {
+	var allStructFields []rlpstruct.Field
+	for i := 0; i < typ.NumField(); i++ {
+		rf := typ.Field(i)
+		allStructFields = append(allStructFields, rlpstruct.Field{
+			Name:     rf.Name,
+			Index:    i,
+			Exported: rf.PkgPath == "",
+			Tag:      string(rf.Tag),
+			Type:     *rtypeToStructType(rf.Type, nil),
+		})
+	}
+	structFields, structTags, err := rlpstruct.ProcessFields(allStructFields)
+	if err != nil {
+		if tagErr, ok := err.(rlpstruct.TagError); ok {
+			tagErr.StructType = typ.String()
+			return nil, tagErr
+		}
+		return nil, err
+	}
+	fields := make([]SchemaField, len(structFields))
+	for i, sf := range structFields {
+		schema, err := schemaOf(typ.Field(sf.Index).Type, inProgress)
+		if err != nil {
+			return nil, structFieldError{typ, sf.Index, err}
+		}
+		tags := structTags[i]
+		fields[i] = SchemaField{
+			Name:     sf.Name,
+			Optional: tags.Optional,
+			Tail:     tags.Tail,
+			Schema:   schema,
+		}
+		if tags.NilOK {
+			fields[i].Nil = "string"
+			if tags.NilKind == rlpstruct.NilKindList {
+				fields[i].Nil = "list"
+			}
+		}
+	}
+	return fields, nil
+}
+
+// isSpecialType은 포인터 타입이 그 자체로 특별하게 인코딩되는지 여부를 반환합니다.
+func isSpecialType(typ reflect.Type) bool {
+	return typ.AssignableTo(reflect.PtrTo(bigInt)) || typ == reflect.PtrTo(u256Int)
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"encoding/json"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+type schemaTestStruct struct {
+	A uint64
+	B *big.Int
+	C *uint256.Int
+	D []byte
+	E [4]byte
+	F []uint16
+	G [2]bool
+	H RawValue
+	I *testEncoder
+	J *schemaTestStruct `rlp:"nil"`
+	K string            `rlp:"optional"`
+
+	ignored uint
+}
+
+type schemaTailStruct struct {
+	A    interface{}
+	Tail []uint `rlp:"tail"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf(reflect.TypeOf(new(schemaTestStruct)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schema{Kind: SchemaStruct, Type: "rlp.schemaTestStruct", Fields: []SchemaField{
+		{Name: "A", Schema: &Schema{Kind: SchemaUint, Type: "uint64", Bits: 64}},
+		{Name: "B", Schema: &Schema{Kind: SchemaBigInt, Type: "*big.Int"}},
+		{Name: "C", Schema: &Schema{Kind: SchemaUint256, Type: "*uint256.Int"}},
+		{Name: "D", Schema: &Schema{Kind: SchemaBytes, Type: "[]uint8"}},
+		{Name: "E", Schema: &Schema{Kind: SchemaByteArray, Type: "[4]uint8", Size: 4}},
+		{Name: "F", Schema: &Schema{Kind: SchemaList, Type: "[]uint16", Elem: &Schema{Kind: SchemaUint, Type: "uint16", Bits: 16}}},
+		{Name: "G", Schema: &Schema{Kind: SchemaArray, Type: "[2]bool", Size: 2, Elem: &Schema{Kind: SchemaBool, Type: "bool"}}},
+		{Name: "H", Schema: &Schema{Kind: SchemaRaw, Type: "rlp.RawValue"}},
+		{Name: "I", Schema: &Schema{Kind: SchemaCustom, Type: "rlp.testEncoder"}},
+		{Name: "J", Nil: "list", Schema: &Schema{Kind: SchemaRef, Type: "rlp.schemaTestStruct"}},
+		{Name: "K", Optional: true, Schema: &Schema{Kind: SchemaString, Type: "string"}},
+	}}
+	if !reflect.DeepEqual(schema, want) {
+		have, _ := json.MarshalIndent(schema, "", "  ")
+		exp, _ := json.MarshalIndent(want, "", "  ")
+		t.Fatalf("schema mismatch\nhave %s\nwant %s", have, exp)
+	}
+}
+
+func TestSchemaJSON(t *testing.T) {
+	enc, err := SchemaJSON(schemaTailStruct{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema Schema
+	if err := json.Unmarshal(enc, &schema); err != nil {
+		t.Fatal(err)
+	}
+	want := Schema{Kind: SchemaStruct, Type: "rlp.schemaTailStruct", Fields: []SchemaField{
+		{Name: "A", Schema: &Schema{Kind: SchemaInterface, Type: "interface {}"}},
+		{Name: "Tail", Tail: true, Schema: &Schema{Kind: SchemaList, Type: "[]uint", Elem: &Schema{Kind: SchemaUint, Type: "uint", Bits: bits.UintSize}}},
+	}}
+	if !reflect.DeepEqual(schema, want) {
+		t.Fatalf("schema mismatch after JSON round trip: %s", enc)
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	tests := []interface{}{
+		int(1),
+		struct{ F float64 }{},
+		struct {
+			A []uint `rlp:"tail"`
+			B uint
+		}{},
+	}
+	for _, val := range tests {
+		if _, err := SchemaOf(reflect.TypeOf(val)); err == nil {
+			t.Errorf("no error for %T", val)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	errNotSeekable   = errors.New("rlp: stream does not support seeking")
+	errInvalidSeek   = errors.New("rlp: seek position out of range")
+	errInvalidWhence = errors.New("rlp: invalid seek whence")
+)
+
+// readerAtBufferSize는 readerAtReader가 한 번의 ReadAt 호출로 미리 읽는 바이트 수입니다.
+const readerAtBufferSize = 512
+
+// NewStreamAt은 r의 offset 위치부터 읽어들이는 새로운 디코딩 스트림을 생성합니다.
+// limit이 0이 아니면 NewStream과 같이 입력 제한으로 사용됩니다.
+//
+// io.ReaderAt에서 읽는 스트림은 Seek을 지원하므로, 값 전체를 버퍼링하지 않고도 입력의 일부
+// 영역을 다시 디코딩할 수 있습니다. 예를 들어 먼저 헤더만 디코딩한 뒤, 같은 값을 Raw로 다시
+// 읽어 해싱할 수 있습니다.
+func NewStreamAt(r io.ReaderAt, offset int64, limit uint64) *Stream {
+	s := new(Stream)
+	ra := &readerAtReader{r: r, base: offset}
+	s.Reset(ra, limit)
+	s.ra = ra
+	s.inputLimit = limit
+	return s
+}
+
+// Seek은 다음 읽기 위치를 스트림 시작 위치에 대한 상대 위치로 설정하고 새 위치를 반환합니다.
+// whence는 io.Seeker와 같은 의미를 가지며, io.SeekEnd는 입력 제한이 있는 스트림에서만
+// 사용할 수 있습니다.
+//
+// Seek은 Reset과 마찬가지로 리스트 중첩을 포함한 모든 디코딩 컨텍스트를 삭제하므로, 새 위치는
+// 최상위 값의 시작으로 취급됩니다. NewStreamAt으로 생성된 스트림만 Seek을 지원합니다.
+func (s *Stream) Seek(offset int64, whence int) (int64, error) {
+	if s.ra == nil {
+		return 0, errNotSeekable
+	}
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = int64(s.pos) + offset
+	case io.SeekEnd:
+		if !s.limited {
+			return 0, errNotSeekable
+		}
+		pos = int64(s.inputLimit) + offset
+	default:
+		return 0, errInvalidWhence
+	}
+	if pos < 0 || (s.limited && uint64(pos) > s.inputLimit) {
+		return 0, errInvalidSeek
+	}
+	s.ra.seek(pos)
+	s.resetState()
+	s.pos = uint64(pos)
+	if s.limited {
+		s.remaining = s.inputLimit - uint64(pos)
+	}
+	return pos, nil
+}
+
+// readerAtReader는 io.ReaderAt을 위치가 있는 ByteReader로 변환합니다.
+type readerAtReader struct {
+	r    io.ReaderAt
+	base int64 // 스트림의 시작 위치
+	pos  int64 // 스트림 시작으로부터의 현재 위치
+
+	buf    []byte // 미리 읽은 데이터를 위한 버퍼
+	start  int    // buf에서 pos에 해당하는 인덱스
+	end    int    // buf에서 유효한 데이터의 끝
+	bufErr error  // buf를 채울 때 발생한 오류
+}
+
+// seek은 현재 위치를 변경하고 미리 읽은 데이터를 버립니다.
+func (rr *readerAtReader) seek(pos int64) {
+	rr.pos = pos
+	rr.start, rr.end = 0, 0
+	rr.bufErr = nil
+}
+
+// fill은 현재 위치부터 데이터를 미리 읽고, 읽은 데이터가 있는지 여부를 반환합니다.
+func (rr *readerAtReader) fill() bool {
+	if rr.buf == nil {
+		rr.buf = make([]byte, readerAtBufferSize)
+	}
+	n, err := rr.r.ReadAt(rr.buf, rr.base+rr.pos)
+	rr.start, rr.end = 0, n
+	if n > 0 {
+		err = nil // 읽은 데이터를 모두 소비한 후 다시 읽을 때 오류를 확인합니다.
+	}
+	rr.bufErr = err
+	return n > 0
+}
+
+func (rr *readerAtReader) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if rr.start == rr.end {
+		if len(b) >= readerAtBufferSize {
+			// 큰 읽기는 버퍼를 거치지 않습니다.
+			n, err := rr.r.ReadAt(b, rr.base+rr.pos)
+			rr.pos += int64(n)
+			if n > 0 && err == io.EOF {
+				err = nil
+			}
+			return n, err
+		}
+		if !rr.fill() {
+			return 0, rr.bufErr
+		}
+	}
+	n := copy(b, rr.buf[rr.start:rr.end])
+	rr.start += n
+	rr.pos += int64(n)
+	return n, nil
+}
+
+func (rr *readerAtReader) ReadByte() (byte, error) {
+	if rr.start == rr.end && !rr.fill() {
+		return 0, rr.bufErr
+	}
+	b := rr.buf[rr.start]
+	rr.start++
+	rr.pos++
+	return b, nil
+}
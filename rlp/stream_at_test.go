@@ -0,0 +1,101 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestStreamAtSeek(t *testing.T) {
+	type value struct {
+		Num  uint
+		Data []byte
+	}
+	val := value{Num: 7, Data: bytes.Repeat([]byte{0xaa}, 2*readerAtBufferSize)}
+	enc, err := EncodeToBytes(&val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Place the value behind some garbage to check the base offset handling.
+	input := append([]byte{0xff, 0xff, 0xff}, enc...)
+	s := NewStreamAt(bytes.NewReader(input), 3, uint64(len(enc)))
+
+	// Decode the first field only, then rewind and read the whole value.
+	if _, err := s.List(); err != nil {
+		t.Fatal(err)
+	}
+	num, err := s.Uint64()
+	if err != nil || num != 7 {
+		t.Fatalf("wrong first field: %d, %v", num, err)
+	}
+	if pos, err := s.Seek(0, io.SeekStart); err != nil || pos != 0 {
+		t.Fatalf("seek failed: %d, %v", pos, err)
+	}
+	raw, err := s.Raw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, enc) {
+		t.Fatal("raw value mismatch after seek")
+	}
+	if _, _, err := s.Kind(); err != io.EOF {
+		t.Fatalf("expected EOF at end of input, got %v", err)
+	}
+	// Seek back into the byte string and decode it.
+	if _, err := s.Seek(-int64(len(val.Data)+3), io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, val.Data) {
+		t.Fatal("byte string mismatch after seek")
+	}
+	if s.Offset() != uint64(len(enc)) {
+		t.Fatalf("wrong offset %d, want %d", s.Offset(), len(enc))
+	}
+	// Full decode through the stream.
+	s.Seek(0, io.SeekStart)
+	var dec value
+	if err := s.Decode(&dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Num != val.Num || !bytes.Equal(dec.Data, val.Data) {
+		t.Fatal("decoded value mismatch")
+	}
+}
+
+func TestStreamAtSeekErrors(t *testing.T) {
+	s := NewStreamAt(bytes.NewReader([]byte{0x01, 0x02}), 0, 2)
+	if _, err := s.Seek(3, io.SeekStart); err == nil {
+		t.Error("seek beyond input limit succeeded")
+	}
+	if _, err := s.Seek(-1, io.SeekCurrent); err == nil {
+		t.Error("seek before start succeeded")
+	}
+	unlimited := NewStreamAt(bytes.NewReader([]byte{0x01}), 0, 0)
+	if _, err := unlimited.Seek(0, io.SeekEnd); err == nil {
+		t.Error("seek relative to end of unlimited stream succeeded")
+	}
+	plain := NewStream(bytes.NewReader([]byte{0x01}), 0)
+	if _, err := plain.Seek(0, io.SeekStart); err != errNotSeekable {
+		t.Errorf("wrong error for non-seekable stream: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+// SplitTopLevelList는 다음 값인 리스트를 읽고, 각 요소의 인코딩을 별도의 RawValue로 반환합니다.
+//
+// Stream은 동시에 사용할 수 없지만, 반환된 요소는 서로 독립적이므로 작업자 고루틴마다 자신의
+// Stream이나 DecodeBytes로 요소를 병렬로 디코딩할 수 있습니다. 스트림이 ResetBytes나
+// DecodeBytes처럼 바이트 슬라이스에서 읽는 경우 요소는 입력을 복사하지 않고 그 일부를
+// 가리키므로, 요소를 사용하는 동안 입력을 수정해서는 안 됩니다. 다른 리더에서 읽는 경우에는
+// 리스트의 내용을 한 번만 읽어 버퍼에 담습니다.
+//
+// 요소의 헤더는 검사되지만 내용은 디코딩되지 않습니다. 다음 값이 리스트가 아니면
+// ErrExpectedList를 반환합니다.
+func (s *Stream) SplitTopLevelList() ([]RawValue, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+	if kind != List {
+		return nil, ErrExpectedList
+	}
+	var content []byte
+	if sr, ok := s.r.(*sliceReader); ok && sr == &s.sr {
+		// Kind의 검사로 입력에 size 바이트가 남아 있음이 보장됩니다.
+		if err := s.willRead(size); err != nil {
+			return nil, err
+		}
+		content = s.sr[:size:size]
+		s.sr = s.sr[size:]
+	} else {
+		if err := s.allocate(size); err != nil {
+			return nil, err
+		}
+		content = make([]byte, size)
+		if err := s.readFull(content); err != nil {
+			return nil, err
+		}
+	}
+
+	n, err := CountValues(content)
+	if err != nil {
+		return nil, err
+	}
+	elems := make([]RawValue, n)
+	for i := range elems {
+		_, _, rest, err := Split(content)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = content[: len(content)-len(rest) : len(content)-len(rest)]
+		content = rest
+	}
+	return elems, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rlp
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestSplitTopLevelList(t *testing.T) {
+	type elem struct {
+		A uint64
+		B []byte
+	}
+	var values []elem
+	for i := 0; i < 100; i++ {
+		values = append(values, elem{uint64(i), bytes.Repeat([]byte{byte(i)}, i)})
+	}
+	input, _ := EncodeToBytes(values)
+	trailer, _ := EncodeToBytes(uint(7))
+	input = append(input, trailer...)
+
+	check := func(name string, s *Stream, zeroCopy bool) {
+		elems, err := s.SplitTopLevelList()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(elems) != len(values) {
+			t.Fatalf("%s: wrong element count %d", name, len(elems))
+		}
+		// Decode the elements concurrently, each with its own stream.
+		decoded := make([]elem, len(elems))
+		var wg sync.WaitGroup
+		for i := range elems {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if err := DecodeBytes(elems[i], &decoded[i]); err != nil {
+					t.Errorf("%s: element %d: %v", name, i, err)
+				}
+			}(i)
+		}
+		wg.Wait()
+		for i := range values {
+			if decoded[i].A != values[i].A || !bytes.Equal(decoded[i].B, values[i].B) {
+				t.Fatalf("%s: element %d mismatch", name, i)
+			}
+		}
+		// The elements alias the input if the stream reads from a byte slice.
+		if aliased := &elems[1][0] == &input[len(elems[0])+3]; aliased != zeroCopy {
+			t.Errorf("%s: elements alias input: %t, want %t", name, aliased, zeroCopy)
+		}
+		// The stream continues after the list.
+		if v, err := s.Uint64(); err != nil || v != 7 {
+			t.Fatalf("%s: wrong value after list: %d, %v", name, v, err)
+		}
+	}
+	s := new(Stream)
+	s.ResetBytes(input)
+	check("bytes", s, true)
+	check("reader", NewStream(bytes.NewReader(input), 0), false)
+}
+
+func TestSplitTopLevelListErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"80", ErrExpectedList},
+		{"C3010203", nil},
+		{"C401020304", nil},
+		{"C50102", ErrValueTooLarge},
+		{"C28101", ErrCanonSize},
+		{"C3820102", nil},
+		{"C48302", ErrValueTooLarge},
+	}
+	for _, test := range tests {
+		s := new(Stream)
+		s.ResetBytes(unhex(test.input))
+		_, err := s.SplitTopLevelList()
+		if err != test.err {
+			t.Errorf("input %s: wrong error %v, want %v", test.input, err, test.err)
+		}
+	}
+}
@@ -76,6 +76,9 @@ func (c *typeCache) info(typ reflect.Type) *typeinfo {
 	}
 
 	// 캐시되지 않은 경우, 이 타입에 대한 정보를 생성해야 합니다.
+	if m := loadMetrics(); m != nil {
+		m.TypeCacheMiss(typ)
+	}
 	return c.generate(typ, rlpstruct.Tags{})
 }
 
@@ -103,6 +106,38 @@ func (c *typeCache) generate(typ reflect.Type, tags rlpstruct.Tags) *typeinfo {
 	return info
 }
 
+// generateAll은 types의 모든 타입에 대한 정보를 한 번의 캐시 교체로 생성합니다. 이미 캐시된
+// 타입은 건너뜁니다.
+func (c *typeCache) generateAll(types []reflect.Type) []*typeinfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cur := c.cur.Load().(map[typekey]*typeinfo)
+	c.next = make(map[typekey]*typeinfo, len(cur)+len(types))
+	for k, v := range cur {
+		c.next[k] = v
+	}
+	infos := make([]*typeinfo, len(types))
+	for i, typ := range types {
+		infos[i] = c.infoWhileGenerating(typ, rlpstruct.Tags{})
+	}
+	c.cur.Store(c.next)
+	c.next = nil
+	return infos
+}
+
+// types는 태그 없이 캐시된 모든 타입을 반환합니다.
+func (c *typeCache) types() []reflect.Type {
+	cur := c.cur.Load().(map[typekey]*typeinfo)
+	types := make([]reflect.Type, 0, len(cur))
+	for key := range cur {
+		if key.Tags == (rlpstruct.Tags{}) {
+			types = append(types, key.Type)
+		}
+	}
+	return types
+}
+
 func (c *typeCache) infoWhileGenerating(typ reflect.Type, tags rlpstruct.Tags) *typeinfo {
 	key := typekey{typ, tags}
 	if info := c.next[key]; info != nil {
@@ -201,6 +236,7 @@ func rtypeToStructType(typ reflect.Type, rec map[reflect.Type]*rlpstruct.Type) *
 		Kind:      k,
 		IsEncoder: typ.Implements(encoderInterface),
 		IsDecoder: typ.Implements(decoderInterface),
+		IsScalar:  scalarCodecOf(typ) != nil,
 	}
 	rec[typ] = t
 	if k == reflect.Array || k == reflect.Slice || k == reflect.Ptr {
@@ -234,5 +270,5 @@ func isUint(k reflect.Kind) bool {
 }
 
 func isByte(typ reflect.Type) bool {
-	return typ.Kind() == reflect.Uint8 && !typ.Implements(encoderInterface)
+	return typ.Kind() == reflect.Uint8 && !typ.Implements(encoderInterface) && scalarCodecOf(typ) == nil
 }
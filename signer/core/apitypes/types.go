@@ -255,8 +255,12 @@ func TypedDataAndHash(typedData TypedData) ([]byte, string, error) {
 	if err != nil {
 		return nil, "", err
 	}
+	sighash, err := crypto.TypedDataHash(domainSeparator, typedDataHash)
+	if err != nil {
+		return nil, "", err
+	}
 	rawData := fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash))
-	return crypto.Keccak256([]byte(rawData)), rawData, nil
+	return sighash, rawData, nil
 }
 
 // HashStruct generates a keccak256 hash of the encoding of the provided data